@@ -88,6 +88,11 @@ CREATE TABLE `clusters` (
   `failure_status` tinyint(1) NOT NULL DEFAULT '0',
   `max_conns_per_host` int(11) NOT NULL DEFAULT '0',
   `llm_config` text,
+  `balance_mode` varchar(16) NOT NULL DEFAULT 'WRR' comment "负载均衡算法: WRR/WLC/EWMA/CHASH",
+  `balance_params` text comment "负载均衡算法参数",
+  `upstream_tls` text comment "后端TLS设置",
+  `retry_policy` text comment "重试及熔断策略",
+  `auto_scheduler` text comment "自动调度设置",
   `created_at` datetime NOT NULL,
   `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
@@ -116,6 +121,7 @@ CREATE TABLE `sub_clusters` (
   `description` varchar(1024) CHARACTER SET utf8 COLLATE utf8_unicode_ci NOT NULL DEFAULT "no desc",
   `bns_name_id` bigint(20) NOT NULL,
   `enabled` tinyint(1) NOT NULL DEFAULT '1',
+  `capacity` bigint(20) NOT NULL DEFAULT 0 comment "子集群容量(QPS)，自动调度使用",
   `created_at` datetime NOT NULL,
   `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
@@ -231,6 +237,7 @@ CREATE TABLE `config_versions` (
   `name` varchar(255) NOT NULL,
   `data_sign` varchar(255) NOT NULL,
   `version` varchar(255) NOT NULL,
+  `triggered_by` varchar(255) NOT NULL DEFAULT '',
   `created_at` datetime NOT NULL,
   `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`)
@@ -275,8 +282,10 @@ CREATE TABLE api_keys (
   `product_name` varchar(255) NOT NULL DEFAULT '' comment "产品线名称",
   `total_quota` bigint(20) NOT NULL default 0 comment '限额总数',
   `expired_time` varchar(255) NOT NULL default '' comment "过期时间",
+  `effective_time` varchar(255) NOT NULL default '' comment "生效时间",
   `allowed_models` text comment "允许的模型",
   `allowed_cidr` varchar(1024) NOT NULL default '' comment "允许的cidr",
+  `upstream_credentials` text comment "加密存储的上游服务商凭证",
   `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
   `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
   PRIMARY KEY (`id`),
@@ -294,6 +303,273 @@ CREATE TABLE api_key_tokens (
   UNIQUE KEY `idx_key` (`api_key`)
 )ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "api-key存储表"; 
 
+-- create api_key_notifications
+DROP TABLE IF EXISTS `api_key_notifications`;
+CREATE TABLE api_key_notifications (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `api_key_id` bigint(20) NOT NULL comment "api key id",
+  `kind` varchar(32) NOT NULL DEFAULT '' comment "通知类型: expire/quota",
+  `threshold` bigint(20) NOT NULL DEFAULT 0 comment "阈值: 过期前天数/额度使用百分比",
+  `cycle` varchar(255) NOT NULL DEFAULT '' comment "周期: 过期时间/额度重置时间",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_threshold` (`api_key_id`, `kind`, `threshold`, `cycle`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "api key生命周期通知记录";
+
+-- create cluster_model_syncs
+DROP TABLE IF EXISTS `cluster_model_syncs`;
+CREATE TABLE cluster_model_syncs (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `cluster_id` bigint(20) NOT NULL comment "集群id",
+  `product_id` bigint(20) NOT NULL comment "产品线id",
+  `status` varchar(32) NOT NULL DEFAULT '' comment "状态: unchanged/pending/applied/failed",
+  `discovered` text comment "服务商提供的模型列表",
+  `added` text comment "新增的模型列表",
+  `removed` text comment "删除的模型列表",
+  `missing_refs` text comment "引用已删除模型的模型映射和API Key",
+  `err_msg` varchar(2048) NOT NULL DEFAULT '' comment "同步失败原因",
+  `approved_by` varchar(255) NOT NULL DEFAULT '' comment "审批人",
+  `synced_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' comment "同步时间",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_cluster_id` (`cluster_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "集群模型同步记录";
+
+-- create model_providers
+DROP TABLE IF EXISTS `model_providers`;
+CREATE TABLE model_providers (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `provider_id` varchar(64) NOT NULL comment "服务商id, 即集群的provider_type",
+  `name` varchar(255) NOT NULL DEFAULT '' comment "展示名称",
+  `description` varchar(1024) NOT NULL DEFAULT '' comment "描述",
+  `protocol` varchar(32) NOT NULL DEFAULT 'openai' comment "数据面协议: openai/anthropic/gemini",
+  `base_url` varchar(1024) NOT NULL DEFAULT '' comment "默认服务地址",
+  `auth_header` varchar(255) NOT NULL DEFAULT '' comment "携带Key的头部",
+  `auth_scheme` varchar(64) NOT NULL DEFAULT '' comment "Key的前缀",
+  `field_mapping` text comment "模型列表的字段映射",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_provider_id` (`provider_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "模型服务商目录";
+
+-- create cluster_templates
+DROP TABLE IF EXISTS `cluster_templates`;
+CREATE TABLE cluster_templates (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `name` varchar(255) NOT NULL comment "模板名称",
+  `product_id` bigint(20) NOT NULL DEFAULT 0 comment "产品线id, 0为系统模板",
+  `description` varchar(1024) NOT NULL DEFAULT '' comment "描述",
+  `content` text comment "集群默认设置",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_product_name` (`product_id`, `name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "集群模板";
+
+-- create cluster_health_checks
+DROP TABLE IF EXISTS `cluster_health_checks`;
+CREATE TABLE cluster_health_checks (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `cluster_id` bigint(20) NOT NULL comment "集群id",
+  `product_id` bigint(20) NOT NULL comment "产品线id",
+  `enable` tinyint(1) NOT NULL DEFAULT '1' comment "是否启用",
+  `probe` varchar(32) NOT NULL DEFAULT 'model_list' comment "探测方式: model_list/completion",
+  `model` varchar(255) NOT NULL DEFAULT '' comment "对话请求的模型",
+  `interval_in_s` int(11) NOT NULL DEFAULT '10' comment "探测间隔",
+  `timeout_in_s` int(11) NOT NULL DEFAULT '5' comment "超时时间",
+  `healthy_threshold` int(11) NOT NULL DEFAULT '2' comment "恢复健康所需的连续成功次数",
+  `unhealthy_threshold` int(11) NOT NULL DEFAULT '3' comment "标记不健康所需的连续失败次数",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_cluster_id` (`cluster_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "集群主动健康检查";
+
+-- create traffic_plans
+DROP TABLE IF EXISTS `traffic_plans`;
+CREATE TABLE traffic_plans (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `product_id` bigint(20) NOT NULL comment "产品线id",
+  `cluster_id` bigint(20) NOT NULL comment "集群id",
+  `name` varchar(255) NOT NULL comment "计划名称",
+  `description` varchar(255) NOT NULL DEFAULT '' comment "描述",
+  `status` varchar(32) NOT NULL DEFAULT 'running' comment "状态: running/paused/finished/aborted/failed",
+  `steps` mediumtext NOT NULL comment "调度步骤",
+  `next_step` int(11) NOT NULL DEFAULT '0' comment "下一步骤序号",
+  `next_step_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' comment "下一步骤执行时间",
+  `paused_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' comment "暂停时间",
+  `created_by` varchar(255) NOT NULL DEFAULT '' comment "创建人",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_cluster_name` (`cluster_id`, `name`),
+  KEY `idx_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "调度变更计划";
+
+-- create traffic_plan_logs
+DROP TABLE IF EXISTS `traffic_plan_logs`;
+CREATE TABLE traffic_plan_logs (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `plan_id` bigint(20) NOT NULL comment "计划id",
+  `step` int(11) NOT NULL DEFAULT '0' comment "步骤序号",
+  `action` varchar(32) NOT NULL comment "操作: create/step/pause/resume/abort/finish/fail",
+  `message` varchar(1024) NOT NULL DEFAULT '' comment "说明",
+  `operator` varchar(255) NOT NULL DEFAULT '' comment "操作人, 后台任务为system",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  KEY `idx_plan_id` (`plan_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "调度变更计划执行记录";
+
+-- create canaries
+DROP TABLE IF EXISTS `canaries`;
+CREATE TABLE canaries (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `product_id` bigint(20) NOT NULL comment "产品线id",
+  `cluster_id` bigint(20) NOT NULL comment "集群id",
+  `sub_cluster` varchar(255) NOT NULL comment "金丝雀子集群名字",
+  `status` varchar(32) NOT NULL DEFAULT 'running' comment "状态: running/paused/promoted/rolled_back",
+  `steps` text NOT NULL comment "每一步的流量百分比",
+  `step_interval_in_s` bigint(20) NOT NULL DEFAULT '600' comment "每一步的持续时间",
+  `max_error_rate` double NOT NULL DEFAULT '0' comment "错误率上限",
+  `max_latency_ms` bigint(20) NOT NULL DEFAULT '0' comment "平均延迟上限, 0为不检查",
+  `min_requests` bigint(20) NOT NULL DEFAULT '100' comment "评估指标所需的最少请求数",
+  `base_lb_matrix` text NOT NULL comment "发布前的调度配置",
+  `step` int(11) NOT NULL DEFAULT '0' comment "当前步骤序号",
+  `step_started_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' comment "当前步骤开始时间",
+  `paused_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' comment "暂停时间",
+  `reason` varchar(1024) NOT NULL DEFAULT '' comment "系统暂停或回滚的原因",
+  `created_by` varchar(255) NOT NULL DEFAULT '' comment "创建人",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_cluster_id` (`cluster_id`),
+  KEY `idx_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "金丝雀发布";
+
+-- create canary_metrics
+DROP TABLE IF EXISTS `canary_metrics`;
+CREATE TABLE canary_metrics (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `canary_id` bigint(20) NOT NULL comment "金丝雀发布id",
+  `step` int(11) NOT NULL DEFAULT '0' comment "步骤序号",
+  `requests` bigint(20) NOT NULL DEFAULT '0' comment "请求数",
+  `errors` bigint(20) NOT NULL DEFAULT '0' comment "错误数",
+  `latency_ms_sum` bigint(20) NOT NULL DEFAULT '0' comment "延迟之和, 单位毫秒",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  KEY `idx_canary_step` (`canary_id`, `step`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "金丝雀发布指标";
+
+-- create canary_logs
+DROP TABLE IF EXISTS `canary_logs`;
+CREATE TABLE canary_logs (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `canary_id` bigint(20) NOT NULL comment "金丝雀发布id",
+  `step` int(11) NOT NULL DEFAULT '0' comment "步骤序号",
+  `percent` int(11) NOT NULL DEFAULT '0' comment "步骤的流量百分比",
+  `action` varchar(32) NOT NULL comment "操作: create/step/pause/resume/promote/rollback",
+  `message` varchar(1024) NOT NULL DEFAULT '' comment "说明",
+  `operator` varchar(255) NOT NULL DEFAULT '' comment "操作人, 后台任务为system",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  KEY `idx_canary_id` (`canary_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "金丝雀发布执行记录";
+
+-- create pool_discoveries
+DROP TABLE IF EXISTS `pool_discoveries`;
+CREATE TABLE pool_discoveries (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `pool_id` bigint(20) NOT NULL comment "实例池id",
+  `product_id` bigint(20) NOT NULL comment "产品线id",
+  `sources` text comment "服务发现源",
+  `status` varchar(32) NOT NULL DEFAULT '' comment "同步状态: synced/failed",
+  `discovered` mediumtext comment "最近一次成功同步发现的实例",
+  `err_msg` varchar(2048) NOT NULL DEFAULT '' comment "同步失败原因",
+  `synced_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' comment "同步时间",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_pool_id` (`pool_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "实例池服务发现";
+
+-- create bfe_nodes
+DROP TABLE IF EXISTS `bfe_nodes`;
+CREATE TABLE bfe_nodes (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `node_id` varchar(255) NOT NULL DEFAULT '' comment "节点id",
+  `bfe_cluster` varchar(255) NOT NULL DEFAULT '' comment "BFE集群名称",
+  `build_version` varchar(255) NOT NULL DEFAULT '' comment "BFE版本",
+  `applied_versions` text comment "各配置主题已加载的版本",
+  `addr` varchar(255) NOT NULL DEFAULT '' comment "节点地址",
+  `heartbeat_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' comment "最近心跳时间",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_node_id` (`node_id`),
+  KEY `idx_bfe_cluster` (`bfe_cluster`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "BFE节点";
+
+-- create config_snapshots
+DROP TABLE IF EXISTS `config_snapshots`;
+CREATE TABLE config_snapshots (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `topic` varchar(255) NOT NULL DEFAULT '' comment "配置主题",
+  `version` varchar(255) NOT NULL DEFAULT '' comment "配置版本",
+  `data` longtext comment "导出数据, gzip压缩, 配置主密钥时加密",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_topic_version` (`topic`, `version`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "配置快照";
+
+-- create config_channels
+DROP TABLE IF EXISTS `config_channels`;
+CREATE TABLE config_channels (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `topic` varchar(255) NOT NULL DEFAULT '' comment "配置主题",
+  `channel` varchar(32) NOT NULL DEFAULT '' comment "发布通道: canary/stable",
+  `version` varchar(255) NOT NULL DEFAULT '' comment "通道当前版本",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_topic_channel` (`topic`, `channel`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "配置发布通道";
+
+-- create bfe_cluster_config_targets
+DROP TABLE IF EXISTS `bfe_cluster_config_targets`;
+CREATE TABLE bfe_cluster_config_targets (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `bfe_cluster` varchar(255) NOT NULL DEFAULT '' comment "BFE集群名称",
+  `topic` varchar(255) NOT NULL DEFAULT '' comment "配置主题",
+  `channel` varchar(32) NOT NULL DEFAULT '' comment "跟随的发布通道, 与version二选一",
+  `version` varchar(255) NOT NULL DEFAULT '' comment "固定的版本, 与channel二选一",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_bfe_cluster_topic` (`bfe_cluster`, `topic`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "BFE集群配置目标";
+
+-- create config_rollbacks
+DROP TABLE IF EXISTS `config_rollbacks`;
+CREATE TABLE config_rollbacks (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT comment "表id",
+  `topic` varchar(255) NOT NULL DEFAULT '' comment "配置主题",
+  `version` varchar(255) NOT NULL DEFAULT '' comment "回滚生成的版本",
+  `from_version` varchar(255) NOT NULL DEFAULT '' comment "回滚到的历史版本",
+  `source_sign` varchar(255) NOT NULL DEFAULT '' comment "回滚时源数据签名, 源数据变化后回滚失效",
+  `created_by` varchar(255) NOT NULL DEFAULT '' comment "操作人",
+  `created_at` datetime NOT NULL DEFAULT '0000-01-01 00:00:00' COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP  comment "更新时间",
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_topic` (`topic`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 comment = "配置回滚";
+
 -- create ai_route_rules
 DROP TABLE IF EXISTS `ai_route_rules`;
 CREATE TABLE `ai_route_rules` (
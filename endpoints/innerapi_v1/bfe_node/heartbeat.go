@@ -0,0 +1,72 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package bfe_node
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// HeartbeatParam Request Param
+type HeartbeatParam struct {
+	NodeID       string `json:"node_id" validate:"required,max=255,excludesall=/"`
+	BFECluster   string `json:"bfe_cluster" validate:"required"`
+	BuildVersion string `json:"build_version" validate:"max=255"`
+	Addr         string `json:"addr" validate:"max=255"` // address of request is used if empty
+
+	// AppliedVersions is topic => version applied by the node
+	AppliedVersions map[string]string `json:"applied_versions" validate:"dive,required"`
+}
+
+// HeartbeatEndpoint route
+var HeartbeatEndpoint = &xreq.Endpoint{
+	Path:       "/bfe-nodes/heartbeat",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(HeartbeatAction),
+	Authorizer: iauth.FA(iauth.FeatureBFENode, iauth.ActionExport),
+}
+
+var _ xreq.Handler = HeartbeatAction
+
+// HeartbeatAction registers node or refreshes its heartbeat, applied versions are replaced by reported ones
+func HeartbeatAction(req *http.Request) (interface{}, error) {
+	param := &HeartbeatParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+
+	if param.Addr == "" {
+		param.Addr = req.RemoteAddr
+		if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+			param.Addr = host
+		}
+	}
+	if param.AppliedVersions == nil {
+		param.AppliedVersions = map[string]string{}
+	}
+
+	return nil, container.BFENodeManager.HeartbeatBFENode(req.Context(), &ibasic.BFENodeParam{
+		NodeID:          &param.NodeID,
+		BFECluster:      &param.BFECluster,
+		BuildVersion:    &param.BuildVersion,
+		Addr:            &param.Addr,
+		AppliedVersions: param.AppliedVersions,
+	})
+}
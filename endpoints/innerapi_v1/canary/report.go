@@ -0,0 +1,69 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package canary
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// MetricParam is metrics of one sub cluster collected since last report
+type MetricParam struct {
+	Cluster    string `json:"cluster" validate:"required"`
+	SubCluster string `json:"sub_cluster" validate:"required"`
+	Requests   int64  `json:"requests" validate:"min=0"`
+	Errors     int64  `json:"errors" validate:"min=0"`
+	LatencyMs  int64  `json:"latency_ms" validate:"min=0"` // average latency of these requests
+}
+
+// ReportParam Request Param
+type ReportParam struct {
+	Metrics []*MetricParam `json:"metrics" validate:"required,dive,required"`
+}
+
+// ReportMetricsEndpoint route
+var ReportMetricsEndpoint = &xreq.Endpoint{
+	Path:       "/canary/metrics",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(ReportMetricsAction),
+	Authorizer: iauth.FA(iauth.FeatureCanary, iauth.ActionExport),
+}
+
+var _ xreq.Handler = ReportMetricsAction
+
+// ReportMetricsAction saves metrics of canary sub clusters, metrics of other sub clusters are ignored
+func ReportMetricsAction(req *http.Request) (interface{}, error) {
+	param := &ReportParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+
+	var reports []*icluster_conf.CanaryMetricReport
+	for _, one := range param.Metrics {
+		reports = append(reports, &icluster_conf.CanaryMetricReport{
+			ClusterName: one.Cluster,
+			SubCluster:  one.SubCluster,
+			Requests:    one.Requests,
+			Errors:      one.Errors,
+			LatencyMs:   one.LatencyMs,
+		})
+	}
+
+	return nil, container.CanaryManager.ReportCanaryMetrics(req.Context(), reports)
+}
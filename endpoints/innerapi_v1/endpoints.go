@@ -17,8 +17,11 @@ package innerapi_v1
 import (
 	"github.com/gorilla/mux"
 
+	"github.com/yf-networks/ai-gateway-api/endpoints/innerapi_v1/bfe_node"
+	"github.com/yf-networks/ai-gateway-api/endpoints/innerapi_v1/canary"
 	"github.com/yf-networks/ai-gateway-api/endpoints/innerapi_v1/extra_file"
 	"github.com/yf-networks/ai-gateway-api/endpoints/innerapi_v1/gslb_data"
+	"github.com/yf-networks/ai-gateway-api/endpoints/innerapi_v1/health_check"
 	"github.com/yf-networks/ai-gateway-api/endpoints/innerapi_v1/mod_api_key"
 	"github.com/yf-networks/ai-gateway-api/endpoints/innerapi_v1/protocol"
 	"github.com/yf-networks/ai-gateway-api/endpoints/innerapi_v1/server_data"
@@ -34,12 +37,15 @@ func endpoints() []*xreq.Endpoint {
 		protocol.ServertCertExportEndpoint,
 		extra_file.ExportExtraFileEndpoint,
 		mod_api_key.ExportRoute,
+		health_check.ExportActiveHealthCheckEndpoint,
+		canary.ReportMetricsEndpoint,
+		bfe_node.HeartbeatEndpoint,
 	}
 }
 
 func RegisterRouter(router *mux.Router) *mux.Router {
 	innerAPIV1Router := router.PathPrefix("/inner-api/v1").Subrouter()
-	innerAPIV1Router.Use(middleware.McUserProbe)
+	innerAPIV1Router.Use(middleware.McUserProbe, middleware.McExportTrigger)
 
 	for _, one := range endpoints() {
 		one.Register(innerAPIV1Router)
@@ -22,6 +22,9 @@ import (
 
 type ExportParam struct {
 	Version string `form:"version"`
+
+	// BFECluster is optional, version targeted by it is exported if set
+	BFECluster string `form:"bfe_cluster"`
 }
 
 // AUTO GEN BY ctrl, MODIFY AS U NEED
@@ -34,7 +34,7 @@ var ExportClusterTableEndpoint = &xreq.Endpoint{
 }
 
 func ExportClusterTableActionProcess(req *http.Request, param *export_util.ExportParam) (*icluster_conf.ClusterTableConf, error) {
-	return container.ClusterManager.ExportClusterTable(req.Context(), param.Version)
+	return container.ClusterManager.ExportClusterTable(req.Context(), param.Version, param.BFECluster)
 }
 
 var _ xreq.Handler = ExportClusterTableAction
@@ -44,5 +44,5 @@ func exportActionProcess(req *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
-	return container.APIKeyRuleManager.ConfigExport(req.Context(), param.Version)
+	return container.APIKeyRuleManager.ConfigExport(req.Context(), param.Version, param.BFECluster)
 }
@@ -34,7 +34,7 @@ var ServertCertExportEndpoint = &xreq.Endpoint{
 }
 
 func exportActionProcess(req *http.Request, param *export_util.ExportParam) (*iprotocol.ServerCertConf, error) {
-	return container.CertificateManager.ExportServerCert(req.Context(), param.Version)
+	return container.CertificateManager.ExportServerCert(req.Context(), param.Version, param.BFECluster)
 }
 
 var _ xreq.Handler = ServerCertExportAction
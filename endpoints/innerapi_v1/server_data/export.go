@@ -34,7 +34,7 @@ var ExportEndpoint = &xreq.Endpoint{
 }
 
 func ExportActionProcess(req *http.Request, param *export_util.ExportParam) (*iroute_conf.RouteRuleExportData, error) {
-	return container.RouteRuleManager.ExportRouteRule(req.Context(), param.Version)
+	return container.RouteRuleManager.ExportRouteRule(req.Context(), param.Version, param.BFECluster)
 }
 
 var _ xreq.Handler = ExportAction
@@ -37,6 +37,7 @@ func convert(handler func(*http.Request) (*http.Request, error)) mux.MiddlewareF
 }
 
 var (
-	McProductProbe = convert(ProductProbeAction)
-	McUserProbe    = convert(UserProbeAction)
+	McProductProbe  = convert(ProductProbeAction)
+	McUserProbe     = convert(UserProbeAction)
+	McExportTrigger = convert(ExportTriggerAction)
 )
@@ -0,0 +1,32 @@
+// Copyright (c) 2021 The BFE Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/iversion_control"
+)
+
+// ExportTriggerAction records the visitor as the trigger of config versions created by the request
+func ExportTriggerAction(req *http.Request) (*http.Request, error) {
+	trigger := "export"
+	if visitor, err := iauth.MustGetVisitor(req.Context()); err == nil {
+		trigger = "export by " + visitor.GetName()
+	}
+
+	return req.WithContext(iversion_control.NewTriggerContext(req.Context(), trigger)), nil
+}
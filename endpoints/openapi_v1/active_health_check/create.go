@@ -0,0 +1,75 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package active_health_check
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// UpsertParam all fields are optional, default values are used when create
+type UpsertParam struct {
+	Enable             *bool   `json:"enable"`
+	Probe              *string `json:"probe" validate:"omitempty,oneof=model_list completion"`
+	Model              *string `json:"model"`
+	IntervalInS        *int32  `json:"interval_in_s"`
+	TimeoutInS         *int32  `json:"timeout_in_s"`
+	HealthyThreshold   *int32  `json:"healthy_threshold"`
+	UnhealthyThreshold *int32  `json:"unhealthy_threshold"`
+}
+
+func (param *UpsertParam) toModel() *icluster_conf.ActiveHealthCheckParam {
+	return &icluster_conf.ActiveHealthCheckParam{
+		Enable:             param.Enable,
+		Probe:              param.Probe,
+		Model:              param.Model,
+		IntervalInS:        param.IntervalInS,
+		TimeoutInS:         param.TimeoutInS,
+		HealthyThreshold:   param.HealthyThreshold,
+		UnhealthyThreshold: param.UnhealthyThreshold,
+	}
+}
+
+var CreateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/active-health-check",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(CreateAction),
+	Authorizer: iauth.FAP(iauth.FeatureActiveHealthCheck, iauth.ActionCreate),
+}
+
+var _ xreq.Handler = CreateAction
+
+// CreateAction creates health check of llm cluster
+func CreateAction(req *http.Request) (interface{}, error) {
+	cluster, err := fetchCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	param := &UpsertParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+
+	if err := container.ActiveHealthCheckManager.CreateActiveHealthCheck(req.Context(), cluster, param.toModel()); err != nil {
+		return nil, err
+	}
+
+	return container.ActiveHealthCheckManager.FetchActiveHealthCheck(req.Context(), cluster)
+}
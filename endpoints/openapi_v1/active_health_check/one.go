@@ -0,0 +1,89 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package active_health_check
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+type OneParam struct {
+	ClusterName *string `uri:"cluster_name" validate:"required,min=2"`
+}
+
+var OneEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/active-health-check",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(OneAction),
+	Authorizer: iauth.FAP(iauth.FeatureActiveHealthCheck, iauth.ActionRead),
+}
+
+// fetchCluster returns cluster in path
+func fetchCluster(req *http.Request) (*icluster_conf.Cluster, error) {
+	param := &OneParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := container.ClusterManager.FetchCluster(req.Context(), &icluster_conf.ClusterFilter{
+		Name:    param.ClusterName,
+		Product: product,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cluster == nil {
+		return nil, xerror.WrapRecordNotExist("Cluster")
+	}
+
+	return cluster, nil
+}
+
+// fetchActiveHealthCheck returns cluster in path and its health check
+func fetchActiveHealthCheck(req *http.Request) (*icluster_conf.Cluster, *icluster_conf.ActiveHealthCheck, error) {
+	cluster, err := fetchCluster(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	one, err := container.ActiveHealthCheckManager.FetchActiveHealthCheck(req.Context(), cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+	if one == nil {
+		return nil, nil, xerror.WrapRecordNotExist("Active Health Check")
+	}
+
+	return cluster, one, nil
+}
+
+var _ xreq.Handler = OneAction
+
+// OneAction returns health check of cluster
+func OneAction(req *http.Request) (interface{}, error) {
+	_, one, err := fetchActiveHealthCheck(req)
+	return one, err
+}
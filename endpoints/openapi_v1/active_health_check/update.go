@@ -0,0 +1,51 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package active_health_check
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+var UpdateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/active-health-check",
+	Method:     http.MethodPatch,
+	Handler:    xreq.Convert(UpdateAction),
+	Authorizer: iauth.FAP(iauth.FeatureActiveHealthCheck, iauth.ActionUpdate),
+}
+
+var _ xreq.Handler = UpdateAction
+
+// UpdateAction updates fields set in body
+func UpdateAction(req *http.Request) (interface{}, error) {
+	cluster, old, err := fetchActiveHealthCheck(req)
+	if err != nil {
+		return nil, err
+	}
+
+	param := &UpsertParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+
+	if err := container.ActiveHealthCheckManager.UpdateActiveHealthCheck(req.Context(), cluster, old, param.toModel()); err != nil {
+		return nil, err
+	}
+
+	return container.ActiveHealthCheckManager.FetchActiveHealthCheck(req.Context(), cluster)
+}
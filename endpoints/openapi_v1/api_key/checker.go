@@ -21,14 +21,16 @@ import (
 	"strings"
 	"time"
 
-	"github.com/yf-networks/ai-gateway-api/lib"
 	"github.com/yf-networks/ai-gateway-api/lib/xerror"
 	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
 )
 
 const (
 	maxLimit   = 100000000 // Maximum allowed quota limit
 	maxNameLen = 255       // Maximum length for API key name
+
+	maxUpstreamKeyLen = 4096 // Maximum length for upstream credential
 )
 
 // checkCreateAPIKey validates parameters for creating a new API key
@@ -52,7 +54,11 @@ func checkCreateAPIKey(param *icluster_conf.APIKeyParam, productName string) err
 		return err
 	}
 
-	if err := checkExpiredTime(param.ExpiredTime); err != nil {
+	if err := checkExpiredTime(param.ExpiredTime, param.EffectiveTime); err != nil {
+		return err
+	}
+
+	if err := checkUpstreamCredentials(param.UpstreamCredentials); err != nil {
 		return err
 	}
 
@@ -72,14 +78,20 @@ func checkName(name *string) error {
 	return nil
 }
 
-// checkExpiredTime validates the expiration time format
-func checkExpiredTime(expiredTime *string) error {
-	if expiredTime == nil || *expiredTime == "" {
-		return nil
+// checkExpiredTime validates the expiration time and effective time format
+func checkExpiredTime(expiredTime, effectiveTime *string) error {
+	now := time.Now()
+	for name, p := range map[string]*string{"expired_time": expiredTime, "effective_time": effectiveTime} {
+		if p == nil || *p == "" {
+			continue
+		}
+
+		if _, err := icluster_conf.NormalizeAPIKeyTime(*p, now); err != nil {
+			return xerror.WrapParamErrorWithMsg("%s: %v", name, err)
+		}
 	}
 
-	_, err := time.Parse(lib.FormatTimeYYMMDD_HHMMSS, *expiredTime)
-	return err
+	return nil
 }
 
 // checkUpdateAPIKey validates parameters for updating an existing API key
@@ -102,7 +114,11 @@ func checkUpdateAPIKey(param *icluster_conf.APIKeyParam, productName string) err
 		}
 	}
 
-	if err := checkExpiredTime(param.ExpiredTime); err != nil {
+	if err := checkExpiredTime(param.ExpiredTime, param.EffectiveTime); err != nil {
+		return err
+	}
+
+	if err := checkUpstreamCredentials(param.UpstreamCredentials); err != nil {
 		return err
 	}
 
@@ -188,3 +204,35 @@ func checkAllowSubnet(cidrs []string) error {
 
 	return nil
 }
+
+// checkUpstreamCredentials validates the tenant owned provider credentials
+func checkUpstreamCredentials(credentials []*icluster_conf.UpstreamCredential) error {
+	targets := map[string]bool{}
+	for i, one := range credentials {
+		if one == nil {
+			return xerror.WrapParamErrorWithMsg(fmt.Sprintf("upstream_credentials[%d] is null", i))
+		}
+
+		hasCluster := one.Cluster != nil && *one.Cluster != ""
+		hasProvider := one.ProviderType != nil && *one.ProviderType != ""
+		if hasCluster == hasProvider {
+			return xerror.WrapParamErrorWithMsg(fmt.Sprintf("upstream_credentials[%d] must set one of cluster and provider_type", i))
+		}
+
+		if one.Key != nil && len(*one.Key) > maxUpstreamKeyLen {
+			return xerror.WrapParamErrorWithMsg(fmt.Sprintf("upstream_credentials[%d].key length must be lower than %d", i, maxUpstreamKeyLen))
+		}
+		if one.Key != nil {
+			if err := isecret.CheckPlain(fmt.Sprintf("upstream_credentials[%d].key", i), *one.Key); err != nil {
+				return err
+			}
+		}
+
+		if targets[one.Target()] {
+			return xerror.WrapParamErrorWithMsg(fmt.Sprintf("upstream_credentials[%d] duplicate with %s", i, one.Target()))
+		}
+		targets[one.Target()] = true
+	}
+
+	return nil
+}
@@ -54,16 +54,19 @@ func APIKeyCreateProcess(ctx context.Context, param *icluster_conf.APIKeyParam,
 		return nil, xerror.WrapParamError(err)
 	}
 
-	err := container.APIKeyManager.CreateAPIKey(ctx, &icluster_conf.APIKeyParam{
+	err := container.APIKeyManager.CreateAPIKey(ctx, product, &icluster_conf.APIKeyParam{
 		Name:          param.Name,
 		Enable:        param.Enable,
 		Key:           param.Key,
 		IsLimit:       param.IsLimit,
 		Limit:         param.Limit,
 		ExpiredTime:   param.ExpiredTime,
+		EffectiveTime: param.EffectiveTime,
 		AllowedModels: param.AllowedModels,
 		AllowedCIDR:   param.AllowedCIDR,
 		ProductName:   &product.Name,
+
+		UpstreamCredentials: param.UpstreamCredentials,
 	})
 
 	return nil, err
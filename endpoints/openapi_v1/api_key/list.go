@@ -32,7 +32,12 @@ var ListRoute = &xreq.Endpoint{
 	Authorizer: iauth.FAP(iauth.FeatureAPIKey, iauth.ActionReadAll),
 }
 
-var _ xreq.Handler = OneAction
+// ListParam filters of api key list, status can be set multiple times
+type ListParam struct {
+	Status []string `form:"status" validate:"dive,oneof=disabled pending enabled expiring expired exhausted"`
+}
+
+var _ xreq.Handler = ListAction
 
 func ListAction(req *http.Request) (interface{}, error) {
 	product, err := ibasic.MustGetProduct(req.Context())
@@ -40,26 +45,13 @@ func ListAction(req *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
-	list, err := container.APIKeyManager.FetchAPIKeyList(req.Context(), &icluster_conf.APIKeyFilter{
-		ProductName: &product.Name,
-	})
-	if err != nil {
+	param := &ListParam{}
+	if err := xreq.BindForm(req, param); err != nil {
 		return nil, err
 	}
 
-	return newResponse(list)
-}
-
-func newResponse(list []*icluster_conf.APIKeyParam) ([]*icluster_conf.APIKeyParam, error) {
-	for i, one := range list {
-		if one.Key != nil {
-			remainingQuota, err := icluster_conf.GetRemainingQuota(one)
-			if err != nil {
-				return nil, err
-			}
-			list[i].RemainingQuota = remainingQuota
-		}
-	}
-
-	return list, nil
+	return container.APIKeyManager.FetchAPIKeyList(req.Context(), &icluster_conf.APIKeyFilter{
+		ProductName: &product.Name,
+		Statuses:    param.Status,
+	})
 }
@@ -69,11 +69,7 @@ func OneAction(req *http.Request) (interface{}, error) {
 		return nil, nil
 	}
 
-	response, err := newResponse([]*icluster_conf.APIKeyParam{one})
-	if err != nil {
-		return nil, err
-	}
-	return response[0], nil
+	return one, nil
 }
 
 func newReq4One(req *http.Request) (*OneReq, error) {
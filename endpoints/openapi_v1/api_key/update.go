@@ -69,7 +69,7 @@ func APIKeyUpdateProcess(ctx context.Context, param *icluster_conf.APIKeyParam,
 		return nil, xerror.WrapParamError(err)
 	}
 
-	return nil, container.APIKeyManager.UpdateAPIKey(ctx, &icluster_conf.APIKeyFilter{
+	return nil, container.APIKeyManager.UpdateAPIKey(ctx, product, &icluster_conf.APIKeyFilter{
 		Name:        param.Name,
 		ProductName: &product.Name,
 	}, &icluster_conf.APIKeyParam{
@@ -78,8 +78,11 @@ func APIKeyUpdateProcess(ctx context.Context, param *icluster_conf.APIKeyParam,
 		IsLimit:       param.IsLimit,
 		Limit:         param.Limit,
 		ExpiredTime:   param.ExpiredTime,
+		EffectiveTime: param.EffectiveTime,
 		AllowedModels: param.AllowedModels,
 		AllowedCIDR:   param.AllowedCIDR,
 		ProductName:   &product.Name,
+
+		UpstreamCredentials: param.UpstreamCredentials,
 	})
 }
@@ -29,6 +29,8 @@ import (
 type BFEClusterCreateParam struct {
 	Name *string `json:"name" uri:"name" validate:"required,min=1"`
 	Pool *string `json:"pool" uri:"pool" validate:"required,min=1"`
+
+	Capacity *int64 `json:"capacity" uri:"capacity" validate:"omitempty,min=0"`
 }
 
 // CreateRoute route
@@ -48,10 +50,15 @@ func newBFEClusterCreateParam4Create(req *http.Request) (*BFEClusterCreateParam,
 }
 
 func createActionProcess(req *http.Request, param *BFEClusterCreateParam) error {
+	capacity := param.Capacity
+	if capacity == nil {
+		capacity = lib.PInt64(0)
+	}
+
 	return container.BFEClusterManager.CreateBFECluster(req.Context(), &ibasic.BFEClusterParam{
 		Name:     param.Name,
 		Pool:     param.Pool,
-		Capacity: lib.PInt64(0),
+		Capacity: capacity,
 	})
 }
 
@@ -22,4 +22,5 @@ var Endpoints = []*xreq.Endpoint{
 	CreateEndpoint,
 	DeleteEndpoint,
 	ListEndpoint,
+	UpdateEndpoint,
 }
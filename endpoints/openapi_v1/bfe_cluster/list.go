@@ -27,6 +27,8 @@ import (
 type BFEClusterDetail struct {
 	Name string `json:"name" uri:"name"`
 	Pool string `json:"pool" uri:"pool"`
+
+	Capacity int64 `json:"capacity" uri:"capacity"`
 }
 
 // ListRoute route
@@ -47,8 +49,9 @@ func listActionProcess(req *http.Request) ([]*BFEClusterDetail, error) {
 	rst := []*BFEClusterDetail{}
 	for _, one := range list {
 		rst = append(rst, &BFEClusterDetail{
-			Name: one.Name,
-			Pool: one.Pool,
+			Name:     one.Name,
+			Pool:     one.Pool,
+			Capacity: one.Capacity,
 		})
 	}
 	return rst, nil
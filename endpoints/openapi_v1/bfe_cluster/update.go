@@ -0,0 +1,52 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package bfe_cluster
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// BFEClusterUpdateParam Request Param
+type BFEClusterUpdateParam struct {
+	Name     *string `uri:"name" validate:"required,min=1"`
+	Capacity *int64  `json:"capacity" validate:"required,min=0"`
+}
+
+// UpdateEndpoint only capacity can be changed, lb matrix of clusters in auto scheduler mode are recomputed
+var UpdateEndpoint = &xreq.Endpoint{
+	Path:       "/bfe-clusters/{name}",
+	Method:     http.MethodPatch,
+	Handler:    xreq.Convert(UpdateAction),
+	Authorizer: iauth.FA(iauth.FeatureBFECluster, iauth.ActionUpdate),
+}
+
+var _ xreq.Handler = UpdateAction
+
+// UpdateAction action
+func UpdateAction(req *http.Request) (interface{}, error) {
+	param := &BFEClusterUpdateParam{}
+	if err := xreq.Bind(req, param); err != nil {
+		return nil, err
+	}
+
+	return nil, container.BFEClusterManager.UpdateBFECluster(req.Context(), *param.Name, &ibasic.BFEClusterParam{
+		Capacity: param.Capacity,
+	})
+}
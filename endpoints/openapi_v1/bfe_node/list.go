@@ -0,0 +1,69 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package bfe_node
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// ListParam filters of bfe node list
+type ListParam struct {
+	BFECluster *string `form:"bfe_cluster"`
+	Stale      *bool   `form:"stale"`
+	Drift      *bool   `form:"drift"`
+}
+
+var ListEndpoint = &xreq.Endpoint{
+	Path:       "/bfe-nodes",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(ListAction),
+	Authorizer: iauth.FA(iauth.FeatureBFENode, iauth.ActionReadAll),
+}
+
+var _ xreq.Handler = ListAction
+
+// ListAction returns bfe nodes, stale nodes and nodes with version drift can be filtered
+func ListAction(req *http.Request) (interface{}, error) {
+	param := &ListParam{}
+	if err := xreq.BindForm(req, param); err != nil {
+		return nil, err
+	}
+
+	list, err := container.BFENodeManager.FetchBFENodeStatuses(req.Context(), &ibasic.BFENodeFilter{
+		BFECluster: param.BFECluster,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rst := []*OneData{}
+	for _, one := range list {
+		if param.Stale != nil && *param.Stale != one.Stale {
+			continue
+		}
+		if param.Drift != nil && *param.Drift != one.Drift {
+			continue
+		}
+
+		rst = append(rst, newOneData(one))
+	}
+
+	return rst, nil
+}
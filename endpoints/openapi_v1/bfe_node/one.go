@@ -0,0 +1,100 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package bfe_node
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+type OneParam struct {
+	NodeID *string `uri:"node_id" validate:"required"`
+}
+
+// TopicData compares version applied by node with the one it should be served
+type TopicData struct {
+	Topic          string `json:"topic"`
+	AppliedVersion string `json:"applied_version"`
+	LatestVersion  string `json:"latest_version"`
+	TargetVersion  string `json:"target_version"`
+	Drift          bool   `json:"drift"`
+}
+
+// OneData is the response of bfe node
+type OneData struct {
+	NodeID       string       `json:"node_id"`
+	BFECluster   string       `json:"bfe_cluster"`
+	BuildVersion string       `json:"build_version"`
+	Addr         string       `json:"addr"`
+	HeartbeatAt  time.Time    `json:"heartbeat_at"`
+	Stale        bool         `json:"stale"`
+	Drift        bool         `json:"drift"`
+	Topics       []*TopicData `json:"topics"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+var OneEndpoint = &xreq.Endpoint{
+	Path:       "/bfe-nodes/{node_id}",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(OneAction),
+	Authorizer: iauth.FA(iauth.FeatureBFENode, iauth.ActionRead),
+}
+
+func newOneData(status *ibasic.BFENodeStatus) *OneData {
+	rst := &OneData{
+		NodeID:       status.NodeID,
+		BFECluster:   status.BFECluster,
+		BuildVersion: status.BuildVersion,
+		Addr:         status.Addr,
+		HeartbeatAt:  status.HeartbeatAt,
+		Stale:        status.Stale,
+		Drift:        status.Drift,
+		Topics:       []*TopicData{},
+		CreatedAt:    status.CreatedAt,
+	}
+	for _, one := range status.Topics {
+		rst.Topics = append(rst.Topics, &TopicData{
+			Topic:          one.Topic,
+			AppliedVersion: one.AppliedVersion,
+			LatestVersion:  one.LatestVersion,
+			TargetVersion:  one.TargetVersion,
+			Drift:          one.Drift,
+		})
+	}
+
+	return rst
+}
+
+var _ xreq.Handler = OneAction
+
+// OneAction returns bfe node with its stale and drift status
+func OneAction(req *http.Request) (interface{}, error) {
+	param := &OneParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	status, err := container.BFENodeManager.FetchBFENodeStatus(req.Context(), *param.NodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOneData(status), nil
+}
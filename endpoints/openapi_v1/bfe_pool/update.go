@@ -52,11 +52,12 @@ func UpdateAction(req *http.Request) (interface{}, error) {
 		return nil, xerror.WrapRecordNotExist("Instance Pool")
 	}
 
-	err = container.PoolManager.UpdateBFEPool(req.Context(), one, &icluster_conf.PoolParam{
+	one, err = container.PoolManager.UpdateBFEPool(req.Context(), one, &icluster_conf.PoolParam{
 		Instances: product_pool.Instancesc2i(param.Instances),
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	one.Instances = product_pool.Instancesc2i(param.Instances)
-
-	return product_pool.NewOneData(one), err
+	return product_pool.NewOneData(one), nil
 }
@@ -0,0 +1,117 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package canary
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// RollbackParam Request Param
+type RollbackParam struct {
+	Reason string `json:"reason" validate:"required,max=1024"`
+}
+
+var PauseEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/canary/pause",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(PauseAction),
+	Authorizer: iauth.FAP(iauth.FeatureCanary, iauth.ActionUpdate),
+}
+
+var ResumeEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/canary/resume",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(ResumeAction),
+	Authorizer: iauth.FAP(iauth.FeatureCanary, iauth.ActionUpdate),
+}
+
+var PromoteEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/canary/promote",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(PromoteAction),
+	Authorizer: iauth.FAP(iauth.FeatureCanary, iauth.ActionUpdate),
+}
+
+var RollbackEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/canary/rollback",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(RollbackAction),
+	Authorizer: iauth.FAP(iauth.FeatureCanary, iauth.ActionUpdate),
+}
+
+// actionProcess changes status of canary in path by fn
+func actionProcess(req *http.Request, fn func(ctx context.Context, canary *icluster_conf.Canary,
+	operator string) (*icluster_conf.Canary, error)) (*OneData, error) {
+
+	canary, err := fetchCanary(req)
+	if err != nil {
+		return nil, err
+	}
+
+	operator, err := visitorName(req)
+	if err != nil {
+		return nil, err
+	}
+
+	canary, err = fn(req.Context(), canary, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOneData(req, canary)
+}
+
+var _ xreq.Handler = PauseAction
+
+// PauseAction stops stepping up, thresholds are still checked
+func PauseAction(req *http.Request) (interface{}, error) {
+	return actionProcess(req, container.CanaryManager.PauseCanary)
+}
+
+var _ xreq.Handler = ResumeAction
+
+// ResumeAction continues paused canary
+func ResumeAction(req *http.Request) (interface{}, error) {
+	return actionProcess(req, container.CanaryManager.ResumeCanary)
+}
+
+var _ xreq.Handler = PromoteAction
+
+// PromoteAction applies the last step at once and ends canary
+func PromoteAction(req *http.Request) (interface{}, error) {
+	return actionProcess(req, container.CanaryManager.PromoteCanary)
+}
+
+var _ xreq.Handler = RollbackAction
+
+// RollbackAction restores lb matrix before canary started and ends canary
+func RollbackAction(req *http.Request) (interface{}, error) {
+	param := &RollbackParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+
+	return actionProcess(req, func(ctx context.Context, canary *icluster_conf.Canary,
+		operator string) (*icluster_conf.Canary, error) {
+
+		return container.CanaryManager.RollbackCanary(ctx, canary, operator, param.Reason)
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package canary
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// CreateParam Request Param, default values are used for optional fields
+type CreateParam struct {
+	SubCluster      *string  `json:"sub_cluster" validate:"required,min=1"`
+	Steps           []int    `json:"steps"`
+	StepIntervalInS *int64   `json:"step_interval_in_s"`
+	MaxErrorRate    *float64 `json:"max_error_rate" validate:"required"`
+	MaxLatencyMs    *int64   `json:"max_latency_ms"`
+	MinRequests     *int64   `json:"min_requests"`
+}
+
+var CreateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/canary",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(CreateAction),
+	Authorizer: iauth.FAP(iauth.FeatureCanary, iauth.ActionCreate),
+}
+
+// visitorName returns name of user who calls the api
+func visitorName(req *http.Request) (string, error) {
+	visitor, err := iauth.MustGetVisitor(req.Context())
+	if err != nil {
+		return "", err
+	}
+
+	return visitor.GetName(), nil
+}
+
+var _ xreq.Handler = CreateAction
+
+// CreateAction starts canary of cluster
+func CreateAction(req *http.Request) (interface{}, error) {
+	param := &CreateParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+
+	cluster, err := fetchCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	operator, err := visitorName(req)
+	if err != nil {
+		return nil, err
+	}
+
+	canary, err := container.CanaryManager.CreateCanary(req.Context(), cluster, &icluster_conf.CanaryParam{
+		SubCluster:      param.SubCluster,
+		Steps:           param.Steps,
+		StepIntervalInS: param.StepIntervalInS,
+		MaxErrorRate:    param.MaxErrorRate,
+		MaxLatencyMs:    param.MaxLatencyMs,
+		MinRequests:     param.MinRequests,
+	}, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOneData(req, canary)
+}
@@ -0,0 +1,46 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package canary
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+var DeleteEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/canary",
+	Method:     http.MethodDelete,
+	Handler:    xreq.Convert(DeleteAction),
+	Authorizer: iauth.FAP(iauth.FeatureCanary, iauth.ActionDelete),
+}
+
+var _ xreq.Handler = DeleteAction
+
+// DeleteAction deletes promoted or rolled back canary together with its logs
+func DeleteAction(req *http.Request) (interface{}, error) {
+	canary, err := fetchCanary(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = container.CanaryManager.DeleteCanary(req.Context(), canary); err != nil {
+		return nil, err
+	}
+
+	return newOneData(req, canary)
+}
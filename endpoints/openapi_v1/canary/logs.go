@@ -0,0 +1,70 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package canary
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// LogData is one audit log of canary
+type LogData struct {
+	Step      int       `json:"step"`
+	Percent   int       `json:"percent"`
+	Action    string    `json:"action"`
+	Message   string    `json:"message"`
+	Operator  string    `json:"operator"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+var LogsEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/canary/logs",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(LogsAction),
+	Authorizer: iauth.FAP(iauth.FeatureCanary, iauth.ActionRead),
+}
+
+var _ xreq.Handler = LogsAction
+
+// LogsAction returns audit logs of canary in time order
+func LogsAction(req *http.Request) (interface{}, error) {
+	canary, err := fetchCanary(req)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := container.CanaryManager.FetchCanaryLogs(req.Context(), canary)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := []*LogData{}
+	for _, one := range list {
+		rst = append(rst, &LogData{
+			Step:      one.Step,
+			Percent:   one.Percent,
+			Action:    one.Action,
+			Message:   one.Message,
+			Operator:  one.Operator,
+			CreatedAt: one.CreatedAt,
+		})
+	}
+
+	return rst, nil
+}
@@ -0,0 +1,167 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package canary
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+type OneParam struct {
+	ClusterName *string `uri:"cluster_name" validate:"required,min=2"`
+}
+
+// MetricsData is the metrics reported for canary sub cluster in current step
+type MetricsData struct {
+	Requests     int64   `json:"requests"`
+	Errors       int64   `json:"errors"`
+	ErrorRate    float64 `json:"error_rate"`
+	AvgLatencyMs int64   `json:"avg_latency_ms"`
+}
+
+// OneData is the response of canary
+type OneData struct {
+	ClusterName     string  `json:"cluster_name"`
+	SubCluster      string  `json:"sub_cluster"`
+	Status          string  `json:"status"`
+	Steps           []int   `json:"steps"`
+	StepIntervalInS int64   `json:"step_interval_in_s"`
+	MaxErrorRate    float64 `json:"max_error_rate"`
+	MaxLatencyMs    int64   `json:"max_latency_ms"`
+	MinRequests     int64   `json:"min_requests"`
+
+	BaseLbMatrix  map[string]map[string]int `json:"base_lb_matrix"`
+	Step          int                       `json:"step"`
+	Percent       int                       `json:"percent"`
+	StepStartedAt time.Time                 `json:"step_started_at"`
+	PausedAt      *time.Time                `json:"paused_at,omitempty"`
+	Reason        string                    `json:"reason,omitempty"`
+	Metrics       *MetricsData              `json:"metrics,omitempty"`
+
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+var OneEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/canary",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(OneAction),
+	Authorizer: iauth.FAP(iauth.FeatureCanary, iauth.ActionRead),
+}
+
+// fetchCluster returns cluster in path
+func fetchCluster(req *http.Request) (*icluster_conf.Cluster, error) {
+	param := &OneParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := container.ClusterManager.FetchCluster(req.Context(), &icluster_conf.ClusterFilter{
+		Name:    param.ClusterName,
+		Product: product,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cluster == nil {
+		return nil, xerror.WrapRecordNotExist("Cluster")
+	}
+
+	return cluster, nil
+}
+
+// fetchCanary returns canary of cluster in path
+func fetchCanary(req *http.Request) (*icluster_conf.Canary, error) {
+	cluster, err := fetchCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	canary, err := container.CanaryManager.FetchCanary(req.Context(), cluster)
+	if err != nil {
+		return nil, err
+	}
+	if canary == nil {
+		return nil, xerror.WrapRecordNotExist("Canary")
+	}
+
+	return canary, nil
+}
+
+// newOneData converts canary to response, metrics of current step are returned for active canary
+func newOneData(req *http.Request, canary *icluster_conf.Canary) (*OneData, error) {
+	rst := &OneData{
+		ClusterName:     canary.ClusterName,
+		SubCluster:      canary.SubCluster,
+		Status:          canary.Status,
+		Steps:           canary.Steps,
+		StepIntervalInS: canary.StepIntervalInS,
+		MaxErrorRate:    canary.MaxErrorRate,
+		MaxLatencyMs:    canary.MaxLatencyMs,
+		MinRequests:     canary.MinRequests,
+		BaseLbMatrix:    canary.BaseLbMatrix,
+		Step:            canary.Step,
+		Percent:         canary.Percent(),
+		StepStartedAt:   canary.StepStartedAt,
+		Reason:          canary.Reason,
+		CreatedBy:       canary.CreatedBy,
+		CreatedAt:       canary.CreatedAt,
+		UpdatedAt:       canary.UpdatedAt,
+	}
+	if canary.Status == icluster_conf.CanaryStatusPaused {
+		pausedAt := canary.PausedAt
+		rst.PausedAt = &pausedAt
+	}
+
+	if canary.Active() {
+		metrics, err := container.CanaryManager.FetchCanaryMetrics(req.Context(), canary)
+		if err != nil {
+			return nil, err
+		}
+		rst.Metrics = &MetricsData{
+			Requests:     metrics.Requests,
+			Errors:       metrics.Errors,
+			ErrorRate:    metrics.ErrorRate(),
+			AvgLatencyMs: metrics.AvgLatencyMs(),
+		}
+	}
+
+	return rst, nil
+}
+
+var _ xreq.Handler = OneAction
+
+// OneAction returns canary of cluster
+func OneAction(req *http.Request) (interface{}, error) {
+	canary, err := fetchCanary(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOneData(req, canary)
+}
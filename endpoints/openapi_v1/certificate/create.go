@@ -17,9 +17,11 @@ package certificate
 import (
 	"net/http"
 
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
 	"github.com/yf-networks/ai-gateway-api/lib/xreq"
 	"github.com/yf-networks/ai-gateway-api/model/iauth"
 	"github.com/yf-networks/ai-gateway-api/model/iprotocol"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
 	"github.com/yf-networks/ai-gateway-api/stateful/container"
 )
 
@@ -42,16 +44,28 @@ type CreateParam struct {
 
 	CertFileName    *string `json:"cert_file_name" validate:"required,min=2"`
 	CertFileContent *string `json:"cert_file_content" validate:"required,min=2"`
-	KeyFileName     *string `json:"key_file_name" validate:"required,min=2"`
-	KeyFileContent  *string `json:"key_file_content" validate:"required,min=2"`
+	KeyFileName     *string `json:"key_file_name" validate:"omitempty,min=2"` // ca certificate if not set
+	KeyFileContent  *string `json:"key_file_content" validate:"omitempty,min=2"`
 	ExpiredDate     *string `json:"expired_date" validate:"required,min=2"`
 }
 
 // AUTO GEN BY ctrl, MODIFY AS U NEED
 func newCreateParam4Create(req *http.Request) (*CreateParam, error) {
 	param := &CreateParam{}
-	err := xreq.BindJSON(req, param)
-	return param, err
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+
+	if (param.KeyFileName == nil) != (param.KeyFileContent == nil) {
+		return nil, xerror.WrapParamErrorWithMsg("key_file_name And key_file_content Must Be Set Together")
+	}
+	if param.KeyFileContent != nil {
+		if err := isecret.CheckPlain("key_file_content", *param.KeyFileContent); err != nil {
+			return nil, err
+		}
+	}
+
+	return param, nil
 }
 
 func createActionProcess(req *http.Request, param *CreateParam) (*OneData, error) {
@@ -0,0 +1,112 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package config_version
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/iversion_control"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// ChannelData is the response of release channel
+type ChannelData struct {
+	Topic     string    `json:"topic"`
+	Channel   string    `json:"channel"`
+	Version   string    `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newChannelData(one *iversion_control.ConfigChannel) *ChannelData {
+	return &ChannelData{
+		Topic:     one.Topic,
+		Channel:   one.Channel,
+		Version:   one.Version,
+		UpdatedAt: one.UpdatedAt,
+	}
+}
+
+var ListChannelEndpoint = &xreq.Endpoint{
+	Path:       "/config-channels",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(ListChannelAction),
+	Authorizer: iauth.FA(iauth.FeatureConfigVersion, iauth.ActionReadAll),
+}
+
+var _ xreq.Handler = ListChannelAction
+
+// ListChannelAction returns promoted channels sorted by topic and channel
+func ListChannelAction(req *http.Request) (interface{}, error) {
+	list, err := container.VersionControlManager.FetchConfigChannels(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Topic != list[j].Topic {
+			return list[i].Topic < list[j].Topic
+		}
+		return list[i].Channel < list[j].Channel
+	})
+
+	rst := []*ChannelData{}
+	for _, one := range list {
+		rst = append(rst, newChannelData(one))
+	}
+
+	return rst, nil
+}
+
+// PromoteParam Request Param
+type PromoteParam struct {
+	Channel *string `uri:"channel" validate:"required,oneof=canary stable"`
+
+	Topic   *string `json:"topic" validate:"required"`
+	Version *string `json:"version"` // the latest version is promoted if empty
+}
+
+var PromoteChannelEndpoint = &xreq.Endpoint{
+	Path:       "/config-channels/{channel}/promote",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(PromoteChannelAction),
+	Authorizer: iauth.FA(iauth.FeatureConfigVersion, iauth.ActionUpdate),
+}
+
+var _ xreq.Handler = PromoteChannelAction
+
+// PromoteChannelAction advances channel of topic to a new version,
+// bfe clusters following the channel are served it since next export
+func PromoteChannelAction(req *http.Request) (interface{}, error) {
+	param := &PromoteParam{}
+	if err := xreq.Bind(req, param); err != nil {
+		return nil, err
+	}
+
+	var version string
+	if param.Version != nil {
+		version = *param.Version
+	}
+
+	channel, err := container.VersionControlManager.PromoteConfigChannel(req.Context(), *param.Channel, *param.Topic, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return newChannelData(channel), nil
+}
@@ -0,0 +1,231 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package config_version
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/iversion_control"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+type TopicParam struct {
+	Topic *string `uri:"topic" validate:"required,min=1"`
+}
+
+// VersionData is the response of config version
+type VersionData struct {
+	Version     string    `json:"version"`
+	TriggeredBy string    `json:"triggered_by"`
+	HasSnapshot bool      `json:"has_snapshot"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// RollbackData is the response of active rollback
+type RollbackData struct {
+	Version     string    `json:"version"`
+	FromVersion string    `json:"from_version"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func newRollbackData(one *iversion_control.ConfigRollback) *RollbackData {
+	if one == nil {
+		return nil
+	}
+
+	return &RollbackData{
+		Version:     one.Version,
+		FromVersion: one.FromVersion,
+		CreatedBy:   one.CreatedBy,
+		CreatedAt:   one.CreatedAt,
+	}
+}
+
+// HistoryData is the response of versions of topic
+type HistoryData struct {
+	Topic    string         `json:"topic"`
+	Versions []*VersionData `json:"versions"`
+	Rollback *RollbackData  `json:"rollback"`
+}
+
+var ListVersionEndpoint = &xreq.Endpoint{
+	Path:       "/config-topics/{topic}/versions",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(ListVersionAction),
+	Authorizer: iauth.FA(iauth.FeatureConfigVersion, iauth.ActionReadAll),
+}
+
+var _ xreq.Handler = ListVersionAction
+
+// ListVersionAction returns versions of topic, the latest first
+func ListVersionAction(req *http.Request) (interface{}, error) {
+	param := &TopicParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	history, err := container.VersionControlManager.FetchConfigHistory(req.Context(), *param.Topic)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := &HistoryData{
+		Topic:    history.Topic,
+		Versions: []*VersionData{},
+		Rollback: newRollbackData(history.Rollback),
+	}
+	for _, one := range history.Versions {
+		rst.Versions = append(rst.Versions, &VersionData{
+			Version:     one.Version,
+			TriggeredBy: one.TriggeredBy,
+			HasSnapshot: one.HasSnapshot,
+			CreatedAt:   one.CreatedAt,
+		})
+	}
+
+	return rst, nil
+}
+
+// DiffParam Request Param
+type DiffParam struct {
+	From *string `form:"from" validate:"required,min=1"`
+	To   *string `form:"to" validate:"required,min=1"`
+}
+
+// DiffItemData is one difference, path is a json pointer of exported data
+type DiffItemData struct {
+	Path string      `json:"path"`
+	Type string      `json:"type"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// DiffData is the response of diff
+type DiffData struct {
+	Topic string          `json:"topic"`
+	From  string          `json:"from"`
+	To    string          `json:"to"`
+	Diffs []*DiffItemData `json:"diffs"`
+}
+
+var DiffVersionEndpoint = &xreq.Endpoint{
+	Path:       "/config-topics/{topic}/diff",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(DiffVersionAction),
+	Authorizer: iauth.FA(iauth.FeatureConfigVersion, iauth.ActionReadAll),
+}
+
+var _ xreq.Handler = DiffVersionAction
+
+// DiffVersionAction returns differences between exported data of two versions of topic,
+// exported data holds secrets in plain text (even in paths, like api key values), so it
+// requires permission to reveal secrets too
+func DiffVersionAction(req *http.Request) (interface{}, error) {
+	err := container.AuthorizeManager.Authorizate(req.Context(), iauth.FA(iauth.FeatureSecret, iauth.ActionRead))
+	if err != nil {
+		return nil, err
+	}
+
+	topic := &TopicParam{}
+	if err := xreq.BindURI(req, topic); err != nil {
+		return nil, err
+	}
+	param := &DiffParam{}
+	if err := xreq.BindForm(req, param); err != nil {
+		return nil, err
+	}
+
+	diffs, err := container.VersionControlManager.DiffConfigVersions(req.Context(), *topic.Topic, *param.From, *param.To)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := &DiffData{
+		Topic: *topic.Topic,
+		From:  *param.From,
+		To:    *param.To,
+		Diffs: []*DiffItemData{},
+	}
+	for _, one := range diffs {
+		rst.Diffs = append(rst.Diffs, &DiffItemData{
+			Path: one.Path,
+			Type: one.Type,
+			From: one.From,
+			To:   one.To,
+		})
+	}
+
+	return rst, nil
+}
+
+// RollbackParam Request Param
+type RollbackParam struct {
+	TopicParam
+
+	Version *string `json:"version" validate:"required,min=1"`
+}
+
+var RollbackEndpoint = &xreq.Endpoint{
+	Path:       "/config-topics/{topic}/rollback",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(RollbackAction),
+	Authorizer: iauth.FA(iauth.FeatureConfigVersion, iauth.ActionUpdate),
+}
+
+var _ xreq.Handler = RollbackAction
+
+// RollbackAction serves snapshot of an old version as a new version of topic since next export,
+// until the source data changed
+func RollbackAction(req *http.Request) (interface{}, error) {
+	param := &RollbackParam{}
+	if err := xreq.Bind(req, param); err != nil {
+		return nil, err
+	}
+
+	visitor, err := iauth.MustGetVisitor(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	rollback, err := container.VersionControlManager.RollbackConfig(req.Context(), *param.Topic, *param.Version, visitor.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	return newRollbackData(rollback), nil
+}
+
+var CancelRollbackEndpoint = &xreq.Endpoint{
+	Path:       "/config-topics/{topic}/rollback",
+	Method:     http.MethodDelete,
+	Handler:    xreq.Convert(CancelRollbackAction),
+	Authorizer: iauth.FA(iauth.FeatureConfigVersion, iauth.ActionDelete),
+}
+
+var _ xreq.Handler = CancelRollbackAction
+
+// CancelRollbackAction ends the active rollback of topic, data generated from source is exported next time
+func CancelRollbackAction(req *http.Request) (interface{}, error) {
+	param := &TopicParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	return nil, container.VersionControlManager.CancelConfigRollback(req.Context(), *param.Topic)
+}
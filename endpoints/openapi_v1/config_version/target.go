@@ -0,0 +1,168 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package config_version
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/iversion_control"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+type TargetParam struct {
+	Name  *string `uri:"name" validate:"required,min=1"`
+	Topic *string `uri:"topic"`
+}
+
+// TargetData is the response of config target
+type TargetData struct {
+	Topic           string    `json:"topic"`
+	Channel         string    `json:"channel,omitempty"`
+	Version         string    `json:"version,omitempty"`
+	ResolvedVersion string    `json:"resolved_version"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// fetchBFECluster returns bfe cluster in path
+func fetchBFECluster(req *http.Request, param *TargetParam) (*ibasic.BFECluster, error) {
+	list, err := container.BFEClusterManager.FetchBFEClusters(req.Context(), &ibasic.BFEClusterFilter{
+		Name: param.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, xerror.WrapRecordNotExist("BFE Cluster")
+	}
+
+	return list[0], nil
+}
+
+var ListTargetEndpoint = &xreq.Endpoint{
+	Path:       "/bfe-clusters/{name}/config-targets",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(ListTargetAction),
+	Authorizer: iauth.FA(iauth.FeatureConfigVersion, iauth.ActionReadAll),
+}
+
+var _ xreq.Handler = ListTargetAction
+
+// ListTargetAction returns config targets of bfe cluster, topics not in list are served the latest version
+func ListTargetAction(req *http.Request) (interface{}, error) {
+	param := &TargetParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	cluster, err := fetchBFECluster(req, param)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := container.VersionControlManager.FetchConfigTargets(req.Context(), cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Topic < list[j].Topic
+	})
+
+	rst := []*TargetData{}
+	for _, one := range list {
+		rst = append(rst, &TargetData{
+			Topic:           one.Topic,
+			Channel:         one.Channel,
+			Version:         one.Version,
+			ResolvedVersion: one.ResolvedVersion,
+			UpdatedAt:       one.UpdatedAt,
+		})
+	}
+
+	return rst, nil
+}
+
+// SetTargetParam Request Param, only one of channel and version can be set
+type SetTargetParam struct {
+	TargetParam
+
+	Channel *string `json:"channel" validate:"omitempty,oneof=canary stable"`
+	Version *string `json:"version"`
+}
+
+var SetTargetEndpoint = &xreq.Endpoint{
+	Path:       "/bfe-clusters/{name}/config-targets/{topic}",
+	Method:     http.MethodPut,
+	Handler:    xreq.Convert(SetTargetAction),
+	Authorizer: iauth.FA(iauth.FeatureConfigVersion, iauth.ActionUpdate),
+}
+
+var _ xreq.Handler = SetTargetAction
+
+// SetTargetAction pins bfe cluster to a version of topic, or makes it follow a release channel
+func SetTargetAction(req *http.Request) (interface{}, error) {
+	param := &SetTargetParam{}
+	if err := xreq.Bind(req, param); err != nil {
+		return nil, err
+	}
+
+	cluster, err := fetchBFECluster(req, &param.TargetParam)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &iversion_control.ConfigTarget{
+		BFECluster: cluster.Name,
+		Topic:      *param.Topic,
+	}
+	if param.Channel != nil {
+		target.Channel = *param.Channel
+	}
+	if param.Version != nil {
+		target.Version = *param.Version
+	}
+
+	return nil, container.VersionControlManager.SetConfigTarget(req.Context(), target)
+}
+
+var DeleteTargetEndpoint = &xreq.Endpoint{
+	Path:       "/bfe-clusters/{name}/config-targets/{topic}",
+	Method:     http.MethodDelete,
+	Handler:    xreq.Convert(DeleteTargetAction),
+	Authorizer: iauth.FA(iauth.FeatureConfigVersion, iauth.ActionDelete),
+}
+
+var _ xreq.Handler = DeleteTargetAction
+
+// DeleteTargetAction makes bfe cluster be served the latest version of topic again
+func DeleteTargetAction(req *http.Request) (interface{}, error) {
+	param := &TargetParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	cluster, err := fetchBFECluster(req, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, container.VersionControlManager.DeleteConfigTarget(req.Context(), cluster.Name, *param.Topic)
+}
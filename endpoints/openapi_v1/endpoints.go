@@ -18,18 +18,24 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/yf-networks/ai-gateway-api/endpoints/middleware"
+	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/active_health_check"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/ai_route"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/api_key"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/auth"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/bfe_cluster"
+	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/bfe_node"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/bfe_pool"
+	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/canary"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/certificate"
+	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/config_version"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/domain"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/general"
+	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/model_provider"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/product"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/product_cluster"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/product_pool"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/route"
+	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/secret"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/subcluster"
 	"github.com/yf-networks/ai-gateway-api/endpoints/openapi_v1/traffic"
 	"github.com/yf-networks/ai-gateway-api/lib/xreq"
@@ -55,11 +61,17 @@ func endpoints() []*xreq.Endpoint {
 		auth.Endpoints,
 		traffic.Endpoints,
 		bfe_cluster.Endpoints,
+		bfe_node.Endpoints,
+		config_version.Endpoints,
 		route.Endpoints,
 		domain.Endpoints,
 		api_key.Endpoints,
 		ai_route.Endpoints,
 		general.Endpoints,
+		secret.Endpoints,
+		model_provider.Endpoints,
+		active_health_check.Endpoints,
+		canary.Endpoints,
 	)
 }
 
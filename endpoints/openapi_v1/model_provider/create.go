@@ -0,0 +1,120 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package model_provider
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// UpsertParam is the provider definition, all fields are optional when update
+type UpsertParam struct {
+	ProviderID   *string                     `json:"id"`
+	Name         *string                     `json:"name" validate:"omitempty,min=1,max=255"`
+	Description  *string                     `json:"description" validate:"omitempty,max=1024"`
+	Protocol     *string                     `json:"protocol" validate:"omitempty,oneof=openai anthropic gemini"`
+	BaseURL      *string                     `json:"base_url" validate:"omitempty,url"`
+	AuthHeader   *string                     `json:"auth_header" validate:"omitempty,max=255"`
+	AuthScheme   *string                     `json:"auth_scheme" validate:"omitempty,max=64"`
+	FieldMapping *icluster_conf.FieldMapping `json:"field_mapping"`
+}
+
+func (param *UpsertParam) toModel() *icluster_conf.ModelProviderParam {
+	return &icluster_conf.ModelProviderParam{
+		ProviderID:   param.ProviderID,
+		Name:         param.Name,
+		Description:  param.Description,
+		Protocol:     param.Protocol,
+		BaseURL:      param.BaseURL,
+		AuthHeader:   param.AuthHeader,
+		AuthScheme:   param.AuthScheme,
+		FieldMapping: param.FieldMapping,
+	}
+}
+
+// providerIDPattern is also the provider_type of llm config
+var providerIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// checkUpsertParam checks the provider defined by param can be used, old is nil when create
+func checkUpsertParam(param *UpsertParam, old *icluster_conf.ModelProvider) error {
+	merged := icluster_conf.ModelProvider{}
+	if old != nil {
+		merged = *old
+	} else if !providerIDPattern.MatchString(*param.ProviderID) {
+		return xerror.WrapParamErrorWithMsg("id must match %s", providerIDPattern.String())
+	}
+	if param.Protocol != nil {
+		merged.Protocol = *param.Protocol
+	}
+	if param.BaseURL != nil {
+		merged.BaseURL = *param.BaseURL
+	}
+	if param.AuthHeader != nil {
+		merged.AuthHeader = *param.AuthHeader
+	}
+	if param.AuthScheme != nil {
+		merged.AuthScheme = *param.AuthScheme
+	}
+	if _, err := icluster_conf.NewProviderProtocol(merged.Protocol, merged.AuthHeader, merged.AuthScheme, merged.BaseURL); err != nil {
+		return xerror.WrapParamError(err)
+	}
+
+	if fm := param.FieldMapping; fm != nil {
+		if fm.IDField == "" {
+			return xerror.WrapParamErrorWithMsg("field_mapping.id_field Want Be Set")
+		}
+	}
+
+	return nil
+}
+
+var CreateEndpoint = &xreq.Endpoint{
+	Path:       "/model-providers",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(CreateAction),
+	Authorizer: iauth.FA(iauth.FeatureModelProvider, iauth.ActionCreate),
+}
+
+var _ xreq.Handler = CreateAction
+
+// CreateAction adds provider into catalog
+func CreateAction(req *http.Request) (interface{}, error) {
+	param := &UpsertParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+
+	if param.ProviderID == nil || param.Name == nil {
+		return nil, xerror.WrapParamErrorWithMsg("id And name Want Be Set")
+	}
+	if param.FieldMapping == nil {
+		return nil, xerror.WrapParamErrorWithMsg("field_mapping Want Be Set")
+	}
+	if err := checkUpsertParam(param, nil); err != nil {
+		return nil, err
+	}
+
+	if err := container.ModelProviderManager.CreateModelProvider(req.Context(), param.toModel()); err != nil {
+		return nil, err
+	}
+
+	return container.ModelProviderManager.FetchModelProvider(req.Context(), *param.ProviderID)
+}
@@ -0,0 +1,60 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package model_provider
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+type OneParam struct {
+	ProviderID *string `uri:"provider_id" validate:"required,min=1"`
+}
+
+var OneEndpoint = &xreq.Endpoint{
+	Path:       "/model-providers/{provider_id}",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(OneAction),
+	Authorizer: iauth.FA(iauth.FeatureModelProvider, iauth.ActionRead),
+}
+
+func fetchModelProvider(req *http.Request) (*icluster_conf.ModelProvider, error) {
+	param := &OneParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	one, err := container.ModelProviderManager.FetchModelProvider(req.Context(), *param.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	if one == nil {
+		return nil, xerror.WrapRecordNotExist("Model Provider")
+	}
+
+	return one, nil
+}
+
+var _ xreq.Handler = OneAction
+
+// OneAction returns one provider in catalog
+func OneAction(req *http.Request) (interface{}, error) {
+	return fetchModelProvider(req)
+}
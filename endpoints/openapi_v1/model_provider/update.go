@@ -0,0 +1,58 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package model_provider
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+var UpdateEndpoint = &xreq.Endpoint{
+	Path:       "/model-providers/{provider_id}",
+	Method:     http.MethodPatch,
+	Handler:    xreq.Convert(UpdateAction),
+	Authorizer: iauth.FA(iauth.FeatureModelProvider, iauth.ActionUpdate),
+}
+
+var _ xreq.Handler = UpdateAction
+
+// UpdateAction updates fields set in body, id can't be changed
+func UpdateAction(req *http.Request) (interface{}, error) {
+	old, err := fetchModelProvider(req)
+	if err != nil {
+		return nil, err
+	}
+
+	param := &UpsertParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+	if param.ProviderID != nil && *param.ProviderID != old.ProviderID {
+		return nil, xerror.WrapParamErrorWithMsg("id can't be changed")
+	}
+	if err := checkUpsertParam(param, old); err != nil {
+		return nil, err
+	}
+
+	if err := container.ModelProviderManager.UpdateModelProvider(req.Context(), old, param.toModel()); err != nil {
+		return nil, err
+	}
+
+	return container.ModelProviderManager.FetchModelProvider(req.Context(), old.ProviderID)
+}
@@ -15,19 +15,28 @@
 package product_cluster
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/yf-networks/ai-gateway-api/lib/xerror"
 	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
 )
 
 const (
-	maxServiceNameLen = 255
-	maxGroupLen       = 255
+	maxServiceNameLen     = 255
+	maxGroupLen           = 255
+	maxProviderKeyNameLen = 64
+	maxProviderKeyLen     = 4096
+	maxProviderKeyWeight  = 100
+	maxBasePathLen        = 255
+	maxAPIVersionLen      = 64
 )
 
-func checkLLMConfig(llmConfig *icluster_conf.LLMConfig) error {
+func checkLLMConfig(ctx context.Context, llmConfig *icluster_conf.LLMConfig) error {
 	if llmConfig == nil {
 		return nil
 	}
@@ -53,6 +62,34 @@ func checkLLMConfig(llmConfig *icluster_conf.LLMConfig) error {
 		return xerror.WrapParamErrorWithMsg(fmt.Sprintf("Must set llm_config.enable"))
 	}
 
+	if err := checkProviderProtocol(ctx, llmConfig); err != nil {
+		return err
+	}
+
+	if llmConfig.Key != nil {
+		if err := isecret.CheckPlain("llm_config.key", *llmConfig.Key); err != nil {
+			return err
+		}
+	}
+
+	if _, err := icluster_conf.NewModelMapper(llmConfig.ModelMappings); err != nil {
+		return xerror.WrapParamErrorWithMsg("llm_config.%v", err)
+	}
+
+	names := map[string]bool{}
+	for i, one := range llmConfig.Keys {
+		if one == nil {
+			return xerror.WrapParamErrorWithMsg("llm_config.keys[%d] must not be null", i)
+		}
+		if err := checkProviderKey(one, true); err != nil {
+			return xerror.WrapParamErrorWithMsg("llm_config.keys[%d]: %v", i, err)
+		}
+		if names[*one.Name] {
+			return xerror.WrapParamErrorWithMsg("llm_config.keys[%d]: duplicate name %s", i, *one.Name)
+		}
+		names[*one.Name] = true
+	}
+
 	if llmConfig.Enable != nil && *llmConfig.Enable {
 		if llmConfig.ServiceName == nil || *llmConfig.ServiceName == "" {
 			return xerror.WrapParamErrorWithMsg(fmt.Sprintf("Must set llm_config.service_name"))
@@ -73,3 +110,130 @@ func checkLLMConfig(llmConfig *icluster_conf.LLMConfig) error {
 
 	return nil
 }
+
+// checkProviderProtocol validates provider type, base path and api version against the provider catalog
+func checkProviderProtocol(ctx context.Context, llmConfig *icluster_conf.LLMConfig) error {
+	providerType := ""
+	if llmConfig.ProviderType != nil {
+		providerType = *llmConfig.ProviderType
+	}
+	protocol, err := container.ModelProviderManager.ProviderProtocol(ctx, providerType)
+	if err != nil {
+		return err
+	}
+	if protocol == nil {
+		types, err := container.ModelProviderManager.ProviderTypes(ctx)
+		if err != nil {
+			return err
+		}
+		return xerror.WrapParamErrorWithMsg("llm_config.provider_type must be one of %s", strings.Join(types, ", "))
+	}
+
+	if llmConfig.BasePath != nil && *llmConfig.BasePath != "" {
+		if !strings.HasPrefix(*llmConfig.BasePath, "/") || len(*llmConfig.BasePath) > maxBasePathLen {
+			return xerror.WrapParamErrorWithMsg("llm_config.base_path must start with / and length must be lower than %d", maxBasePathLen)
+		}
+	}
+
+	if llmConfig.APIVersion != nil && *llmConfig.APIVersion != "" {
+		if protocol.VersionHeader == "" {
+			return xerror.WrapParamErrorWithMsg("llm_config.api_version not supported by provider_type %s, set it in base_path", providerType)
+		}
+		if len(*llmConfig.APIVersion) > maxAPIVersionLen {
+			return xerror.WrapParamErrorWithMsg("llm_config.api_version length must be lower than %d", maxAPIVersionLen)
+		}
+	}
+
+	return nil
+}
+
+// checkProviderKey validates provider key, name and key are required when create
+func checkProviderKey(key *icluster_conf.ProviderKey, create bool) error {
+	if create {
+		if key.Name == nil || *key.Name == "" {
+			return fmt.Errorf("must set name")
+		}
+		if key.Key == nil || *key.Key == "" {
+			return fmt.Errorf("must set key")
+		}
+	}
+
+	if key.Name != nil && len(*key.Name) > maxProviderKeyNameLen {
+		return fmt.Errorf("name length must be lower than %d", maxProviderKeyNameLen)
+	}
+
+	if key.Key != nil && len(*key.Key) > maxProviderKeyLen {
+		return fmt.Errorf("key length must be lower than %d", maxProviderKeyLen)
+	}
+	if key.Key != nil && isecret.IsSealed(*key.Key) {
+		return fmt.Errorf("key must be plain text")
+	}
+
+	if key.Weight != nil && (*key.Weight < 0 || *key.Weight > maxProviderKeyWeight) {
+		return fmt.Errorf("weight must between 0 and %d", maxProviderKeyWeight)
+	}
+
+	if limits := key.Limits; limits != nil {
+		if limits.RPM != nil && *limits.RPM < 0 {
+			return fmt.Errorf("limits.rpm must not be negative")
+		}
+		if limits.TPM != nil && *limits.TPM < 0 {
+			return fmt.Errorf("limits.tpm must not be negative")
+		}
+	}
+
+	return nil
+}
+
+// checkBalance validates balance params of basic.balance_mode and fills default values
+func checkBalance(basic *BasicParam) error {
+	if basic.BalanceMode == nil {
+		return nil
+	}
+
+	params, err := icluster_conf.CheckClusterBalance(*basic.BalanceMode, basic.BalanceParams)
+	if err != nil {
+		return xerror.WrapParamErrorWithMsg("basic.%v", err)
+	}
+	basic.BalanceParams = params
+
+	return nil
+}
+
+// checkUpstreamTLS validates tls setting of https cluster
+func checkUpstreamTLS(ctx context.Context, protocol string, upstreamTLS *icluster_conf.ClusterUpstreamTLS) error {
+	if upstreamTLS == nil {
+		return nil
+	}
+
+	if protocol != "https" {
+		return xerror.WrapParamErrorWithMsg("upstream_tls only supported by https cluster")
+	}
+
+	if err := icluster_conf.CheckUpstreamTLS(ctx, upstreamTLS, container.CertificateManager.FetchCertificates); err != nil {
+		return xerror.WrapParamErrorWithMsg("upstream_tls.%v", err)
+	}
+
+	return nil
+}
+
+// checkRetryPolicy validates retry policy with retry times of basic.retries
+func checkRetryPolicy(param *UpsertParam) error {
+	if param.RetryPolicy == nil {
+		return nil
+	}
+
+	var retries *icluster_conf.ClusterBasicRetriesParam
+	if param.Basic != nil && param.Basic.Retries != nil {
+		retries = &icluster_conf.ClusterBasicRetriesParam{
+			MaxRetryInSubcluster:    param.Basic.Retries.MaxRetryInSubcluster,
+			MaxRetryCrossSubcluster: param.Basic.Retries.MaxRetryCrossSubcluster,
+		}
+	}
+
+	if err := icluster_conf.CheckRetryPolicy(param.RetryPolicy, retries); err != nil {
+		return xerror.WrapParamErrorWithMsg("retry_policy.%v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package product_cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+const (
+	// CloneSecretsCopy copies provider keys of source cluster
+	CloneSecretsCopy = "copy"
+	// CloneSecretsOmit leaves provider keys empty, set them by update or provider key API
+	CloneSecretsOmit = "omit"
+)
+
+// CloneParam new cluster copies settings of source cluster except sub clusters,
+// which can only be bound to one cluster. Default scheduler is used if not set
+type CloneParam struct {
+	SourceName  *string                   `uri:"cluster_name" validate:"required,min=2"`
+	Name        *string                   `json:"name" validate:"required,min=2"`
+	Description *string                   `json:"description"`
+	ProductName *string                   `json:"product_name" validate:"omitempty,min=1"`
+	Secrets     *string                   `json:"secrets" validate:"required,oneof=copy omit"`
+	SubClusters []string                  `json:"sub_clusters"`
+	Scheduler   map[string]map[string]int `json:"scheduler"`
+}
+
+var CloneEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/clone",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(CloneAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionCreate),
+}
+
+// cluster2UpsertParam returns create param with settings of cluster, provider keys
+// are the sealed ones stored, open them before check
+func cluster2UpsertParam(cluster *icluster_conf.Cluster) (*UpsertParam, error) {
+	// ClusterData has the same json shape as UpsertParam
+	bs, err := json.Marshal(clusterModel2Control(cluster))
+	if err != nil {
+		return nil, err
+	}
+	param := &UpsertParam{}
+	if err := json.Unmarshal(bs, param); err != nil {
+		return nil, err
+	}
+
+	// keys are redacted by clusterModel2Control
+	param.LLMConfig = nil
+	if cluster.LLMConfig != nil {
+		bs, err := json.Marshal(cluster.LLMConfig)
+		if err != nil {
+			return nil, err
+		}
+		param.LLMConfig = &icluster_conf.LLMConfig{}
+		if err := json.Unmarshal(bs, param.LLMConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	return param, nil
+}
+
+// cloneTargetProduct returns product new cluster created in, visitor must be
+// able to create cluster in it
+func cloneTargetProduct(req *http.Request, product *ibasic.Product, name *string) (*ibasic.Product, error) {
+	if name == nil || *name == product.Name {
+		return product, nil
+	}
+
+	list, err := container.ProductManager.FetchProducts(req.Context(), &ibasic.ProductFilter{
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, xerror.WrapRecordNotExist("Product")
+	}
+
+	target := list[0]
+	ctx := ibasic.NewProductContext(req.Context(), target)
+	err = container.AuthorizeManager.Authorizate(ctx, iauth.FAP(iauth.FeatureProductCluster, iauth.ActionCreate))
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// newCloneUpsertParam returns checked create param of cloned cluster, copied provider
+// keys are opened so they pass the checks and are sealed again when created
+func newCloneUpsertParam(ctx context.Context, source *icluster_conf.Cluster, param *CloneParam) (*UpsertParam, error) {
+	upsertParam, err := cluster2UpsertParam(source)
+	if err != nil {
+		return nil, err
+	}
+	upsertParam.Name = param.Name
+	upsertParam.SubClusters = param.SubClusters
+	upsertParam.Scheduler = param.Scheduler
+	if param.Description != nil {
+		upsertParam.Description = param.Description
+	}
+
+	if llmConfig := upsertParam.LLMConfig; llmConfig != nil {
+		if *param.Secrets == CloneSecretsOmit {
+			llmConfig.Key = nil
+			llmConfig.Keys = nil
+		} else if err := icluster_conf.OpenLLMConfigKeys(ctx, llmConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkCreateParam(ctx, upsertParam); err != nil {
+		return nil, err
+	}
+
+	return upsertParam, nil
+}
+
+var _ xreq.Handler = CloneAction
+
+// CloneAction creates cluster with settings of another one, provider keys are copied
+// or omitted as secrets param says
+func CloneAction(req *http.Request) (interface{}, error) {
+	param := &CloneParam{}
+	if err := xreq.Bind(req, param); err != nil {
+		return nil, err
+	}
+	if len(param.SubClusters) == 0 {
+		return nil, xerror.WrapParamErrorWithMsg("SubClusters Want Be Set")
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := container.ClusterManager.FetchCluster(req.Context(), &icluster_conf.ClusterFilter{
+		Name:    param.SourceName,
+		Product: product,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, xerror.WrapRecordNotExist("Cluster")
+	}
+
+	target, err := cloneTargetProduct(req, product, param.ProductName)
+	if err != nil {
+		return nil, err
+	}
+
+	upsertParam, err := newCloneUpsertParam(req.Context(), source, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return createCluster(req.Context(), target, upsertParam)
+}
@@ -0,0 +1,185 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package product_cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+type fakeTxn struct{}
+
+func (fakeTxn) AtomExecute(ctx context.Context, do func(context.Context) error) error {
+	return do(ctx)
+}
+
+type fakeModelProviderStorager struct {
+	icluster_conf.ModelProviderStorager
+}
+
+func (s *fakeModelProviderStorager) FetchModelProviders(ctx context.Context, filter *icluster_conf.ModelProviderFilter) ([]*icluster_conf.ModelProvider, error) {
+	return []*icluster_conf.ModelProvider{
+		{ProviderID: icluster_conf.ProviderTypeOpenAI, Protocol: icluster_conf.ProtocolOpenAI},
+	}, nil
+}
+
+// setTestContainer sets the secret and provider catalog used by the checks
+func setTestContainer(t *testing.T) {
+	t.Helper()
+
+	isecret.Init(isecret.NewLocalKeyManager([]byte("master-key")))
+	old := container.ModelProviderManager
+	container.ModelProviderManager = icluster_conf.NewModelProviderManager(fakeTxn{}, &fakeModelProviderStorager{}, nil)
+	t.Cleanup(func() {
+		isecret.Init(isecret.NewLocalKeyManager(nil))
+		container.ModelProviderManager = old
+	})
+}
+
+func mustSeal(t *testing.T, plain string) *string {
+	t.Helper()
+
+	sealed, err := isecret.Seal(context.Background(), plain)
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	return &sealed
+}
+
+func newTestSourceCluster(llmConfig *icluster_conf.LLMConfig) *icluster_conf.Cluster {
+	return &icluster_conf.Cluster{
+		Name: "source",
+		Basic: &icluster_conf.ClusterBasic{
+			Connection: &icluster_conf.ClusterBasicConnection{MaxIdleConnPerRs: 16},
+			Retries:    &icluster_conf.ClusterBasicRetries{MaxRetryInSubcluster: 1},
+			Buffers:    &icluster_conf.ClusterBasicBuffers{ReqWriteBufferSize: 512},
+			Timeouts: &icluster_conf.ClusterBasicTimeouts{
+				TimeoutConnServ:        1000,
+				TimeoutResponseHeader:  1000,
+				TimeoutReadbodyClient:  1000,
+				TimeoutReadClientAgain: 1000,
+				TimeoutWriteClient:     1000,
+			},
+			Protocol:    lib.PString("http"),
+			BalanceMode: icluster_conf.BalanceModeWRR,
+		},
+		StickySessions: &icluster_conf.ClusterStickySessions{
+			HashStrategy: icluster_conf.ClusterHashStrategyClientIPOnlyI,
+		},
+		SubClusters: []*icluster_conf.SubCluster{{Name: "source-sub"}},
+		Scheduler:   map[string]map[string]int{"bfe": {"source-sub": 100}},
+		PassiveHealthCheck: &icluster_conf.ClusterPassiveHealthCheck{
+			Schema:   "http",
+			Interval: 3,
+			Failnum:  5,
+			Host:     "example.org",
+			Uri:      "/health",
+		},
+		LLMConfig: llmConfig,
+	}
+}
+
+func TestNewCloneUpsertParam(t *testing.T) {
+	setTestContainer(t)
+
+	newLLMConfig := func() *icluster_conf.LLMConfig {
+		return &icluster_conf.LLMConfig{
+			Enable:        lib.PBool(true),
+			ServiceName:   lib.PString("chat"),
+			ModelEndpoint: &icluster_conf.Endpoint{Schema: "https", URI: "/v1/models"},
+			Models:        []string{"gpt-4o"},
+			Key:           mustSeal(t, "sk-main"),
+			Keys: []*icluster_conf.ProviderKey{
+				{Name: lib.PString("a"), Key: mustSeal(t, "sk-a"), Weight: lib.PInt(1)},
+				{Name: lib.PString("b"), Key: mustSeal(t, "sk-b"), Weight: lib.PInt(2)},
+			},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		source   *icluster_conf.LLMConfig
+		secrets  string
+		wantKey  *string
+		wantKeys []string
+	}{
+		{
+			name:     "copy opens key and keys",
+			source:   newLLMConfig(),
+			secrets:  CloneSecretsCopy,
+			wantKey:  lib.PString("sk-main"),
+			wantKeys: []string{"sk-a", "sk-b"},
+		},
+		{
+			name:    "omit drops key and keys",
+			source:  newLLMConfig(),
+			secrets: CloneSecretsOmit,
+		},
+		{
+			name:    "copy without llm config",
+			secrets: CloneSecretsCopy,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			source := newTestSourceCluster(c.source)
+			param := &CloneParam{
+				SourceName:  lib.PString(source.Name),
+				Name:        lib.PString("target"),
+				Secrets:     lib.PString(c.secrets),
+				SubClusters: []string{"target-sub"},
+			}
+
+			got, err := newCloneUpsertParam(context.Background(), source, param)
+			if err != nil {
+				t.Fatalf("newCloneUpsertParam: %v", err)
+			}
+			if *got.Name != "target" || len(got.SubClusters) != 1 || got.SubClusters[0] != "target-sub" {
+				t.Errorf("name or sub clusters not replaced: %s %v", *got.Name, got.SubClusters)
+			}
+
+			if c.source == nil {
+				if got.LLMConfig != nil {
+					t.Errorf("llm config = %+v, want nil", got.LLMConfig)
+				}
+				return
+			}
+
+			if (got.LLMConfig.Key == nil) != (c.wantKey == nil) ||
+				(c.wantKey != nil && *got.LLMConfig.Key != *c.wantKey) {
+				t.Errorf("key = %v, want %v", got.LLMConfig.Key, c.wantKey)
+			}
+			if len(got.LLMConfig.Keys) != len(c.wantKeys) {
+				t.Fatalf("keys = %d, want %d", len(got.LLMConfig.Keys), len(c.wantKeys))
+			}
+			for i, one := range got.LLMConfig.Keys {
+				if *one.Key != c.wantKeys[i] {
+					t.Errorf("keys[%d] = %s, want %s", i, *one.Key, c.wantKeys[i])
+				}
+			}
+
+			// keys of source cluster stay sealed
+			if !isecret.IsSealed(*c.source.Key) || !isecret.IsSealed(*c.source.Keys[0].Key) {
+				t.Errorf("keys of source cluster changed")
+			}
+		})
+	}
+}
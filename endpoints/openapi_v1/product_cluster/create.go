@@ -29,6 +29,7 @@
 package product_cluster
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/yf-networks/ai-gateway-api/lib"
@@ -73,6 +74,13 @@ type UpsertParam struct {
 
 	PassiveHealthCheck *PassiveHealthCheckParam `json:"passive_health_check"`
 	LLMConfig          *icluster_conf.LLMConfig `json:"llm_config"`
+
+	UpstreamTLS *icluster_conf.ClusterUpstreamTLS `json:"upstream_tls"`
+	RetryPolicy *icluster_conf.ClusterRetryPolicy `json:"retry_policy"`
+
+	// ClearUpstreamTLS and ClearRetryPolicy remove the setting when update, it is kept if omitted
+	ClearUpstreamTLS bool `json:"clear_upstream_tls"`
+	ClearRetryPolicy bool `json:"clear_retry_policy"`
 }
 
 // ConnectionParam Request Param
@@ -106,6 +114,9 @@ type BasicParam struct {
 	Buffers    *BuffersParam    `json:"buffers" validate:"required"`
 	Timeouts   *TimeoutsParam   `json:"timeouts" validate:"required"`
 	Protocol   *string          `json:"protocol"`
+
+	BalanceMode   *string                             `json:"balance_mode" validate:"omitempty,oneof=WRR WLC EWMA CHASH"`
+	BalanceParams *icluster_conf.ClusterBalanceParams `json:"balance_params"`
 }
 
 // RetriesParam Request Param
@@ -132,13 +143,17 @@ var CreateEndpoint = &xreq.Endpoint{
 	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionCreate),
 }
 
-// AUTO GEN BY ctrl, MODIFY AS U NEED
-func newCreateParam4Create(req *http.Request) (*UpsertParam, error) {
-	param := &UpsertParam{
+func newDefaultUpsertParam() *UpsertParam {
+	return &UpsertParam{
 		StickySessions: &StickySessionsParam{
 			HashStrategy: lib.PString(clusterHashStrategyClientIDOnly),
 		},
 	}
+}
+
+// AUTO GEN BY ctrl, MODIFY AS U NEED
+func newCreateParam4Create(req *http.Request) (*UpsertParam, error) {
+	param := newDefaultUpsertParam()
 	err := xreq.BindJSON(req, param)
 	if err != nil {
 		return nil, err
@@ -148,31 +163,51 @@ func newCreateParam4Create(req *http.Request) (*UpsertParam, error) {
 		return nil, xerror.WrapParamErrorWithMsg("Scheduler Want Be Set")
 	}
 
+	if err := checkCreateParam(req.Context(), param); err != nil {
+		return nil, err
+	}
+
+	return param, nil
+}
+
+// checkCreateParam checks param of new cluster and fills default values
+func checkCreateParam(ctx context.Context, param *UpsertParam) error {
+	if param.Name == nil || *param.Name == "" {
+		return xerror.WrapParamErrorWithMsg("Name Want Be Set")
+	}
+
 	if len(param.SubClusters) == 0 {
-		return nil, xerror.WrapParamErrorWithMsg("SubClusters Want Be Set")
+		return xerror.WrapParamErrorWithMsg("SubClusters Want Be Set")
 	}
 
 	if param.Basic == nil {
-		return nil, xerror.WrapParamErrorWithMsg("Basic Want Be Set")
+		return xerror.WrapParamErrorWithMsg("Basic Want Be Set")
 	}
 
 	if param.PassiveHealthCheck == nil {
-		return nil, xerror.WrapParamErrorWithMsg("PassiveHealthCheck Want Be Set")
+		return xerror.WrapParamErrorWithMsg("PassiveHealthCheck Want Be Set")
 	}
 
 	if param.StickySessions == nil {
-		return nil, xerror.WrapParamErrorWithMsg("StickySessions Want Be Set")
+		return xerror.WrapParamErrorWithMsg("StickySessions Want Be Set")
 	}
 	if *param.StickySessions.HashStrategy != clusterHashStrategyClientIPOnly && param.StickySessions.HashHeader == nil {
-		return nil, xerror.WrapParamErrorWithMsg("StickySessions.HashHeader Want Be Set")
+		return xerror.WrapParamErrorWithMsg("StickySessions.HashHeader Want Be Set")
 	}
 
-	if err := checkLLMConfig(param.LLMConfig); err != nil {
-		return nil, err
+	if err := checkLLMConfig(ctx, param.LLMConfig); err != nil {
+		return err
 	}
 
 	if param.Basic.Protocol == nil {
-		return nil, xerror.WrapParamErrorWithMsg("Basic.Protocol Want Be Set")
+		return xerror.WrapParamErrorWithMsg("Basic.Protocol Want Be Set")
+	}
+
+	if param.Basic.BalanceMode == nil {
+		param.Basic.BalanceMode = lib.PString(icluster_conf.BalanceModeWRR)
+	}
+	if err := checkBalance(param.Basic); err != nil {
+		return err
 	}
 
 	switch *param.Basic.Protocol {
@@ -182,7 +217,21 @@ func newCreateParam4Create(req *http.Request) (*UpsertParam, error) {
 		param.Basic.Protocol = lib.PString("http")
 	}
 
-	return param, err
+	// verify server with system ca list by default
+	if *param.Basic.Protocol == "https" && param.UpstreamTLS == nil {
+		param.UpstreamTLS = &icluster_conf.ClusterUpstreamTLS{
+			Verify: true,
+		}
+	}
+	if err := checkUpstreamTLS(ctx, *param.Basic.Protocol, param.UpstreamTLS); err != nil {
+		return err
+	}
+
+	if err := checkRetryPolicy(param); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 var (
@@ -198,11 +247,18 @@ func clusterParamControlModel(param *UpsertParam) *icluster_conf.ClusterParam {
 		SubClusters: param.SubClusters,
 		Scheduler:   param.Scheduler,
 		LLMConfig:   param.LLMConfig,
+		UpstreamTLS: param.UpstreamTLS,
+		RetryPolicy: param.RetryPolicy,
+
+		ClearUpstreamTLS: param.ClearUpstreamTLS,
+		ClearRetryPolicy: param.ClearRetryPolicy,
 	}
 
 	if basic := param.Basic; basic != nil {
 		rst.Basic = &icluster_conf.ClusterBasicParam{
-			Protocol: basic.Protocol,
+			Protocol:      basic.Protocol,
+			BalanceMode:   basic.BalanceMode,
+			BalanceParams: basic.BalanceParams,
 		}
 
 		if conn := basic.Connection; conn != nil {
@@ -294,14 +350,18 @@ func CreateActionProcess(req *http.Request, _param *UpsertParam) (*ClusterData,
 		return nil, err
 	}
 
+	return createCluster(req.Context(), product, _param)
+}
+
+func createCluster(ctx context.Context, product *ibasic.Product, _param *UpsertParam) (*ClusterData, error) {
 	param := clusterParamControlModel(_param)
 
-	err = container.ClusterManager.CreateCluster(req.Context(), product, param)
+	err := container.ClusterManager.CreateCluster(ctx, product, param)
 	if err != nil {
 		return nil, err
 	}
 
-	cluster, err := container.ClusterManager.FetchCluster(req.Context(), &icluster_conf.ClusterFilter{
+	cluster, err := container.ClusterManager.FetchCluster(ctx, &icluster_conf.ClusterFilter{
 		Name: param.Name,
 	})
 	if err != nil {
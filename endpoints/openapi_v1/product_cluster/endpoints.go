@@ -28,4 +28,27 @@ var Endpoints = []*xreq.Endpoint{
 	ReadyEndpoint,
 	ListModelProvidersRoute,
 	ListModelsRoute,
+	CreateProviderKeyEndpoint,
+	UpdateProviderKeyEndpoint,
+	DeleteProviderKeyEndpoint,
+	OneModelSyncEndpoint,
+	SyncModelEndpoint,
+	ApproveModelSyncEndpoint,
+	ProbeClusterEndpoint,
+	ProbeLLMConfigEndpoint,
+	ResolveModelEndpoint,
+	CloneEndpoint,
+
+	ListTemplatesEndpoint,
+	OneTemplateEndpoint,
+	CreateTemplateEndpoint,
+	UpdateTemplateEndpoint,
+	DeleteTemplateEndpoint,
+	CreateFromTemplateEndpoint,
+
+	ListSystemTemplatesEndpoint,
+	OneSystemTemplateEndpoint,
+	CreateSystemTemplateEndpoint,
+	UpdateSystemTemplateEndpoint,
+	DeleteSystemTemplateEndpoint,
 }
@@ -0,0 +1,96 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package product_cluster
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+)
+
+// ResolveModelParam Request Param
+type ResolveModelParam struct {
+	Models []string `form:"model" validate:"required,min=1,dive,min=1"`
+}
+
+// ResolvedModel is the model of provider resolved from model of client
+type ResolvedModel struct {
+	Model  string `json:"model"`
+	Target string `json:"target"`
+
+	// Mapping is the matched entry of llm_config.model_mappings, null if no one matched
+	Mapping *ResolvedMapping `json:"mapping"`
+}
+
+type ResolvedMapping struct {
+	Index int    `json:"index"`
+	Type  string `json:"type"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+var ResolveModelEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/model-mappings/resolve",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(ResolveModelAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionRead),
+}
+
+var _ xreq.Handler = ResolveModelAction
+
+// ResolveModelAction previews what models of client resolve to by model mappings of cluster
+func ResolveModelAction(req *http.Request) (interface{}, error) {
+	_, cluster, err := fetchModelSyncCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	param := &ResolveModelParam{}
+	if err := xreq.BindForm(req, param); err != nil {
+		return nil, err
+	}
+
+	if cluster.LLMConfig == nil {
+		return nil, xerror.WrapParamErrorWithMsg("Cluster %s Not Set llm_config", cluster.Name)
+	}
+
+	mapper, err := icluster_conf.NewModelMapper(cluster.LLMConfig.ModelMappings)
+	if err != nil {
+		return nil, xerror.WrapDirtyDataErrorWithMsg("llm_config.%v", err)
+	}
+
+	rst := []*ResolvedModel{}
+	for _, model := range param.Models {
+		target, rule := mapper.Resolve(model)
+		one := &ResolvedModel{
+			Model:  model,
+			Target: target,
+		}
+		if rule != nil {
+			one.Mapping = &ResolvedMapping{
+				Index: rule.Index,
+				Type:  rule.Type,
+				Key:   rule.Key,
+				Value: rule.Value,
+			}
+		}
+		rst = append(rst, one)
+	}
+
+	return rst, nil
+}
@@ -2,14 +2,12 @@ package product_cluster
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"os"
 
-	"github.com/yf-networks/ai-gateway-api/lib/xerror"
 	"github.com/yf-networks/ai-gateway-api/lib/xreq"
 	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
 )
 
 var _ xreq.Handler = ListModelProvidersAction
@@ -31,12 +29,21 @@ type ModelProvider struct {
 }
 
 func listModelProvidersProcess(ctx context.Context) (interface{}, error) {
-	data, err := os.ReadFile("conf/ai/models.json")
+	list, err := container.ModelProviderManager.FetchModelProviders(ctx)
 	if err != nil {
-		return nil, xerror.WrapParamError(fmt.Errorf("failed to read config conf/ai/models.json: %w", err))
+		return nil, err
 	}
 
-	var models []ModelProvider
-	err = json.Unmarshal(data, &models)
-	return models, err
+	models := []ModelProvider{}
+	for _, one := range list {
+		if one.ProviderID == icluster_conf.DefaultModelProviderID {
+			continue
+		}
+		models = append(models, ModelProvider{
+			Name: one.Name,
+			ID:   one.ProviderID,
+		})
+	}
+
+	return models, nil
 }
@@ -0,0 +1,113 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package product_cluster
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+var OneModelSyncEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/model-sync",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(OneModelSyncAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionRead),
+}
+
+var SyncModelEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/model-sync",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(SyncModelAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionUpdate),
+}
+
+var ApproveModelSyncEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/model-sync/approve",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(ApproveModelSyncAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionUpdate),
+}
+
+func fetchModelSyncCluster(req *http.Request) (*ibasic.Product, *icluster_conf.Cluster, error) {
+	param := &OneParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cluster, err := container.ClusterManager.FetchCluster(req.Context(), &icluster_conf.ClusterFilter{
+		Name:    param.Name,
+		Product: product,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if cluster == nil {
+		return nil, nil, xerror.WrapRecordNotExist("Cluster")
+	}
+
+	return product, cluster, nil
+}
+
+var _ xreq.Handler = OneModelSyncAction
+
+// OneModelSyncAction returns the latest model sync result of cluster, null if never be synced
+func OneModelSyncAction(req *http.Request) (interface{}, error) {
+	_, cluster, err := fetchModelSyncCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.ModelSyncManager.FetchModelSync(req.Context(), cluster)
+}
+
+var _ xreq.Handler = SyncModelAction
+
+// SyncModelAction syncs models of cluster immediately
+func SyncModelAction(req *http.Request) (interface{}, error) {
+	_, cluster, err := fetchModelSyncCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.ModelSyncManager.SyncCluster(req.Context(), cluster)
+}
+
+var _ xreq.Handler = ApproveModelSyncAction
+
+// ApproveModelSyncAction applies the staged model changes of cluster
+func ApproveModelSyncAction(req *http.Request) (interface{}, error) {
+	product, cluster, err := fetchModelSyncCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	visitor, err := iauth.MustGetVisitor(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return container.ModelSyncManager.ApproveModelSync(req.Context(), product, cluster, visitor.GetName())
+}
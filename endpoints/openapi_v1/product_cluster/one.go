@@ -56,6 +56,9 @@ type Basic struct {
 	Buffers    *Buffers    `json:"buffers" uri:"buffers"`
 	Timeouts   *Timeouts   `json:"timeouts" uri:"timeouts"`
 	Protocol   *string     `json:"protocol"`
+
+	BalanceMode   string                              `json:"balance_mode"`
+	BalanceParams *icluster_conf.ClusterBalanceParams `json:"balance_params"`
 }
 
 // Connection Request Param
@@ -118,6 +121,9 @@ type ClusterData struct {
 
 	Scheduler map[string]map[string]int `json:"scheduler,omitempty"`
 	LLMConfig *icluster_conf.LLMConfig  `json:"llm_config"`
+
+	UpstreamTLS *icluster_conf.ClusterUpstreamTLS `json:"upstream_tls"`
+	RetryPolicy *icluster_conf.ClusterRetryPolicy `json:"retry_policy"`
 }
 
 type AutoLbMatrix struct {
@@ -152,6 +158,9 @@ func clusterModel2Control(cluster *icluster_conf.Cluster) *ClusterData {
 				TimeoutWriteClient:     cluster.Basic.Timeouts.TimeoutWriteClient,
 			},
 			Protocol: cluster.Basic.Protocol,
+
+			BalanceMode:   cluster.Basic.BalanceMode,
+			BalanceParams: cluster.Basic.BalanceParams,
 		},
 		StickySessions: &StickySessions{
 			SessionStickyType: map[bool]string{
@@ -172,7 +181,10 @@ func clusterModel2Control(cluster *icluster_conf.Cluster) *ClusterData {
 
 		PassiveHealthCheck: PassiveHealthCheckM2C(cluster.PassiveHealthCheck),
 
-		LLMConfig: cluster.LLMConfig,
+		LLMConfig: icluster_conf.RedactLLMConfig(cluster.LLMConfig),
+
+		UpstreamTLS: cluster.UpstreamTLS,
+		RetryPolicy: cluster.RetryPolicy,
 	}
 
 	return rsp
@@ -0,0 +1,128 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package product_cluster
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// ProbeParam Request Param
+type ProbeParam struct {
+	// LLMConfig is required when test an unsaved llm config, overrides llm config of saved cluster
+	LLMConfig *icluster_conf.LLMConfig `json:"llm_config"`
+
+	// Target is "ip:port" or url of provider, first enabled instance of saved cluster by default.
+	// It must be an instance of saved cluster when test saved llm config
+	Target string `json:"target"`
+
+	Completion bool   `json:"completion"`
+	Model      string `json:"model"`
+
+	TimeoutInS int `json:"timeout_in_s" validate:"omitempty,min=1,max=60"`
+}
+
+var ProbeClusterEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/connection-test",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(ProbeClusterAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionUpdate),
+}
+
+var ProbeLLMConfigEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/connection-test",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(ProbeLLMConfigAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionUpdate),
+}
+
+func bindProbeParam(req *http.Request) (*ProbeParam, error) {
+	param := &ProbeParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+
+	if param.LLMConfig != nil {
+		if err := checkLLMConfig(req.Context(), param.LLMConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	return param, nil
+}
+
+func probeProvider(req *http.Request, cluster *icluster_conf.Cluster, param *ProbeParam) (interface{}, error) {
+	return container.ModelProviderManager.ProbeProvider(req.Context(), &icluster_conf.ProviderProbeParam{
+		Cluster:    cluster,
+		LLMConfig:  param.LLMConfig,
+		Target:     param.Target,
+		Completion: param.Completion,
+		Model:      param.Model,
+		Timeout:    time.Duration(param.TimeoutInS) * time.Second,
+	})
+}
+
+var _ xreq.Handler = ProbeClusterAction
+
+// ProbeClusterAction tests connection and credential of llm config of saved cluster
+func ProbeClusterAction(req *http.Request) (interface{}, error) {
+	_, cluster, err := fetchModelSyncCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	param, err := bindProbeParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// saved llm config is tested against instances of cluster only
+	if param.LLMConfig == nil {
+		return probeProvider(req, cluster, param)
+	}
+
+	if param.Target == "" {
+		if param.Target, err = icluster_conf.ProbeTarget(cluster); err != nil {
+			return nil, err
+		}
+	}
+
+	return probeProvider(req, nil, param)
+}
+
+var _ xreq.Handler = ProbeLLMConfigAction
+
+// ProbeLLMConfigAction tests connection and credential of unsaved llm config
+func ProbeLLMConfigAction(req *http.Request) (interface{}, error) {
+	param, err := bindProbeParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if param.LLMConfig == nil {
+		return nil, xerror.WrapParamErrorWithMsg("llm_config Not Set")
+	}
+	if param.Target == "" {
+		return nil, xerror.WrapParamErrorWithMsg("target Not Set")
+	}
+
+	return probeProvider(req, nil, param)
+}
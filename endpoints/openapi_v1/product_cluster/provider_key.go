@@ -0,0 +1,157 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package product_cluster
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// ProviderKeyParam locates provider key of cluster
+type ProviderKeyParam struct {
+	ClusterName *string `uri:"cluster_name" validate:"required,min=1"`
+	KeyName     *string `uri:"key_name"`
+}
+
+var CreateProviderKeyEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/provider-keys",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(CreateProviderKeyAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionUpdate),
+}
+
+var UpdateProviderKeyEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/provider-keys/{key_name}",
+	Method:     http.MethodPatch,
+	Handler:    xreq.Convert(UpdateProviderKeyAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionUpdate),
+}
+
+var DeleteProviderKeyEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/provider-keys/{key_name}",
+	Method:     http.MethodDelete,
+	Handler:    xreq.Convert(DeleteProviderKeyAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionUpdate),
+}
+
+func fetchProviderKeyCluster(req *http.Request) (*ibasic.Product, *icluster_conf.Cluster, *ProviderKeyParam, error) {
+	param := &ProviderKeyParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, nil, nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cluster, err := container.ClusterManager.FetchCluster(req.Context(), &icluster_conf.ClusterFilter{
+		Name:    param.ClusterName,
+		Product: product,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if cluster == nil {
+		return nil, nil, nil, xerror.WrapRecordNotExist("Cluster")
+	}
+
+	return product, cluster, param, nil
+}
+
+// providerKeysResponse return the provider keys after changed
+func providerKeysResponse(req *http.Request, cluster *icluster_conf.Cluster) (interface{}, error) {
+	one, err := container.ClusterManager.FetchCluster(req.Context(), &icluster_conf.ClusterFilter{
+		ID: &cluster.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return icluster_conf.RedactLLMConfig(one.LLMConfig).Keys, nil
+}
+
+var _ xreq.Handler = CreateProviderKeyAction
+
+// CreateProviderKeyAction adds a provider key to cluster
+func CreateProviderKeyAction(req *http.Request) (interface{}, error) {
+	product, cluster, _, err := fetchProviderKeyCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &icluster_conf.ProviderKey{}
+	if err := xreq.BindJSON(req, key); err != nil {
+		return nil, err
+	}
+	if err := checkProviderKey(key, true); err != nil {
+		return nil, xerror.WrapParamError(err)
+	}
+
+	if err := container.ClusterManager.CreateProviderKey(req.Context(), product, cluster, key); err != nil {
+		return nil, err
+	}
+
+	return providerKeysResponse(req, cluster)
+}
+
+var _ xreq.Handler = UpdateProviderKeyAction
+
+// UpdateProviderKeyAction updates weight, enable, limits or key of provider key
+func UpdateProviderKeyAction(req *http.Request) (interface{}, error) {
+	product, cluster, param, err := fetchProviderKeyCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &icluster_conf.ProviderKey{}
+	if err := xreq.BindJSON(req, key); err != nil {
+		return nil, err
+	}
+	if key.Name != nil && *key.Name != *param.KeyName {
+		return nil, xerror.WrapParamErrorWithMsg("name can't be changed")
+	}
+	if err := checkProviderKey(key, false); err != nil {
+		return nil, xerror.WrapParamError(err)
+	}
+
+	if err := container.ClusterManager.UpdateProviderKey(req.Context(), product, cluster, *param.KeyName, key); err != nil {
+		return nil, err
+	}
+
+	return providerKeysResponse(req, cluster)
+}
+
+var _ xreq.Handler = DeleteProviderKeyAction
+
+// DeleteProviderKeyAction removes provider key from cluster
+func DeleteProviderKeyAction(req *http.Request) (interface{}, error) {
+	product, cluster, param, err := fetchProviderKeyCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := container.ClusterManager.DeleteProviderKey(req.Context(), product, cluster, *param.KeyName); err != nil {
+		return nil, err
+	}
+
+	return providerKeysResponse(req, cluster)
+}
@@ -0,0 +1,449 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package product_cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// TemplateContent is the cluster settings stored in template, it's UpsertParam without
+// the fields differ in every cluster: name, description, sub_clusters and scheduler.
+// Provider keys are never stored in template
+type TemplateContent struct {
+	Basic              *BasicParam                       `json:"basic,omitempty"`
+	StickySessions     *StickySessionsParam              `json:"sticky_sessions,omitempty"`
+	PassiveHealthCheck *PassiveHealthCheckParam          `json:"passive_health_check,omitempty"`
+	LLMConfig          *icluster_conf.LLMConfig          `json:"llm_config,omitempty"`
+	UpstreamTLS        *icluster_conf.ClusterUpstreamTLS `json:"upstream_tls,omitempty"`
+	RetryPolicy        *icluster_conf.ClusterRetryPolicy `json:"retry_policy,omitempty"`
+}
+
+// toUpsertParam returns create param with default values filled by template
+func (c *TemplateContent) toUpsertParam() *UpsertParam {
+	param := newDefaultUpsertParam()
+	if c.StickySessions != nil {
+		param.StickySessions = c.StickySessions
+	}
+	param.Basic = c.Basic
+	param.PassiveHealthCheck = c.PassiveHealthCheck
+	param.LLMConfig = c.LLMConfig
+	param.UpstreamTLS = c.UpstreamTLS
+	param.RetryPolicy = c.RetryPolicy
+
+	return param
+}
+
+// TemplateUpsertParam all fields are optional when update, name can't be changed
+type TemplateUpsertParam struct {
+	Name        *string          `json:"name" validate:"omitempty,min=2,max=255"`
+	Description *string          `json:"description" validate:"omitempty,max=1024"`
+	Content     *TemplateContent `json:"content"`
+}
+
+type TemplateOneParam struct {
+	Name *string `uri:"template_name" validate:"required,min=2"`
+}
+
+type TemplateData struct {
+	Name        string           `json:"name"`
+	Scope       string           `json:"scope"`
+	Description string           `json:"description"`
+	Content     *TemplateContent `json:"content"`
+}
+
+func templateModel2Control(one *icluster_conf.ClusterTemplate) (*TemplateData, error) {
+	content := &TemplateContent{}
+	if err := json.Unmarshal([]byte(one.Content), content); err != nil {
+		return nil, xerror.WrapDirtyDataErrorWithMsg("Cluster Template %s Content Unmarshal fail, err: %v", one.Name, err)
+	}
+
+	return &TemplateData{
+		Name:        one.Name,
+		Scope:       one.Scope(),
+		Description: one.Description,
+		Content:     content,
+	}, nil
+}
+
+// checkTemplateContent checks the settings set in template, the rest are checked
+// when cluster be created
+func checkTemplateContent(ctx context.Context, content *TemplateContent) error {
+	if llmConfig := content.LLMConfig; llmConfig != nil {
+		if llmConfig.Key != nil && *llmConfig.Key != "" {
+			return xerror.WrapParamErrorWithMsg("content.llm_config.key can't be stored in template, set it when create cluster")
+		}
+		if len(llmConfig.Keys) != 0 {
+			return xerror.WrapParamErrorWithMsg("content.llm_config.keys can't be stored in template, set it when create cluster")
+		}
+		if err := checkLLMConfig(ctx, llmConfig); err != nil {
+			return err
+		}
+	}
+
+	protocol := "http"
+	if basic := content.Basic; basic != nil {
+		if basic.Protocol != nil && *basic.Protocol == "https" {
+			protocol = "https"
+		}
+		if err := checkBalance(basic); err != nil {
+			return err
+		}
+	}
+
+	if sticky := content.StickySessions; sticky != nil {
+		if *sticky.HashStrategy != clusterHashStrategyClientIPOnly && sticky.HashHeader == nil {
+			return xerror.WrapParamErrorWithMsg("content.sticky_sessions.hash_header Want Be Set")
+		}
+	}
+
+	if err := checkUpstreamTLS(ctx, protocol, content.UpstreamTLS); err != nil {
+		return err
+	}
+
+	return checkRetryPolicy(&UpsertParam{
+		Basic:       content.Basic,
+		RetryPolicy: content.RetryPolicy,
+	})
+}
+
+func (param *TemplateUpsertParam) toModel() (*icluster_conf.ClusterTemplateParam, error) {
+	rst := &icluster_conf.ClusterTemplateParam{
+		Name:        param.Name,
+		Description: param.Description,
+	}
+	if param.Content != nil {
+		bs, err := json.Marshal(param.Content)
+		if err != nil {
+			return nil, err
+		}
+		rst.Content = lib.PString(string(bs))
+	}
+
+	return rst, nil
+}
+
+// fetchTemplate returns template of the product in path, system template if product is nil
+func fetchTemplate(req *http.Request, product *ibasic.Product) (*icluster_conf.ClusterTemplate, error) {
+	param := &TemplateOneParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	one, err := container.ClusterTemplateManager.FetchClusterTemplate(req.Context(), product, *param.Name)
+	if err != nil {
+		return nil, err
+	}
+	if one == nil {
+		return nil, xerror.WrapRecordNotExist("Cluster Template")
+	}
+
+	return one, nil
+}
+
+func listTemplates(req *http.Request, product *ibasic.Product) ([]*TemplateData, error) {
+	list, err := container.ClusterTemplateManager.FetchClusterTemplates(req.Context(), product)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := []*TemplateData{}
+	for _, one := range list {
+		data, err := templateModel2Control(one)
+		if err != nil {
+			return nil, err
+		}
+		rst = append(rst, data)
+	}
+
+	return rst, nil
+}
+
+func createTemplate(req *http.Request, product *ibasic.Product) (*TemplateData, error) {
+	param := &TemplateUpsertParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+	if param.Name == nil || param.Content == nil {
+		return nil, xerror.WrapParamErrorWithMsg("name And content Want Be Set")
+	}
+	if err := checkTemplateContent(req.Context(), param.Content); err != nil {
+		return nil, err
+	}
+
+	data, err := param.toModel()
+	if err != nil {
+		return nil, err
+	}
+	if err := container.ClusterTemplateManager.CreateClusterTemplate(req.Context(), product, data); err != nil {
+		return nil, err
+	}
+
+	one, err := container.ClusterTemplateManager.FetchClusterTemplate(req.Context(), product, *param.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return templateModel2Control(one)
+}
+
+// updateTemplate replaces description or content set in body
+func updateTemplate(req *http.Request, product *ibasic.Product) (*TemplateData, error) {
+	old, err := fetchTemplate(req, product)
+	if err != nil {
+		return nil, err
+	}
+
+	param := &TemplateUpsertParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+	if param.Name != nil && *param.Name != old.Name {
+		return nil, xerror.WrapParamErrorWithMsg("name can't be changed")
+	}
+	if param.Content != nil {
+		if err := checkTemplateContent(req.Context(), param.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := param.toModel()
+	if err != nil {
+		return nil, err
+	}
+	if err := container.ClusterTemplateManager.UpdateClusterTemplate(req.Context(), old, data); err != nil {
+		return nil, err
+	}
+
+	one, err := container.ClusterTemplateManager.FetchClusterTemplate(req.Context(), product, old.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return templateModel2Control(one)
+}
+
+func deleteTemplate(req *http.Request, product *ibasic.Product) (*TemplateData, error) {
+	old, err := fetchTemplate(req, product)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := container.ClusterTemplateManager.DeleteClusterTemplate(req.Context(), old); err != nil {
+		return nil, err
+	}
+
+	return templateModel2Control(old)
+}
+
+func oneTemplate(req *http.Request, product *ibasic.Product) (*TemplateData, error) {
+	one, err := fetchTemplate(req, product)
+	if err != nil {
+		return nil, err
+	}
+
+	return templateModel2Control(one)
+}
+
+// withProduct adapts template handlers to product scope
+func withProduct(f func(*http.Request, *ibasic.Product) (*TemplateData, error)) xreq.Handler {
+	return func(req *http.Request) (interface{}, error) {
+		product, err := ibasic.MustGetProduct(req.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		return f(req, product)
+	}
+}
+
+// withSystem adapts template handlers to system scope
+func withSystem(f func(*http.Request, *ibasic.Product) (*TemplateData, error)) xreq.Handler {
+	return func(req *http.Request) (interface{}, error) {
+		return f(req, nil)
+	}
+}
+
+// system templates can be used by all products, maintained by system admin
+
+var ListSystemTemplatesEndpoint = &xreq.Endpoint{
+	Path:       "/cluster-templates",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(ListSystemTemplatesAction),
+	Authorizer: iauth.FA(iauth.FeatureClusterTemplate, iauth.ActionReadAll),
+}
+
+// ListSystemTemplatesAction returns system templates
+func ListSystemTemplatesAction(req *http.Request) (interface{}, error) {
+	return listTemplates(req, nil)
+}
+
+var OneSystemTemplateEndpoint = &xreq.Endpoint{
+	Path:       "/cluster-templates/{template_name}",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(withSystem(oneTemplate)),
+	Authorizer: iauth.FA(iauth.FeatureClusterTemplate, iauth.ActionRead),
+}
+
+var CreateSystemTemplateEndpoint = &xreq.Endpoint{
+	Path:       "/cluster-templates",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(withSystem(createTemplate)),
+	Authorizer: iauth.FA(iauth.FeatureClusterTemplate, iauth.ActionCreate),
+}
+
+var UpdateSystemTemplateEndpoint = &xreq.Endpoint{
+	Path:       "/cluster-templates/{template_name}",
+	Method:     http.MethodPatch,
+	Handler:    xreq.Convert(withSystem(updateTemplate)),
+	Authorizer: iauth.FA(iauth.FeatureClusterTemplate, iauth.ActionUpdate),
+}
+
+var DeleteSystemTemplateEndpoint = &xreq.Endpoint{
+	Path:       "/cluster-templates/{template_name}",
+	Method:     http.MethodDelete,
+	Handler:    xreq.Convert(withSystem(deleteTemplate)),
+	Authorizer: iauth.FA(iauth.FeatureClusterTemplate, iauth.ActionDelete),
+}
+
+// product templates, list returns system templates too
+
+var ListTemplatesEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/cluster-templates",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(ListTemplatesAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionReadAll),
+}
+
+// ListTemplatesAction returns templates of product and system templates
+func ListTemplatesAction(req *http.Request) (interface{}, error) {
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return listTemplates(req, product)
+}
+
+var OneTemplateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/cluster-templates/{template_name}",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(withProduct(oneTemplate)),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionRead),
+}
+
+var CreateTemplateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/cluster-templates",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(withProduct(createTemplate)),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionCreate),
+}
+
+var UpdateTemplateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/cluster-templates/{template_name}",
+	Method:     http.MethodPatch,
+	Handler:    xreq.Convert(withProduct(updateTemplate)),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionUpdate),
+}
+
+var DeleteTemplateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/cluster-templates/{template_name}",
+	Method:     http.MethodDelete,
+	Handler:    xreq.Convert(withProduct(deleteTemplate)),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionDelete),
+}
+
+// CreateFromTemplateParam selects template used, template of product is preferred
+// when scope not set
+type CreateFromTemplateParam struct {
+	Name  *string `uri:"template_name" validate:"required,min=2"`
+	Scope *string `form:"scope" validate:"omitempty,oneof=system product"`
+}
+
+var CreateFromTemplateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/cluster-templates/{template_name}/clusters",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(CreateFromTemplateAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductCluster, iauth.ActionCreate),
+}
+
+var _ xreq.Handler = CreateFromTemplateAction
+
+// CreateFromTemplateAction creates cluster with settings of template, settings in body
+// override the ones of template. Default scheduler is used if not set
+func CreateFromTemplateAction(req *http.Request) (interface{}, error) {
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	tParam := &CreateFromTemplateParam{}
+	if err := xreq.BindURI(req, tParam); err != nil {
+		return nil, err
+	}
+	if err := xreq.BindForm(req, tParam); err != nil {
+		return nil, err
+	}
+
+	var template *icluster_conf.ClusterTemplate
+	if tParam.Scope == nil {
+		template, err = container.ClusterTemplateManager.ResolveClusterTemplate(req.Context(), product, *tParam.Name)
+	} else if *tParam.Scope == icluster_conf.ClusterTemplateScopeSystem {
+		template, err = container.ClusterTemplateManager.FetchClusterTemplate(req.Context(), nil, *tParam.Name)
+	} else {
+		template, err = container.ClusterTemplateManager.FetchClusterTemplate(req.Context(), product, *tParam.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, xerror.WrapRecordNotExist("Cluster Template")
+	}
+
+	data, err := templateModel2Control(template)
+	if err != nil {
+		return nil, err
+	}
+
+	param, err := newTemplateUpsertParam(req, data.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	return createCluster(req.Context(), product, param)
+}
+
+// newTemplateUpsertParam returns checked create param, settings in body override
+// the ones of template
+func newTemplateUpsertParam(req *http.Request, content *TemplateContent) (*UpsertParam, error) {
+	param := content.toUpsertParam()
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+	if err := checkCreateParam(req.Context(), param); err != nil {
+		return nil, err
+	}
+
+	return param, nil
+}
@@ -0,0 +1,187 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package product_cluster
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+)
+
+const testTemplateContent = `{
+	"basic": {
+		"connection": {"max_idle_conn_per_rs": 16, "cancel_on_client_close": false},
+		"retries": {"max_retry_in_subcluster": 1, "max_retry_cross_subcluster": 0},
+		"buffers": {"req_write_buffer_size": 512},
+		"timeouts": {
+			"timeout_conn_serv": 1000,
+			"timeout_response_header": 1000,
+			"timeout_readbody_client": 1000,
+			"timeout_read_client_again": 1000,
+			"timeout_write_client": 1000
+		},
+		"protocol": "http"
+	},
+	"sticky_sessions": {"session_sticky_type": "SUB_CLUSTER", "hash_strategy": "CLIENT_ID_ONLY", "hash_header": "X-Client-Id"},
+	"passive_health_check": {"schema": "http", "interval": 3, "failnum": 5, "statuscode": 0, "host": "example.org", "uri": "/health"},
+	"llm_config": {
+		"enable": true,
+		"service_name": "chat",
+		"model_endpoint": {"schema": "https", "uri": "/v1/models"},
+		"models": ["gpt-4o"]
+	}
+}`
+
+func TestNewTemplateUpsertParam(t *testing.T) {
+	setTestContainer(t)
+
+	cases := []struct {
+		name    string
+		body    string
+		wantErr bool
+		check   func(t *testing.T, param *UpsertParam)
+	}{
+		{
+			name: "template settings with defaults",
+			body: `{"name": "c1", "sub_clusters": ["s1"]}`,
+			check: func(t *testing.T, param *UpsertParam) {
+				if *param.Basic.BalanceMode != icluster_conf.BalanceModeWRR {
+					t.Errorf("balance_mode = %s, want %s", *param.Basic.BalanceMode, icluster_conf.BalanceModeWRR)
+				}
+				if *param.StickySessions.HashStrategy != clusterHashStrategyClientIDOnly {
+					t.Errorf("hash_strategy = %s, want %s", *param.StickySessions.HashStrategy, clusterHashStrategyClientIDOnly)
+				}
+				if *param.LLMConfig.ServiceName != "chat" || param.LLMConfig.Key != nil {
+					t.Errorf("llm_config = %+v, want the one of template", param.LLMConfig)
+				}
+			},
+		},
+		{
+			name: "body overrides template",
+			body: `{"name": "c1", "description": "d", "sub_clusters": ["s1"],
+				"basic": {"timeouts": {"timeout_conn_serv": 2000}},
+				"sticky_sessions": {"hash_strategy": "CLIENT_IP_ONLY"},
+				"llm_config": {"service_name": "embedding", "key": "sk-plain"}}`,
+			check: func(t *testing.T, param *UpsertParam) {
+				if *param.Description != "d" {
+					t.Errorf("description = %s, want d", *param.Description)
+				}
+				if *param.Basic.Timeouts.TimeoutConnServ != 2000 || *param.Basic.Timeouts.TimeoutWriteClient != 1000 {
+					t.Errorf("timeouts = %+v, want timeout_conn_serv overridden only", param.Basic.Timeouts)
+				}
+				if *param.StickySessions.HashStrategy != clusterHashStrategyClientIPOnly {
+					t.Errorf("hash_strategy = %s, want %s", *param.StickySessions.HashStrategy, clusterHashStrategyClientIPOnly)
+				}
+				if *param.LLMConfig.ServiceName != "embedding" || *param.LLMConfig.Key != "sk-plain" {
+					t.Errorf("llm_config = %+v, want service_name and key overridden", param.LLMConfig)
+				}
+				if len(param.LLMConfig.Models) != 1 {
+					t.Errorf("models = %v, want the ones of template", param.LLMConfig.Models)
+				}
+			},
+		},
+		{
+			name:    "name missing",
+			body:    `{"sub_clusters": ["s1"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "sub clusters missing",
+			body:    `{"name": "c1"}`,
+			wantErr: true,
+		},
+		{
+			name:    "sealed key rejected",
+			body:    `{"name": "c1", "sub_clusters": ["s1"], "llm_config": {"key": "` + *mustSeal(t, "sk-plain") + `"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid balance mode override",
+			body:    `{"name": "c1", "sub_clusters": ["s1"], "basic": {"balance_mode": "RR"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			content := &TemplateContent{}
+			if err := json.Unmarshal([]byte(testTemplateContent), content); err != nil {
+				t.Fatalf("unmarshal template: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(c.body))
+
+			param, err := newTemplateUpsertParam(req, content)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, c.wantErr)
+			}
+			if err == nil && c.check != nil {
+				c.check(t, param)
+			}
+		})
+	}
+}
+
+func TestCheckTemplateContent(t *testing.T) {
+	setTestContainer(t)
+
+	cases := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			content: testTemplateContent,
+		},
+		{
+			name:    "key stored in template",
+			content: `{"llm_config": {"service_name": "chat", "key": "sk-plain"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "keys stored in template",
+			content: `{"llm_config": {"service_name": "chat", "keys": [{"name": "a", "key": "sk-plain"}]}}`,
+			wantErr: true,
+		},
+		{
+			name:    "tls of http cluster",
+			content: `{"basic": {"protocol": "http"}, "upstream_tls": {"verify": true}}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown provider type",
+			content: `{"llm_config": {"provider_type": "unknown"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			content := &TemplateContent{}
+			if err := json.Unmarshal([]byte(c.content), content); err != nil {
+				t.Fatalf("unmarshal template: %v", err)
+			}
+
+			err := checkTemplateContent(httptest.NewRequest(http.MethodPost, "/", nil).Context(), content)
+			if (err != nil) != c.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
@@ -70,7 +70,7 @@ func newUpdateParam4Update(req *http.Request) (*UpsertParam, error) {
 		}
 	}
 
-	if err := checkLLMConfig(param.LLMConfig); err != nil {
+	if err := checkLLMConfig(req.Context(), param.LLMConfig); err != nil {
 		return nil, err
 	}
 
@@ -78,6 +78,13 @@ func newUpdateParam4Update(req *http.Request) (*UpsertParam, error) {
 		return nil, xerror.WrapParamErrorWithMsg("Basic.Protocol Want Be Set")
 	}
 
+	if param.Basic.BalanceMode == nil && param.Basic.BalanceParams != nil {
+		return nil, xerror.WrapParamErrorWithMsg("Basic.BalanceMode Want Be Set With Basic.BalanceParams")
+	}
+	if err := checkBalance(param.Basic); err != nil {
+		return nil, err
+	}
+
 	switch *param.Basic.Protocol {
 	case "http":
 	case "https":
@@ -85,6 +92,20 @@ func newUpdateParam4Update(req *http.Request) (*UpsertParam, error) {
 		param.Basic.Protocol = lib.PString("http")
 	}
 
+	if param.ClearUpstreamTLS && param.UpstreamTLS != nil {
+		return nil, xerror.WrapParamErrorWithMsg("clear_upstream_tls Conflicts With upstream_tls")
+	}
+	if err := checkUpstreamTLS(req.Context(), *param.Basic.Protocol, param.UpstreamTLS); err != nil {
+		return nil, err
+	}
+
+	if param.ClearRetryPolicy && param.RetryPolicy != nil {
+		return nil, xerror.WrapParamErrorWithMsg("clear_retry_policy Conflicts With retry_policy")
+	}
+	if err := checkRetryPolicy(param); err != nil {
+		return nil, err
+	}
+
 	return param, nil
 }
 
@@ -107,6 +107,7 @@ func Instancesc2i(is []*Instance) []icluster_conf.Instance {
 			Ports:    instance.Ports,
 			Port:     port,
 			Tags:     instance.Tags,
+			Disable:  instance.Disable,
 		})
 	}
 
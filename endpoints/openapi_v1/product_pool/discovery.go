@@ -0,0 +1,146 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package product_pool
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// DiscoveryParam Request Param
+type DiscoveryParam struct {
+	InstancePoolName string `uri:"instance_pool_name" validate:"required,min=2"`
+
+	Sources []*icluster_conf.DiscoverySource `json:"sources" validate:"required,min=1"`
+}
+
+var DiscoveryOneEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/instance-pools/{instance_pool_name}/discovery",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(DiscoveryOneAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductPool, iauth.ActionRead),
+}
+
+var DiscoveryUpsertEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/instance-pools/{instance_pool_name}/discovery",
+	Method:     http.MethodPut,
+	Handler:    xreq.Convert(DiscoveryUpsertAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductPool, iauth.ActionUpdate),
+}
+
+var DiscoveryDeleteEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/instance-pools/{instance_pool_name}/discovery",
+	Method:     http.MethodDelete,
+	Handler:    xreq.Convert(DiscoveryDeleteAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductPool, iauth.ActionUpdate),
+}
+
+var DiscoverySyncEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/instance-pools/{instance_pool_name}/discovery/sync",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(DiscoverySyncAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductPool, iauth.ActionUpdate),
+}
+
+func fetchDiscoveryPool(req *http.Request, poolName string) (*icluster_conf.Pool, error) {
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := container.PoolManager.FetchProductPool(req.Context(), product, poolName)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, xerror.WrapRecordNotExist("Instance Pool")
+	}
+
+	return pool, nil
+}
+
+var _ xreq.Handler = DiscoveryOneAction
+
+// DiscoveryOneAction returns discovery sources and the latest sync result of pool, null if not set
+func DiscoveryOneAction(req *http.Request) (interface{}, error) {
+	param, err := NewOneParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := fetchDiscoveryPool(req, param.InstancePoolName)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.PoolDiscoveryManager.FetchPoolDiscovery(req.Context(), pool)
+}
+
+var _ xreq.Handler = DiscoveryUpsertAction
+
+// DiscoveryUpsertAction sets discovery sources of pool and syncs pool immediately
+func DiscoveryUpsertAction(req *http.Request) (interface{}, error) {
+	param := &DiscoveryParam{}
+	if err := xreq.Bind(req, param); err != nil {
+		return nil, err
+	}
+
+	pool, err := fetchDiscoveryPool(req, param.InstancePoolName)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.PoolDiscoveryManager.SetPoolDiscovery(req.Context(), pool, param.Sources)
+}
+
+var _ xreq.Handler = DiscoveryDeleteAction
+
+// DiscoveryDeleteAction removes discovery sources of pool, instances of pool are kept
+func DiscoveryDeleteAction(req *http.Request) (interface{}, error) {
+	param, err := NewOneParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := fetchDiscoveryPool(req, param.InstancePoolName)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.PoolDiscoveryManager.DeletePoolDiscovery(req.Context(), pool)
+}
+
+var _ xreq.Handler = DiscoverySyncAction
+
+// DiscoverySyncAction syncs instances of pool from discovery sources immediately
+func DiscoverySyncAction(req *http.Request) (interface{}, error) {
+	param, err := NewOneParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := fetchDiscoveryPool(req, param.InstancePoolName)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.PoolDiscoveryManager.SyncPool(req.Context(), pool)
+}
@@ -0,0 +1,156 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package product_pool
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// DrainParam Request Param
+type DrainParam struct {
+	InstanceOneParam
+
+	DurationInS int64  `json:"duration_in_s" validate:"required,min=1,max=86400"`
+	Steps       *int64 `json:"steps" validate:"omitempty,min=1,max=100"`
+}
+
+// DrainData is the drain progress of instance
+type DrainData struct {
+	InstanceName string `json:"instance_name"`
+	Weight       int64  `json:"weight"`
+
+	*icluster_conf.InstanceDrainProgress
+}
+
+var DrainCreateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/instance-pools/{instance_pool_name}/instances/{instance_name}/drain",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(DrainCreateAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductPool, iauth.ActionUpdate),
+}
+
+var DrainOneEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/instance-pools/{instance_pool_name}/instances/{instance_name}/drain",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(DrainOneAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductPool, iauth.ActionRead),
+}
+
+var DrainDeleteEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/instance-pools/{instance_pool_name}/instances/{instance_name}/drain",
+	Method:     http.MethodDelete,
+	Handler:    xreq.Convert(DrainDeleteAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductPool, iauth.ActionUpdate),
+}
+
+func newDrainData(pool *icluster_conf.Pool, instanceName string) (*DrainData, error) {
+	instance, err := pool.FindInstance(instanceName)
+	if err != nil {
+		return nil, err
+	}
+	if instance.Drain == nil {
+		return nil, xerror.WrapRecordNotExist("Instance Drain")
+	}
+
+	progress := instance.Drain.Progress(time.Now())
+	return &DrainData{
+		InstanceName: instance.HostName,
+		// weight in config is updated by runner periodically, may fall behind progress
+		Weight:                instance.Weight,
+		InstanceDrainProgress: progress,
+	}, nil
+}
+
+var _ xreq.Handler = DrainCreateAction
+
+// DrainCreateAction starts drain of instance
+func DrainCreateAction(req *http.Request) (interface{}, error) {
+	param := &DrainParam{}
+	if err := xreq.Bind(req, param); err != nil {
+		return nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := container.PoolManager.DrainPoolInstance(req.Context(), product, param.InstancePoolName,
+		param.InstanceName, &icluster_conf.InstanceDrainParam{
+			DurationInS: param.DurationInS,
+			Steps:       param.Steps,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return newDrainData(pool, param.InstanceName)
+}
+
+var _ xreq.Handler = DrainOneAction
+
+// DrainOneAction shows drain progress of instance
+func DrainOneAction(req *http.Request) (interface{}, error) {
+	param, err := NewInstanceOneParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := container.PoolManager.FetchProductPool(req.Context(), product, param.InstancePoolName)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, xerror.WrapRecordNotExist("Instance Pool")
+	}
+
+	return newDrainData(pool, param.InstanceName)
+}
+
+var _ xreq.Handler = DrainDeleteAction
+
+// DrainDeleteAction cancels drain of instance and restores its original weight
+func DrainDeleteAction(req *http.Request) (interface{}, error) {
+	param, err := NewInstanceOneParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := container.PoolManager.CancelPoolInstanceDrain(req.Context(), product, param.InstancePoolName,
+		param.InstanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOneData(pool), nil
+}
@@ -22,4 +22,17 @@ var Endpoints = []*xreq.Endpoint{
 	DeleteEndpoint,
 	UpdateEndpoint,
 	CreateEndpoint,
+
+	InstanceCreateEndpoint,
+	InstanceUpdateEndpoint,
+	InstanceDeleteEndpoint,
+
+	DrainCreateEndpoint,
+	DrainOneEndpoint,
+	DrainDeleteEndpoint,
+
+	DiscoveryOneEndpoint,
+	DiscoveryUpsertEndpoint,
+	DiscoveryDeleteEndpoint,
+	DiscoverySyncEndpoint,
 }
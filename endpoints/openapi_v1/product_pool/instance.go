@@ -0,0 +1,145 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package product_pool
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// InstanceOneParam locates one instance of pool
+type InstanceOneParam struct {
+	InstancePoolName string `uri:"instance_pool_name" validate:"required,min=2"`
+	InstanceName     string `uri:"instance_name" validate:"required"`
+}
+
+// InstanceUpdateParam only not nil field will be changed
+type InstanceUpdateParam struct {
+	InstanceOneParam
+
+	Weight  *int64 `json:"weight" validate:"omitempty,min=0,max=100"`
+	Disable *bool  `json:"disable"`
+}
+
+// InstanceCreateParam Request Param
+type InstanceCreateParam struct {
+	InstancePoolName string `uri:"instance_pool_name" validate:"required,min=2"`
+
+	Instance
+}
+
+var InstanceCreateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/instance-pools/{instance_pool_name}/instances",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(InstanceCreateAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductPool, iauth.ActionUpdate),
+}
+
+var InstanceUpdateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/instance-pools/{instance_pool_name}/instances/{instance_name}",
+	Method:     http.MethodPatch,
+	Handler:    xreq.Convert(InstanceUpdateAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductPool, iauth.ActionUpdate),
+}
+
+var InstanceDeleteEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/instance-pools/{instance_pool_name}/instances/{instance_name}",
+	Method:     http.MethodDelete,
+	Handler:    xreq.Convert(InstanceDeleteAction),
+	Authorizer: iauth.FAP(iauth.FeatureProductPool, iauth.ActionUpdate),
+}
+
+func NewInstanceOneParam(req *http.Request) (*InstanceOneParam, error) {
+	param := &InstanceOneParam{}
+	err := xreq.BindURI(req, param)
+	return param, err
+}
+
+var _ xreq.Handler = InstanceCreateAction
+
+// InstanceCreateAction adds one instance to pool
+func InstanceCreateAction(req *http.Request) (interface{}, error) {
+	param := &InstanceCreateParam{}
+	if err := xreq.Bind(req, param); err != nil {
+		return nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	instances := Instancesc2i([]*Instance{&param.Instance})
+	pool, err := container.PoolManager.AddPoolInstance(req.Context(), product, param.InstancePoolName, &instances[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOneData(pool), nil
+}
+
+var _ xreq.Handler = InstanceUpdateAction
+
+// InstanceUpdateAction enables/disables instance or sets weight of instance
+func InstanceUpdateAction(req *http.Request) (interface{}, error) {
+	param := &InstanceUpdateParam{}
+	if err := xreq.Bind(req, param); err != nil {
+		return nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := container.PoolManager.UpdatePoolInstance(req.Context(), product, param.InstancePoolName,
+		param.InstanceName, &icluster_conf.InstanceParam{
+			Weight:  param.Weight,
+			Disable: param.Disable,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOneData(pool), nil
+}
+
+var _ xreq.Handler = InstanceDeleteAction
+
+// InstanceDeleteAction removes one instance from pool
+func InstanceDeleteAction(req *http.Request) (interface{}, error) {
+	param, err := NewInstanceOneParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := container.PoolManager.DeletePoolInstance(req.Context(), product, param.InstancePoolName,
+		param.InstanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOneData(pool), nil
+}
@@ -30,6 +30,7 @@ package product_pool
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/yf-networks/ai-gateway-api/lib/xerror"
 	"github.com/yf-networks/ai-gateway-api/lib/xreq"
@@ -53,6 +54,9 @@ type Instance struct {
 	Weight   int64             `json:"weight" uri:"weight" validate:"min=0,max=100"`
 	Ports    map[string]int    `json:"ports" uri:"ports" validate:"required,min=1"`
 	Tags     map[string]string `json:"tags" uri:"tags" validate:"required,min=1"`
+	Disable  bool              `json:"disable" uri:"disable"`
+
+	Drain *icluster_conf.InstanceDrainProgress `json:"drain,omitempty"`
 }
 
 // OneData Request Param
@@ -63,15 +67,22 @@ type OneData struct {
 }
 
 func NewOneData(pool *icluster_conf.Pool) *OneData {
+	now := time.Now()
 	is := []*Instance{}
 	for _, one := range pool.Instances {
-		is = append(is, &Instance{
+		instance := &Instance{
 			Hostname: one.HostName,
 			IP:       one.IP,
 			Weight:   one.Weight,
 			Ports:    one.Ports,
 			Tags:     one.Tags,
-		})
+			Disable:  one.Disable,
+		}
+		if one.Drain != nil {
+			instance.Drain = one.Drain.Progress(now)
+		}
+
+		is = append(is, instance)
 	}
 
 	return &OneData{
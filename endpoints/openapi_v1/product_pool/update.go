@@ -56,11 +56,12 @@ func UpdateAction(req *http.Request) (interface{}, error) {
 		return nil, xerror.WrapRecordNotExist("Instance Pool")
 	}
 
-	err = container.PoolManager.UpdateProductPool(req.Context(), product, one, &icluster_conf.PoolParam{
+	one, err = container.PoolManager.UpdateProductPool(req.Context(), product, one, &icluster_conf.PoolParam{
 		Instances: Instancesc2i(param.Instances),
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	one.Instances = Instancesc2i(param.Instances)
-
-	return NewOneData(one), err
+	return NewOneData(one), nil
 }
@@ -0,0 +1,230 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/model/iprotocol"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// RevealData is the plain text secret
+type RevealData struct {
+	Key string `json:"key"`
+}
+
+// ClusterKeyData is the plain text provider keys of cluster
+type ClusterKeyData struct {
+	Key  string             `json:"key,omitempty"`
+	Keys []*ProviderKeyData `json:"keys,omitempty"`
+}
+
+// ProviderKeyData is the plain text provider key
+type ProviderKeyData struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// UpstreamCredentialData is the plain text upstream credential of api key
+type UpstreamCredentialData struct {
+	Cluster      *string `json:"cluster,omitempty"`
+	ProviderType *string `json:"provider_type,omitempty"`
+	Key          string  `json:"key"`
+}
+
+var RevealClusterKeyRoute = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/secrets/llm-key",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(RevealClusterKeyAction),
+	Authorizer: iauth.FAP(iauth.FeatureSecret, iauth.ActionRead),
+}
+
+var RevealCertificateKeyRoute = &xreq.Endpoint{
+	Path:       "/certificates/{cert_name}/secrets/key",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(RevealCertificateKeyAction),
+	Authorizer: iauth.FA(iauth.FeatureSecret, iauth.ActionRead),
+}
+
+var RevealAPIKeyUpstreamCredentialsRoute = &xreq.Endpoint{
+	Path:       "/products/{product_name}/api-keys/{api_key_name}/secrets/upstream-credentials",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(RevealAPIKeyUpstreamCredentialsAction),
+	Authorizer: iauth.FAP(iauth.FeatureSecret, iauth.ActionRead),
+}
+
+type ClusterParam struct {
+	ClusterName *string `uri:"cluster_name" validate:"required,min=1"`
+}
+
+type CertificateParam struct {
+	CertName *string `uri:"cert_name" validate:"required,min=2"`
+}
+
+type APIKeyParam struct {
+	APIKeyName *string `uri:"api_key_name" validate:"required,max=255"`
+}
+
+// auditReveal records who revealed which secret
+func auditReveal(ctx context.Context, secret string) {
+	name := "unknown"
+	if visitor, err := iauth.MustGetVisitor(ctx); err == nil {
+		name = visitor.GetName()
+	}
+
+	stateful.AccessLogger.Warn("secret revealed: user=%s, secret=%s", name, secret)
+}
+
+var _ xreq.Handler = RevealClusterKeyAction
+
+// RevealClusterKeyAction return plain text provider key of cluster
+func RevealClusterKeyAction(req *http.Request) (interface{}, error) {
+	param := &ClusterParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := container.ClusterManager.FetchCluster(req.Context(), &icluster_conf.ClusterFilter{
+		Name:    param.ClusterName,
+		Product: product,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cluster == nil {
+		return nil, xerror.WrapRecordNotExist("Cluster")
+	}
+
+	if cluster.LLMConfig == nil {
+		return nil, xerror.WrapRecordNotExist("Cluster LLM Key")
+	}
+
+	rst := &ClusterKeyData{}
+	key, err := icluster_conf.OpenLLMConfigKey(req.Context(), cluster.LLMConfig)
+	if err != nil {
+		return nil, xerror.WrapModelError(err)
+	}
+	if key != nil {
+		rst.Key = *key
+	}
+
+	for _, one := range cluster.LLMConfig.Keys {
+		if one.Key == nil {
+			continue
+		}
+
+		key, err := isecret.Open(req.Context(), *one.Key)
+		if err != nil {
+			return nil, xerror.WrapModelError(err)
+		}
+		rst.Keys = append(rst.Keys, &ProviderKeyData{
+			Name: *one.Name,
+			Key:  key,
+		})
+	}
+
+	auditReveal(req.Context(), "cluster llm key "+cluster.Name)
+
+	return rst, nil
+}
+
+var _ xreq.Handler = RevealCertificateKeyAction
+
+// RevealCertificateKeyAction return plain text private key of certificate
+func RevealCertificateKeyAction(req *http.Request) (interface{}, error) {
+	param := &CertificateParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	list, err := container.CertificateManager.FetchCertificates(req.Context(), &iprotocol.CertificateFilter{
+		CertName: param.CertName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, xerror.WrapRecordNotExist("Certificate")
+	}
+
+	key, err := container.CertificateManager.FetchCertificateKey(req.Context(), list[0])
+	if err != nil {
+		return nil, xerror.WrapModelError(err)
+	}
+
+	auditReveal(req.Context(), "certificate key "+list[0].CertName)
+
+	return &RevealData{
+		Key: key,
+	}, nil
+}
+
+var _ xreq.Handler = RevealAPIKeyUpstreamCredentialsAction
+
+// RevealAPIKeyUpstreamCredentialsAction return plain text upstream credentials of api key
+func RevealAPIKeyUpstreamCredentialsAction(req *http.Request) (interface{}, error) {
+	param := &APIKeyParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, err
+	}
+
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	one, err := container.APIKeyManager.FetchAPIKey(req.Context(), &icluster_conf.APIKeyFilter{
+		Name:        param.APIKeyName,
+		ProductName: &product.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if one == nil {
+		return nil, xerror.WrapRecordNotExist("APIKey")
+	}
+
+	rst := []*UpstreamCredentialData{}
+	for _, credential := range one.UpstreamCredentials {
+		key, err := icluster_conf.OpenUpstreamCredential(req.Context(), credential)
+		if err != nil {
+			return nil, xerror.WrapModelError(err)
+		}
+
+		rst = append(rst, &UpstreamCredentialData{
+			Cluster:      credential.Cluster,
+			ProviderType: credential.ProviderType,
+			Key:          key,
+		})
+	}
+
+	auditReveal(req.Context(), "upstream credentials of api key "+*one.Name)
+
+	return rst, nil
+}
@@ -30,6 +30,7 @@ type CreateParam struct {
 	Name         *string `json:"name" uri:"name" validate:"required,min=2"`
 	InstancePool *string `json:"instance_pool" uri:"instance_pool" validate:"required,min=2"`
 	Description  *string `json:"description" uri:"description" validate:"omitempty,min=2"`
+	Capacity     *int64  `json:"capacity" uri:"capacity" validate:"omitempty,min=0"`
 }
 
 // CreateRoute route
@@ -74,6 +75,7 @@ func CreateProcess(req *http.Request, param *CreateParam) (*OneData, error) {
 			Product:     product,
 			PoolName:    param.InstancePool,
 			Description: param.Description,
+			Capacity:    param.Capacity,
 		})
 	if err != nil {
 		return nil, err
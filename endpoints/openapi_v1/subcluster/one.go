@@ -46,6 +46,7 @@ type OneData struct {
 	InstancePool string                   `json:"instance_pool" uri:"instance_pool"`
 	Description  string                   `json:"description" uri:"description"`
 	Ready        bool                     `json:"ready" uri:"ready"`
+	Capacity     int64                    `json:"capacity" uri:"capacity"`
 	ProductName  string                   `json:"product_name,omitempty"`
 	Instances    []icluster_conf.Instance `json:"instances"`
 }
@@ -119,6 +120,7 @@ func newOneData(sc *icluster_conf.SubCluster) *OneData {
 		Name:        sc.Name,
 		Description: sc.Description,
 		Ready:       sc.Ready,
+		Capacity:    sc.Capacity,
 		ProductName: sc.ProductName,
 	}
 
@@ -30,6 +30,7 @@ import (
 type UpdateParam struct {
 	Name           *string `json:"name" uri:"name" validate:"min=2"`
 	Description    *string `json:"description" uri:"description" validate:"omitempty,min=2"`
+	Capacity       *int64  `json:"capacity" uri:"capacity" validate:"omitempty,min=0"`
 	SubClusterName *string `uri:"sub_cluster_name" validate:"required,min=2"`
 }
 
@@ -83,6 +84,7 @@ func updateActionProcess(req *http.Request, param *UpdateParam) (*OneData, error
 
 	err = container.SubClusterManager.UpdateSubCluster(req.Context(), oldOne, &icluster_conf.SubClusterParam{
 		Description: param.Description,
+		Capacity:    param.Capacity,
 	})
 	if err != nil {
 		return nil, err
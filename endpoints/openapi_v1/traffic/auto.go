@@ -0,0 +1,118 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package traffic
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// AutoUpdateParam Request Param
+type AutoUpdateParam struct {
+	MaxRegionLoad    *float64 `json:"max_region_load" validate:"required,gt=0,lte=1"`
+	BlackholeEnabled bool     `json:"blackhole_enabled"`
+	MaxBlackholeLoad float64  `json:"max_blackhole_load" validate:"min=0,max=1"`
+}
+
+var AutoUpdateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/scheduler/auto",
+	Method:     http.MethodPatch,
+	Handler:    xreq.Convert(AutoUpdateAction),
+	Authorizer: iauth.FAP(iauth.FeatureTraffic, iauth.ActionUpdate),
+}
+
+var AutoDeleteEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/scheduler/auto",
+	Method:     http.MethodDelete,
+	Handler:    xreq.Convert(AutoDeleteAction),
+	Authorizer: iauth.FAP(iauth.FeatureTraffic, iauth.ActionUpdate),
+}
+
+func fetchCluster(req *http.Request) (*ibasic.Product, *icluster_conf.Cluster, error) {
+	product, err := ibasic.MustGetProduct(req.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	param, err := newOneParam4One(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cluster, err := container.ClusterManager.FetchCluster(req.Context(), &icluster_conf.ClusterFilter{
+		Product: product,
+		Name:    &param.ClusterName,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if cluster == nil {
+		return nil, nil, xerror.WrapRecordNotExist("Cluster")
+	}
+
+	return product, cluster, nil
+}
+
+var _ xreq.Handler = AutoUpdateAction
+
+// AutoUpdateAction enables auto scheduler or changes its setting, lb matrix is recomputed at once
+func AutoUpdateAction(req *http.Request) (interface{}, error) {
+	param := &AutoUpdateParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+
+	product, cluster, err := fetchCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = container.ClusterManager.EnableAutoScheduler(req.Context(), product, cluster, &icluster_conf.ClusterAutoScheduler{
+		MaxRegionLoad:    *param.MaxRegionLoad,
+		BlackholeEnabled: param.BlackholeEnabled,
+		MaxBlackholeLoad: param.MaxBlackholeLoad,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return oneActionProcess(req, &OneParam{
+		ClusterName: cluster.Name,
+	})
+}
+
+var _ xreq.Handler = AutoDeleteAction
+
+// AutoDeleteAction switches cluster back to manual mode
+func AutoDeleteAction(req *http.Request) (interface{}, error) {
+	product, cluster, err := fetchCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = container.ClusterManager.DisableAutoScheduler(req.Context(), product, cluster); err != nil {
+		return nil, err
+	}
+
+	return oneActionProcess(req, &OneParam{
+		ClusterName: cluster.Name,
+	})
+}
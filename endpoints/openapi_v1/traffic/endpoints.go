@@ -21,4 +21,15 @@ import (
 var Endpoints = []*xreq.Endpoint{
 	OneEndpoint,
 	ManualUpdateEndpoint,
+	AutoUpdateEndpoint,
+	AutoDeleteEndpoint,
+	PreviewEndpoint,
+	PlanListEndpoint,
+	PlanOneEndpoint,
+	PlanCreateEndpoint,
+	PlanDeleteEndpoint,
+	PlanLogsEndpoint,
+	PlanPauseEndpoint,
+	PlanResumeEndpoint,
+	PlanAbortEndpoint,
 }
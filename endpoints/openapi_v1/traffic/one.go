@@ -25,6 +25,11 @@ import (
 	"github.com/yf-networks/ai-gateway-api/stateful/container"
 )
 
+const (
+	SchedulerModeManual = "manual"
+	SchedulerModeAuto   = "auto"
+)
+
 // OneParam Request Param
 // AUTO GEN BY ctrl, MODIFY AS U NEED
 type OneParam struct {
@@ -38,6 +43,11 @@ type AutoScheduler struct {
 	MaxBlackholeLoad float64          `json:"max_blackhole_load" uri:"max_blackhole_load"`
 	BlackholeEnabled bool             `json:"blackhole_enabled" uri:"blackhole_enabled"`
 	Capacity         map[string]int64 `json:"capacity"`
+
+	// BFECapacity is the traffic planned to be forwarded by bfe clusters
+	BFECapacity  map[string]int64   `json:"bfe_capacity"`
+	ExpectedLoad map[string]float64 `json:"expected_load"`
+	Overloaded   bool               `json:"overloaded"`
 }
 
 // SubCluster Request Param
@@ -51,6 +61,7 @@ type SubCluster struct {
 // AUTO GEN BY ctrl, MODIFY AS U NEED
 type OneData struct {
 	Cluster       string                    `json:"cluster" uri:"cluster"`
+	Mode          string                    `json:"mode" uri:"mode"`
 	Scheduler     map[string]map[string]int `json:"scheduler,omitempty" uri:"scheduler"`
 	AutoScheduler *AutoScheduler            `json:"auto_scheduler,omitempty" uri:"auto_scheduler"`
 }
@@ -92,10 +103,52 @@ func oneActionProcess(req *http.Request, param *OneParam) (*OneData, error) {
 		return nil, xerror.WrapDirtyDataErrorWithMsg("Manual Mode, But Without LbMatrix Setting")
 	}
 
-	return &OneData{
+	data := &OneData{
 		Cluster:   cluster.Name,
+		Mode:      SchedulerModeManual,
 		Scheduler: cluster.Scheduler,
-	}, nil
+	}
+	if !cluster.AutoScheduler.Enabled() {
+		return data, nil
+	}
+
+	data.Mode = SchedulerModeAuto
+	data.AutoScheduler, err = newAutoScheduler(req, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func newAutoScheduler(req *http.Request, cluster *icluster_conf.Cluster) (*AutoScheduler, error) {
+	plan, err := container.ClusterManager.FetchAutoSchedulerPlan(req.Context(), cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	bfeClusters, err := container.BFEClusterManager.FetchBFEClusters(req.Context(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	as := &AutoScheduler{
+		MaxRegionLoad:    cluster.AutoScheduler.MaxRegionLoad,
+		MaxBlackholeLoad: cluster.AutoScheduler.MaxBlackholeLoad,
+		BlackholeEnabled: cluster.AutoScheduler.BlackholeEnabled,
+		Capacity:         map[string]int64{},
+		BFECapacity:      map[string]int64{},
+		ExpectedLoad:     plan.Loads,
+		Overloaded:       plan.Overloaded,
+	}
+	for _, one := range cluster.SubClusters {
+		as.Capacity[one.Name] = one.Capacity
+	}
+	for _, one := range bfeClusters {
+		as.BFECapacity[one.Name] = one.Capacity
+	}
+
+	return as, nil
 }
 
 var _ xreq.Handler = OneAction
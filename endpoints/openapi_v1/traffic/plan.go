@@ -0,0 +1,284 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package traffic
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+// PlanParam is the plan in path
+type PlanParam struct {
+	PlanName string `uri:"plan_name" validate:"required,min=1,max=255"`
+}
+
+// PlanStep is one step of traffic plan, exactly one of start_at and interval_in_s should be set
+type PlanStep struct {
+	LbMatrix    map[string]map[string]int `json:"lb_matrix" validate:"required"`
+	StartAt     *time.Time                `json:"start_at,omitempty"`
+	IntervalInS *int64                    `json:"interval_in_s,omitempty"`
+}
+
+// PlanCreateParam Request Param
+type PlanCreateParam struct {
+	Name        *string     `json:"name" validate:"required,min=1,max=255"`
+	Description *string     `json:"description" validate:"omitempty,max=255"`
+	Steps       []*PlanStep `json:"steps" validate:"required,min=1,dive,required"`
+}
+
+// PlanData is the response of traffic plan
+type PlanData struct {
+	Name        string      `json:"name"`
+	ClusterName string      `json:"cluster_name"`
+	Description string      `json:"description"`
+	Status      string      `json:"status"`
+	Steps       []*PlanStep `json:"steps"`
+	NextStep    int         `json:"next_step"`
+	NextStepAt  *time.Time  `json:"next_step_at,omitempty"`
+	PausedAt    *time.Time  `json:"paused_at,omitempty"`
+	CreatedBy   string      `json:"created_by"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// PlanLogData is one execution log of traffic plan
+type PlanLogData struct {
+	Step      int       `json:"step"`
+	Action    string    `json:"action"`
+	Message   string    `json:"message"`
+	Operator  string    `json:"operator"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newPlanData(plan *icluster_conf.TrafficPlan) *PlanData {
+	rst := &PlanData{
+		Name:        plan.Name,
+		ClusterName: plan.ClusterName,
+		Description: plan.Description,
+		Status:      plan.Status,
+		NextStep:    plan.NextStep,
+		CreatedBy:   plan.CreatedBy,
+		CreatedAt:   plan.CreatedAt,
+		UpdatedAt:   plan.UpdatedAt,
+	}
+	for _, step := range plan.Steps {
+		rst.Steps = append(rst.Steps, &PlanStep{
+			LbMatrix:    step.LbMatrix,
+			StartAt:     step.StartAt,
+			IntervalInS: step.IntervalInS,
+		})
+	}
+	if plan.NextStep < len(plan.Steps) && plan.Active() {
+		nextStepAt := plan.NextStepAt
+		rst.NextStepAt = &nextStepAt
+	}
+	if plan.Status == icluster_conf.TrafficPlanStatusPaused {
+		pausedAt := plan.PausedAt
+		rst.PausedAt = &pausedAt
+	}
+
+	return rst
+}
+
+// visitorName returns name of user who calls the api
+func visitorName(req *http.Request) (string, error) {
+	visitor, err := iauth.MustGetVisitor(req.Context())
+	if err != nil {
+		return "", err
+	}
+
+	return visitor.GetName(), nil
+}
+
+// fetchPlan returns cluster and plan in path
+func fetchPlan(req *http.Request) (*icluster_conf.Cluster, *icluster_conf.TrafficPlan, error) {
+	param := &PlanParam{}
+	if err := xreq.BindURI(req, param); err != nil {
+		return nil, nil, err
+	}
+
+	_, cluster, err := fetchCluster(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := container.TrafficPlanManager.FetchTrafficPlan(req.Context(), cluster, param.PlanName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if plan == nil {
+		return nil, nil, xerror.WrapRecordNotExist("Traffic Plan")
+	}
+
+	return cluster, plan, nil
+}
+
+var PlanListEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/traffic-plans",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(PlanListAction),
+	Authorizer: iauth.FAP(iauth.FeatureTraffic, iauth.ActionRead),
+}
+
+var _ xreq.Handler = PlanListAction
+
+// PlanListAction returns traffic plans of cluster
+func PlanListAction(req *http.Request) (interface{}, error) {
+	_, cluster, err := fetchCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := container.TrafficPlanManager.FetchTrafficPlans(req.Context(), cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := []*PlanData{}
+	for _, one := range list {
+		rst = append(rst, newPlanData(one))
+	}
+
+	return rst, nil
+}
+
+var PlanOneEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/traffic-plans/{plan_name}",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(PlanOneAction),
+	Authorizer: iauth.FAP(iauth.FeatureTraffic, iauth.ActionRead),
+}
+
+var _ xreq.Handler = PlanOneAction
+
+// PlanOneAction returns traffic plan
+func PlanOneAction(req *http.Request) (interface{}, error) {
+	_, plan, err := fetchPlan(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPlanData(plan), nil
+}
+
+var PlanCreateEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/traffic-plans",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(PlanCreateAction),
+	Authorizer: iauth.FAP(iauth.FeatureTraffic, iauth.ActionCreate),
+}
+
+var _ xreq.Handler = PlanCreateAction
+
+// PlanCreateAction creates traffic plan, which starts running at once
+func PlanCreateAction(req *http.Request) (interface{}, error) {
+	param := &PlanCreateParam{}
+	if err := xreq.BindJSON(req, param); err != nil {
+		return nil, err
+	}
+
+	_, cluster, err := fetchCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	operator, err := visitorName(req)
+	if err != nil {
+		return nil, err
+	}
+
+	mp := &icluster_conf.TrafficPlanParam{
+		Name:        param.Name,
+		Description: param.Description,
+	}
+	for _, step := range param.Steps {
+		mp.Steps = append(mp.Steps, &icluster_conf.TrafficPlanStep{
+			LbMatrix:    step.LbMatrix,
+			StartAt:     step.StartAt,
+			IntervalInS: step.IntervalInS,
+		})
+	}
+
+	plan, err := container.TrafficPlanManager.CreateTrafficPlan(req.Context(), cluster, mp, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPlanData(plan), nil
+}
+
+var PlanDeleteEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/traffic-plans/{plan_name}",
+	Method:     http.MethodDelete,
+	Handler:    xreq.Convert(PlanDeleteAction),
+	Authorizer: iauth.FAP(iauth.FeatureTraffic, iauth.ActionDelete),
+}
+
+var _ xreq.Handler = PlanDeleteAction
+
+// PlanDeleteAction deletes ended traffic plan and its logs
+func PlanDeleteAction(req *http.Request) (interface{}, error) {
+	_, plan, err := fetchPlan(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = container.TrafficPlanManager.DeleteTrafficPlan(req.Context(), plan); err != nil {
+		return nil, err
+	}
+
+	return newPlanData(plan), nil
+}
+
+var PlanLogsEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/traffic-plans/{plan_name}/logs",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(PlanLogsAction),
+	Authorizer: iauth.FAP(iauth.FeatureTraffic, iauth.ActionRead),
+}
+
+var _ xreq.Handler = PlanLogsAction
+
+// PlanLogsAction returns execution logs of traffic plan in time order
+func PlanLogsAction(req *http.Request) (interface{}, error) {
+	_, plan, err := fetchPlan(req)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := container.TrafficPlanManager.FetchTrafficPlanLogs(req.Context(), plan)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := []*PlanLogData{}
+	for _, one := range list {
+		rst = append(rst, &PlanLogData{
+			Step:      one.Step,
+			Action:    one.Action,
+			Message:   one.Message,
+			Operator:  one.Operator,
+			CreatedAt: one.CreatedAt,
+		})
+	}
+
+	return rst, nil
+}
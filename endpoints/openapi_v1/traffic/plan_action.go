@@ -0,0 +1,89 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package traffic
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+var PlanPauseEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/traffic-plans/{plan_name}/pause",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(PlanPauseAction),
+	Authorizer: iauth.FAP(iauth.FeatureTraffic, iauth.ActionUpdate),
+}
+
+var PlanResumeEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/traffic-plans/{plan_name}/resume",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(PlanResumeAction),
+	Authorizer: iauth.FAP(iauth.FeatureTraffic, iauth.ActionUpdate),
+}
+
+var PlanAbortEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/traffic-plans/{plan_name}/abort",
+	Method:     http.MethodPost,
+	Handler:    xreq.Convert(PlanAbortAction),
+	Authorizer: iauth.FAP(iauth.FeatureTraffic, iauth.ActionUpdate),
+}
+
+// planActionProcess changes status of plan in path by fn
+func planActionProcess(req *http.Request, fn func(ctx context.Context, plan *icluster_conf.TrafficPlan,
+	operator string) (*icluster_conf.TrafficPlan, error)) (*PlanData, error) {
+
+	_, plan, err := fetchPlan(req)
+	if err != nil {
+		return nil, err
+	}
+
+	operator, err := visitorName(req)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err = fn(req.Context(), plan, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPlanData(plan), nil
+}
+
+var _ xreq.Handler = PlanPauseAction
+
+// PlanPauseAction stops applying steps until resumed
+func PlanPauseAction(req *http.Request) (interface{}, error) {
+	return planActionProcess(req, container.TrafficPlanManager.PauseTrafficPlan)
+}
+
+var _ xreq.Handler = PlanResumeAction
+
+// PlanResumeAction continues paused plan, or retries the failed step
+func PlanResumeAction(req *http.Request) (interface{}, error) {
+	return planActionProcess(req, container.TrafficPlanManager.ResumeTrafficPlan)
+}
+
+var _ xreq.Handler = PlanAbortAction
+
+// PlanAbortAction ends plan, lb matrix applied by previous steps is kept
+func PlanAbortAction(req *http.Request) (interface{}, error) {
+	return planActionProcess(req, container.TrafficPlanManager.AbortTrafficPlan)
+}
@@ -0,0 +1,42 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package traffic
+
+import (
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xreq"
+	"github.com/yf-networks/ai-gateway-api/model/iauth"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
+)
+
+var PreviewEndpoint = &xreq.Endpoint{
+	Path:       "/products/{product_name}/clusters/{cluster_name}/scheduler/preview",
+	Method:     http.MethodGet,
+	Handler:    xreq.Convert(PreviewAction),
+	Authorizer: iauth.FAP(iauth.FeatureTraffic, iauth.ActionRead),
+}
+
+var _ xreq.Handler = PreviewAction
+
+// PreviewAction returns effective share of cluster traffic per sub cluster and instance
+func PreviewAction(req *http.Request) (interface{}, error) {
+	_, cluster, err := fetchCluster(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.ClusterManager.PreviewTraffic(req.Context(), cluster)
+}
@@ -0,0 +1,139 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package xcrypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SealedPrefix marks values produced by Seal, so they can't be confused with plain text
+const SealedPrefix = "enc:v1:"
+
+var ErrKeyNotSet = errors.New("master key not set")
+
+// deriveKey stretches any length secret to an AES-256 key
+func deriveKey(secret []byte) []byte {
+	sum := sha256.Sum256(secret)
+	return sum[:]
+}
+
+// Encrypt encrypts plainText with AES-256-GCM, key must be 32 bytes, the nonce is prepended to the result
+func Encrypt(key []byte, plainText []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plainText, nil), nil
+}
+
+// Decrypt decrypts value produced by Encrypt
+func Decrypt(key []byte, cipherText []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cipherText) < gcm.NonceSize() {
+		return nil, errors.New("sealed value too short")
+	}
+
+	nonce, data := cipherText[:gcm.NonceSize()], cipherText[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, data, nil)
+}
+
+// NewKey generates random AES-256 key
+func NewKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Seal encrypts plainText with key derived from secret, the result is base64 encoded and prefixed with SealedPrefix
+func Seal(secret []byte, plainText []byte) (string, error) {
+	if len(secret) == 0 {
+		return "", ErrKeyNotSet
+	}
+
+	sealed, err := Encrypt(deriveKey(secret), plainText)
+	if err != nil {
+		return "", err
+	}
+
+	return SealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts value produced by Seal
+func Open(secret []byte, sealedText string) ([]byte, error) {
+	if len(secret) == 0 {
+		return nil, ErrKeyNotSet
+	}
+	if !IsSealed(sealedText) {
+		return nil, errors.New("value is not sealed")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(sealedText, SealedPrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	return Decrypt(deriveKey(secret), raw)
+}
+
+// KeyID return short identity of secret, which is safe to be stored with data,
+// it's derived separately so it reveals nothing of the encryption key
+func KeyID(secret []byte) string {
+	sum := sha256.Sum256(append([]byte("key-id:"), secret...))
+	return fmt.Sprintf("%x", sum[:4])
+}
+
+// IsSealed check whether value produced by Seal
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, SealedPrefix)
+}
+
+// Mask hide the secret, only keep the last 4 characters
+// eg: sk-1234567890abcd => ****abcd
+func Mask(secret string) string {
+	if len(secret) <= 8 {
+		return "****"
+	}
+
+	return "****" + secret[len(secret)-4:]
+}
@@ -29,6 +29,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	_ "net/http/pprof"
@@ -40,7 +41,10 @@ import (
 	"gopkg.in/tylerb/graceful.v1"
 
 	"github.com/yf-networks/ai-gateway-api/endpoints"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
 	"github.com/yf-networks/ai-gateway-api/stateful"
+	"github.com/yf-networks/ai-gateway-api/stateful/container"
 	"github.com/yf-networks/ai-gateway-api/stateful/container/rdb"
 	"github.com/yf-networks/ai-gateway-api/version"
 )
@@ -52,6 +56,8 @@ var (
 	serverConf *string = flag.String("sc", "ai_gateway_api.toml", "server conf file")
 
 	logDir *string = flag.String("l", "./log", "dir path of log")
+
+	rekey *bool = flag.Bool("rekey", false, "to seal secrets stored in database with the current master key, then exit")
 )
 
 func main() {
@@ -85,6 +91,12 @@ func main() {
 		stateful.Exit("config.InitLog", err, -1)
 	}
 
+	keyManager, err := isecret.NewKeyManagerFromConfig(&config.Secret)
+	if err != nil {
+		stateful.Exit("isecret.NewKeyManagerFromConfig", err, -1)
+	}
+	isecret.Init(keyManager)
+
 	defer func() {
 		time.Sleep(time.Second)
 		stateful.CloseLog()
@@ -103,11 +115,99 @@ func main() {
 		config.RedisConf.Init()
 	}
 
+	initKubernetesDiscoverer()
+
 	rdb.Init()
 
+	if *rekey {
+		rekeySecrets()
+		return
+	}
+
+	seedModelProviders()
+
+	startBackgroundJobs()
+
 	serverStartUp()
 }
 
+// initKubernetesDiscoverer creates discoverer before managers, PoolDiscoveryManager enables kubernetes source by it
+func initKubernetesDiscoverer() {
+	conf := &stateful.DefaultConfig.KubernetesDiscovery
+	if !conf.Enable {
+		return
+	}
+
+	client, err := icluster_conf.NewKubernetesClient(conf)
+	if err != nil {
+		stateful.Exit("icluster_conf.NewKubernetesClient", err, -1)
+	}
+	container.KubernetesDiscoverer = icluster_conf.NewKubernetesDiscoverer(client, conf)
+}
+
+// seedModelProviders imports provider definitions from conf/ai on first start
+func seedModelProviders() {
+	count, err := container.ModelProviderManager.SeedModelProviders(context.Background(),
+		icluster_conf.ModelProvidersSeedFile, icluster_conf.ModelDefinitionSeedFile)
+	if err != nil {
+		stateful.Exit("seedModelProviders", err, -1)
+	}
+	if count > 0 {
+		stateful.AccessLogger.Info("model provider catalog seeded with %d providers", count)
+	}
+}
+
+func startBackgroundJobs() {
+	ctx := context.Background()
+
+	if stateful.DefaultConfig.Notification.Enable {
+		go container.APIKeyLifecycleWatcher.Run(ctx)
+	}
+
+	if stateful.DefaultConfig.ModelSync.Enable {
+		go container.ModelSyncManager.Run(ctx)
+	}
+
+	go container.PoolDrainRunner.Run(ctx)
+
+	if container.KubernetesDiscoverer != nil {
+		go container.KubernetesDiscoverer.Run(ctx, container.PoolDiscoveryManager.SyncKubernetesPools)
+	}
+
+	go container.PoolDiscoveryManager.Run(ctx)
+
+	go container.TrafficPlanManager.Run(ctx)
+
+	go container.CanaryManager.Run(ctx)
+}
+
+// rekeySecrets seals secrets with the current master key, run it after rotating master key
+// (move the previous one to OldMasterKeyFile/OldMasterKeyEnv) or enabling encryption on existed data
+func rekeySecrets() {
+	ctx := context.Background()
+	if len(stateful.DefaultConfig.Secret.MasterKey()) == 0 {
+		stateful.Exit("rekey", fmt.Errorf("master key not configured"), -1)
+	}
+
+	jobs := []struct {
+		name string
+		fn   func(context.Context) (int, error)
+	}{
+		{"cluster llm keys", container.ClusterManager.RekeySecrets},
+		{"certificate keys", container.CertificateManager.RekeySecrets},
+		{"api key upstream credentials", container.APIKeyManager.RekeySecrets},
+		{"config snapshots", container.VersionControlManager.RekeySecrets},
+	}
+
+	for _, job := range jobs {
+		count, err := job.fn(ctx)
+		if err != nil {
+			stateful.Exit("rekey "+job.name, err, -1)
+		}
+		fmt.Printf("rekey %s: %d updated\n", job.name, count)
+	}
+}
+
 func serverStartUp() {
 	serverConfig := stateful.DefaultConfig.Server
 
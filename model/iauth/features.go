@@ -116,6 +116,23 @@ const (
 	FeatureNLBCluster Feature = "NLBCluster"
 	FeatureAIRoute    Feature = "AIRoute"
 	FeatureAPIKey     Feature = "APIKey"
+
+	// secret, reveal plain text of secrets stored encrypted
+	FeatureSecret Feature = "Secret"
+
+	FeatureModelProvider Feature = "ModelProvider"
+
+	// cluster templates of system scope, product templates use FeatureProductCluster
+	FeatureClusterTemplate Feature = "ClusterTemplate"
+
+	// canary of cluster, data plane reports canary metrics with ActionExport
+	FeatureCanary Feature = "Canary"
+
+	// bfe data plane nodes, conf-agent reports heartbeats with ActionExport
+	FeatureBFENode Feature = "BFENode"
+
+	// versions of exported config, release channels and config targets of bfe clusters
+	FeatureConfigVersion Feature = "ConfigVersion"
 )
 
 var (
@@ -161,6 +178,18 @@ var scope2permission = map[string]map[Feature]Action{
 		FeatureNLBCluster: actionAll,
 		FeatureAIRoute:    actionAll,
 		FeatureAPIKey:     actionAll,
+
+		FeatureSecret: ActionRead,
+
+		FeatureModelProvider: actionAll,
+
+		FeatureClusterTemplate: actionAll,
+
+		FeatureCanary: actionAll,
+
+		FeatureBFENode: actionAll,
+
+		FeatureConfigVersion: actionAll,
 	},
 	ScopeProduct: {
 		FeatureUser:       ActionReadAll,
@@ -187,6 +216,10 @@ var scope2permission = map[string]map[Feature]Action{
 		FeatureNLBCluster: actionProductNormal,
 		FeatureAIRoute:    actionProductNormal,
 		FeatureAPIKey:     actionProductNormal,
+
+		FeatureClusterTemplate: ActionRead.Grant(ActionReadAll),
+
+		FeatureCanary: actionProductNormal,
 	},
 	ScopeSupport: {
 		FeatureProxyPool:         ActionExport,
@@ -194,5 +227,7 @@ var scope2permission = map[string]map[Feature]Action{
 		FeatureCert:              ActionExport,
 		FeatureActiveHealthCheck: ActionExport,
 		FeatureExtraFile:         ActionExport,
+		FeatureCanary:            ActionExport,
+		FeatureBFENode:           ActionExport,
 	},
 }
@@ -44,19 +44,36 @@ type BFEClusterFilter struct {
 type BFEClusterStorager interface {
 	DeleteBFECluster(context.Context, *BFECluster) error
 	CreateBFECluster(context.Context, *BFEClusterParam) error
+	UpdateBFECluster(context.Context, *BFECluster, *BFEClusterParam) error
 	FetchBFEClusters(context.Context, *BFEClusterFilter) ([]*BFECluster, error)
 }
 
 type BFEClusterManager struct {
 	storager BFEClusterStorager
 	txn      itxn.TxnStorager
+
+	// changeHooks are called in the same txn after bfe clusters be created, updated or deleted
+	changeHooks map[string]func(context.Context) error
 }
 
-func NewBFEClusterManager(txn itxn.TxnStorager, storager BFEClusterStorager) *BFEClusterManager {
+func NewBFEClusterManager(txn itxn.TxnStorager, storager BFEClusterStorager,
+	changeHooks map[string]func(context.Context) error) *BFEClusterManager {
+
 	return &BFEClusterManager{
-		txn:      txn,
-		storager: storager,
+		txn:         txn,
+		storager:    storager,
+		changeHooks: changeHooks,
+	}
+}
+
+func (pm *BFEClusterManager) afterChange(ctx context.Context) error {
+	for _, hook := range pm.changeHooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
 func (pm *BFEClusterManager) FetchBFEClusters(ctx context.Context, param *BFEClusterFilter) (list []*BFECluster, err error) {
@@ -80,7 +97,34 @@ func (pm *BFEClusterManager) CreateBFECluster(ctx context.Context, param *BFEClu
 			return xerror.WrapRecordExisted("BFE Cluster")
 		}
 
-		return pm.storager.CreateBFECluster(ctx, param)
+		if err = pm.storager.CreateBFECluster(ctx, param); err != nil {
+			return err
+		}
+
+		return pm.afterChange(ctx)
+	})
+}
+
+// UpdateBFECluster only capacity can be changed
+func (pm *BFEClusterManager) UpdateBFECluster(ctx context.Context, name string, param *BFEClusterParam) (err error) {
+	return pm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := pm.storager.FetchBFEClusters(ctx, &BFEClusterFilter{
+			Name: &name,
+		})
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return xerror.WrapRecordNotExist("BFE Cluster")
+		}
+
+		if err = pm.storager.UpdateBFECluster(ctx, list[0], &BFEClusterParam{
+			Capacity: param.Capacity,
+		}); err != nil {
+			return err
+		}
+
+		return pm.afterChange(ctx)
 	})
 }
 
@@ -97,7 +141,11 @@ func (pm *BFEClusterManager) DeleteBFECluster(ctx context.Context, param *BFEClu
 			return xerror.WrapRecordNotExist("BFE Cluster")
 		}
 
-		return pm.storager.DeleteBFECluster(ctx, list[0])
+		if err = pm.storager.DeleteBFECluster(ctx, list[0]); err != nil {
+			return err
+		}
+
+		return pm.afterChange(ctx)
 	})
 }
 
@@ -0,0 +1,267 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package ibasic
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/itxn"
+	"github.com/yf-networks/ai-gateway-api/model/iversion_control"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+// BFENode is a bfe instance of data plane, registered by heartbeats of its conf-agent
+type BFENode struct {
+	NodeID       string
+	BFECluster   string
+	BuildVersion string
+	Addr         string
+
+	// AppliedVersions is topic => version applied by the node
+	AppliedVersions map[string]string
+
+	HeartbeatAt time.Time
+	CreatedAt   time.Time
+}
+
+type BFENodeParam struct {
+	NodeID          *string
+	BFECluster      *string
+	BuildVersion    *string
+	Addr            *string
+	AppliedVersions map[string]string
+	HeartbeatAt     *time.Time
+}
+
+type BFENodeFilter struct {
+	NodeID     *string
+	BFECluster *string
+}
+
+type BFENodeStorager interface {
+	FetchBFENodes(context.Context, *BFENodeFilter) ([]*BFENode, error)
+	// UpsertBFENode creates node or updates the existed one of same NodeID
+	UpsertBFENode(context.Context, *BFENodeParam) error
+	DeleteBFENode(context.Context, *BFENode) error
+}
+
+// BFENodeTopicStatus compares version applied by node with the one it should be served
+type BFENodeTopicStatus struct {
+	Topic          string
+	AppliedVersion string
+	// LatestVersion is empty if topic never be exported
+	LatestVersion string
+	// TargetVersion is the version served to bfe cluster of node, it is LatestVersion if not targeted
+	TargetVersion string
+	Drift         bool
+}
+
+// BFENodeStatus is node with its health judged by heartbeat and applied versions
+type BFENodeStatus struct {
+	*BFENode
+
+	// Stale means no heartbeat received within BFENodeConfig.StaleInS
+	Stale bool
+	// Drift means version of any topic differs from the one it should be served
+	Drift  bool
+	Topics []*BFENodeTopicStatus
+}
+
+type BFENodeManager struct {
+	storager           BFENodeStorager
+	bfeClusterStorager BFEClusterStorager
+	versionStorager    iversion_control.VersionControlStorager
+	targetStorager     iversion_control.ConfigTargetStorager
+	txn                itxn.TxnStorager
+
+	// topics is topic => whether exported per bfe cluster,
+	// version of topic exported per bfe cluster is recorded as topic.bfe_cluster
+	topics map[string]bool
+
+	conf *stateful.BFENodeConfig
+}
+
+func NewBFENodeManager(txn itxn.TxnStorager, storager BFENodeStorager, bfeClusterStorager BFEClusterStorager,
+	versionStorager iversion_control.VersionControlStorager, targetStorager iversion_control.ConfigTargetStorager,
+	topics map[string]bool, conf *stateful.BFENodeConfig) *BFENodeManager {
+
+	return &BFENodeManager{
+		txn:                txn,
+		storager:           storager,
+		bfeClusterStorager: bfeClusterStorager,
+		versionStorager:    versionStorager,
+		targetStorager:     targetStorager,
+		topics:             topics,
+		conf:               conf,
+	}
+}
+
+// HeartbeatBFENode registers node if not existed, or refreshes its heartbeat and applied versions
+func (bm *BFENodeManager) HeartbeatBFENode(ctx context.Context, param *BFENodeParam) error {
+	for topic := range param.AppliedVersions {
+		if _, ok := bm.topics[topic]; !ok {
+			return xerror.WrapParamErrorWithMsg("Topic %s Not Supported", topic)
+		}
+	}
+
+	now := time.Now()
+	param.HeartbeatAt = &now
+
+	return bm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		clusters, err := bm.bfeClusterStorager.FetchBFEClusters(ctx, &BFEClusterFilter{
+			Name: param.BFECluster,
+		})
+		if err != nil {
+			return err
+		}
+		if len(clusters) == 0 {
+			return xerror.WrapRecordNotExist("BFE Cluster")
+		}
+
+		return bm.storager.UpsertBFENode(ctx, param)
+	})
+}
+
+// FetchBFENodeStatuses returns nodes sorted by bfe cluster and node id
+func (bm *BFENodeManager) FetchBFENodeStatuses(ctx context.Context, filter *BFENodeFilter) (list []*BFENodeStatus, err error) {
+	err = bm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		nodes, err := bm.storager.FetchBFENodes(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		list, err = bm.nodeStatuses(ctx, nodes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].BFECluster != list[j].BFECluster {
+			return list[i].BFECluster < list[j].BFECluster
+		}
+		return list[i].NodeID < list[j].NodeID
+	})
+
+	return list, nil
+}
+
+func (bm *BFENodeManager) FetchBFENodeStatus(ctx context.Context, nodeID string) (*BFENodeStatus, error) {
+	list, err := bm.FetchBFENodeStatuses(ctx, &BFENodeFilter{
+		NodeID: &nodeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, xerror.WrapRecordNotExist("BFE Node")
+	}
+
+	return list[0], nil
+}
+
+// DeleteBFENode removes node from registry, it will be registered again if conf-agent keeps reporting
+func (bm *BFENodeManager) DeleteBFENode(ctx context.Context, nodeID string) error {
+	return bm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := bm.storager.FetchBFENodes(ctx, &BFENodeFilter{
+			NodeID: &nodeID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return xerror.WrapRecordNotExist("BFE Node")
+		}
+
+		return bm.storager.DeleteBFENode(ctx, list[0])
+	})
+}
+
+// versionTopic returns topic recorded in version control for node of bfeCluster
+func (bm *BFENodeManager) versionTopic(topic, bfeCluster string) string {
+	if bm.topics[topic] {
+		return topic + "." + bfeCluster
+	}
+
+	return topic
+}
+
+func (bm *BFENodeManager) nodeStatuses(ctx context.Context, nodes []*BFENode) ([]*BFENodeStatus, error) {
+	var topics []string
+	seen := map[string]bool{}
+	for _, node := range nodes {
+		for topic := range node.AppliedVersions {
+			one := bm.versionTopic(topic, node.BFECluster)
+			if !seen[one] {
+				seen[one] = true
+				topics = append(topics, one)
+			}
+		}
+	}
+
+	latest, err := bm.versionStorager.FetchLastExportedVersions(ctx, topics)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := map[string]map[string]string{}
+	for _, node := range nodes {
+		if _, ok := targets[node.BFECluster]; ok {
+			continue
+		}
+		if targets[node.BFECluster], err = iversion_control.FetchTargetVersions(ctx, bm.targetStorager, node.BFECluster); err != nil {
+			return nil, err
+		}
+	}
+
+	staleBefore := time.Now().Add(-time.Duration(bm.conf.StaleInS) * time.Second)
+
+	rst := make([]*BFENodeStatus, 0, len(nodes))
+	for _, node := range nodes {
+		status := &BFENodeStatus{
+			BFENode: node,
+			Stale:   node.HeartbeatAt.Before(staleBefore),
+			Topics:  []*BFENodeTopicStatus{},
+		}
+
+		for topic, version := range node.AppliedVersions {
+			one := &BFENodeTopicStatus{
+				Topic:          topic,
+				AppliedVersion: version,
+				LatestVersion:  latest[bm.versionTopic(topic, node.BFECluster)],
+				TargetVersion:  targets[node.BFECluster][topic],
+			}
+			if one.TargetVersion == "" {
+				one.TargetVersion = one.LatestVersion
+			}
+			// revocations applied to the target version do not make a drift
+			one.Drift = one.TargetVersion != "" && one.TargetVersion != iversion_control.BaseVersion(one.AppliedVersion)
+			status.Drift = status.Drift || one.Drift
+
+			status.Topics = append(status.Topics, one)
+		}
+		sort.Slice(status.Topics, func(i, j int) bool {
+			return status.Topics[i].Topic < status.Topics[j].Topic
+		})
+
+		rst = append(rst, status)
+	}
+
+	return rst, nil
+}
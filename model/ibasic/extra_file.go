@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
 )
 
 type ExtraFile struct {
@@ -46,6 +48,7 @@ type ExtraFileStorager interface {
 	CreateExtraFile(context.Context, *Product, ...*ExtraFileParam) error
 	DeleteExtraFile(context.Context, *ExtraFileFilter) error
 	FetchExtraFiles(context.Context, *ExtraFileFilter) ([]*ExtraFile, error)
+	UpdateExtraFile(context.Context, *ExtraFile, *ExtraFileParam) error
 }
 
 func ExtraFilePath(moduleDir string, product *Product, fileName string) string {
@@ -71,9 +74,19 @@ func (em *ExtraFileManager) FetchExtraFile(ctx context.Context, fileName string)
 		return nil, err
 	}
 
-	if len(list) > 0 {
-		return list[0], nil
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	// content of secret file (eg: certificate private key) is sealed
+	one := list[0]
+	if isecret.IsSealed(string(one.Content)) {
+		content, err := isecret.Open(ctx, string(one.Content))
+		if err != nil {
+			return nil, fmt.Errorf("open extra file %s fail: %v", fileName, err)
+		}
+		one.Content = []byte(content)
 	}
 
-	return nil, nil
+	return one, nil
 }
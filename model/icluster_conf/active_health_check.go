@@ -0,0 +1,401 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/itxn"
+	"github.com/yf-networks/ai-gateway-api/model/iversion_control"
+)
+
+const (
+	// ActiveHealthCheckProbeModelList requests model list api of provider
+	ActiveHealthCheckProbeModelList = "model_list"
+	// ActiveHealthCheckProbeCompletion sends a completion request with max tokens 1
+	ActiveHealthCheckProbeCompletion = "completion"
+
+	ConfigTopicActiveHealthCheck = "active_health_check"
+)
+
+var (
+	DefaultActiveHealthCheckIntervalInS        int32 = 10
+	DefaultActiveHealthCheckTimeoutInS         int32 = 5
+	DefaultActiveHealthCheckHealthyThreshold   int32 = 2
+	DefaultActiveHealthCheckUnhealthyThreshold int32 = 3
+
+	maxActiveHealthCheckIntervalInS int32 = 3600
+	maxActiveHealthCheckTimeoutInS  int32 = 60
+	maxActiveHealthCheckThreshold   int32 = 10
+)
+
+// ActiveHealthCheck is the synthetic probe sent to every instance of llm cluster,
+// instance is marked unhealthy after UnhealthyThreshold consecutive failures and
+// healthy again after HealthyThreshold consecutive successes
+type ActiveHealthCheck struct {
+	ClusterID   int64  `json:"-"`
+	ProductID   int64  `json:"-"`
+	ClusterName string `json:"cluster_name"`
+
+	Enable             bool   `json:"enable"`
+	Probe              string `json:"probe"`           // see ActiveHealthCheckProbeXXX
+	Model              string `json:"model,omitempty"` // model of completion probe, client side name
+	IntervalInS        int32  `json:"interval_in_s"`
+	TimeoutInS         int32  `json:"timeout_in_s"`
+	HealthyThreshold   int32  `json:"healthy_threshold"`
+	UnhealthyThreshold int32  `json:"unhealthy_threshold"`
+}
+
+type ActiveHealthCheckParam struct {
+	Enable             *bool
+	Probe              *string
+	Model              *string
+	IntervalInS        *int32
+	TimeoutInS         *int32
+	HealthyThreshold   *int32
+	UnhealthyThreshold *int32
+}
+
+type ActiveHealthCheckFilter struct {
+	ClusterID  *int64
+	ProductID  *int64
+	ClusterIDs []int64
+}
+
+type ActiveHealthCheckStorager interface {
+	FetchActiveHealthChecks(ctx context.Context, filter *ActiveHealthCheckFilter) ([]*ActiveHealthCheck, error)
+	CreateActiveHealthCheck(ctx context.Context, cluster *Cluster, data *ActiveHealthCheck) error
+	UpdateActiveHealthCheck(ctx context.Context, cluster *Cluster, data *ActiveHealthCheck) error
+	DeleteActiveHealthCheck(ctx context.Context, cluster *Cluster) error
+}
+
+// ActiveHealthCheckManager manages active health check of llm clusters,
+// which is exported as topic ConfigTopicActiveHealthCheck
+type ActiveHealthCheckManager struct {
+	txn              itxn.TxnStorager
+	storager         ActiveHealthCheckStorager
+	clusterStorager  ClusterStorager
+	providerProtocol ProviderProtocolGetter
+
+	versionControlManager *iversion_control.VersionControlManager
+}
+
+func NewActiveHealthCheckManager(txn itxn.TxnStorager, storager ActiveHealthCheckStorager,
+	clusterStorager ClusterStorager, providerProtocol ProviderProtocolGetter,
+	versionControlManager *iversion_control.VersionControlManager) *ActiveHealthCheckManager {
+
+	return &ActiveHealthCheckManager{
+		txn:                   txn,
+		storager:              storager,
+		clusterStorager:       clusterStorager,
+		providerProtocol:      providerProtocol,
+		versionControlManager: versionControlManager,
+	}
+}
+
+// FetchActiveHealthChecks returns health checks of clusters in product
+func (m *ActiveHealthCheckManager) FetchActiveHealthChecks(ctx context.Context, product *ibasic.Product) (list []*ActiveHealthCheck, err error) {
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err = m.storager.FetchActiveHealthChecks(ctx, &ActiveHealthCheckFilter{
+			ProductID: &product.ID,
+		})
+		return err
+	})
+
+	return
+}
+
+// FetchActiveHealthCheck returns nil if cluster has no health check
+func (m *ActiveHealthCheckManager) FetchActiveHealthCheck(ctx context.Context, cluster *Cluster) (*ActiveHealthCheck, error) {
+	var list []*ActiveHealthCheck
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) (err error) {
+		list, err = m.storager.FetchActiveHealthChecks(ctx, &ActiveHealthCheckFilter{
+			ClusterID: &cluster.ID,
+		})
+		return err
+	})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	return list[0], nil
+}
+
+// mergeActiveHealthCheck applies param on old one, default values are used if old is nil
+func mergeActiveHealthCheck(cluster *Cluster, old *ActiveHealthCheck, param *ActiveHealthCheckParam) *ActiveHealthCheck {
+	rst := &ActiveHealthCheck{
+		ClusterID:          cluster.ID,
+		ProductID:          cluster.ProductID,
+		ClusterName:        cluster.Name,
+		Enable:             true,
+		Probe:              ActiveHealthCheckProbeModelList,
+		IntervalInS:        DefaultActiveHealthCheckIntervalInS,
+		TimeoutInS:         DefaultActiveHealthCheckTimeoutInS,
+		HealthyThreshold:   DefaultActiveHealthCheckHealthyThreshold,
+		UnhealthyThreshold: DefaultActiveHealthCheckUnhealthyThreshold,
+	}
+	if old != nil {
+		*rst = *old
+	}
+
+	if param.Enable != nil {
+		rst.Enable = *param.Enable
+	}
+	if param.Probe != nil {
+		rst.Probe = *param.Probe
+	}
+	if param.Model != nil {
+		rst.Model = *param.Model
+	}
+	if param.IntervalInS != nil {
+		rst.IntervalInS = *param.IntervalInS
+	}
+	if param.TimeoutInS != nil {
+		rst.TimeoutInS = *param.TimeoutInS
+	}
+	if param.HealthyThreshold != nil {
+		rst.HealthyThreshold = *param.HealthyThreshold
+	}
+	if param.UnhealthyThreshold != nil {
+		rst.UnhealthyThreshold = *param.UnhealthyThreshold
+	}
+	if rst.Probe != ActiveHealthCheckProbeCompletion {
+		rst.Model = ""
+	}
+
+	return rst
+}
+
+// CheckActiveHealthCheck checks health check can be used by cluster
+func CheckActiveHealthCheck(cluster *Cluster, hc *ActiveHealthCheck) error {
+	if cluster.LLMConfig == nil {
+		return fmt.Errorf("cluster %s has no llm_config, active health check only supported by llm cluster", cluster.Name)
+	}
+
+	switch hc.Probe {
+	case ActiveHealthCheckProbeModelList:
+	case ActiveHealthCheckProbeCompletion:
+		if hc.Model != "" && !lib.StringSliceHasElement(cluster.LLMConfig.Models, hc.Model) {
+			return fmt.Errorf("model %s not in llm_config.models", hc.Model)
+		}
+		if hc.Model == "" && len(cluster.LLMConfig.Models) == 0 {
+			return fmt.Errorf("model must be set, llm_config.models is empty")
+		}
+	default:
+		return fmt.Errorf("probe must be %s or %s", ActiveHealthCheckProbeModelList, ActiveHealthCheckProbeCompletion)
+	}
+
+	if hc.IntervalInS < 1 || hc.IntervalInS > maxActiveHealthCheckIntervalInS {
+		return fmt.Errorf("interval_in_s must between 1 and %d", maxActiveHealthCheckIntervalInS)
+	}
+	if hc.TimeoutInS < 1 || hc.TimeoutInS > maxActiveHealthCheckTimeoutInS {
+		return fmt.Errorf("timeout_in_s must between 1 and %d", maxActiveHealthCheckTimeoutInS)
+	}
+	if hc.TimeoutInS > hc.IntervalInS {
+		return fmt.Errorf("timeout_in_s must not be bigger than interval_in_s")
+	}
+	if hc.HealthyThreshold < 1 || hc.HealthyThreshold > maxActiveHealthCheckThreshold {
+		return fmt.Errorf("healthy_threshold must between 1 and %d", maxActiveHealthCheckThreshold)
+	}
+	if hc.UnhealthyThreshold < 1 || hc.UnhealthyThreshold > maxActiveHealthCheckThreshold {
+		return fmt.Errorf("unhealthy_threshold must between 1 and %d", maxActiveHealthCheckThreshold)
+	}
+
+	return nil
+}
+
+// CreateActiveHealthCheck creates health check of cluster, fields not set in param use default values
+func (m *ActiveHealthCheckManager) CreateActiveHealthCheck(ctx context.Context, cluster *Cluster, param *ActiveHealthCheckParam) error {
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		old, err := m.storager.FetchActiveHealthChecks(ctx, &ActiveHealthCheckFilter{
+			ClusterID: &cluster.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(old) != 0 {
+			return xerror.WrapRecordExisted("Active Health Check")
+		}
+
+		data := mergeActiveHealthCheck(cluster, nil, param)
+		if err := CheckActiveHealthCheck(cluster, data); err != nil {
+			return xerror.WrapParamError(err)
+		}
+
+		return m.storager.CreateActiveHealthCheck(ctx, cluster, data)
+	})
+}
+
+// UpdateActiveHealthCheck updates fields set in param
+func (m *ActiveHealthCheckManager) UpdateActiveHealthCheck(ctx context.Context, cluster *Cluster,
+	old *ActiveHealthCheck, param *ActiveHealthCheckParam) error {
+
+	data := mergeActiveHealthCheck(cluster, old, param)
+	if err := CheckActiveHealthCheck(cluster, data); err != nil {
+		return xerror.WrapParamError(err)
+	}
+
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		return m.storager.UpdateActiveHealthCheck(ctx, cluster, data)
+	})
+}
+
+func (m *ActiveHealthCheckManager) DeleteActiveHealthCheck(ctx context.Context, cluster *Cluster) error {
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		return m.storager.DeleteActiveHealthCheck(ctx, cluster)
+	})
+}
+
+// ExportActiveHealthCheck is the probe of one cluster in data plane format,
+// provider key is added by data plane the same as proxied requests, see AIConf
+type ExportActiveHealthCheck struct {
+	Schema             string
+	Method             string
+	Uri                string
+	Headers            map[string]string `json:",omitempty"`
+	Body               string            `json:",omitempty"`
+	IntervalMs         int
+	TimeoutMs          int
+	HealthyThreshold   int
+	UnhealthyThreshold int
+}
+
+type ActiveHealthCheckConf struct {
+	Version string
+	Config  map[string]*ExportActiveHealthCheck
+}
+
+func (c *ActiveHealthCheckConf) UpdateVersion(version string) error {
+	c.Version = version
+
+	return nil
+}
+
+func (m *ActiveHealthCheckManager) exportActiveHealthCheck(ctx context.Context, cluster *Cluster,
+	hc *ActiveHealthCheck) (*ExportActiveHealthCheck, error) {
+
+	conf := cluster.LLMConfig
+	_, protocol, err := resolveProviderProtocol(ctx, m.providerProtocol, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := &ExportActiveHealthCheck{
+		Schema:             "https",
+		Method:             http.MethodGet,
+		Uri:                providerModelListURI(protocol, conf),
+		IntervalMs:         int(hc.IntervalInS) * 1000,
+		TimeoutMs:          int(hc.TimeoutInS) * 1000,
+		HealthyThreshold:   int(hc.HealthyThreshold),
+		UnhealthyThreshold: int(hc.UnhealthyThreshold),
+	}
+
+	// probes are sent like model list and completion requests of provider
+	headers := map[string]string{}
+	if conf.ModelEndpoint != nil {
+		if conf.ModelEndpoint.Schema != "" {
+			rst.Schema = conf.ModelEndpoint.Schema
+		}
+		for k, v := range conf.ModelEndpoint.Headers {
+			headers[k] = v
+		}
+	}
+	for k, v := range providerVersionHeaders(protocol, conf) {
+		headers[k] = v
+	}
+
+	model := hc.Model
+	// model removed from llm config after health check created
+	if (model == "" || !lib.StringSliceHasElement(conf.Models, model)) && len(conf.Models) > 0 {
+		model = conf.Models[0]
+	}
+	if hc.Probe == ActiveHealthCheckProbeCompletion && model != "" {
+		uri, payload := providerProbeCompletion(protocol, providerBasePath(protocol, conf), backendModel(conf, model))
+		rst.Method = http.MethodPost
+		rst.Uri = uri
+		rst.Body = string(payload)
+		headers["Content-Type"] = "application/json"
+	}
+	if len(headers) > 0 {
+		rst.Headers = headers
+	}
+
+	return rst, nil
+}
+
+func (m *ActiveHealthCheckManager) activeHealthCheckGenerator(ctx context.Context) (*iversion_control.ExportData, error) {
+	list, err := m.storager.FetchActiveHealthChecks(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusterIDs []int64
+	for _, one := range list {
+		if one.Enable {
+			clusterIDs = append(clusterIDs, one.ClusterID)
+		}
+	}
+
+	var clusters []*Cluster
+	if len(clusterIDs) > 0 {
+		if clusters, err = m.clusterStorager.FetchClusterList(ctx, &ClusterFilter{IDs: clusterIDs}); err != nil {
+			return nil, err
+		}
+	}
+	clusterMap := ClusterList2MapByID(clusters)
+
+	conf := &ActiveHealthCheckConf{
+		Config: map[string]*ExportActiveHealthCheck{},
+	}
+	for _, one := range list {
+		cluster := clusterMap[one.ClusterID]
+		// llm config removed after health check created
+		if !one.Enable || cluster == nil || cluster.LLMConfig == nil {
+			continue
+		}
+
+		item, err := m.exportActiveHealthCheck(ctx, cluster, one)
+		if err != nil {
+			return nil, xerror.WrapModelErrorWithMsg("cluster %s: %v", cluster.Name, err)
+		}
+		conf.Config[cluster.Name] = item
+	}
+
+	return &iversion_control.ExportData{
+		Topic:              ConfigTopicActiveHealthCheck,
+		DataWithoutVersion: conf,
+	}, nil
+}
+
+// ExportActiveHealthCheckConf returns nil if config not changed since lastVersion,
+// the version targeted by bfeCluster is exported
+func (m *ActiveHealthCheckManager) ExportActiveHealthCheckConf(ctx context.Context, lastVersion, bfeCluster string) (*ActiveHealthCheckConf, error) {
+	ed, err := m.versionControlManager.ExportTargetConfig(ctx, ConfigTopicActiveHealthCheck, bfeCluster, m.activeHealthCheckGenerator)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := ed.DataWithoutVersion.(*ActiveHealthCheckConf)
+	if conf.Version == lastVersion {
+		return nil, nil
+	}
+
+	return conf, nil
+}
@@ -0,0 +1,96 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+)
+
+func TestExportActiveHealthCheckHeaders(t *testing.T) {
+	endpoint := &Endpoint{Schema: "http", Headers: map[string]string{"X-Tenant": "t1"}}
+
+	cases := []struct {
+		name       string
+		conf       *LLMConfig
+		probe      string
+		wantMethod string
+		want       map[string]string
+	}{
+		{
+			name:       "model list without endpoint headers",
+			conf:       &LLMConfig{Models: []string{"gpt"}},
+			probe:      ActiveHealthCheckProbeModelList,
+			wantMethod: http.MethodGet,
+			want:       nil,
+		},
+		{
+			name:       "model list with endpoint headers",
+			conf:       &LLMConfig{Models: []string{"gpt"}, ModelEndpoint: endpoint},
+			probe:      ActiveHealthCheckProbeModelList,
+			wantMethod: http.MethodGet,
+			want:       map[string]string{"X-Tenant": "t1"},
+		},
+		{
+			name:       "completion keeps endpoint headers",
+			conf:       &LLMConfig{Models: []string{"gpt"}, ModelEndpoint: endpoint},
+			probe:      ActiveHealthCheckProbeCompletion,
+			wantMethod: http.MethodPost,
+			want:       map[string]string{"X-Tenant": "t1", "Content-Type": "application/json"},
+		},
+		{
+			name: "completion of anthropic carries version header",
+			conf: &LLMConfig{Models: []string{"claude"}, ModelEndpoint: endpoint,
+				ProviderType: lib.PString(ProviderTypeAnthropic)},
+			probe:      ActiveHealthCheckProbeCompletion,
+			wantMethod: http.MethodPost,
+			want:       map[string]string{"X-Tenant": "t1", "Content-Type": "application/json", "anthropic-version": "2023-06-01"},
+		},
+		{
+			name: "model list of anthropic with api version",
+			conf: &LLMConfig{Models: []string{"claude"}, ProviderType: lib.PString(ProviderTypeAnthropic),
+				APIVersion: lib.PString("2024-01-01")},
+			probe:      ActiveHealthCheckProbeModelList,
+			wantMethod: http.MethodGet,
+			want:       map[string]string{"anthropic-version": "2024-01-01"},
+		},
+	}
+
+	m := NewActiveHealthCheckManager(nil, nil, nil, nil, nil)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := &Cluster{Name: "cluster", LLMConfig: c.conf}
+			rst, err := m.exportActiveHealthCheck(context.Background(), cluster, &ActiveHealthCheck{Probe: c.probe})
+			if err != nil {
+				t.Fatalf("exportActiveHealthCheck() error = %v", err)
+			}
+			if rst.Method != c.wantMethod {
+				t.Errorf("method = %s, want %s", rst.Method, c.wantMethod)
+			}
+			if !reflect.DeepEqual(rst.Headers, c.want) {
+				t.Errorf("headers = %v, want %v", rst.Headers, c.want)
+			}
+		})
+	}
+
+	// headers of endpoint are not changed by export
+	if len(endpoint.Headers) != 1 {
+		t.Errorf("endpoint headers changed: %v", endpoint.Headers)
+	}
+}
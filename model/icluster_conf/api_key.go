@@ -21,7 +21,10 @@ import (
 	"time"
 
 	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xcrypto"
 	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
 	"github.com/yf-networks/ai-gateway-api/model/itxn"
 	"github.com/yf-networks/ai-gateway-api/stateful"
 )
@@ -43,20 +46,55 @@ type APIKeyParam struct {
 	// Limit is the specific quota limit, required when IsLimit is true, range: 0-100000000
 	Limit *int64 `json:"total_quota,omitempty"`
 
-	// ExpiredTime defines the expiration time with formats:
-	// Empty string: Never expires
-	// "1m": One month later
-	// "1d": One day later
-	// "1h": One hour later
-	// Or timestamp string: "2025-01-01 01:01:01"
-	ExpiredTime    *string  `json:"expired_time,omitempty"`
+	// ExpiredTime defines the expiration time, see NormalizeAPIKeyTime for accepted formats,
+	// empty string means never expires. Stored as server local time: "2025-01-01 01:01:01"
+	ExpiredTime *string `json:"expired_time,omitempty"`
+	// EffectiveTime defines the time key begins to work, same formats as ExpiredTime,
+	// empty string means effective immediately
+	EffectiveTime  *string  `json:"effective_time,omitempty"`
 	AllowedModels  []string `json:"allowed_models,omitempty"`
 	AllowedCIDR    []string `json:"allowed_subnets,omitempty"`
 	ProductName    *string  `json:"-"`
 	ID             *int64   `json:"-"`
 	RemainingQuota *int64   `json:"remaining_quota,omitempty"`
+
+	// UpstreamCredentials are provider credentials owned by the tenant,
+	// nil means not change when update, empty slice means remove all
+	UpstreamCredentials []*UpstreamCredential `json:"upstream_credentials,omitempty"`
+}
+
+// UpstreamCredential is the provider credential which replace LLMConfig.Key of the cluster
+// on requests carrying this api key. Exactly one of Cluster and ProviderType must be set,
+// credential bound to cluster takes precedence over the one bound to provider type.
+type UpstreamCredential struct {
+	Cluster      *string `json:"cluster,omitempty"`
+	ProviderType *string `json:"provider_type,omitempty"`
+
+	// Key is plain text credential, only used as input
+	Key *string `json:"key,omitempty"`
+	// KeyHint is masked credential, only used as output
+	KeyHint string `json:"key_hint,omitempty"`
+	// SealedKey is encrypted credential
+	SealedKey string `json:"-"`
+}
+
+// Target return the unique identity of the credential binding
+func (uc *UpstreamCredential) Target() string {
+	if uc.Cluster != nil && *uc.Cluster != "" {
+		return UpstreamCredentialTargetCluster + *uc.Cluster
+	}
+	if uc.ProviderType != nil {
+		return UpstreamCredentialTargetProvider + *uc.ProviderType
+	}
+
+	return ""
 }
 
+const (
+	UpstreamCredentialTargetCluster  = "cluster:"
+	UpstreamCredentialTargetProvider = "provider:"
+)
+
 // APIKeyTokenParam defines parameters for API key token operations
 type APIKeyTokenParam struct {
 	Key       *string
@@ -76,6 +114,9 @@ type APIKeyFilter struct {
 	Name         *string
 	ALBGroupName *string
 	ID           *int64
+
+	// Statuses filters by status evaluated by EvaluateAPIKeyStatus, empty means not filter
+	Statuses []string
 }
 
 // APIKeyStorager interface defines storage operations for API keys
@@ -88,6 +129,9 @@ type APIKeyStorager interface {
 	CreateAPIKeyToken(ctx context.Context, param *APIKeyTokenParam) (int64, error)
 	UpdateAPIKeyToken(ctx context.Context, filter *APIKeyTokenFilter, param *APIKeyTokenParam) error
 	FetchAPIKeyTokenList(ctx context.Context, filter *APIKeyTokenFilter) ([]*APIKeyTokenParam, error)
+
+	FetchAPIKeyNotificationList(ctx context.Context, filter *APIKeyNotificationFilter) ([]*APIKeyNotification, error)
+	CreateAPIKeyNotification(ctx context.Context, param *APIKeyNotification) error
 }
 
 // APIKeyManager manages API key operations with transaction support
@@ -136,6 +180,11 @@ func (rppm *APIKeyManager) FetchAPIKeyList(ctx context.Context,
 	filter *APIKeyFilter) (list []*APIKeyParam, err error) {
 	err = rppm.txn.AtomExecute(ctx, func(ctx context.Context) error {
 		list, err = rppm.storager.FetchAPIKeyList(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		list, err = applyAPIKeyStatus(list, filter, time.Now())
 		return err
 	})
 
@@ -150,6 +199,11 @@ func (rppm *APIKeyManager) FetchAPIKey(ctx context.Context,
 		if err != nil {
 			return err
 		}
+
+		list, err = applyAPIKeyStatus(list, filter, time.Now())
+		if err != nil {
+			return err
+		}
 		if len(list) > 0 {
 			one = list[0]
 		}
@@ -175,8 +229,8 @@ func (rppm *APIKeyManager) DeleteAPIKey(ctx context.Context, filter *APIKeyFilte
 	})
 }
 
-// UpdateAPIKey updates an existing API key
-func (rppm *APIKeyManager) UpdateAPIKey(ctx context.Context, filter *APIKeyFilter, param *APIKeyParam) error {
+// UpdateAPIKey updates an existing API key of product
+func (rppm *APIKeyManager) UpdateAPIKey(ctx context.Context, product *ibasic.Product, filter *APIKeyFilter, param *APIKeyParam) error {
 	return rppm.txn.AtomExecute(ctx, func(ctx context.Context) error {
 		// Verify the API key exists before update
 		list, err := rppm.storager.FetchAPIKeyList(ctx, filter)
@@ -188,6 +242,16 @@ func (rppm *APIKeyManager) UpdateAPIKey(ctx context.Context, filter *APIKeyFilte
 		}
 
 		one := list[0]
+		if err = normalizeAPIKeyTimes(param, time.Now()); err != nil {
+			return err
+		}
+
+		if param.UpstreamCredentials != nil {
+			if err = rppm.sealUpstreamCredentials(ctx, product, one.UpstreamCredentials, param.UpstreamCredentials); err != nil {
+				return err
+			}
+		}
+
 		// Skip quota update if the limit value remains unchanged
 		if param.Enable != nil && *param.Enable && param.IsLimit != nil && *param.IsLimit &&
 			param.Limit != nil && *param.Limit > 0 && one.Limit != nil && *param.Limit == *one.Limit {
@@ -201,8 +265,8 @@ func (rppm *APIKeyManager) UpdateAPIKey(ctx context.Context, filter *APIKeyFilte
 	})
 }
 
-// CreateAPIKey creates a new API key
-func (rppm *APIKeyManager) CreateAPIKey(ctx context.Context,
+// CreateAPIKey creates a new API key of product
+func (rppm *APIKeyManager) CreateAPIKey(ctx context.Context, product *ibasic.Product,
 	param *APIKeyParam) (err error) {
 	err = rppm.txn.AtomExecute(ctx, func(ctx context.Context) error {
 		// Check for duplicate API key name within the same product
@@ -227,6 +291,14 @@ func (rppm *APIKeyManager) CreateAPIKey(ctx context.Context,
 			return xerror.WrapDirtyDataErrorWithMsg(fmt.Sprintf("API-Key-Token:%s", *param.Key))
 		}
 
+		if err = normalizeAPIKeyTimes(param, time.Now()); err != nil {
+			return err
+		}
+
+		if err = rppm.sealUpstreamCredentials(ctx, product, nil, param.UpstreamCredentials); err != nil {
+			return err
+		}
+
 		// Set updated time based on existing token or current time
 		if len(tokens) > 0 {
 			param.UpdatedTime = lib.PString(tokens[0].CreatedAt.Format(lib.FormatTimeYYMMDD_HHMMSS))
@@ -258,3 +330,97 @@ func (rppm *APIKeyManager) CreateAPIKeyToken(ctx context.Context,
 
 	return
 }
+
+// sealUpstreamCredentials encrypts the plain text credentials, credential without key
+// keeps the sealed one of the same target in olds
+func (rppm *APIKeyManager) sealUpstreamCredentials(ctx context.Context, product *ibasic.Product,
+	olds, news []*UpstreamCredential) error {
+
+	oldMap := map[string]*UpstreamCredential{}
+	for _, one := range olds {
+		oldMap[one.Target()] = one
+	}
+
+	for _, one := range news {
+		if one.Cluster != nil && *one.Cluster != "" {
+			cluster, err := rppm.clusterStorager.FetchCluster(ctx, &ClusterFilter{
+				Name:    one.Cluster,
+				Product: product,
+			})
+			if err != nil {
+				return err
+			}
+			if cluster == nil {
+				return xerror.WrapParamErrorWithMsg("upstream_credentials: cluster %s not exist", *one.Cluster)
+			}
+		}
+
+		if one.Key == nil || *one.Key == "" {
+			old, ok := oldMap[one.Target()]
+			if !ok {
+				return xerror.WrapParamErrorWithMsg("upstream_credentials: must set key for %s", one.Target())
+			}
+			one.SealedKey, one.KeyHint = old.SealedKey, old.KeyHint
+			continue
+		}
+
+		sealed, err := isecret.Seal(ctx, *one.Key)
+		if err != nil {
+			return err
+		}
+		one.SealedKey, one.KeyHint = sealed, xcrypto.Mask(*one.Key)
+		one.Key = nil
+	}
+
+	return nil
+}
+
+// OpenUpstreamCredential decrypts the sealed credential
+func OpenUpstreamCredential(ctx context.Context, one *UpstreamCredential) (string, error) {
+	plain, err := isecret.Open(ctx, one.SealedKey)
+	if err != nil {
+		return "", fmt.Errorf("open upstream credential %s fail: %v", one.Target(), err)
+	}
+
+	return plain, nil
+}
+
+// RekeySecrets seals upstream credentials of all api keys with the current master key
+func (rppm *APIKeyManager) RekeySecrets(ctx context.Context) (count int, err error) {
+	err = rppm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := rppm.storager.FetchAPIKeyList(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, one := range list {
+			changedAny := false
+			for _, credential := range one.UpstreamCredentials {
+				sealed, changed, err := isecret.Rekey(ctx, credential.SealedKey)
+				if err != nil {
+					return fmt.Errorf("rekey upstream credential %s of api key %s fail: %v", credential.Target(), *one.Name, err)
+				}
+				credential.SealedKey = sealed
+				changedAny = changedAny || changed
+			}
+			if !changedAny {
+				continue
+			}
+
+			if _, err = rppm.storager.UpdateAPIKey(ctx, &APIKeyFilter{
+				ID: one.ID,
+			}, &APIKeyParam{
+				// storager always overwrites allowed subnets
+				AllowedCIDR:         one.AllowedCIDR,
+				UpstreamCredentials: one.UpstreamCredentials,
+			}); err != nil {
+				return err
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return
+}
@@ -0,0 +1,304 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/inotify"
+	"github.com/yf-networks/ai-gateway-api/model/itxn"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+const (
+	APIKeyNotificationKindExpire = "expire"
+	APIKeyNotificationKindQuota  = "quota"
+
+	EventTypeAPIKeyExpiring  = "api_key.expiring"
+	EventTypeAPIKeyExpired   = "api_key.expired"
+	EventTypeAPIKeyQuotaUsed = "api_key.quota_used"
+)
+
+// APIKeyNotification records a threshold already notified.
+// Cycle identify the lifecycle the threshold belongs to: expired time for expire kind,
+// quota reset time for quota kind. A new cycle make thresholds notify again.
+type APIKeyNotification struct {
+	APIKeyID  int64
+	Kind      string
+	Threshold int64
+	Cycle     string
+}
+
+// APIKeyNotificationFilter defines filters for querying notification records
+type APIKeyNotificationFilter struct {
+	APIKeyID *int64
+}
+
+// APIKeyLifecycleWatcher scans api keys periodically, and notify owners
+// when expire or quota thresholds are crossed
+type APIKeyLifecycleWatcher struct {
+	txn             itxn.TxnStorager
+	storager        APIKeyStorager
+	productStorager ibasic.ProductStorager
+	notifier        *inotify.Notifier
+	conf            *stateful.NotificationConfig
+
+	now func() time.Time
+}
+
+func NewAPIKeyLifecycleWatcher(txn itxn.TxnStorager, storager APIKeyStorager, productStorager ibasic.ProductStorager,
+	notifier *inotify.Notifier, conf *stateful.NotificationConfig) *APIKeyLifecycleWatcher {
+
+	return &APIKeyLifecycleWatcher{
+		txn:             txn,
+		storager:        storager,
+		productStorager: productStorager,
+		notifier:        notifier,
+		conf:            conf,
+		now:             time.Now,
+	}
+}
+
+// Run scan until ctx done
+func (w *APIKeyLifecycleWatcher) Run(ctx context.Context) {
+	defer lib.Recover("APIKeyLifecycleWatcher")
+
+	ticker := time.NewTicker(time.Duration(w.conf.CheckIntervalInS) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Scan(lib.NewLogContext(ctx)); err != nil {
+			stateful.AccessLogger.Warn("APIKeyLifecycleWatcher scan fail: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// apiKeyThreshold is a crossed threshold waiting to be notified
+type apiKeyThreshold struct {
+	kind      string
+	threshold int64
+	cycle     string
+	event     *inotify.Event
+}
+
+// Scan check all api keys once
+func (w *APIKeyLifecycleWatcher) Scan(ctx context.Context) error {
+	var keys []*APIKeyParam
+	var products []*ibasic.Product
+	err := w.txn.AtomExecute(ctx, func(ctx context.Context) (err error) {
+		if keys, err = w.storager.FetchAPIKeyList(ctx, &APIKeyFilter{}); err != nil {
+			return err
+		}
+		products, err = w.productStorager.FetchProducts(ctx, nil)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	productMap := map[string]*ibasic.Product{}
+	for _, one := range products {
+		productMap[one.Name] = one
+	}
+
+	for _, key := range keys {
+		if key.Enable == nil || !*key.Enable {
+			continue
+		}
+
+		thresholds, err := w.crossedThresholds(key)
+		if err != nil {
+			stateful.AccessLogger.Warn("APIKeyLifecycleWatcher check key %s fail: %v", *key.Name, err)
+			continue
+		}
+		if len(thresholds) == 0 {
+			continue
+		}
+
+		if err := w.notify(ctx, key, productMap[*key.ProductName], thresholds); err != nil {
+			stateful.AccessLogger.Warn("APIKeyLifecycleWatcher notify key %s fail: %v", *key.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// notify sends the tightest crossed threshold of each kind if not notified yet, looser ones are only
+// marked as notified, so a key crossing several thresholds at once produces one message.
+// Thresholds are recorded before sending and messages are sent after the txn committed,
+// a failed send is not retried rather than a message sent twice
+func (w *APIKeyLifecycleWatcher) notify(ctx context.Context, key *APIKeyParam, product *ibasic.Product,
+	thresholds []*apiKeyThreshold) error {
+
+	var events []*inotify.Event
+	err := w.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		events = nil
+
+		records, err := w.storager.FetchAPIKeyNotificationList(ctx, &APIKeyNotificationFilter{
+			APIKeyID: key.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		notified := map[string]bool{}
+		for _, one := range records {
+			notified[fmt.Sprintf("%s/%d/%s", one.Kind, one.Threshold, one.Cycle)] = true
+		}
+
+		// thresholds are sorted tightest first in each kind
+		seen := map[string]bool{}
+		for _, one := range thresholds {
+			tightest := !seen[one.kind]
+			seen[one.kind] = true
+			if notified[fmt.Sprintf("%s/%d/%s", one.kind, one.threshold, one.cycle)] {
+				continue
+			}
+
+			if tightest {
+				events = append(events, one.event)
+			}
+
+			if err := w.storager.CreateAPIKeyNotification(ctx, &APIKeyNotification{
+				APIKeyID:  *key.ID,
+				Kind:      one.kind,
+				Threshold: one.threshold,
+				Cycle:     one.cycle,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, event := range events {
+		if product != nil {
+			event.Receivers = product.MailList
+		}
+		if err := w.notifier.Notify(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// crossedThresholds return crossed thresholds of the key, the tightest first in each kind
+func (w *APIKeyLifecycleWatcher) crossedThresholds(key *APIKeyParam) ([]*apiKeyThreshold, error) {
+	var rst []*apiKeyThreshold
+
+	now := w.now()
+	if key.ExpiredTime != nil && *key.ExpiredTime != "" {
+		expiredAt, err := ParseAPIKeyTime(*key.ExpiredTime)
+		if err != nil {
+			return nil, err
+		}
+
+		left := expiredAt.Sub(now)
+		daysLeft := int(math.Ceil(left.Hours() / 24))
+		days := append([]int{0}, w.conf.ExpireInDays...)
+		sort.Ints(days)
+		for _, day := range days {
+			if left > time.Duration(day)*24*time.Hour {
+				continue
+			}
+
+			event := w.newEvent(key, EventTypeAPIKeyExpiring, now)
+			event.Subject = fmt.Sprintf("API key %s of product %s will expire in %d days", *key.Name, *key.ProductName, daysLeft)
+			if day == 0 {
+				event.Type = EventTypeAPIKeyExpired
+				event.Subject = fmt.Sprintf("API key %s of product %s expired", *key.Name, *key.ProductName)
+			}
+			event.Content = fmt.Sprintf("%s, expired time: %s", event.Subject, *key.ExpiredTime)
+			event.Labels["expired_time"] = *key.ExpiredTime
+			event.Labels["days_left"] = strconv.Itoa(daysLeft)
+
+			rst = append(rst, &apiKeyThreshold{
+				kind:      APIKeyNotificationKindExpire,
+				threshold: int64(day),
+				cycle:     *key.ExpiredTime,
+				event:     event,
+			})
+		}
+	}
+
+	if key.IsLimit != nil && *key.IsLimit && key.Limit != nil && *key.Limit > 0 &&
+		stateful.DefaultClientSet != nil && stateful.DefaultClientSet.RedisClient != nil {
+
+		remaining, err := GetRemainingQuota(key)
+		if err != nil {
+			return nil, err
+		}
+
+		used := *key.Limit
+		if remaining != nil {
+			used = *key.Limit - *remaining
+		}
+		usedPercent := used * 100 / *key.Limit
+
+		percents := append([]int{100}, w.conf.QuotaPercents...)
+		sort.Sort(sort.Reverse(sort.IntSlice(percents)))
+		for _, percent := range percents {
+			if usedPercent < int64(percent) {
+				continue
+			}
+
+			event := w.newEvent(key, EventTypeAPIKeyQuotaUsed, now)
+			event.Subject = fmt.Sprintf("API key %s of product %s used %d%% quota", *key.Name, *key.ProductName, percent)
+			event.Content = fmt.Sprintf("%s, used: %d, total: %d", event.Subject, used, *key.Limit)
+			event.Labels["used_quota"] = strconv.FormatInt(used, 10)
+			event.Labels["total_quota"] = strconv.FormatInt(*key.Limit, 10)
+
+			rst = append(rst, &apiKeyThreshold{
+				kind:      APIKeyNotificationKindQuota,
+				threshold: int64(percent),
+				cycle:     strconv.FormatInt(key.KeyCreateAt.Unix(), 10),
+				event:     event,
+			})
+		}
+	}
+
+	return rst, nil
+}
+
+func (w *APIKeyLifecycleWatcher) newEvent(key *APIKeyParam, eventType string, now time.Time) *inotify.Event {
+	return &inotify.Event{
+		Type:        eventType,
+		ProductName: *key.ProductName,
+		OccurredAt:  now,
+		Labels: map[string]string{
+			"api_key_name": *key.Name,
+		},
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/inotify"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+// fakeAPIKeyNotificationStorager keeps notification records in memory
+type fakeAPIKeyNotificationStorager struct {
+	APIKeyStorager
+
+	records []*APIKeyNotification
+}
+
+func (s *fakeAPIKeyNotificationStorager) FetchAPIKeyNotificationList(ctx context.Context,
+	filter *APIKeyNotificationFilter) ([]*APIKeyNotification, error) {
+	return s.records, nil
+}
+
+func (s *fakeAPIKeyNotificationStorager) CreateAPIKeyNotification(ctx context.Context, record *APIKeyNotification) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+// recordingChannel saves subjects of events and how many records existed when each was sent
+type recordingChannel struct {
+	storager *fakeAPIKeyNotificationStorager
+	err      error
+
+	subjects []string
+	recorded []int
+}
+
+func (c *recordingChannel) Name() string {
+	return "recording"
+}
+
+func (c *recordingChannel) Send(ctx context.Context, event *inotify.Event) error {
+	c.subjects = append(c.subjects, event.Subject)
+	c.recorded = append(c.recorded, len(c.storager.records))
+	return c.err
+}
+
+func TestAPIKeyLifecycleWatcherNotify(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.Local)
+
+	cases := []struct {
+		name        string
+		expiredAt   time.Time
+		sendErr     error
+		wantSubject []string
+		wantRecords int
+	}{
+		{
+			name:        "days left in subject",
+			expiredAt:   now.Add(3 * 24 * time.Hour),
+			wantSubject: []string{"API key k of product p will expire in 3 days"},
+			wantRecords: 1,
+		},
+		{
+			name:        "partial day rounds up",
+			expiredAt:   now.Add(36 * time.Hour),
+			wantSubject: []string{"API key k of product p will expire in 2 days"},
+			wantRecords: 1,
+		},
+		{
+			name:        "expired notifies tightest only",
+			expiredAt:   now.Add(-time.Hour),
+			wantSubject: []string{"API key k of product p expired"},
+			wantRecords: 2,
+		},
+		{
+			name:        "recorded when send fails",
+			expiredAt:   now.Add(3 * 24 * time.Hour),
+			sendErr:     fmt.Errorf("down"),
+			wantSubject: []string{"API key k of product p will expire in 3 days"},
+			wantRecords: 1,
+		},
+		{
+			name:        "not crossed",
+			expiredAt:   now.Add(30 * 24 * time.Hour),
+			wantRecords: 0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			storager := &fakeAPIKeyNotificationStorager{}
+			channel := &recordingChannel{storager: storager, err: c.sendErr}
+			w := NewAPIKeyLifecycleWatcher(fakeTxn{}, storager, nil, inotify.NewNotifier(channel),
+				&stateful.NotificationConfig{ExpireInDays: []int{7}})
+			w.now = func() time.Time { return now }
+
+			key := &APIKeyParam{
+				ID:          lib.PInt64(1),
+				Name:        lib.PString("k"),
+				ProductName: lib.PString("p"),
+				ExpiredTime: lib.PString(c.expiredAt.Format(lib.FormatTimeYYMMDD_HHMMSS)),
+			}
+
+			for i := 0; i < 2; i++ { // thresholds notified once
+				thresholds, err := w.crossedThresholds(key)
+				if err != nil {
+					t.Fatalf("crossedThresholds() error = %v", err)
+				}
+				if len(thresholds) == 0 {
+					continue
+				}
+				err = w.notify(context.Background(), key, nil, thresholds)
+				if (err != nil) != (c.sendErr != nil && i == 0) {
+					t.Fatalf("notify() error = %v", err)
+				}
+			}
+
+			if fmt.Sprint(channel.subjects) != fmt.Sprint(c.wantSubject) {
+				t.Errorf("subjects = %v, want %v", channel.subjects, c.wantSubject)
+			}
+			if len(storager.records) != c.wantRecords {
+				t.Errorf("records = %d, want %d", len(storager.records), c.wantRecords)
+			}
+			for _, recorded := range channel.recorded {
+				if recorded != c.wantRecords {
+					t.Errorf("sent with %d records, want thresholds recorded before send", recorded)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/bfenetworks/bfe/bfe_modules/mod_ai_token_auth"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+)
+
+// API key status, evaluated in this precedence:
+// disabled > pending > expired > exhausted > expiring > enabled
+const (
+	APIKeyStatusDisabled  = "disabled"  // enable is false
+	APIKeyStatusPending   = "pending"   // effective time not reached
+	APIKeyStatusEnabled   = "enabled"   // usable
+	APIKeyStatusExpiring  = "expiring"  // usable, but will expire in APIKeyExpiringWindow
+	APIKeyStatusExpired   = "expired"   // expired time reached
+	APIKeyStatusExhausted = "exhausted" // quota used up
+)
+
+// APIKeyExpiringWindow key will expire in this window is treated as expiring
+var APIKeyExpiringWindow = 7 * 24 * time.Hour
+
+// APIKeyState is the result of EvaluateAPIKeyStatus
+type APIKeyState struct {
+	Status         string
+	EffectiveAt    *time.Time
+	ExpiredAt      *time.Time
+	RemainingQuota *int64
+}
+
+// TokenStatus maps status to the status of data plane token
+func (s *APIKeyState) TokenStatus() int {
+	switch s.Status {
+	case APIKeyStatusEnabled, APIKeyStatusExpiring:
+		return mod_ai_token_auth.TokenStatusEnabled
+	case APIKeyStatusExpired:
+		return mod_ai_token_auth.TokenStatusExpired
+	case APIKeyStatusExhausted:
+		return mod_ai_token_auth.TokenStatusExhausted
+	default:
+		return mod_ai_token_auth.TokenStatusDisabled
+	}
+}
+
+// QuotaGetter return remaining quota of the key, nil means quota used up
+type QuotaGetter func(param *APIKeyParam) (*int64, error)
+
+// EvaluateAPIKeyStatus is the only place deciding the status of api key,
+// the list api, the filters and the export all depend on it
+func EvaluateAPIKeyStatus(key *APIKeyParam, now time.Time, quotaGetter QuotaGetter) (*APIKeyState, error) {
+	state := &APIKeyState{}
+
+	var err error
+	if key.EffectiveTime != nil {
+		if state.EffectiveAt, err = ParseAPIKeyTime(*key.EffectiveTime); err != nil {
+			return nil, err
+		}
+	}
+	if key.ExpiredTime != nil {
+		if state.ExpiredAt, err = ParseAPIKeyTime(*key.ExpiredTime); err != nil {
+			return nil, err
+		}
+	}
+
+	isLimit := key.IsLimit != nil && *key.IsLimit
+	if isLimit && key.Key != nil && quotaGetter != nil {
+		if state.RemainingQuota, err = quotaGetter(key); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case key.Enable == nil || !*key.Enable:
+		state.Status = APIKeyStatusDisabled
+	case state.EffectiveAt != nil && now.Before(*state.EffectiveAt):
+		state.Status = APIKeyStatusPending
+	case state.ExpiredAt != nil && !now.Before(*state.ExpiredAt):
+		state.Status = APIKeyStatusExpired
+	case isLimit && quotaGetter != nil && state.RemainingQuota == nil:
+		state.Status = APIKeyStatusExhausted
+	case state.ExpiredAt != nil && state.ExpiredAt.Sub(now) <= APIKeyExpiringWindow:
+		state.Status = APIKeyStatusExpiring
+	default:
+		state.Status = APIKeyStatusEnabled
+	}
+
+	return state, nil
+}
+
+// ParseAPIKeyTime parses time stored by NormalizeAPIKeyTime, nil means not set
+func ParseAPIKeyTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation(lib.FormatTimeYYMMDD_HHMMSS, s, time.Local)
+	if err != nil {
+		return nil, xerror.WrapDirtyDataErrorWithMsg("parse api key time %s fail: %v", s, err)
+	}
+
+	return &t, nil
+}
+
+var relativeTimeRegexp = regexp.MustCompile(`^\+?(\d+)([hdwmy])$`)
+
+// NormalizeAPIKeyTime converts user input to the stored format (server local time).
+// Accepted inputs:
+// Empty string: not set
+// Relative to now: "12h", "30d", "2w", "1m" (month), "1y"
+// RFC3339 with time zone: "2025-01-01T01:01:01+08:00"
+// Server local time: "2025-01-01 01:01:01"
+func NormalizeAPIKeyTime(input string, now time.Time) (string, error) {
+	if input == "" {
+		return "", nil
+	}
+
+	if m := relativeTimeRegexp.FindStringSubmatch(input); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return "", err
+		}
+
+		var t time.Time
+		switch m[2] {
+		case "h":
+			t = now.Add(time.Duration(n) * time.Hour)
+		case "d":
+			t = now.AddDate(0, 0, n)
+		case "w":
+			t = now.AddDate(0, 0, 7*n)
+		case "m":
+			t = now.AddDate(0, n, 0)
+		case "y":
+			t = now.AddDate(n, 0, 0)
+		}
+		return t.Local().Format(lib.FormatTimeYYMMDD_HHMMSS), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, input); err == nil {
+		return t.Local().Format(lib.FormatTimeYYMMDD_HHMMSS), nil
+	}
+
+	if t, err := time.ParseInLocation(lib.FormatTimeYYMMDD_HHMMSS, input, time.Local); err == nil {
+		return t.Format(lib.FormatTimeYYMMDD_HHMMSS), nil
+	}
+
+	return "", fmt.Errorf("invalid time %s, want relative time like 30d, RFC3339 or %s", input, lib.FormatTimeYYMMDD_HHMMSS)
+}
+
+// normalizeAPIKeyTimes converts ExpiredTime and EffectiveTime of param to the stored format
+func normalizeAPIKeyTimes(param *APIKeyParam, now time.Time) error {
+	for name, p := range map[string]*string{"expired_time": param.ExpiredTime, "effective_time": param.EffectiveTime} {
+		if p == nil {
+			continue
+		}
+
+		t, err := NormalizeAPIKeyTime(*p, now)
+		if err != nil {
+			return xerror.WrapParamErrorWithMsg("%s: %v", name, err)
+		}
+		*p = t
+	}
+
+	return nil
+}
+
+// applyAPIKeyStatus sets Status and RemainingQuota of each key, and filters by filter.Statuses
+func applyAPIKeyStatus(list []*APIKeyParam, filter *APIKeyFilter, now time.Time) ([]*APIKeyParam, error) {
+	statuses := map[string]bool{}
+	if filter != nil {
+		for _, status := range filter.Statuses {
+			statuses[status] = true
+		}
+	}
+
+	rst := make([]*APIKeyParam, 0, len(list))
+	for _, one := range list {
+		state, err := EvaluateAPIKeyStatus(one, now, GetRemainingQuota)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(statuses) > 0 && !statuses[state.Status] {
+			continue
+		}
+
+		one.Status = lib.PString(state.Status)
+		one.RemainingQuota = state.RemainingQuota
+		rst = append(rst, one)
+	}
+
+	return rst, nil
+}
@@ -0,0 +1,239 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bfenetworks/bfe/bfe_modules/mod_ai_token_auth"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+)
+
+func TestEvaluateAPIKeyStatus(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.Local)
+	at := func(d time.Duration) *string {
+		return lib.PString(now.Add(d).Format(lib.FormatTimeYYMMDD_HHMMSS))
+	}
+	day := 24 * time.Hour
+
+	remaining := func(param *APIKeyParam) (*int64, error) {
+		quota := int64(10)
+		return &quota, nil
+	}
+	exhausted := func(param *APIKeyParam) (*int64, error) {
+		return nil, nil
+	}
+
+	cases := []struct {
+		name        string
+		key         *APIKeyParam
+		quotaGetter QuotaGetter
+		want        string
+		wantToken   int
+		wantErr     bool
+	}{
+		// precedence: disabled > pending > expired > exhausted > expiring > enabled
+		{
+			name: "disabled before all",
+			key: &APIKeyParam{Enable: lib.PBool(false), IsLimit: lib.PBool(true), Key: lib.PString("sk-1"),
+				EffectiveTime: at(day), ExpiredTime: at(-day)},
+			quotaGetter: exhausted,
+			want:        APIKeyStatusDisabled,
+			wantToken:   mod_ai_token_auth.TokenStatusDisabled,
+		},
+		{
+			name:      "enable not set",
+			key:       &APIKeyParam{},
+			want:      APIKeyStatusDisabled,
+			wantToken: mod_ai_token_auth.TokenStatusDisabled,
+		},
+		{
+			name: "pending before expired",
+			key: &APIKeyParam{Enable: lib.PBool(true), IsLimit: lib.PBool(true), Key: lib.PString("sk-1"),
+				EffectiveTime: at(day), ExpiredTime: at(-day)},
+			quotaGetter: exhausted,
+			want:        APIKeyStatusPending,
+			wantToken:   mod_ai_token_auth.TokenStatusDisabled,
+		},
+		{
+			name:        "expired before exhausted",
+			key:         &APIKeyParam{Enable: lib.PBool(true), IsLimit: lib.PBool(true), Key: lib.PString("sk-1"), ExpiredTime: at(-day)},
+			quotaGetter: exhausted,
+			want:        APIKeyStatusExpired,
+			wantToken:   mod_ai_token_auth.TokenStatusExpired,
+		},
+		{
+			name:        "expired at expired time",
+			key:         &APIKeyParam{Enable: lib.PBool(true), IsLimit: lib.PBool(true), Key: lib.PString("sk-1"), ExpiredTime: at(0)},
+			quotaGetter: remaining,
+			want:        APIKeyStatusExpired,
+			wantToken:   mod_ai_token_auth.TokenStatusExpired,
+		},
+		{
+			name:        "exhausted before expiring",
+			key:         &APIKeyParam{Enable: lib.PBool(true), IsLimit: lib.PBool(true), Key: lib.PString("sk-1"), ExpiredTime: at(day)},
+			quotaGetter: exhausted,
+			want:        APIKeyStatusExhausted,
+			wantToken:   mod_ai_token_auth.TokenStatusExhausted,
+		},
+		{
+			name:        "expiring before enabled",
+			key:         &APIKeyParam{Enable: lib.PBool(true), IsLimit: lib.PBool(true), Key: lib.PString("sk-1"), ExpiredTime: at(day)},
+			quotaGetter: remaining,
+			want:        APIKeyStatusExpiring,
+			wantToken:   mod_ai_token_auth.TokenStatusEnabled,
+		},
+		{
+			name:        "expiring at window",
+			key:         &APIKeyParam{Enable: lib.PBool(true), ExpiredTime: at(APIKeyExpiringWindow)},
+			quotaGetter: remaining,
+			want:        APIKeyStatusExpiring,
+			wantToken:   mod_ai_token_auth.TokenStatusEnabled,
+		},
+		{
+			name: "enabled",
+			key: &APIKeyParam{Enable: lib.PBool(true), IsLimit: lib.PBool(true), Key: lib.PString("sk-1"),
+				EffectiveTime: at(-day), ExpiredTime: at(APIKeyExpiringWindow + time.Second)},
+			quotaGetter: remaining,
+			want:        APIKeyStatusEnabled,
+			wantToken:   mod_ai_token_auth.TokenStatusEnabled,
+		},
+		{
+			name:        "effective at effective time",
+			key:         &APIKeyParam{Enable: lib.PBool(true), EffectiveTime: at(0)},
+			quotaGetter: remaining,
+			want:        APIKeyStatusEnabled,
+			wantToken:   mod_ai_token_auth.TokenStatusEnabled,
+		},
+		{
+			name:      "limited without quota getter",
+			key:       &APIKeyParam{Enable: lib.PBool(true), IsLimit: lib.PBool(true), Key: lib.PString("sk-1")},
+			want:      APIKeyStatusEnabled,
+			wantToken: mod_ai_token_auth.TokenStatusEnabled,
+		},
+
+		// unlimited keys are never exhausted, but still expire
+		{
+			name:        "unlimited expired",
+			key:         &APIKeyParam{Enable: lib.PBool(true), IsLimit: lib.PBool(false), Key: lib.PString("sk-1"), ExpiredTime: at(-time.Second)},
+			quotaGetter: exhausted,
+			want:        APIKeyStatusExpired,
+			wantToken:   mod_ai_token_auth.TokenStatusExpired,
+		},
+		{
+			name:        "unlimited expiring",
+			key:         &APIKeyParam{Enable: lib.PBool(true), IsLimit: lib.PBool(false), Key: lib.PString("sk-1"), ExpiredTime: at(day)},
+			quotaGetter: exhausted,
+			want:        APIKeyStatusExpiring,
+			wantToken:   mod_ai_token_auth.TokenStatusEnabled,
+		},
+		{
+			name:        "unlimited without expiry",
+			key:         &APIKeyParam{Enable: lib.PBool(true), IsLimit: lib.PBool(false), Key: lib.PString("sk-1"), ExpiredTime: lib.PString("")},
+			quotaGetter: exhausted,
+			want:        APIKeyStatusEnabled,
+			wantToken:   mod_ai_token_auth.TokenStatusEnabled,
+		},
+
+		{
+			name:    "invalid expired time",
+			key:     &APIKeyParam{Enable: lib.PBool(true), ExpiredTime: lib.PString("30d")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid effective time",
+			key:     &APIKeyParam{Enable: lib.PBool(true), EffectiveTime: lib.PString("2026/01/01")},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state, err := EvaluateAPIKeyStatus(c.key, now, c.quotaGetter)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("EvaluateAPIKeyStatus() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if c.wantErr {
+				return
+			}
+
+			if state.Status != c.want {
+				t.Errorf("status = %s, want %s", state.Status, c.want)
+			}
+			if state.TokenStatus() != c.wantToken {
+				t.Errorf("token status = %d, want %d", state.TokenStatus(), c.wantToken)
+			}
+			if (c.key.ExpiredTime != nil && *c.key.ExpiredTime != "") != (state.ExpiredAt != nil) {
+				t.Errorf("expired at = %v, want set as %v", state.ExpiredAt, c.key.ExpiredTime)
+			}
+		})
+	}
+}
+
+func TestNormalizeAPIKeyTime(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.Local)
+	local := func(t time.Time) string {
+		return t.Local().Format(lib.FormatTimeYYMMDD_HHMMSS)
+	}
+
+	cases := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: ""},
+
+		// relative to now
+		{input: "12h", want: local(now.Add(12 * time.Hour))},
+		{input: "+30d", want: local(now.AddDate(0, 0, 30))},
+		{input: "2w", want: local(now.AddDate(0, 0, 14))},
+		{input: "1m", want: local(now.AddDate(0, 1, 0))},
+		{input: "1y", want: local(now.AddDate(1, 0, 0))},
+		{input: "0d", want: local(now)},
+
+		// RFC3339 converted to server local time
+		{input: "2026-03-01T08:00:00Z", want: local(time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC))},
+		{input: "2026-03-01T08:00:00+08:00", want: local(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))},
+
+		// server local time
+		{input: "2026-03-01 08:00:00", want: "2026-03-01 08:00:00"},
+
+		{input: "-1d", wantErr: true},
+		{input: "30s", wantErr: true},
+		{input: "d", wantErr: true},
+		{input: "2026/03/01", wantErr: true},
+		{input: "2026-03-01", wantErr: true},
+		{input: "2026-03-01T08:00:00", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			got, err := NormalizeAPIKeyTime(c.input, now)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("NormalizeAPIKeyTime(%q) error = %v, wantErr %v", c.input, err, c.wantErr)
+			}
+			if got != c.want {
+				t.Errorf("NormalizeAPIKeyTime(%q) = %q, want %q", c.input, got, c.want)
+			}
+
+			// stored time is parsed back by ParseAPIKeyTime
+			if _, err = ParseAPIKeyTime(got); err != nil {
+				t.Errorf("ParseAPIKeyTime(%q) error: %v", got, err)
+			}
+		})
+	}
+}
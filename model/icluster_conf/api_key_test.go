@@ -0,0 +1,100 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+)
+
+// fakeProductClusterStorager finds clusters by name within the product of filter
+type fakeProductClusterStorager struct {
+	ClusterStorager
+
+	clusters []*Cluster
+}
+
+func (s *fakeProductClusterStorager) FetchCluster(ctx context.Context, filter *ClusterFilter) (*Cluster, error) {
+	for _, one := range s.clusters {
+		if filter.Name != nil && *filter.Name != one.Name {
+			continue
+		}
+		if filter.Product != nil && filter.Product.ID != one.ProductID {
+			continue
+		}
+		return one, nil
+	}
+	return nil, nil
+}
+
+func TestSealUpstreamCredentials(t *testing.T) {
+	setTestSecret(t)
+
+	m := NewAPIKeyManager(fakeTxn{}, nil, &fakeProductClusterStorager{
+		clusters: []*Cluster{{Name: "mine", ProductID: 1}, {Name: "other", ProductID: 2}},
+	})
+	product := &ibasic.Product{ID: 1, Name: "p"}
+
+	cases := []struct {
+		name    string
+		olds    []*UpstreamCredential
+		news    []*UpstreamCredential
+		wantErr bool
+	}{
+		{
+			name: "cluster of product",
+			news: []*UpstreamCredential{{Cluster: lib.PString("mine"), Key: lib.PString("sk-a")}},
+		},
+		{
+			name:    "cluster of other product",
+			news:    []*UpstreamCredential{{Cluster: lib.PString("other"), Key: lib.PString("sk-a")}},
+			wantErr: true,
+		},
+		{
+			name: "provider type",
+			news: []*UpstreamCredential{{ProviderType: lib.PString("openai"), Key: lib.PString("sk-a")}},
+		},
+		{
+			name: "omitted key kept",
+			olds: []*UpstreamCredential{{Cluster: lib.PString("mine"), SealedKey: "sealed"}},
+			news: []*UpstreamCredential{{Cluster: lib.PString("mine")}},
+		},
+		{
+			name:    "omitted key without old",
+			news:    []*UpstreamCredential{{Cluster: lib.PString("mine")}},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := m.sealUpstreamCredentials(context.Background(), product, c.olds, c.news)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("sealUpstreamCredentials() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for _, one := range c.news {
+				if one.Key != nil || one.SealedKey == "" {
+					t.Errorf("credential %s not sealed", one.Target())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+const autoSchedulerEpsilon = 1e-9
+
+// ClusterAutoScheduler computes lb matrix of cluster from capacities instead of setting it manually.
+// Capacity of bfe cluster is the traffic planned to be forwarded by it, capacity of sub cluster
+// is the traffic it can serve, both in QPS.
+type ClusterAutoScheduler struct {
+	Enable bool `json:"enable"`
+
+	// MaxRegionLoad is the ceiling of load (traffic / capacity) of every sub cluster, range (0, 1]
+	MaxRegionLoad float64 `json:"max_region_load"`
+
+	// traffic beyond the ceiling of sub clusters spills over to blackhole when BlackholeEnabled,
+	// MaxBlackholeLoad is the ceiling of the rate sent to blackhole by every bfe cluster, range [0, 1]
+	BlackholeEnabled bool    `json:"blackhole_enabled"`
+	MaxBlackholeLoad float64 `json:"max_blackhole_load"`
+}
+
+// Enabled return true if lb matrix of cluster is computed by auto scheduler
+func (as *ClusterAutoScheduler) Enabled() bool {
+	return as != nil && as.Enable
+}
+
+func CheckClusterAutoScheduler(as *ClusterAutoScheduler) error {
+	if as.MaxRegionLoad <= 0 || as.MaxRegionLoad > 1 {
+		return xerror.WrapParamErrorWithMsg("max_region_load Must Be In (0, 1]")
+	}
+	if as.MaxBlackholeLoad < 0 || as.MaxBlackholeLoad > 1 {
+		return xerror.WrapParamErrorWithMsg("max_blackhole_load Must Be In [0, 1]")
+	}
+
+	return nil
+}
+
+// AutoSchedulerPlan is the result of auto scheduler
+type AutoSchedulerPlan struct {
+	LbMatrix map[string]map[string]int
+
+	// Traffic is sum of capacity of bfe clusters
+	Traffic int64
+	// Loads are expected load (traffic / capacity) of sub clusters
+	Loads map[string]float64
+	// Overloaded is true if some sub cluster is loaded beyond MaxRegionLoad,
+	// blackhole is disabled or limited by MaxBlackholeLoad
+	Overloaded bool
+}
+
+// PlanAutoScheduler splits traffic of every bfe cluster to sub clusters in proportion to their capacity.
+// If traffic exceeds MaxRegionLoad of total capacity, the overflow is sent to blackhole.
+func PlanAutoScheduler(as *ClusterAutoScheduler, bfeClusters []*ibasic.BFECluster,
+	subClusters []*SubCluster) (*AutoSchedulerPlan, error) {
+
+	if len(bfeClusters) == 0 {
+		return nil, xerror.WrapModelErrorWithMsg("Auto Scheduler Want At Least One BFE Cluster")
+	}
+
+	var capacity int64
+	for _, one := range subClusters {
+		capacity += one.Capacity
+	}
+	if capacity <= 0 {
+		return nil, xerror.WrapModelErrorWithMsg("Auto Scheduler Want Capacity Of SubClusters, But All Are 0")
+	}
+
+	var traffic int64
+	for _, one := range bfeClusters {
+		traffic += one.Capacity
+	}
+
+	blackhole := 0
+	serviceable := as.MaxRegionLoad * float64(capacity)
+	if as.BlackholeEnabled && float64(traffic) > serviceable {
+		overflow := 1 - serviceable/float64(traffic)
+		blackhole = int(math.Ceil(overflow*100 - autoSchedulerEpsilon))
+		if limit := int(math.Floor(as.MaxBlackholeLoad*100 + autoSchedulerEpsilon)); blackhole > limit {
+			blackhole = limit
+		}
+	}
+
+	rates := splitByCapacity(100-blackhole, subClusters, capacity)
+	plan := &AutoSchedulerPlan{
+		LbMatrix: map[string]map[string]int{},
+		Traffic:  traffic,
+		Loads:    map[string]float64{},
+	}
+	for _, bfeCluster := range bfeClusters {
+		row := map[string]int{
+			BlackHole: blackhole,
+		}
+		for name, rate := range rates {
+			row[name] = rate
+		}
+
+		plan.LbMatrix[bfeCluster.Name] = row
+	}
+
+	for _, one := range subClusters {
+		if one.Capacity == 0 {
+			continue
+		}
+
+		load := float64(traffic) * float64(rates[one.Name]) / 100 / float64(one.Capacity)
+		plan.Loads[one.Name] = load
+		if load > as.MaxRegionLoad+autoSchedulerEpsilon {
+			plan.Overloaded = true
+		}
+	}
+
+	return plan, nil
+}
+
+// splitByCapacity splits total to sub clusters in proportion to capacity by largest remainder method
+func splitByCapacity(total int, subClusters []*SubCluster, capacity int64) map[string]int {
+	sorted := make([]*SubCluster, len(subClusters))
+	copy(sorted, subClusters)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri := int64(total) * sorted[i].Capacity % capacity
+		rj := int64(total) * sorted[j].Capacity % capacity
+		if ri != rj {
+			return ri > rj
+		}
+
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	rates := map[string]int{}
+	left := total
+	for _, one := range sorted {
+		rate := int(int64(total) * one.Capacity / capacity)
+		rates[one.Name] = rate
+		left -= rate
+	}
+	for i := 0; left > 0; i++ {
+		rates[sorted[i].Name]++
+		left--
+	}
+
+	return rates
+}
+
+func (cm *ClusterManager) planAutoScheduler(ctx context.Context, as *ClusterAutoScheduler,
+	subClusters []*SubCluster) (*AutoSchedulerPlan, error) {
+
+	bfeClusters, err := cm.bfeClusterStorager.FetchBFEClusters(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return PlanAutoScheduler(as, bfeClusters, subClusters)
+}
+
+// FetchAutoSchedulerPlan return (nil, nil) if auto scheduler of cluster not enabled
+func (cm *ClusterManager) FetchAutoSchedulerPlan(ctx context.Context, cluster *Cluster) (plan *AutoSchedulerPlan, err error) {
+	if !cluster.AutoScheduler.Enabled() {
+		return nil, nil
+	}
+
+	err = cm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		plan, err = cm.planAutoScheduler(ctx, cluster.AutoScheduler, cluster.SubClusters)
+		return err
+	})
+
+	return
+}
+
+// EnableAutoScheduler enables or changes auto scheduler, lb matrix is recomputed at once
+func (cm *ClusterManager) EnableAutoScheduler(ctx context.Context, product *ibasic.Product, cluster *Cluster,
+	as *ClusterAutoScheduler) error {
+
+	if err := CheckClusterAutoScheduler(as); err != nil {
+		return err
+	}
+	as.Enable = true
+
+	return cm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		if err := cm.checkScheduler(ctx, cluster); err != nil {
+			return err
+		}
+
+		plan, err := cm.planAutoScheduler(ctx, as, cluster.SubClusters)
+		if err != nil {
+			return err
+		}
+
+		return cm.storager.ClusterUpdate(ctx, product, cluster, &ClusterParam{
+			Scheduler:     plan.LbMatrix,
+			AutoScheduler: as,
+		})
+	})
+}
+
+// DisableAutoScheduler switches cluster back to manual mode, the last computed lb matrix is kept
+func (cm *ClusterManager) DisableAutoScheduler(ctx context.Context, product *ibasic.Product, cluster *Cluster) error {
+	if !cluster.AutoScheduler.Enabled() {
+		return xerror.WrapParamErrorWithMsg("Cluster %s Scheduler Is Not In Auto Mode", cluster.Name)
+	}
+
+	as := *cluster.AutoScheduler
+	as.Enable = false
+
+	return cm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		return cm.storager.ClusterUpdate(ctx, product, cluster, &ClusterParam{
+			AutoScheduler: &as,
+		})
+	})
+}
+
+// refreshAutoScheduler recomputes lb matrix of cluster in auto mode, should be called in txn
+func (cm *ClusterManager) refreshAutoScheduler(ctx context.Context, cluster *Cluster) error {
+	if !cluster.AutoScheduler.Enabled() {
+		return nil
+	}
+
+	plan, err := cm.planAutoScheduler(ctx, cluster.AutoScheduler, cluster.SubClusters)
+	if err != nil {
+		return xerror.WrapModelErrorWithMsg("Recompute Lb Matrix Of Cluster %s Fail: %v", cluster.Name, err)
+	}
+
+	return cm.storager.ClusterUpdate(ctx, &ibasic.Product{ID: cluster.ProductID}, cluster, &ClusterParam{
+		Scheduler: plan.LbMatrix,
+	})
+}
+
+// BFEClusterChangeHook recomputes lb matrix of all clusters in auto mode after bfe clusters changed,
+// a cluster failing to recompute keeps its lb matrix and is logged, so it never blocks the bfe cluster change
+func (cm *ClusterManager) BFEClusterChangeHook(ctx context.Context) error {
+	clusters, err := cm.storager.FetchClusterList(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range clusters {
+		if err := cm.refreshAutoScheduler(ctx, cluster); err != nil {
+			stateful.AccessLogger.Warn("BFEClusterChangeHook skip cluster %s: %v", cluster.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// SubClusterChangeHook recomputes lb matrix of the cluster which sub cluster be mounted to
+func (cm *ClusterManager) SubClusterChangeHook(ctx context.Context, subCluster *SubCluster) error {
+	if subCluster.ClusterID <= 0 {
+		return nil
+	}
+
+	cluster, err := cm.storager.FetchCluster(ctx, &ClusterFilter{
+		ID: &subCluster.ClusterID,
+	})
+	if err != nil || cluster == nil {
+		return err
+	}
+
+	return cm.refreshAutoScheduler(ctx, cluster)
+}
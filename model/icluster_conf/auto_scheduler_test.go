@@ -0,0 +1,94 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+)
+
+// fakeClusterListStorager keeps clusters and records names of clusters whose lb matrix updated
+type fakeClusterListStorager struct {
+	ClusterStorager
+
+	clusters []*Cluster
+	updated  []string
+}
+
+func (s *fakeClusterListStorager) FetchClusterList(ctx context.Context, filter *ClusterFilter) ([]*Cluster, error) {
+	return s.clusters, nil
+}
+
+func (s *fakeClusterListStorager) ClusterUpdate(ctx context.Context, product *ibasic.Product, old *Cluster, param *ClusterParam) error {
+	if param.Scheduler != nil {
+		s.updated = append(s.updated, old.Name)
+	}
+	return nil
+}
+
+func TestBFEClusterChangeHook(t *testing.T) {
+	as := &ClusterAutoScheduler{Enable: true, MaxRegionLoad: 1}
+
+	cases := []struct {
+		name     string
+		clusters []*Cluster
+		want     []string
+	}{
+		{
+			name: "all recomputed",
+			clusters: []*Cluster{
+				{Name: "a", AutoScheduler: as, SubClusters: []*SubCluster{{Name: "s", Capacity: 10}}},
+				{Name: "b", AutoScheduler: as, SubClusters: []*SubCluster{{Name: "s", Capacity: 10}}},
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "failed cluster skipped",
+			clusters: []*Cluster{
+				{Name: "a", AutoScheduler: as, SubClusters: []*SubCluster{{Name: "s"}}},
+				{Name: "b", AutoScheduler: as, SubClusters: []*SubCluster{{Name: "s", Capacity: 10}}},
+			},
+			want: []string{"b"},
+		},
+		{
+			name: "manual cluster skipped",
+			clusters: []*Cluster{
+				{Name: "a", SubClusters: []*SubCluster{{Name: "s", Capacity: 10}}},
+			},
+			want: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			storager := &fakeClusterListStorager{clusters: c.clusters}
+			cm := NewClusterManager(fakeTxn{}, storager, nil, &fakeBFEClusterStorager{}, nil, nil, nil)
+
+			if err := cm.BFEClusterChangeHook(context.Background()); err != nil {
+				t.Fatalf("BFEClusterChangeHook() error = %v", err)
+			}
+			if len(storager.updated) != len(c.want) {
+				t.Fatalf("updated = %v, want %v", storager.updated, c.want)
+			}
+			for i := range c.want {
+				if storager.updated[i] != c.want[i] {
+					t.Errorf("updated = %v, want %v", storager.updated, c.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bfenetworks/bfe/bfe_config/bfe_cluster_conf/cluster_conf"
+)
+
+// balance modes of instances in sub cluster
+const (
+	BalanceModeWRR   = cluster_conf.BalanceModeWrr // weighted round robin
+	BalanceModeWLC   = cluster_conf.BalanceModeWlc // weighted least connection, for long-lived streaming requests
+	BalanceModeEWMA  = "EWMA"                      // least latency by exponentially weighted moving average
+	BalanceModeCHash = "CHASH"                     // consistent hash on request header, for kv cache locality
+
+	DefaultEWMADecayInMs int32 = 10000
+	DefaultVirtualNodes  int32 = 160
+
+	maxEWMADecayInMs    = 600000
+	maxVirtualNodes     = 1000
+	minLoadFactor       = 100
+	maxBalanceHeaderLen = 255
+)
+
+// BalanceModes returns all balance modes
+func BalanceModes() []string {
+	return []string{BalanceModeWRR, BalanceModeWLC, BalanceModeEWMA, BalanceModeCHash}
+}
+
+// ClusterBalanceParams are algorithm specific parameters of balance mode, zero means default
+type ClusterBalanceParams struct {
+	// EWMA: decay time of latency
+	EWMADecayInMs int32 `json:"ewma_decay_in_ms,omitempty"`
+
+	// CHASH: header of hash key, virtual nodes per instance weight and bounded load factor in percent,
+	// instance whose load exceeds average * LoadFactor / 100 is skipped, 0 means unbounded
+	HashHeader   string `json:"hash_header,omitempty"`
+	VirtualNodes int32  `json:"virtual_nodes,omitempty"`
+	LoadFactor   int32  `json:"load_factor,omitempty"`
+}
+
+// CheckClusterBalance validates balance params of mode and fills default values
+func CheckClusterBalance(mode string, params *ClusterBalanceParams) (*ClusterBalanceParams, error) {
+	rst := &ClusterBalanceParams{}
+	if params != nil {
+		tmp := *params
+		rst = &tmp
+	}
+
+	switch mode {
+	case BalanceModeWRR, BalanceModeWLC:
+		if *rst != (ClusterBalanceParams{}) {
+			return nil, fmt.Errorf("balance_params not supported by balance_mode %s", mode)
+		}
+		return nil, nil
+
+	case BalanceModeEWMA:
+		if rst.HashHeader != "" || rst.VirtualNodes != 0 || rst.LoadFactor != 0 {
+			return nil, fmt.Errorf("balance_mode %s only supports balance_params.ewma_decay_in_ms", mode)
+		}
+		if rst.EWMADecayInMs == 0 {
+			rst.EWMADecayInMs = DefaultEWMADecayInMs
+		}
+		if rst.EWMADecayInMs < 0 || rst.EWMADecayInMs > maxEWMADecayInMs {
+			return nil, fmt.Errorf("balance_params.ewma_decay_in_ms must between 1 and %d", maxEWMADecayInMs)
+		}
+
+	case BalanceModeCHash:
+		if rst.EWMADecayInMs != 0 {
+			return nil, fmt.Errorf("balance_mode %s not supports balance_params.ewma_decay_in_ms", mode)
+		}
+		if rst.HashHeader == "" || len(rst.HashHeader) > maxBalanceHeaderLen || strings.ContainsAny(rst.HashHeader, " :\t\r\n") {
+			return nil, fmt.Errorf("balance_params.hash_header must be a valid header name when balance_mode is %s", mode)
+		}
+		if rst.VirtualNodes == 0 {
+			rst.VirtualNodes = DefaultVirtualNodes
+		}
+		if rst.VirtualNodes < 0 || rst.VirtualNodes > maxVirtualNodes {
+			return nil, fmt.Errorf("balance_params.virtual_nodes must between 1 and %d", maxVirtualNodes)
+		}
+		if rst.LoadFactor != 0 && rst.LoadFactor < minLoadFactor {
+			return nil, fmt.Errorf("balance_params.load_factor must be 0 or not less than %d", minLoadFactor)
+		}
+
+	default:
+		return nil, fmt.Errorf("balance_mode must be one of %s", strings.Join(BalanceModes(), ", "))
+	}
+
+	return rst, nil
+}
+
+// GslbBasicConf is cluster_conf.GslbBasicConf with parameters of extended balance modes
+type GslbBasicConf struct {
+	cluster_conf.GslbBasicConf
+
+	EWMADecay      *int                      `json:",omitempty"` // in ms, for EWMA
+	ConsistentHash *ExportConsistentHashConf `json:",omitempty"` // for CHASH
+}
+
+type ExportConsistentHashConf struct {
+	HashHeader   string
+	VirtualNodes int
+	LoadFactor   int `json:",omitempty"`
+}
+
+func newGslbBasicConf(basic cluster_conf.GslbBasicConf, mode string, params *ClusterBalanceParams) *GslbBasicConf {
+	if mode == "" {
+		mode = BalanceModeWRR
+	}
+	basic.BalanceMode = &mode
+
+	rst := &GslbBasicConf{
+		GslbBasicConf: basic,
+	}
+	if params == nil {
+		return rst
+	}
+
+	switch mode {
+	case BalanceModeEWMA:
+		decay := int(params.EWMADecayInMs)
+		rst.EWMADecay = &decay
+	case BalanceModeCHash:
+		rst.ConsistentHash = &ExportConsistentHashConf{
+			HashHeader:   params.HashHeader,
+			VirtualNodes: int(params.VirtualNodes),
+			LoadFactor:   int(params.LoadFactor),
+		}
+	}
+
+	return rst
+}
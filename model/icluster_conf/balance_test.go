@@ -0,0 +1,143 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bfenetworks/bfe/bfe_config/bfe_cluster_conf/cluster_conf"
+)
+
+func TestCheckClusterBalance(t *testing.T) {
+	cases := []struct {
+		name    string
+		mode    string
+		params  *ClusterBalanceParams
+		want    *ClusterBalanceParams
+		wantErr bool
+	}{
+		// WRR and WLC
+		{name: "wrr", mode: BalanceModeWRR},
+		{name: "wlc empty params", mode: BalanceModeWLC, params: &ClusterBalanceParams{}},
+		{name: "wrr with ewma param", mode: BalanceModeWRR, params: &ClusterBalanceParams{EWMADecayInMs: 100}, wantErr: true},
+		{name: "wlc with hash param", mode: BalanceModeWLC, params: &ClusterBalanceParams{HashHeader: "X-Session"}, wantErr: true},
+
+		// EWMA
+		{name: "ewma default", mode: BalanceModeEWMA, want: &ClusterBalanceParams{EWMADecayInMs: DefaultEWMADecayInMs}},
+		{name: "ewma decay", mode: BalanceModeEWMA, params: &ClusterBalanceParams{EWMADecayInMs: 500},
+			want: &ClusterBalanceParams{EWMADecayInMs: 500}},
+		{name: "ewma max decay", mode: BalanceModeEWMA, params: &ClusterBalanceParams{EWMADecayInMs: maxEWMADecayInMs},
+			want: &ClusterBalanceParams{EWMADecayInMs: maxEWMADecayInMs}},
+		{name: "ewma negative decay", mode: BalanceModeEWMA, params: &ClusterBalanceParams{EWMADecayInMs: -1}, wantErr: true},
+		{name: "ewma decay too large", mode: BalanceModeEWMA, params: &ClusterBalanceParams{EWMADecayInMs: maxEWMADecayInMs + 1}, wantErr: true},
+		{name: "ewma with hash header", mode: BalanceModeEWMA, params: &ClusterBalanceParams{HashHeader: "X-Session"}, wantErr: true},
+		{name: "ewma with virtual nodes", mode: BalanceModeEWMA, params: &ClusterBalanceParams{VirtualNodes: 10}, wantErr: true},
+		{name: "ewma with load factor", mode: BalanceModeEWMA, params: &ClusterBalanceParams{LoadFactor: 150}, wantErr: true},
+
+		// CHASH
+		{name: "chash default", mode: BalanceModeCHash, params: &ClusterBalanceParams{HashHeader: "X-Session"},
+			want: &ClusterBalanceParams{HashHeader: "X-Session", VirtualNodes: DefaultVirtualNodes}},
+		{name: "chash bounded", mode: BalanceModeCHash,
+			params: &ClusterBalanceParams{HashHeader: "X-Session", VirtualNodes: 50, LoadFactor: minLoadFactor},
+			want:   &ClusterBalanceParams{HashHeader: "X-Session", VirtualNodes: 50, LoadFactor: minLoadFactor}},
+		{name: "chash without header", mode: BalanceModeCHash, wantErr: true},
+		{name: "chash invalid header", mode: BalanceModeCHash, params: &ClusterBalanceParams{HashHeader: "X Session"}, wantErr: true},
+		{name: "chash header too long", mode: BalanceModeCHash,
+			params: &ClusterBalanceParams{HashHeader: strings.Repeat("x", maxBalanceHeaderLen+1)}, wantErr: true},
+		{name: "chash negative virtual nodes", mode: BalanceModeCHash,
+			params: &ClusterBalanceParams{HashHeader: "X-Session", VirtualNodes: -1}, wantErr: true},
+		{name: "chash too many virtual nodes", mode: BalanceModeCHash,
+			params: &ClusterBalanceParams{HashHeader: "X-Session", VirtualNodes: maxVirtualNodes + 1}, wantErr: true},
+		{name: "chash load factor too small", mode: BalanceModeCHash,
+			params: &ClusterBalanceParams{HashHeader: "X-Session", LoadFactor: minLoadFactor - 1}, wantErr: true},
+		{name: "chash with ewma decay", mode: BalanceModeCHash,
+			params: &ClusterBalanceParams{HashHeader: "X-Session", EWMADecayInMs: 100}, wantErr: true},
+
+		{name: "unknown mode", mode: "RANDOM", wantErr: true},
+		{name: "empty mode", mode: "", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var origin ClusterBalanceParams
+			if c.params != nil {
+				origin = *c.params
+			}
+
+			got, err := CheckClusterBalance(c.mode, c.params)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("CheckClusterBalance() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("CheckClusterBalance() = %+v, want %+v", got, c.want)
+			}
+			if c.params != nil && *c.params != origin {
+				t.Errorf("params changed to %+v", c.params)
+			}
+		})
+	}
+}
+
+func TestNewGslbBasicConf(t *testing.T) {
+	cases := []struct {
+		name   string
+		mode   string
+		params *ClusterBalanceParams
+		want   string // json of exported extended fields with BalanceMode
+	}{
+		{name: "default mode", want: `{"BalanceMode":"WRR"}`},
+		{name: "wlc", mode: BalanceModeWLC, want: `{"BalanceMode":"WLC"}`},
+		{name: "ewma", mode: BalanceModeEWMA, params: &ClusterBalanceParams{EWMADecayInMs: 500},
+			want: `{"BalanceMode":"EWMA","EWMADecay":500}`},
+		{name: "chash", mode: BalanceModeCHash,
+			params: &ClusterBalanceParams{HashHeader: "X-Session", VirtualNodes: 160},
+			want:   `{"BalanceMode":"CHASH","ConsistentHash":{"HashHeader":"X-Session","VirtualNodes":160}}`},
+		{name: "chash bounded", mode: BalanceModeCHash,
+			params: &ClusterBalanceParams{HashHeader: "X-Session", VirtualNodes: 160, LoadFactor: 125},
+			want:   `{"BalanceMode":"CHASH","ConsistentHash":{"HashHeader":"X-Session","VirtualNodes":160,"LoadFactor":125}}`},
+		{name: "params of other mode ignored", mode: BalanceModeWRR, params: &ClusterBalanceParams{EWMADecayInMs: 500},
+			want: `{"BalanceMode":"WRR"}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			conf := newGslbBasicConf(cluster_conf.GslbBasicConf{}, c.mode, c.params)
+
+			bs, err := json.Marshal(conf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got map[string]interface{}
+			if err = json.Unmarshal(bs, &got); err != nil {
+				t.Fatal(err)
+			}
+			for key, value := range got {
+				if value == nil {
+					delete(got, key)
+				}
+			}
+			var want map[string]interface{}
+			if err = json.Unmarshal([]byte(c.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("newGslbBasicConf() = %s, want %s", bs, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,752 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/itxn"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+const (
+	CanaryStatusRunning    = "running"
+	CanaryStatusPaused     = "paused"
+	CanaryStatusPromoted   = "promoted"
+	CanaryStatusRolledBack = "rolled_back"
+
+	CanaryActionCreate   = "create"
+	CanaryActionStep     = "step"
+	CanaryActionPause    = "pause"
+	CanaryActionResume   = "resume"
+	CanaryActionPromote  = "promote"
+	CanaryActionRollback = "rollback"
+
+	// CanaryOperatorSystem is the operator of logs written by CanaryManager.Run
+	CanaryOperatorSystem = "system"
+
+	MaxCanarySteps           = 20
+	MaxCanaryStepIntervalInS = 86400
+)
+
+var (
+	DefaultCanarySteps                 = []int{1, 5, 25, 50, 100}
+	DefaultCanaryStepIntervalInS int64 = 600
+	DefaultCanaryMinRequests     int64 = 100
+)
+
+// Canary shifts traffic of cluster to one sub cluster step by step, each step lasts
+// StepIntervalInS and is guarded by metrics reported by data plane.
+// Canary is rolled back to BaseLbMatrix once error rate or latency exceeds thresholds
+type Canary struct {
+	ID          int64
+	ProductID   int64
+	ClusterID   int64
+	ClusterName string
+
+	SubCluster      string
+	Status          string
+	Steps           []int // percent of traffic sent to SubCluster in each BFE cluster
+	StepIntervalInS int64
+
+	MaxErrorRate float64
+	MaxLatencyMs int64 // 0 means latency not checked
+	MinRequests  int64 // metrics are not evaluated before canary receives MinRequests in current step
+
+	// BaseLbMatrix is lb matrix of cluster before canary started, restored when rolled back
+	BaseLbMatrix map[string]map[string]int
+
+	Step          int // index of current step
+	StepStartedAt time.Time
+	PausedAt      time.Time // only makes sense when Status is paused
+	Reason        string    // why canary paused or rolled back by system
+
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Active returns true if canary is not promoted or rolled back
+func (c *Canary) Active() bool {
+	return c.Status == CanaryStatusRunning || c.Status == CanaryStatusPaused
+}
+
+// Percent returns percent of traffic sent to canary sub cluster in current step
+func (c *Canary) Percent() int {
+	return c.Steps[c.Step]
+}
+
+// CanaryMetrics is the sum of metrics reported for canary sub cluster in one step
+type CanaryMetrics struct {
+	Requests     int64
+	Errors       int64
+	LatencyMsSum int64
+}
+
+func (m *CanaryMetrics) ErrorRate() float64 {
+	if m.Requests == 0 {
+		return 0
+	}
+
+	return float64(m.Errors) / float64(m.Requests)
+}
+
+func (m *CanaryMetrics) AvgLatencyMs() int64 {
+	if m.Requests == 0 {
+		return 0
+	}
+
+	return m.LatencyMsSum / m.Requests
+}
+
+func (m *CanaryMetrics) String() string {
+	return fmt.Sprintf("requests %d, error rate %.4f, avg latency %dms", m.Requests, m.ErrorRate(), m.AvgLatencyMs())
+}
+
+// breach returns the threshold exceeded by metrics, or empty string
+func (c *Canary) breach(m *CanaryMetrics) string {
+	if m.Requests == 0 || m.Requests < c.MinRequests {
+		return ""
+	}
+	if m.ErrorRate() > c.MaxErrorRate {
+		return fmt.Sprintf("error rate %.4f exceeds %.4f", m.ErrorRate(), c.MaxErrorRate)
+	}
+	if c.MaxLatencyMs > 0 && m.AvgLatencyMs() > c.MaxLatencyMs {
+		return fmt.Sprintf("avg latency %dms exceeds %dms", m.AvgLatencyMs(), c.MaxLatencyMs)
+	}
+
+	return ""
+}
+
+// CanaryMetricReport is the metrics of canary sub cluster collected by data plane
+// since its last report, LatencyMs is the average latency of these requests
+type CanaryMetricReport struct {
+	ClusterName string
+	SubCluster  string
+	Requests    int64
+	Errors      int64
+	LatencyMs   int64
+}
+
+type CanaryLog struct {
+	ID        int64
+	CanaryID  int64
+	Step      int
+	Percent   int
+	Action    string
+	Message   string
+	Operator  string
+	CreatedAt time.Time
+}
+
+type CanaryParam struct {
+	SubCluster      *string
+	Steps           []int
+	StepIntervalInS *int64
+	MaxErrorRate    *float64
+	MaxLatencyMs    *int64
+	MinRequests     *int64
+}
+
+type CanaryFilter struct {
+	ID         *int64
+	ClusterID  *int64
+	ClusterIDs []int64
+	Statuses   []string
+
+	// ForUpdate locks fetched canaries until txn ends
+	ForUpdate bool
+}
+
+type CanaryStorager interface {
+	FetchCanaries(ctx context.Context, filter *CanaryFilter) ([]*Canary, error)
+	CreateCanary(ctx context.Context, cluster *Cluster, data *Canary) (int64, error)
+	// UpdateCanary saves Status, Step, StepStartedAt, PausedAt and Reason of canary
+	UpdateCanary(ctx context.Context, data *Canary) error
+	// DeleteCanary deletes canary together with its metrics and logs
+	DeleteCanary(ctx context.Context, data *Canary) error
+
+	CreateCanaryMetric(ctx context.Context, canary *Canary, metrics *CanaryMetrics) error
+	// FetchCanaryMetrics returns sum of metrics reported in current step of canary
+	FetchCanaryMetrics(ctx context.Context, canary *Canary) (*CanaryMetrics, error)
+
+	CreateCanaryLog(ctx context.Context, log *CanaryLog) error
+	FetchCanaryLogs(ctx context.Context, canary *Canary) ([]*CanaryLog, error)
+}
+
+// CanaryManager manages canaries of clusters and steps or rolls back running canaries
+type CanaryManager struct {
+	txn                 itxn.TxnStorager
+	storager            CanaryStorager
+	trafficPlanStorager TrafficPlanStorager
+	clusterManager      *ClusterManager
+	conf                *stateful.CanaryConfig
+
+	now func() time.Time
+}
+
+func NewCanaryManager(txn itxn.TxnStorager, storager CanaryStorager, trafficPlanStorager TrafficPlanStorager,
+	clusterManager *ClusterManager, conf *stateful.CanaryConfig) *CanaryManager {
+
+	return &CanaryManager{
+		txn:                 txn,
+		storager:            storager,
+		trafficPlanStorager: trafficPlanStorager,
+		clusterManager:      clusterManager,
+		conf:                conf,
+		now:                 time.Now,
+	}
+}
+
+// FetchCanary returns nil if cluster has no canary
+func (m *CanaryManager) FetchCanary(ctx context.Context, cluster *Cluster) (*Canary, error) {
+	var list []*Canary
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) (err error) {
+		list, err = m.storager.FetchCanaries(ctx, &CanaryFilter{
+			ClusterID: &cluster.ID,
+		})
+		return err
+	})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	return list[0], nil
+}
+
+// FetchCanaryMetrics returns metrics of current step
+func (m *CanaryManager) FetchCanaryMetrics(ctx context.Context, canary *Canary) (metrics *CanaryMetrics, err error) {
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		metrics, err = m.storager.FetchCanaryMetrics(ctx, canary)
+		return err
+	})
+
+	return
+}
+
+func (m *CanaryManager) FetchCanaryLogs(ctx context.Context, canary *Canary) (list []*CanaryLog, err error) {
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err = m.storager.FetchCanaryLogs(ctx, canary)
+		return err
+	})
+
+	return
+}
+
+// splitByWeight splits total in proportion to weights by largest remainder method
+func splitByWeight(total int, weights map[string]int) map[string]int {
+	sum := 0
+	var names []string
+	for name, weight := range weights {
+		sum += weight
+		names = append(names, name)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		ri := total * weights[names[i]] % sum
+		rj := total * weights[names[j]] % sum
+		if ri != rj {
+			return ri > rj
+		}
+
+		return names[i] < names[j]
+	})
+
+	rates := map[string]int{}
+	left := total
+	for _, name := range names {
+		rate := total * weights[name] / sum
+		rates[name] = rate
+		left -= rate
+	}
+	for i := 0; left > 0; i++ {
+		rates[names[i]]++
+		left--
+	}
+
+	return rates
+}
+
+// CanaryLbMatrix sends percent of traffic of each BFE cluster to subCluster,
+// the rest is split to other targets in proportion to base lb matrix
+func CanaryLbMatrix(base map[string]map[string]int, subCluster string, percent int) (map[string]map[string]int, error) {
+	rst := map[string]map[string]int{}
+	for bfeCluster, rates := range base {
+		others := map[string]int{}
+		sum := 0
+		for name, rate := range rates {
+			if name != subCluster {
+				others[name] = rate
+				sum += rate
+			}
+		}
+		if sum == 0 && percent < 100 {
+			return nil, fmt.Errorf("BFE cluster %s sends no traffic to other targets than %s", bfeCluster, subCluster)
+		}
+
+		one := map[string]int{}
+		for name := range others {
+			one[name] = 0
+		}
+		if sum > 0 {
+			one = splitByWeight(100-percent, others)
+		}
+		one[subCluster] = percent
+		rst[bfeCluster] = one
+	}
+
+	return rst, nil
+}
+
+// newCanary checks canary settings, default values are used if not given in param
+func newCanary(cluster *Cluster, param *CanaryParam) (*Canary, error) {
+	canary := &Canary{
+		ProductID:       cluster.ProductID,
+		ClusterID:       cluster.ID,
+		ClusterName:     cluster.Name,
+		Status:          CanaryStatusRunning,
+		Steps:           DefaultCanarySteps,
+		StepIntervalInS: DefaultCanaryStepIntervalInS,
+		MinRequests:     DefaultCanaryMinRequests,
+		BaseLbMatrix:    cluster.Scheduler,
+	}
+	if param.SubCluster != nil {
+		canary.SubCluster = *param.SubCluster
+	}
+	if param.Steps != nil {
+		canary.Steps = param.Steps
+	}
+	if param.StepIntervalInS != nil {
+		canary.StepIntervalInS = *param.StepIntervalInS
+	}
+	if param.MaxErrorRate != nil {
+		canary.MaxErrorRate = *param.MaxErrorRate
+	}
+	if param.MaxLatencyMs != nil {
+		canary.MaxLatencyMs = *param.MaxLatencyMs
+	}
+	if param.MinRequests != nil {
+		canary.MinRequests = *param.MinRequests
+	}
+
+	if !lib.StringSliceHasElement(cluster.SubClusterNames(), canary.SubCluster) {
+		return nil, fmt.Errorf("sub cluster %s not bound to cluster %s", canary.SubCluster, cluster.Name)
+	}
+	if len(canary.Steps) == 0 || len(canary.Steps) > MaxCanarySteps {
+		return nil, fmt.Errorf("steps size must be in [1, %d]", MaxCanarySteps)
+	}
+	for i, percent := range canary.Steps {
+		if percent < 1 || percent > 100 {
+			return nil, fmt.Errorf("steps[%d] must be in [1, 100]", i)
+		}
+		if i > 0 && percent <= canary.Steps[i-1] {
+			return nil, fmt.Errorf("steps must be increasing")
+		}
+	}
+	if canary.StepIntervalInS < 1 || canary.StepIntervalInS > MaxCanaryStepIntervalInS {
+		return nil, fmt.Errorf("step_interval_in_s must be in [1, %d]", MaxCanaryStepIntervalInS)
+	}
+	if canary.MaxErrorRate <= 0 || canary.MaxErrorRate > 1 {
+		return nil, fmt.Errorf("max_error_rate must be in (0, 1]")
+	}
+	if canary.MaxLatencyMs < 0 {
+		return nil, fmt.Errorf("max_latency_ms must not be negative")
+	}
+	if canary.MinRequests < 0 {
+		return nil, fmt.Errorf("min_requests must not be negative")
+	}
+	if len(canary.BaseLbMatrix) == 0 {
+		return nil, fmt.Errorf("cluster %s has no lb matrix", cluster.Name)
+	}
+
+	return canary, nil
+}
+
+// CreateCanary starts canary at once by sending traffic of its first step to the sub cluster.
+// A cluster can have only one canary, and it should not be in auto scheduler mode or running traffic plan
+func (m *CanaryManager) CreateCanary(ctx context.Context, cluster *Cluster, param *CanaryParam, operator string) (*Canary, error) {
+	if cluster.AutoScheduler.Enabled() {
+		return nil, xerror.WrapParamErrorWithMsg("Cluster %s Scheduler Is In Auto Mode, Disable It Before Creating Canary", cluster.Name)
+	}
+
+	canary, err := newCanary(cluster, param)
+	if err != nil {
+		return nil, xerror.WrapParamError(err)
+	}
+	lbMatrix, err := CanaryLbMatrix(canary.BaseLbMatrix, canary.SubCluster, canary.Percent())
+	if err != nil {
+		return nil, xerror.WrapParamError(err)
+	}
+
+	now := m.now()
+	canary.StepStartedAt = now
+	canary.PausedAt = now
+	canary.CreatedBy = operator
+	canary.CreatedAt = now
+	canary.UpdatedAt = now
+
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		old, err := m.storager.FetchCanaries(ctx, &CanaryFilter{
+			ClusterID: &cluster.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(old) != 0 {
+			return xerror.WrapRecordExisted("Canary")
+		}
+
+		plans, err := m.trafficPlanStorager.FetchTrafficPlans(ctx, &TrafficPlanFilter{
+			ClusterID: &cluster.ID,
+			Statuses:  []string{TrafficPlanStatusRunning, TrafficPlanStatusPaused},
+		})
+		if err != nil {
+			return err
+		}
+		if len(plans) != 0 {
+			return xerror.WrapParamErrorWithMsg("Cluster %s Has Active Traffic Plan %s", cluster.Name, plans[0].Name)
+		}
+
+		if err = m.clusterManager.checkManualLB(ctx, cluster, &ClusterParam{Scheduler: lbMatrix}); err != nil {
+			return err
+		}
+
+		if canary.ID, err = m.storager.CreateCanary(ctx, cluster, canary); err != nil {
+			return err
+		}
+
+		if err = m.clusterManager.updateLbMatrix(ctx, cluster.ID, lbMatrix); err != nil {
+			return err
+		}
+
+		return m.storager.CreateCanaryLog(ctx, &CanaryLog{
+			CanaryID: canary.ID,
+			Percent:  canary.Percent(),
+			Action:   CanaryActionCreate,
+			Message:  fmt.Sprintf("%d%% traffic to sub cluster %s", canary.Percent(), canary.SubCluster),
+			Operator: operator,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return canary, nil
+}
+
+// CanarySchedulerChecker returns a scheduler checker of ClusterManager, lb matrix and auto scheduler
+// of cluster can't be changed by operator while its canary is active, as rolling back restores the
+// lb matrix before canary started
+func CanarySchedulerChecker(storager CanaryStorager) func(context.Context, *Cluster) error {
+	return func(ctx context.Context, cluster *Cluster) error {
+		list, err := storager.FetchCanaries(ctx, &CanaryFilter{
+			ClusterID: &cluster.ID,
+			Statuses:  []string{CanaryStatusRunning, CanaryStatusPaused},
+		})
+		if err != nil {
+			return err
+		}
+		if len(list) != 0 {
+			return xerror.WrapParamErrorWithMsg("Cluster %s Has Active Canary, Promote Or Rollback It Before Changing Scheduler", cluster.Name)
+		}
+
+		return nil
+	}
+}
+
+// DeleteCanary deletes canary with its metrics and logs, active canary should be promoted or rolled back first
+func (m *CanaryManager) DeleteCanary(ctx context.Context, canary *Canary) error {
+	if canary.Active() {
+		return xerror.WrapParamErrorWithMsg("Canary Of Cluster %s Is %s, Promote Or Rollback It Before Deleting", canary.ClusterName, canary.Status)
+	}
+
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		return m.storager.DeleteCanary(ctx, canary)
+	})
+}
+
+// record re-fetches and locks canary in txn and saves it with log returned by change, change
+// updates lb matrix of cluster in the same txn if needed. Nothing is saved if change returns nil log
+func (m *CanaryManager) record(ctx context.Context, id int64,
+	change func(ctx context.Context, cur *Canary) (*CanaryLog, error)) (canary *Canary, err error) {
+
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := m.storager.FetchCanaries(ctx, &CanaryFilter{
+			ID:        &id,
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return xerror.WrapRecordNotExist("Canary")
+		}
+
+		canary = list[0]
+		log, err := change(ctx, canary)
+		if err != nil || log == nil {
+			return err
+		}
+		if err = m.storager.UpdateCanary(ctx, canary); err != nil {
+			return err
+		}
+
+		log.CanaryID = canary.ID
+		log.Step = canary.Step
+		log.Percent = canary.Percent()
+		return m.storager.CreateCanaryLog(ctx, log)
+	})
+
+	return
+}
+
+// PauseCanary stops stepping up, thresholds are still checked while paused
+func (m *CanaryManager) PauseCanary(ctx context.Context, canary *Canary, operator string) (*Canary, error) {
+	return m.record(ctx, canary.ID, func(ctx context.Context, cur *Canary) (*CanaryLog, error) {
+		if cur.Status != CanaryStatusRunning {
+			return nil, xerror.WrapParamErrorWithMsg("Canary Of Cluster %s Is %s, Only Running Canary Can Be Paused", cur.ClusterName, cur.Status)
+		}
+
+		cur.Status = CanaryStatusPaused
+		cur.PausedAt = m.now()
+		return &CanaryLog{Action: CanaryActionPause, Operator: operator}, nil
+	})
+}
+
+// ResumeCanary continues paused canary, current step lasts the remaining interval
+func (m *CanaryManager) ResumeCanary(ctx context.Context, canary *Canary, operator string) (*Canary, error) {
+	return m.record(ctx, canary.ID, func(ctx context.Context, cur *Canary) (*CanaryLog, error) {
+		if cur.Status != CanaryStatusPaused {
+			return nil, xerror.WrapParamErrorWithMsg("Canary Of Cluster %s Is %s, Only Paused Canary Can Be Resumed", cur.ClusterName, cur.Status)
+		}
+
+		cur.Status = CanaryStatusRunning
+		cur.StepStartedAt = cur.StepStartedAt.Add(m.now().Sub(cur.PausedAt))
+		cur.Reason = ""
+		return &CanaryLog{Action: CanaryActionResume, Operator: operator}, nil
+	})
+}
+
+// PromoteCanary applies the last step at once and ends canary
+func (m *CanaryManager) PromoteCanary(ctx context.Context, canary *Canary, operator string) (*Canary, error) {
+	if !canary.Active() {
+		return nil, xerror.WrapParamErrorWithMsg("Canary Of Cluster %s Is %s, Can Not Be Promoted", canary.ClusterName, canary.Status)
+	}
+
+	return m.record(ctx, canary.ID, func(ctx context.Context, cur *Canary) (*CanaryLog, error) {
+		if !cur.Active() {
+			return nil, nil
+		}
+
+		last := len(cur.Steps) - 1
+		if cur.Step != last {
+			lbMatrix, err := CanaryLbMatrix(cur.BaseLbMatrix, cur.SubCluster, cur.Steps[last])
+			if err != nil {
+				return nil, xerror.WrapModelErrorWithMsg("%v", err)
+			}
+			if err = m.clusterManager.updateLbMatrix(ctx, cur.ClusterID, lbMatrix); err != nil {
+				return nil, err
+			}
+		}
+
+		cur.Status = CanaryStatusPromoted
+		cur.Step = last
+		return &CanaryLog{Action: CanaryActionPromote, Operator: operator}, nil
+	})
+}
+
+// RollbackCanary restores lb matrix before canary started and ends canary, lb matrix can't be
+// changed by operator while canary is active, so nothing else is lost
+func (m *CanaryManager) RollbackCanary(ctx context.Context, canary *Canary, operator string, reason string) (*Canary, error) {
+	if !canary.Active() {
+		return nil, xerror.WrapParamErrorWithMsg("Canary Of Cluster %s Is %s, Can Not Be Rolled Back", canary.ClusterName, canary.Status)
+	}
+
+	return m.record(ctx, canary.ID, func(ctx context.Context, cur *Canary) (*CanaryLog, error) {
+		if !cur.Active() {
+			return nil, nil
+		}
+		if err := m.clusterManager.updateLbMatrix(ctx, cur.ClusterID, cur.BaseLbMatrix); err != nil {
+			return nil, err
+		}
+
+		cur.Status = CanaryStatusRolledBack
+		cur.Reason = reason
+		return &CanaryLog{Action: CanaryActionRollback, Message: reason, Operator: operator}, nil
+	})
+}
+
+// ReportCanaryMetrics saves metrics of canary sub clusters, reports of other sub clusters are ignored
+func (m *CanaryManager) ReportCanaryMetrics(ctx context.Context, reports []*CanaryMetricReport) error {
+	for _, one := range reports {
+		if one.Requests < 0 || one.Errors < 0 || one.LatencyMs < 0 || one.Errors > one.Requests {
+			return xerror.WrapParamErrorWithMsg("Metrics Of Cluster %s Sub Cluster %s Illegal", one.ClusterName, one.SubCluster)
+		}
+	}
+
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := m.storager.FetchCanaries(ctx, &CanaryFilter{
+			Statuses: []string{CanaryStatusRunning, CanaryStatusPaused},
+		})
+		if err != nil {
+			return err
+		}
+
+		canaries := map[string]*Canary{}
+		for _, one := range list {
+			canaries[one.ClusterName] = one
+		}
+
+		for _, one := range reports {
+			canary := canaries[one.ClusterName]
+			if canary == nil || canary.SubCluster != one.SubCluster || one.Requests == 0 {
+				continue
+			}
+
+			err = m.storager.CreateCanaryMetric(ctx, canary, &CanaryMetrics{
+				Requests:     one.Requests,
+				Errors:       one.Errors,
+				LatencyMsSum: one.LatencyMs * one.Requests,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Run checks canaries until ctx done
+func (m *CanaryManager) Run(ctx context.Context) {
+	defer lib.Recover("CanaryManager")
+
+	ticker := time.NewTicker(time.Duration(m.conf.IntervalInS) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := m.RunOnce(lib.NewLogContext(ctx)); err != nil {
+			stateful.AccessLogger.Warn("CanaryManager run fail: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce rolls back active canaries exceeding thresholds, and steps up running canaries
+// whose current step lasted StepIntervalInS with enough requests
+func (m *CanaryManager) RunOnce(ctx context.Context) error {
+	var list []*Canary
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) (err error) {
+		list, err = m.storager.FetchCanaries(ctx, &CanaryFilter{
+			Statuses: []string{CanaryStatusRunning, CanaryStatusPaused},
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, canary := range list {
+		if err := m.check(ctx, canary); err != nil {
+			stateful.AccessLogger.Warn("CanaryManager check canary of cluster %s fail: %v", canary.ClusterName, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *CanaryManager) check(ctx context.Context, canary *Canary) error {
+	metrics, err := m.FetchCanaryMetrics(ctx, canary)
+	if err != nil {
+		return err
+	}
+
+	if reason := canary.breach(metrics); reason != "" {
+		_, err = m.RollbackCanary(ctx, canary, CanaryOperatorSystem, fmt.Sprintf("step %d%%: %s", canary.Percent(), reason))
+		return err
+	}
+
+	now := m.now()
+	interval := time.Duration(canary.StepIntervalInS) * time.Second
+	if canary.Status != CanaryStatusRunning || now.Before(canary.StepStartedAt.Add(interval)) || metrics.Requests < canary.MinRequests {
+		return nil
+	}
+
+	idx := canary.Step
+	if idx == len(canary.Steps)-1 {
+		_, err = m.record(ctx, canary.ID, func(ctx context.Context, cur *Canary) (*CanaryLog, error) {
+			if cur.Status != CanaryStatusRunning || cur.Step != idx {
+				return nil, nil
+			}
+
+			cur.Status = CanaryStatusPromoted
+			return &CanaryLog{Action: CanaryActionPromote, Message: metrics.String(), Operator: CanaryOperatorSystem}, nil
+		})
+		return err
+	}
+
+	// canary is stepped up only if it is still running at the same step when locked,
+	// lb matrix is updated in the same txn
+	var stepErr error
+	_, err = m.record(ctx, canary.ID, func(ctx context.Context, cur *Canary) (*CanaryLog, error) {
+		if cur.Status != CanaryStatusRunning || cur.Step != idx {
+			return nil, nil
+		}
+
+		lbMatrix, err := CanaryLbMatrix(cur.BaseLbMatrix, cur.SubCluster, cur.Steps[idx+1])
+		if err == nil {
+			err = m.clusterManager.updateLbMatrix(ctx, cur.ClusterID, lbMatrix)
+		}
+		if err != nil {
+			stepErr = err
+			return nil, err
+		}
+
+		cur.Step = idx + 1
+		cur.StepStartedAt = m.now()
+		return &CanaryLog{
+			Action:   CanaryActionStep,
+			Message:  fmt.Sprintf("step %d%% passed with %s", cur.Steps[idx], metrics),
+			Operator: CanaryOperatorSystem,
+		}, nil
+	})
+	if stepErr == nil {
+		return err
+	}
+
+	// wait for operator, for example bfe clusters changed since canary started
+	reason := fmt.Sprintf("step to %d%% fail: %s", canary.Steps[idx+1], xerror.Cause(stepErr).Error())
+	_, err = m.record(ctx, canary.ID, func(ctx context.Context, cur *Canary) (*CanaryLog, error) {
+		if cur.Status != CanaryStatusRunning || cur.Step != idx {
+			return nil, nil
+		}
+
+		cur.Status = CanaryStatusPaused
+		cur.PausedAt = now
+		cur.Reason = reason
+		return &CanaryLog{Action: CanaryActionPause, Message: reason, Operator: CanaryOperatorSystem}, nil
+	})
+
+	return err
+}
@@ -0,0 +1,211 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+// fakeCanaryStorager keeps one canary, FetchCanaries returns copies like a database does
+type fakeCanaryStorager struct {
+	CanaryStorager
+
+	canary  *Canary
+	metrics *CanaryMetrics
+	logs    []*CanaryLog
+}
+
+func (s *fakeCanaryStorager) FetchCanaries(ctx context.Context, filter *CanaryFilter) ([]*Canary, error) {
+	if s.canary == nil {
+		return nil, nil
+	}
+	if filter.ClusterID != nil && *filter.ClusterID != s.canary.ClusterID {
+		return nil, nil
+	}
+	// statuses filtered are always the active ones
+	if len(filter.Statuses) > 0 && !s.canary.Active() {
+		return nil, nil
+	}
+
+	one := *s.canary
+	return []*Canary{&one}, nil
+}
+
+func (s *fakeCanaryStorager) UpdateCanary(ctx context.Context, data *Canary) error {
+	one := *data
+	s.canary = &one
+	return nil
+}
+
+func (s *fakeCanaryStorager) FetchCanaryMetrics(ctx context.Context, canary *Canary) (*CanaryMetrics, error) {
+	return s.metrics, nil
+}
+
+func (s *fakeCanaryStorager) CreateCanaryLog(ctx context.Context, log *CanaryLog) error {
+	s.logs = append(s.logs, log)
+	return nil
+}
+
+// fakeClusterStorager keeps one cluster and counts lb matrix updates
+type fakeClusterStorager struct {
+	ClusterStorager
+
+	cluster *Cluster
+	updates int
+}
+
+func (s *fakeClusterStorager) FetchCluster(ctx context.Context, filter *ClusterFilter) (*Cluster, error) {
+	one := *s.cluster
+	return &one, nil
+}
+
+func (s *fakeClusterStorager) ClusterUpdate(ctx context.Context, product *ibasic.Product, old *Cluster, param *ClusterParam) error {
+	if param.Scheduler != nil {
+		s.cluster.Scheduler = param.Scheduler
+		s.updates++
+	}
+	if param.LLMConfig != nil {
+		s.cluster.LLMConfig = param.LLMConfig
+	}
+	return nil
+}
+
+type fakeBFEClusterStorager struct {
+	ibasic.BFEClusterStorager
+}
+
+func (s *fakeBFEClusterStorager) FetchBFEClusters(ctx context.Context, filter *ibasic.BFEClusterFilter) ([]*ibasic.BFECluster, error) {
+	return []*ibasic.BFECluster{{Name: "bfe"}}, nil
+}
+
+// newTestClusterStorager returns cluster with sub clusters old and new, 10% traffic to new
+func newTestClusterStorager() *fakeClusterStorager {
+	return &fakeClusterStorager{
+		cluster: &Cluster{
+			ID:          1,
+			Name:        "cluster",
+			SubClusters: []*SubCluster{{Name: "old"}, {Name: "new"}},
+			Scheduler:   map[string]map[string]int{"bfe": {"old": 90, "new": 10}},
+		},
+	}
+}
+
+var testBaseLbMatrix = map[string]map[string]int{"bfe": {"old": 100, "new": 0}}
+
+func newTestCanaryManager(status string) (*CanaryManager, *fakeCanaryStorager, *fakeClusterStorager) {
+	now := time.Now()
+	canaryStorager := &fakeCanaryStorager{
+		canary: &Canary{
+			ID:              1,
+			ClusterID:       1,
+			ClusterName:     "cluster",
+			SubCluster:      "new",
+			Status:          status,
+			Steps:           []int{10, 50, 100},
+			StepIntervalInS: 60,
+			MaxErrorRate:    0.1,
+			MinRequests:     10,
+			BaseLbMatrix:    testBaseLbMatrix,
+			StepStartedAt:   now.Add(-time.Hour),
+		},
+		metrics: &CanaryMetrics{Requests: 100},
+	}
+	clusterStorager := newTestClusterStorager()
+
+	clusterManager := NewClusterManager(fakeTxn{}, clusterStorager, nil, &fakeBFEClusterStorager{}, nil, nil,
+		map[string]func(context.Context, *Cluster) error{
+			"canary": CanarySchedulerChecker(canaryStorager),
+		})
+	m := NewCanaryManager(fakeTxn{}, canaryStorager, nil, clusterManager, &stateful.CanaryConfig{})
+	m.now = func() time.Time { return now }
+
+	return m, canaryStorager, clusterStorager
+}
+
+func TestCanaryCheck(t *testing.T) {
+	cases := []struct {
+		name string
+		// status of canary stored, the one passed to check is running
+		stored    string
+		errors    int64
+		want      string
+		wantStep  int
+		wantRate  int
+		wantWrite bool
+	}{
+		{name: "step up", stored: CanaryStatusRunning, want: CanaryStatusRunning, wantStep: 1, wantRate: 50, wantWrite: true},
+		{name: "paused meanwhile", stored: CanaryStatusPaused, want: CanaryStatusPaused, wantRate: 10},
+		{name: "rolled back meanwhile", stored: CanaryStatusRolledBack, want: CanaryStatusRolledBack, wantRate: 10},
+		{name: "breach", stored: CanaryStatusRunning, errors: 50, want: CanaryStatusRolledBack, wantRate: 0, wantWrite: true},
+		{name: "breach after promoted", stored: CanaryStatusPromoted, errors: 50, want: CanaryStatusPromoted, wantRate: 10},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m, canaryStorager, clusterStorager := newTestCanaryManager(CanaryStatusRunning)
+			canaryStorager.metrics.Errors = c.errors
+
+			// canary fetched before others changed it
+			stale := *canaryStorager.canary
+			canaryStorager.canary.Status = c.stored
+
+			if err := m.check(context.Background(), &stale); err != nil {
+				t.Fatalf("check() error: %v", err)
+			}
+
+			got := canaryStorager.canary
+			if got.Status != c.want || got.Step != c.wantStep {
+				t.Errorf("canary status %s step %d, want %s step %d", got.Status, got.Step, c.want, c.wantStep)
+			}
+			if rate := clusterStorager.cluster.Scheduler["bfe"]["new"]; rate != c.wantRate {
+				t.Errorf("rate of canary sub cluster %d, want %d", rate, c.wantRate)
+			}
+			if (clusterStorager.updates > 0) != c.wantWrite {
+				t.Errorf("lb matrix updated %d times, want updated %v", clusterStorager.updates, c.wantWrite)
+			}
+		})
+	}
+}
+
+func TestCanarySchedulerChecker(t *testing.T) {
+	cases := []struct {
+		status  string
+		wantErr bool
+	}{
+		{status: CanaryStatusRunning, wantErr: true},
+		{status: CanaryStatusPaused, wantErr: true},
+		{status: CanaryStatusPromoted},
+		{status: CanaryStatusRolledBack},
+	}
+
+	for _, c := range cases {
+		t.Run(c.status, func(t *testing.T) {
+			m, _, clusterStorager := newTestCanaryManager(c.status)
+
+			cluster := *clusterStorager.cluster
+			err := m.clusterManager.UpdateCluster(context.Background(), &ibasic.Product{}, &cluster, &ClusterParam{
+				Scheduler: map[string]map[string]int{"bfe": {"old": 50, "new": 50}},
+			})
+			if (err != nil) != c.wantErr {
+				t.Errorf("UpdateCluster() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
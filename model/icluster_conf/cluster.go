@@ -38,6 +38,7 @@ import (
 	"github.com/yf-networks/ai-gateway-api/lib"
 	"github.com/yf-networks/ai-gateway-api/lib/xerror"
 	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/iprotocol"
 	"github.com/yf-networks/ai-gateway-api/model/itxn"
 	"github.com/yf-networks/ai-gateway-api/model/iversion_control"
 	"github.com/yf-networks/ai-gateway-api/stateful"
@@ -92,6 +93,10 @@ type ClusterBasicParam struct {
 	Buffers    *ClusterBasicBuffersParam
 	Timeouts   *ClusterBasicTimeoutsParam
 	Protocol   *string
+
+	// BalanceParams is saved together with BalanceMode, see CheckClusterBalance
+	BalanceMode   *string
+	BalanceParams *ClusterBalanceParams
 }
 
 type ClusterStickySessionsParam struct {
@@ -117,6 +122,9 @@ type ClusterFilter struct {
 	Name  *string
 
 	Product *ibasic.Product
+
+	// ForUpdate locks fetched clusters until txn ends
+	ForUpdate bool
 }
 
 type ClusterParam struct {
@@ -139,6 +147,18 @@ type ClusterParam struct {
 	PassiveHealthCheck *ClusterPassiveHealthCheckParam
 
 	LLMConfig *LLMConfig
+
+	// UpstreamTLS and RetryPolicy are kept if nil when update
+	UpstreamTLS *ClusterUpstreamTLS
+	RetryPolicy *ClusterRetryPolicy
+
+	// ClearUpstreamTLS removes tls setting when update, https cluster skips verify then
+	ClearUpstreamTLS bool
+	// ClearRetryPolicy removes retry policy when update, only connect failure is retried then
+	ClearRetryPolicy bool
+
+	// AutoScheduler is kept if nil when update
+	AutoScheduler *ClusterAutoScheduler
 }
 
 type ClusterBasicConnection struct {
@@ -171,6 +191,9 @@ type ClusterBasic struct {
 	Buffers    *ClusterBasicBuffers
 	Timeouts   *ClusterBasicTimeouts
 	Protocol   *string
+
+	BalanceMode   string
+	BalanceParams *ClusterBalanceParams
 }
 
 type ClusterStickySessions struct {
@@ -180,19 +203,28 @@ type ClusterStickySessions struct {
 }
 
 type LLMConfig struct {
-	Enable        *bool      `json:"enable"`         // service switch
-	ServiceName   *string    `json:"service_name"`   //
-	Group         *string    `json:"group"`          // group name
-	ModelEndpoint *Endpoint  `json:"model_endpoint"` // model list endpoints
-	Models        []string   `json:"models"`         // model name list
-	ModelMappings []*Mapping `json:"model_mappings"` // model mapping
-	Key           *string    `json:"key"`            // service auth key
-	ProviderType  *string    `json:"provider_type"`
+	Enable        *bool      `json:"enable"`                // service switch
+	ServiceName   *string    `json:"service_name"`          //
+	Group         *string    `json:"group"`                 // group name
+	ModelEndpoint *Endpoint  `json:"model_endpoint"`        // model list endpoints
+	Models        []string   `json:"models"`                // model name list
+	ModelMappings []*Mapping `json:"model_mappings"`        // model mapping
+	Key           *string    `json:"key"`                   // service auth key
+	ProviderType  *string    `json:"provider_type"`         // see ProviderTypes, empty means openai
+	APIVersion    *string    `json:"api_version,omitempty"` // overrides default api version of provider
+	BasePath      *string    `json:"base_path,omitempty"`   // overrides default base path of provider
+
+	// Keys are provider keys used in turn by data plane, see ProviderKey
+	Keys []*ProviderKey `json:"keys,omitempty"`
 }
 
 type Mapping struct {
 	Key   *string `json:"key"`
 	Value *string `json:"value"`
+
+	// Type is one of MappingTypes, empty means exact. Value of non exact mapping
+	// may reference capture groups, see ModelMapper
+	Type *string `json:"type,omitempty"`
 }
 
 type Endpoint struct {
@@ -243,6 +275,9 @@ type Cluster struct {
 	Scheduler          map[string]map[string]int
 	PassiveHealthCheck *ClusterPassiveHealthCheck
 	LLMConfig          *LLMConfig
+	UpstreamTLS        *ClusterUpstreamTLS   // nil for https cluster means skip verify
+	RetryPolicy        *ClusterRetryPolicy   // nil means only connect failure is retried
+	AutoScheduler      *ClusterAutoScheduler // nil or not enabled means lb matrix is set manually
 }
 
 func (cluster *Cluster) SubClusterNames() []string {
@@ -279,7 +314,8 @@ func ClusterList2MapByID(list []*Cluster) map[int64]*Cluster {
 func NewClusterManager(txn itxn.TxnStorager, storager ClusterStorager,
 	subClusterStorager SubClusterStorager, bfeClusterStorager ibasic.BFEClusterStorager,
 	versionControlManager *iversion_control.VersionControlManager,
-	deleteCheckers map[string]func(context.Context, *ibasic.Product, *Cluster) error) *ClusterManager {
+	deleteCheckers map[string]func(context.Context, *ibasic.Product, *Cluster) error,
+	schedulerCheckers map[string]func(context.Context, *Cluster) error) *ClusterManager {
 
 	return &ClusterManager{
 		txn:                   txn,
@@ -288,7 +324,8 @@ func NewClusterManager(txn itxn.TxnStorager, storager ClusterStorager,
 		bfeClusterStorager:    bfeClusterStorager,
 		versionControlManager: versionControlManager,
 
-		deleteCheckers: deleteCheckers,
+		deleteCheckers:    deleteCheckers,
+		schedulerCheckers: schedulerCheckers,
 	}
 }
 
@@ -311,6 +348,10 @@ type ClusterManager struct {
 	versionControlManager *iversion_control.VersionControlManager
 
 	deleteCheckers map[string]func(context.Context, *ibasic.Product, *Cluster) error
+
+	// schedulerCheckers refuse updating lb matrix or auto scheduler of cluster by operator,
+	// for example while canary of cluster owns its lb matrix
+	schedulerCheckers map[string]func(context.Context, *Cluster) error
 }
 
 func (rm *ClusterManager) FetchClusterList(ctx context.Context, param *ClusterFilter) (list []*Cluster, err error) {
@@ -370,6 +411,10 @@ func (cm *ClusterManager) CreateCluster(ctx context.Context, product *ibasic.Pro
 			}
 		}
 
+		if err = sealLLMConfigKey(ctx, nil, param.LLMConfig); err != nil {
+			return err
+		}
+
 		clusterID, err := cm.storager.ClusterCreate(ctx, product, param, bindingSubClusters)
 		if err != nil {
 			return err
@@ -492,17 +537,90 @@ func (cm *ClusterManager) checkBindingSubClusters(ctx context.Context, cluster *
 func (cm *ClusterManager) UpdateCluster(ctx context.Context, product *ibasic.Product, oldData *Cluster,
 	param *ClusterParam) (err error) {
 
+	if param.Scheduler != nil && param.AutoScheduler == nil && oldData.AutoScheduler.Enabled() {
+		return xerror.WrapParamErrorWithMsg("Cluster %s Scheduler Is In Auto Mode, Disable It Before Setting LbMatrix", oldData.Name)
+	}
+
+	if err = checkUpdateRetryPolicy(oldData, param); err != nil {
+		return err
+	}
+
 	err = cm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		if param.Scheduler != nil || param.AutoScheduler != nil {
+			if err = cm.checkScheduler(ctx, oldData); err != nil {
+				return err
+			}
+		}
+
 		if err = cm.checkManualLB(ctx, oldData, param); err != nil {
 			return err
 		}
 
+		if param.LLMConfig != nil {
+			// provider keys may be changed by provider key API after oldData fetched
+			cur, err := lockCluster(ctx, cm.storager, oldData.ID)
+			if err != nil {
+				return err
+			}
+			if err = mergeLLMConfig(ctx, cur.LLMConfig, param.LLMConfig); err != nil {
+				return err
+			}
+		}
+
 		return cm.storager.ClusterUpdate(ctx, product, oldData, param)
 	})
 
 	return
 }
 
+// checkScheduler returns error if lb matrix or auto scheduler of cluster can't be changed by operator now
+func (cm *ClusterManager) checkScheduler(ctx context.Context, cluster *Cluster) error {
+	for _, checker := range cm.schedulerCheckers {
+		if err := checker(ctx, cluster); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lockCluster re-fetches cluster with row lock, it must be called in txn
+func lockCluster(ctx context.Context, storager ClusterStorager, clusterID int64) (*Cluster, error) {
+	cluster, err := storager.FetchCluster(ctx, &ClusterFilter{
+		ID:        &clusterID,
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cluster == nil {
+		return nil, xerror.WrapRecordNotExist("Cluster")
+	}
+
+	return cluster, nil
+}
+
+// updateLbMatrix saves lb matrix of cluster in txn of caller, which owns lb matrix of cluster
+// (canary or traffic plan), so schedulerCheckers are skipped. Cluster is re-fetched and locked
+func (cm *ClusterManager) updateLbMatrix(ctx context.Context, clusterID int64, lbMatrix map[string]map[string]int) error {
+	cluster, err := lockCluster(ctx, cm.storager, clusterID)
+	if err != nil {
+		return err
+	}
+	if cluster.AutoScheduler.Enabled() {
+		return xerror.WrapParamErrorWithMsg("Cluster %s Scheduler Is In Auto Mode, Disable It Before Setting LbMatrix", cluster.Name)
+	}
+
+	param := &ClusterParam{
+		Scheduler: lbMatrix,
+	}
+	if err = cm.checkManualLB(ctx, cluster, param); err != nil {
+		return err
+	}
+
+	return cm.storager.ClusterUpdate(ctx, &ibasic.Product{ID: cluster.ProductID}, cluster, param)
+}
+
 func (cm *ClusterManager) checkLbMatrix(cluster *Cluster, unbindSubClusters, appendSubClusters []string) (map[string]map[string]int, error) {
 	unbindSubClusterMap := lib.StringSlice2Map(unbindSubClusters)
 	newManualLbMatrix := map[string]map[string]int{}
@@ -537,9 +655,13 @@ func (cm *ClusterManager) RebindSubCluster(ctx context.Context, product *ibasic.
 		return nil
 	}
 
-	manualLbMatrix, err := cm.checkLbMatrix(cluster, unbindSubClusterNames, appendSubClusterNames)
-	if err != nil {
-		return err
+	// lb matrix of cluster in auto mode is recomputed after binding
+	var manualLbMatrix map[string]map[string]int
+	if !cluster.AutoScheduler.Enabled() {
+		var err error
+		if manualLbMatrix, err = cm.checkLbMatrix(cluster, unbindSubClusterNames, appendSubClusterNames); err != nil {
+			return err
+		}
 	}
 
 	return cm.txn.AtomExecute(ctx, func(ctx context.Context) error {
@@ -577,7 +699,21 @@ func (cm *ClusterManager) RebindSubCluster(ctx context.Context, product *ibasic.
 		}
 
 		// U should check param by yourself
-		return cm.storager.BindSubCluster(ctx, cluster, appendSubClusters, unbindSubClusters)
+		if err = cm.storager.BindSubCluster(ctx, cluster, appendSubClusters, unbindSubClusters); err != nil {
+			return err
+		}
+		if !cluster.AutoScheduler.Enabled() {
+			return nil
+		}
+
+		newCluster, err := cm.storager.FetchCluster(ctx, &ClusterFilter{
+			ID: &cluster.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return cm.refreshAutoScheduler(ctx, newCluster)
 	})
 }
 
@@ -624,8 +760,54 @@ func AppendAdvancedRuleCluster(list []*Cluster) []*Cluster {
 	})
 }
 
-func NewBfeClusterConf(version string, clusters []*Cluster) *cluster_conf.BfeClusterConf {
-	clusterConfMap := cluster_conf.ClusterToConf{}
+// AIConf extends cluster_conf.AIConf with fields not supported by bfe config lib
+type AIConf struct {
+	cluster_conf.AIConf
+
+	Keys []*ExportProviderKey `json:",omitempty"`
+
+	// ModelMappingRules are non exact model mappings in precedence, tried after ModelMapping
+	ModelMappingRules []*ExportModelMappingRule `json:",omitempty"`
+
+	// protocol of provider, see ProviderProtocol
+	Provider   string
+	AuthHeader string
+	AuthScheme string `json:",omitempty"`
+	BasePath   string
+	Headers    map[string]string `json:",omitempty"`
+}
+
+// ClusterConf is cluster_conf.ClusterConf whose AIConf and GslbBasic be replaced by the extended one
+type ClusterConf struct {
+	cluster_conf.ClusterConf
+
+	GslbBasic   *GslbBasicConf
+	AIConf      *AIConf
+	RetryPolicy *ExportRetryPolicy `json:",omitempty"`
+}
+
+// BfeClusterConf is the exported cluster conf of data plane
+type BfeClusterConf struct {
+	Version *string
+	Config  *map[string]ClusterConf
+}
+
+// NewBfeClusterConf builds cluster conf of data plane, protocols resolves provider type of llm config,
+// builtin providers are used if it is nil. certificates fetches certificates referred by tls setting of https cluster
+func NewBfeClusterConf(ctx context.Context, version string, clusters []*Cluster,
+	protocols ProviderProtocolGetter, certificates CertificateFetcher) (*BfeClusterConf, error) {
+	clusterConfMap := map[string]ClusterConf{}
+
+	var certs map[string]*iprotocol.Certificate
+	for _, cluster := range clusters {
+		if cluster.UpstreamTLS != nil && certificates != nil {
+			var err error
+			if certs, err = fetchCertificateMap(ctx, certificates); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
 
 	int322intp := func(i int32) *int {
 		tmp := int(i)
@@ -661,7 +843,6 @@ func NewBfeClusterConf(version string, clusters []*Cluster) *cluster_conf.BfeClu
 					HashHeader:    &cluster.StickySessions.HashHeader,
 					SessionSticky: &cluster.StickySessions.SessionSticky,
 				},
-				BalanceMode: lib.PString("WRR"),
 			},
 			ClusterBasic: &cluster_conf.ClusterBasicConf{
 				TimeoutReadClient:      int322intp(cluster.Basic.Timeouts.TimeoutReadbodyClient),
@@ -675,13 +856,11 @@ func NewBfeClusterConf(version string, clusters []*Cluster) *cluster_conf.BfeClu
 		}
 
 		if cluster.Basic.Protocol != nil && *cluster.Basic.Protocol == "https" {
-			clusterConf.HTTPSConf = &cluster_conf.BackendHTTPS{
-				RSHost:               lib.PString(""),
-				RSInsecureSkipVerify: lib.PBool(true),
-				RSCAList:             nil,
-				BFECertFile:          nil,
-				BFEKeyFile:           nil,
+			httpsConf, err := exportBackendHTTPS(cluster.UpstreamTLS, certs)
+			if err != nil {
+				return nil, xerror.WrapModelErrorWithMsg("cluster %s: %v", cluster.Name, err)
 			}
+			clusterConf.HTTPSConf = httpsConf
 		}
 
 		if isDomainPool(cluster.SubClusters) {
@@ -689,34 +868,124 @@ func NewBfeClusterConf(version string, clusters []*Cluster) *cluster_conf.BfeClu
 			clusterConf.ClusterBasic.DisableHostHeader = lib.PBool(true)
 		}
 
+		conf := ClusterConf{
+			ClusterConf: clusterConf,
+			GslbBasic:   newGslbBasicConf(*clusterConf.GslbBasic, cluster.Basic.BalanceMode, cluster.Basic.BalanceParams),
+			RetryPolicy: exportRetryPolicy(cluster.RetryPolicy),
+		}
 		if cluster.LLMConfig != nil {
-			clusterConf.AIConf = &cluster_conf.AIConf{
-				Type:         0,
-				ModelMapping: convertToBFEModelMapping(cluster.LLMConfig.ModelMappings),
-				Key:          cluster.LLMConfig.Key,
+			key, err := OpenLLMConfigKey(ctx, cluster.LLMConfig)
+			if err != nil {
+				return nil, xerror.WrapModelErrorWithMsg("cluster %s: %v", cluster.Name, err)
+			}
+
+			keys, err := exportProviderKeys(ctx, cluster.LLMConfig.Keys)
+			if err != nil {
+				return nil, xerror.WrapModelErrorWithMsg("cluster %s: %v", cluster.Name, err)
+			}
+
+			// data plane not support Keys use Key
+			if key == nil && len(keys) > 0 {
+				key = lib.PString(keys[0].Key)
+			}
+
+			// mappings are checked when saved, invalid ones of legacy data are skipped
+			mapper, errs := loadModelMapper(cluster.LLMConfig.ModelMappings)
+			for _, err := range errs {
+				stateful.AccessLogger.Warn("export cluster %s skip model mapping: %v", cluster.Name, err)
 			}
+
+			conf.AIConf = &AIConf{
+				AIConf: cluster_conf.AIConf{
+					ModelMapping: convertToBFEModelMapping(mapper),
+					Key:          key,
+				},
+				Keys:              keys,
+				ModelMappingRules: exportModelMappingRules(mapper),
+			}
+
+			providerType, protocol, err := resolveProviderProtocol(ctx, protocols, cluster.LLMConfig)
+			if err != nil {
+				return nil, xerror.WrapModelErrorWithMsg("cluster %s: %v", cluster.Name, err)
+			}
+			exportProtocol(conf.AIConf, cluster.LLMConfig, providerType, protocol)
 		}
 
-		clusterConfMap[cluster.Name] = clusterConf
+		clusterConfMap[cluster.Name] = conf
 	}
-	return &cluster_conf.BfeClusterConf{
-		Version: &version,
-		Config:  &clusterConfMap,
+	conf := &BfeClusterConf{
+		Config: &clusterConfMap,
+	}
+	if err := conf.UpdateVersion(version); err != nil {
+		return nil, err
 	}
+
+	return conf, nil
 }
 
-func convertToBFEModelMapping(modelMappings []*Mapping) *map[string]string {
-	responseMap := make(map[string]string)
-	for _, modelMapping := range modelMappings {
-		responseMap[*modelMapping.Key] = *modelMapping.Value
+// UpdateVersion sets version of cluster conf, certificates of https clusters are referred in tls conf dir of the version
+func (bcc *BfeClusterConf) UpdateVersion(version string) error {
+	bcc.Version = &version
+
+	for name, conf := range *bcc.Config {
+		httpsConf := conf.HTTPSConf
+		if httpsConf == nil {
+			continue
+		}
+
+		if httpsConf.RSCAList != nil {
+			caList := []string{}
+			for _, path := range *httpsConf.RSCAList {
+				path, err := iprotocol.VersionedTLSFilePath(path, version)
+				if err != nil {
+					return xerror.WrapModelErrorWithMsg("cluster %s: %v", name, err)
+				}
+				caList = append(caList, path)
+			}
+			httpsConf.RSCAList = &caList
+		}
+
+		for _, file := range []**string{&httpsConf.BFECertFile, &httpsConf.BFEKeyFile} {
+			if *file == nil {
+				continue
+			}
+			path, err := iprotocol.VersionedTLSFilePath(**file, version)
+			if err != nil {
+				return xerror.WrapModelErrorWithMsg("cluster %s: %v", name, err)
+			}
+			*file = &path
+		}
 	}
 
+	return nil
+}
+
+func convertToBFEModelMapping(mapper *ModelMapper) *map[string]string {
+	responseMap := mapper.Exact()
 	if len(responseMap) == 0 {
 		return nil
 	}
 	return &responseMap
 }
 
+// ExportModelMappingRule is the non exact model mapping of data plane,
+// Target may reference capture groups of Pattern, like $1 or ${name}
+type ExportModelMappingRule struct {
+	Pattern string
+	Target  string
+}
+
+func exportModelMappingRules(mapper *ModelMapper) []*ExportModelMappingRule {
+	var rst []*ExportModelMappingRule
+	for _, rule := range mapper.Rules() {
+		rst = append(rst, &ExportModelMappingRule{
+			Pattern: rule.Pattern(),
+			Target:  rule.Value,
+		})
+	}
+	return rst
+}
+
 func isDomainPool(subClusters []*SubCluster) bool {
 	for _, subCluster := range subClusters {
 		if subCluster.InstancePool != nil {
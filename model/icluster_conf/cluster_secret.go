@@ -0,0 +1,175 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
+)
+
+// sealLLMConfigKey encrypts the provider key of conf, redacted key keeps the one of old config
+func sealLLMConfigKey(ctx context.Context, old, conf *LLMConfig) (err error) {
+	if conf == nil {
+		return nil
+	}
+
+	var oldKey *string
+	var oldKeys []*ProviderKey
+	if old != nil {
+		oldKey, oldKeys = old.Key, old.Keys
+	}
+
+	if conf.Key, err = isecret.SealOrKeep(ctx, oldKey, conf.Key); err != nil {
+		return err
+	}
+
+	for _, one := range conf.Keys {
+		var oldKey *string
+		if _, tmp := findProviderKey(oldKeys, *one.Name); tmp != nil {
+			oldKey = tmp.Key
+		}
+
+		if one.Key, err = isecret.SealOrKeep(ctx, oldKey, one.Key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeLLMConfig keeps provider keys of old config when keys of conf are omitted,
+// send an empty list to remove all of them. Keys of conf are sealed
+func mergeLLMConfig(ctx context.Context, old, conf *LLMConfig) error {
+	if err := sealLLMConfigKey(ctx, old, conf); err != nil {
+		return err
+	}
+
+	if conf.Keys == nil && old != nil {
+		conf.Keys = old.Keys
+	}
+
+	return nil
+}
+
+// OpenLLMConfigKey decrypts the provider key
+func OpenLLMConfigKey(ctx context.Context, conf *LLMConfig) (*string, error) {
+	if conf == nil || conf.Key == nil {
+		return nil, nil
+	}
+
+	key, err := isecret.Open(ctx, *conf.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// OpenLLMConfigKeys decrypts provider keys of conf in place, conf must be a copy
+// of the stored config
+func OpenLLMConfigKeys(ctx context.Context, conf *LLMConfig) (err error) {
+	if conf.Key, err = OpenLLMConfigKey(ctx, conf); err != nil {
+		return err
+	}
+
+	for _, one := range conf.Keys {
+		if one.Key == nil {
+			continue
+		}
+		key, err := isecret.Open(ctx, *one.Key)
+		if err != nil {
+			return err
+		}
+		one.Key = &key
+	}
+
+	return nil
+}
+
+// RedactLLMConfig return a copy of conf with provider key hidden, used by read API
+func RedactLLMConfig(conf *LLMConfig) *LLMConfig {
+	if conf == nil {
+		return conf
+	}
+
+	tmp := *conf
+	if conf.Key != nil {
+		tmp.Key = lib.PString(isecret.Redact(*conf.Key))
+	}
+
+	tmp.Keys = nil
+	for _, one := range conf.Keys {
+		key := *one
+		if one.Key != nil {
+			key.Key = lib.PString(isecret.Redact(*one.Key))
+		}
+		tmp.Keys = append(tmp.Keys, &key)
+	}
+
+	return &tmp
+}
+
+// RekeySecrets seals provider keys of all clusters with the current master key,
+// keys stored in plain text before encryption be enabled are sealed too
+func (cm *ClusterManager) RekeySecrets(ctx context.Context) (count int, err error) {
+	err = cm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		clusters, err := cm.storager.FetchClusterList(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, cluster := range clusters {
+			if cluster.LLMConfig == nil {
+				continue
+			}
+
+			keys := []*string{cluster.LLMConfig.Key}
+			for _, one := range cluster.LLMConfig.Keys {
+				keys = append(keys, one.Key)
+			}
+
+			changedAny := false
+			for _, key := range keys {
+				if key == nil {
+					continue
+				}
+
+				sealed, changed, err := isecret.Rekey(ctx, *key)
+				if err != nil {
+					return xerror.WrapModelErrorWithMsg("cluster %s: %v", cluster.Name, err)
+				}
+				*key = sealed
+				changedAny = changedAny || changed
+			}
+			if !changedAny {
+				continue
+			}
+
+			if err = cm.storager.ClusterUpdate(ctx, nil, cluster, &ClusterParam{
+				LLMConfig: cluster.LLMConfig,
+			}); err != nil {
+				return err
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return
+}
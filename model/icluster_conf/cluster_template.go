@@ -0,0 +1,188 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/itxn"
+)
+
+const (
+	// ClusterTemplateScopeSystem templates can be used by all products
+	ClusterTemplateScopeSystem = "system"
+	// ClusterTemplateScopeProduct templates can only be used by the product owns them
+	ClusterTemplateScopeProduct = "product"
+)
+
+// ClusterTemplate stores default settings of clusters, ProductID is 0 for system templates.
+// Content is the json of cluster settings in the shape of create cluster API, it's opaque
+// to model layer and never contains provider keys
+type ClusterTemplate struct {
+	ID          int64
+	Name        string
+	ProductID   int64
+	Description string
+	Content     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (t *ClusterTemplate) Scope() string {
+	if t.ProductID == 0 {
+		return ClusterTemplateScopeSystem
+	}
+
+	return ClusterTemplateScopeProduct
+}
+
+type ClusterTemplateFilter struct {
+	ID         *int64
+	Name       *string
+	ProductID  *int64
+	ProductIDs []int64
+}
+
+type ClusterTemplateParam struct {
+	Name        *string
+	ProductID   *int64
+	Description *string
+	Content     *string
+}
+
+type ClusterTemplateStorager interface {
+	FetchClusterTemplates(ctx context.Context, filter *ClusterTemplateFilter) ([]*ClusterTemplate, error)
+	CreateClusterTemplate(ctx context.Context, param *ClusterTemplateParam) (int64, error)
+	UpdateClusterTemplate(ctx context.Context, old *ClusterTemplate, param *ClusterTemplateParam) error
+	DeleteClusterTemplate(ctx context.Context, old *ClusterTemplate) error
+}
+
+type ClusterTemplateManager struct {
+	txn      itxn.TxnStorager
+	storager ClusterTemplateStorager
+}
+
+func NewClusterTemplateManager(txn itxn.TxnStorager, storager ClusterTemplateStorager) *ClusterTemplateManager {
+	return &ClusterTemplateManager{
+		txn:      txn,
+		storager: storager,
+	}
+}
+
+func templateProductID(product *ibasic.Product) int64 {
+	if product == nil {
+		return 0
+	}
+
+	return product.ID
+}
+
+// FetchClusterTemplates returns templates of product and system templates,
+// only system templates are returned if product is nil
+func (m *ClusterTemplateManager) FetchClusterTemplates(ctx context.Context, product *ibasic.Product) (list []*ClusterTemplate, err error) {
+	productIDs := []int64{0}
+	if product != nil {
+		productIDs = append(productIDs, product.ID)
+	}
+
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err = m.storager.FetchClusterTemplates(ctx, &ClusterTemplateFilter{
+			ProductIDs: productIDs,
+		})
+		return err
+	})
+
+	return
+}
+
+// FetchClusterTemplate returns template with name in scope of product, system template
+// is returned if product is nil. nil returned if not existed
+func (m *ClusterTemplateManager) FetchClusterTemplate(ctx context.Context, product *ibasic.Product,
+	name string) (*ClusterTemplate, error) {
+
+	var list []*ClusterTemplate
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) (err error) {
+		list, err = m.storager.FetchClusterTemplates(ctx, &ClusterTemplateFilter{
+			Name:      &name,
+			ProductID: lib.PInt64(templateProductID(product)),
+		})
+		return err
+	})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	return list[0], nil
+}
+
+// ResolveClusterTemplate finds template used by product, template of product
+// takes precedence over system template with the same name
+func (m *ClusterTemplateManager) ResolveClusterTemplate(ctx context.Context, product *ibasic.Product,
+	name string) (*ClusterTemplate, error) {
+
+	one, err := m.FetchClusterTemplate(ctx, product, name)
+	if err != nil || one != nil || product == nil {
+		return one, err
+	}
+
+	return m.FetchClusterTemplate(ctx, nil, name)
+}
+
+// CreateClusterTemplate creates template in scope of product, system template if product is nil
+func (m *ClusterTemplateManager) CreateClusterTemplate(ctx context.Context, product *ibasic.Product,
+	param *ClusterTemplateParam) error {
+
+	productID := templateProductID(product)
+	param.ProductID = &productID
+
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		old, err := m.storager.FetchClusterTemplates(ctx, &ClusterTemplateFilter{
+			Name:      param.Name,
+			ProductID: param.ProductID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(old) != 0 {
+			return xerror.WrapRecordExisted("Cluster Template")
+		}
+
+		_, err = m.storager.CreateClusterTemplate(ctx, param)
+		return err
+	})
+}
+
+// UpdateClusterTemplate updates template, name and scope can't be changed
+func (m *ClusterTemplateManager) UpdateClusterTemplate(ctx context.Context, old *ClusterTemplate,
+	param *ClusterTemplateParam) error {
+
+	param.Name = nil
+	param.ProductID = nil
+
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		return m.storager.UpdateClusterTemplate(ctx, old, param)
+	})
+}
+
+// DeleteClusterTemplate deletes template, clusters created from it are not affected
+func (m *ClusterTemplateManager) DeleteClusterTemplate(ctx context.Context, old *ClusterTemplate) error {
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		return m.storager.DeleteClusterTemplate(ctx, old)
+	})
+}
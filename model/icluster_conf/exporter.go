@@ -56,11 +56,17 @@ func (rm *ClusterManager) clusterTableConfGenerator(ctx context.Context) (*ivers
 
 			subClusterBackend := make(cluster_table_conf.SubClusterBackend, 0, len(subCluster.InstancePool.Instances))
 			for _, instance := range subCluster.InstancePool.Instances {
+				// disabled instance is kept with zero weight, so it receives no traffic
+				weight := int(instance.Weight)
+				if instance.Disable {
+					weight = 0
+				}
+
 				subClusterBackend = append(subClusterBackend, &cluster_table_conf.BackendConf{
 					Name:   lib.PString(instance.HostName),
 					Addr:   lib.PString(instance.IP),
 					Port:   lib.PInt(instance.Port),
-					Weight: lib.PInt(int(instance.Weight)),
+					Weight: lib.PInt(weight),
 				})
 			}
 
@@ -87,8 +93,9 @@ func (rm *ClusterManager) clusterTableConfGenerator(ctx context.Context) (*ivers
 	}, nil
 }
 
-func (rm *ClusterManager) ExportClusterTable(ctx context.Context, lastVersion string) (*ClusterTableConf, error) {
-	ed, err := rm.versionControlManager.ExportConfig(ctx, ConfigTopicClusterTable, rm.clusterTableConfGenerator)
+// ExportClusterTable exports the version targeted by bfeCluster, returns nil if it is lastVersion
+func (rm *ClusterManager) ExportClusterTable(ctx context.Context, lastVersion, bfeCluster string) (*ClusterTableConf, error) {
+	ed, err := rm.versionControlManager.ExportTargetConfig(ctx, ConfigTopicClusterTable, bfeCluster, rm.clusterTableConfGenerator)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,322 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// types of model mapping, entries are matched in precedence:
+// exact, prefix (longest key first), wildcard (in order), regex (in order)
+const (
+	MappingTypeExact    = "exact"
+	MappingTypePrefix   = "prefix"
+	MappingTypeWildcard = "wildcard"
+	MappingTypeRegex    = "regex"
+)
+
+var mappingTypePrecedence = map[string]int{
+	MappingTypeExact:    0,
+	MappingTypePrefix:   1,
+	MappingTypeWildcard: 2,
+	MappingTypeRegex:    3,
+}
+
+// MappingTypes returns all types of model mapping
+func MappingTypes() []string {
+	return []string{MappingTypeExact, MappingTypePrefix, MappingTypeWildcard, MappingTypeRegex}
+}
+
+// value references capture group like $1, ${1}, $name or ${name}, $$ is a literal $
+var mappingGroupRef = regexp.MustCompile(`\$(\$|\w+|\{[^}]*\})`)
+
+// ModelMappingRule is a compiled model mapping
+type ModelMappingRule struct {
+	Index int // index in llm_config.model_mappings
+	Type  string
+	Key   string
+	Value string
+
+	pattern *regexp.Regexp // nil for exact
+}
+
+// Pattern returns the anchored regular expression of rule, empty for exact
+func (r *ModelMappingRule) Pattern() string {
+	if r.pattern == nil {
+		return ""
+	}
+	return r.pattern.String()
+}
+
+// Static tells whether value of rule has no capture group reference
+func (r *ModelMappingRule) Static() bool {
+	if r.pattern == nil {
+		return true
+	}
+
+	for _, ref := range mappingGroupRef.FindAllStringSubmatch(r.Value, -1) {
+		if ref[1] != "$" {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *ModelMappingRule) match(model string) (string, bool) {
+	if r.pattern == nil {
+		return r.Value, model == r.Key
+	}
+
+	groups := r.pattern.FindStringSubmatchIndex(model)
+	if groups == nil {
+		return "", false
+	}
+	return string(r.pattern.ExpandString(nil, r.Value, model, groups)), true
+}
+
+// ModelMapper resolves model of client to model of provider
+type ModelMapper struct {
+	exact map[string]*ModelMappingRule
+
+	// rules except exact ones, in precedence
+	rules []*ModelMappingRule
+}
+
+// NewModelMapper compiles model mappings, invalid, conflict and unreachable entries are rejected
+func NewModelMapper(mappings []*Mapping) (*ModelMapper, error) {
+	m, errs := loadModelMapper(mappings)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	return m, nil
+}
+
+// loadModelMapper compiles model mappings, invalid, conflict and unreachable entries are skipped
+// and returned as errors. Mappings are checked when saved, it is used by readers of stored mappings,
+// so one invalid entry of legacy data not breaks others
+func loadModelMapper(mappings []*Mapping) (*ModelMapper, []error) {
+	m := &ModelMapper{
+		exact: map[string]*ModelMappingRule{},
+	}
+
+	var errs []error
+	var rules []*ModelMappingRule
+	for i, one := range mappings {
+		rule, err := compileModelMapping(i, one)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("model_mappings[%d]: %v", i, err))
+			continue
+		}
+
+		if rule.Type == MappingTypeExact {
+			if old, ok := m.exact[rule.Key]; ok {
+				errs = append(errs, fmt.Errorf("model_mappings[%d]: key %s conflicts with model_mappings[%d]", i, rule.Key, old.Index))
+				continue
+			}
+			m.exact[rule.Key] = rule
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	// stable sort keeps order of wildcard and regex entries
+	sort.SliceStable(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if a.Type != b.Type {
+			return mappingTypePrecedence[a.Type] < mappingTypePrecedence[b.Type]
+		}
+		if a.Type == MappingTypePrefix {
+			return len(a.Key) > len(b.Key)
+		}
+		return false
+	})
+
+	for _, rule := range rules {
+		if err := checkShadowed(m.rules, rule); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m.rules = append(m.rules, rule)
+	}
+
+	return m, errs
+}
+
+// checkShadowed returns error if rule conflicts with or is unreachable after prior rules
+func checkShadowed(priors []*ModelMappingRule, rule *ModelMappingRule) error {
+	for _, prior := range priors {
+		if !shadows(prior, rule) {
+			continue
+		}
+		if prior.Type == rule.Type && prior.Key == rule.Key {
+			return fmt.Errorf("model_mappings[%d]: %s key %s conflicts with model_mappings[%d]",
+				rule.Index, rule.Type, rule.Key, prior.Index)
+		}
+		return fmt.Errorf("model_mappings[%d]: %s key %s is unreachable, all models matched by model_mappings[%d] %s key %s",
+			rule.Index, rule.Type, rule.Key, prior.Index, prior.Type, prior.Key)
+	}
+
+	return nil
+}
+
+// Resolve returns model of provider and the matched rule, model itself and nil if no rule matched
+func (m *ModelMapper) Resolve(model string) (string, *ModelMappingRule) {
+	if rule, ok := m.exact[model]; ok {
+		return rule.Value, rule
+	}
+
+	for _, rule := range m.rules {
+		if target, ok := rule.match(model); ok {
+			return target, rule
+		}
+	}
+
+	return model, nil
+}
+
+// Exact returns exact mappings, nil if none
+func (m *ModelMapper) Exact() map[string]string {
+	if len(m.exact) == 0 {
+		return nil
+	}
+
+	rst := map[string]string{}
+	for k, rule := range m.exact {
+		rst[k] = rule.Value
+	}
+	return rst
+}
+
+// Rules returns mappings except exact ones, in precedence
+func (m *ModelMapper) Rules() []*ModelMappingRule {
+	return m.rules
+}
+
+func compileModelMapping(index int, mapping *Mapping) (*ModelMappingRule, error) {
+	if mapping == nil {
+		return nil, fmt.Errorf("must not be null")
+	}
+	if mapping.Key == nil || *mapping.Key == "" {
+		return nil, fmt.Errorf("must set key")
+	}
+	if mapping.Value == nil || *mapping.Value == "" {
+		return nil, fmt.Errorf("must set value")
+	}
+
+	rule := &ModelMappingRule{
+		Index: index,
+		Type:  MappingTypeExact,
+		Key:   *mapping.Key,
+		Value: *mapping.Value,
+	}
+	if mapping.Type != nil && *mapping.Type != "" {
+		rule.Type = *mapping.Type
+	}
+
+	var expr string
+	switch rule.Type {
+	case MappingTypeExact:
+		return rule, nil
+	case MappingTypePrefix:
+		expr = "^" + regexp.QuoteMeta(rule.Key) + "(.*)$"
+	case MappingTypeWildcard:
+		if !strings.Contains(rule.Key, "*") {
+			return nil, fmt.Errorf("wildcard key %s must contain *", rule.Key)
+		}
+		parts := strings.Split(rule.Key, "*")
+		for i := range parts {
+			parts[i] = regexp.QuoteMeta(parts[i])
+		}
+		expr = "^" + strings.Join(parts, "(.*)") + "$"
+	case MappingTypeRegex:
+		expr = "^(?:" + rule.Key + ")$"
+	default:
+		return nil, fmt.Errorf("type must be one of %s", strings.Join(MappingTypes(), ", "))
+	}
+
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key %s: %v", rule.Key, err)
+	}
+	rule.pattern = pattern
+
+	if err := checkGroupReference(pattern, rule.Value); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// checkGroupReference makes sure capture groups referenced by value exist
+func checkGroupReference(pattern *regexp.Regexp, value string) error {
+	names := map[string]bool{}
+	for _, name := range pattern.SubexpNames() {
+		if name != "" {
+			names[name] = true
+		}
+	}
+
+	for _, ref := range mappingGroupRef.FindAllStringSubmatch(value, -1) {
+		if ref[1] == "$" {
+			continue
+		}
+
+		name := strings.TrimSuffix(strings.TrimPrefix(ref[1], "{"), "}")
+		if n, err := strconv.Atoi(name); err == nil {
+			if n > pattern.NumSubexp() {
+				return fmt.Errorf("value %s references group %d, but key has %d groups", value, n, pattern.NumSubexp())
+			}
+			continue
+		}
+		if !names[name] {
+			// $1x is group named 1x, not group 1 followed by x
+			if name[0] >= '0' && name[0] <= '9' {
+				return fmt.Errorf("value %s references unknown group %s, use ${N} when group number is followed by letters, digits or _", value, name)
+			}
+			return fmt.Errorf("value %s references unknown group %s", value, name)
+		}
+	}
+
+	return nil
+}
+
+// shadows tells whether every model matched by rule is matched by prior.
+// Only prefix and wildcard rules are compared, regex rules are not analyzed.
+func shadows(prior, rule *ModelMappingRule) bool {
+	if prior.Type == MappingTypeRegex || rule.Type == MappingTypeRegex {
+		return prior.Type == rule.Type && prior.Key == rule.Key
+	}
+
+	// every model matched by rule starts with its literal head
+	head := rule.Key
+	if rule.Type == MappingTypeWildcard {
+		head = head[:strings.Index(head, "*")]
+	}
+	if prior.Type == MappingTypePrefix {
+		return strings.HasPrefix(head, prior.Key)
+	}
+
+	// prior is wildcard, "*" in rule can be only matched by "*" of prior
+	key := rule.Key
+	if rule.Type == MappingTypePrefix {
+		key += "*"
+	}
+	return prior.pattern.MatchString(key)
+}
@@ -0,0 +1,137 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+)
+
+func testMapping(typ, key, value string) *Mapping {
+	return &Mapping{Type: lib.PString(typ), Key: lib.PString(key), Value: lib.PString(value)}
+}
+
+func TestModelMapperResolve(t *testing.T) {
+	mapper, err := NewModelMapper([]*Mapping{
+		testMapping(MappingTypeExact, "gpt-4", "gpt-4-0613"),
+		testMapping(MappingTypePrefix, "claude-", "anthropic/claude-$1"),
+		testMapping(MappingTypePrefix, "claude-3-", "anthropic/v3-${1}x"),
+		testMapping(MappingTypeWildcard, "*-mini", "${1}-small"),
+		testMapping(MappingTypeRegex, `llama-(?P<size>\d+)b`, "meta/llama-$size"),
+		testMapping(MappingTypeRegex, `price-(\d+)`, "$$$1"),
+	})
+	if err != nil {
+		t.Fatalf("NewModelMapper() error = %v", err)
+	}
+
+	cases := []struct {
+		model     string
+		want      string
+		wantIndex int // -1 means no rule matched
+	}{
+		{model: "gpt-4", want: "gpt-4-0613", wantIndex: 0},
+		{model: "claude-3-opus", want: "anthropic/v3-opusx", wantIndex: 2},
+		{model: "claude-2", want: "anthropic/claude-2", wantIndex: 1},
+		{model: "gpt-4o-mini", want: "gpt-4o-small", wantIndex: 3},
+		{model: "llama-70b", want: "meta/llama-70", wantIndex: 4},
+		{model: "price-10", want: "$10", wantIndex: 5},
+		{model: "unknown", want: "unknown", wantIndex: -1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.model, func(t *testing.T) {
+			got, rule := mapper.Resolve(c.model)
+			if got != c.want {
+				t.Errorf("Resolve(%s) = %s, want %s", c.model, got, c.want)
+			}
+			index := -1
+			if rule != nil {
+				index = rule.Index
+			}
+			if index != c.wantIndex {
+				t.Errorf("Resolve(%s) rule = %d, want %d", c.model, index, c.wantIndex)
+			}
+		})
+	}
+}
+
+func TestNewModelMapperInvalid(t *testing.T) {
+	cases := []struct {
+		name     string
+		mappings []*Mapping
+		wantErr  bool
+	}{
+		{name: "valid", mappings: []*Mapping{testMapping(MappingTypePrefix, "a-", "b-$1")}},
+		{name: "empty key", mappings: []*Mapping{testMapping(MappingTypeExact, "", "b")}, wantErr: true},
+		{name: "unknown type", mappings: []*Mapping{testMapping("glob", "a", "b")}, wantErr: true},
+		{name: "wildcard without star", mappings: []*Mapping{testMapping(MappingTypeWildcard, "a", "b")}, wantErr: true},
+		{name: "invalid regex", mappings: []*Mapping{testMapping(MappingTypeRegex, "a(", "b")}, wantErr: true},
+		{name: "group out of range", mappings: []*Mapping{testMapping(MappingTypePrefix, "a-", "b-$2")}, wantErr: true},
+		{name: "group followed by letter", mappings: []*Mapping{testMapping(MappingTypePrefix, "a-", "b-$1x")}, wantErr: true},
+		{name: "group in braces followed by letter", mappings: []*Mapping{testMapping(MappingTypePrefix, "a-", "b-${1}x")}},
+		{name: "unknown named group", mappings: []*Mapping{testMapping(MappingTypeRegex, "(?P<v>.*)", "$w")}, wantErr: true},
+		{name: "literal dollar", mappings: []*Mapping{testMapping(MappingTypePrefix, "a-", "$$b")}},
+		{
+			name:     "exact conflict",
+			mappings: []*Mapping{testMapping(MappingTypeExact, "a", "b"), testMapping(MappingTypeExact, "a", "c")},
+			wantErr:  true,
+		},
+		{
+			name:     "prefix unreachable",
+			mappings: []*Mapping{testMapping(MappingTypePrefix, "a-", "b"), testMapping(MappingTypePrefix, "a-b-", "c")},
+		},
+		{
+			name:     "wildcard unreachable",
+			mappings: []*Mapping{testMapping(MappingTypeWildcard, "a-*", "b"), testMapping(MappingTypeWildcard, "a-b-*", "c")},
+			wantErr:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := NewModelMapper(c.mappings); (err != nil) != c.wantErr {
+				t.Errorf("NewModelMapper() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
+
+// TestLoadModelMapper makes sure invalid entries of legacy data are skipped, others still work
+func TestLoadModelMapper(t *testing.T) {
+	mapper, errs := loadModelMapper([]*Mapping{
+		testMapping(MappingTypeExact, "a", "b"),
+		testMapping(MappingTypePrefix, "x-", "y-$1x"),
+		testMapping(MappingTypeExact, "a", "c"),
+		testMapping(MappingTypePrefix, "p-", "q-$1"),
+	})
+	if len(errs) != 2 {
+		t.Errorf("errs = %v, want 2", errs)
+	}
+
+	cases := []struct {
+		model string
+		want  string
+	}{
+		{model: "a", want: "b"},
+		{model: "x-1", want: "x-1"},
+		{model: "p-1", want: "q-1"},
+	}
+	for _, c := range cases {
+		if got, _ := mapper.Resolve(c.model); got != c.want {
+			t.Errorf("Resolve(%s) = %s, want %s", c.model, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,502 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/itxn"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+const (
+	ModelSyncStatusUnchanged = "unchanged" // models of provider same as llm_config.models
+	ModelSyncStatusPending   = "pending"   // changes staged, waiting for approval
+	ModelSyncStatusApplied   = "applied"   // changes written into llm_config.models
+	ModelSyncStatusFailed    = "failed"    // request or parse model list fail
+)
+
+const (
+	ModelReferenceSourceMapping = "model_mapping"
+	ModelReferenceSourceAPIKey  = "api_key"
+)
+
+// ModelReference is a model referenced by model mapping or api key,
+// but not provided by the provider any more
+type ModelReference struct {
+	Model  string `json:"model"`
+	Source string `json:"source"` // see ModelReferenceSourceXXX
+	Name   string `json:"name"`   // key of model mapping or name of api key
+}
+
+// ModelSync is the result of the latest model sync of cluster
+type ModelSync struct {
+	ClusterID   int64  `json:"-"`
+	ProductID   int64  `json:"-"`
+	ClusterName string `json:"cluster_name"`
+
+	Status            string            `json:"status"`
+	Discovered        []string          `json:"discovered"`
+	Added             []string          `json:"added"`
+	Removed           []string          `json:"removed"`
+	MissingReferences []*ModelReference `json:"missing_references"`
+	ErrMsg            string            `json:"err_msg,omitempty"`
+	ApprovedBy        string            `json:"approved_by,omitempty"`
+	SyncedAt          time.Time         `json:"synced_at"`
+}
+
+type ModelSyncStorager interface {
+	// FetchModelSync return (nil, nil) if cluster never be synced
+	FetchModelSync(ctx context.Context, clusterID int64) (*ModelSync, error)
+	UpsertModelSync(ctx context.Context, param *ModelSync) error
+}
+
+// ModelSyncManager syncs llm_config.models of llm clusters with model list api of provider
+type ModelSyncManager struct {
+	txn             itxn.TxnStorager
+	storager        ModelSyncStorager
+	clusterStorager ClusterStorager
+	apiKeyStorager  APIKeyStorager
+	productStorager ibasic.ProductStorager
+	providerManager *ModelProviderManager
+	conf            *stateful.ModelSyncConfig
+
+	callModelAPI func(ctx context.Context, param *ModelEndpointRequest) ([]byte, error)
+	now          func() time.Time
+}
+
+func NewModelSyncManager(txn itxn.TxnStorager, storager ModelSyncStorager, clusterStorager ClusterStorager,
+	apiKeyStorager APIKeyStorager, productStorager ibasic.ProductStorager, providerManager *ModelProviderManager,
+	conf *stateful.ModelSyncConfig) *ModelSyncManager {
+
+	return &ModelSyncManager{
+		txn:             txn,
+		storager:        storager,
+		clusterStorager: clusterStorager,
+		apiKeyStorager:  apiKeyStorager,
+		productStorager: productStorager,
+		providerManager: providerManager,
+		conf:            conf,
+		callModelAPI:    CallModelAPI,
+		now:             time.Now,
+	}
+}
+
+// Run sync until ctx done
+func (m *ModelSyncManager) Run(ctx context.Context) {
+	defer lib.Recover("ModelSyncManager")
+
+	ticker := time.NewTicker(time.Duration(m.conf.IntervalInS) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := m.SyncAll(lib.NewLogContext(ctx)); err != nil {
+			stateful.AccessLogger.Warn("ModelSyncManager sync fail: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncAll syncs all clusters whose llm config be enabled, fail of one cluster
+// is recorded and not stop others
+func (m *ModelSyncManager) SyncAll(ctx context.Context) error {
+	var clusters []*Cluster
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) (err error) {
+		clusters, err = m.clusterStorager.FetchClusterList(ctx, &ClusterFilter{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range clusters {
+		if !llmConfigEnabled(cluster) {
+			continue
+		}
+
+		if _, err := m.SyncCluster(ctx, cluster); err != nil {
+			stateful.AccessLogger.Warn("ModelSyncManager sync cluster %s fail: %v", cluster.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func llmConfigEnabled(cluster *Cluster) bool {
+	conf := cluster.LLMConfig
+	return conf != nil && conf.Enable != nil && *conf.Enable && conf.ModelEndpoint != nil
+}
+
+// SyncCluster fetch models of cluster from provider, then apply or stage the changes
+func (m *ModelSyncManager) SyncCluster(ctx context.Context, cluster *Cluster) (*ModelSync, error) {
+	if !llmConfigEnabled(cluster) {
+		return nil, xerror.WrapParamErrorWithMsg("Cluster %s Not Enable llm_config Or Not Set model_endpoint", cluster.Name)
+	}
+
+	// request provider out of transaction
+	discovered, fetchErr := m.fetchModels(ctx, cluster)
+
+	var rst *ModelSync
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		old, err := m.storager.FetchModelSync(ctx, cluster.ID)
+		if err != nil {
+			return err
+		}
+
+		if fetchErr != nil {
+			rst = &ModelSync{}
+			if old != nil {
+				tmp := *old
+				rst = &tmp
+			}
+			rst.ClusterID, rst.ProductID = cluster.ID, cluster.ProductID
+			rst.Status = ModelSyncStatusFailed
+			rst.ErrMsg = fetchErr.Error()
+			rst.SyncedAt = m.now()
+			return m.storager.UpsertModelSync(ctx, rst)
+		}
+
+		product, err := m.fetchProduct(ctx, cluster.ProductID)
+		if err != nil {
+			return err
+		}
+
+		keys, err := m.apiKeyStorager.FetchAPIKeyList(ctx, &APIKeyFilter{
+			ProductName: &product.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		// models may be changed by user since cluster fetched
+		cur, err := lockCluster(ctx, m.clusterStorager, cluster.ID)
+		if err != nil {
+			return err
+		}
+
+		rst = diffModels(cur, discovered, keys)
+		rst.SyncedAt = m.now()
+		switch {
+		case len(rst.Added) == 0 && len(rst.Removed) == 0:
+			rst.Status = ModelSyncStatusUnchanged
+		// removing every model is more likely a wrong model list, it waits for approval
+		case m.conf.AutoApply && (len(rst.Removed) == 0 || len(rst.Removed) < len(cur.LLMConfig.Models)):
+			if err := m.applyModelSync(ctx, product, cur, rst); err != nil {
+				return err
+			}
+			rst.Status = ModelSyncStatusApplied
+		default:
+			rst.Status = ModelSyncStatusPending
+		}
+
+		return m.storager.UpsertModelSync(ctx, rst)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rst.ClusterName = cluster.Name
+	return rst, nil
+}
+
+// FetchModelSync return the latest sync result of cluster, (nil, nil) if never be synced
+func (m *ModelSyncManager) FetchModelSync(ctx context.Context, cluster *Cluster) (rst *ModelSync, err error) {
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		rst, err = m.storager.FetchModelSync(ctx, cluster.ID)
+		return err
+	})
+	if rst != nil {
+		rst.ClusterName = cluster.Name
+	}
+
+	return
+}
+
+// ApproveModelSync applies the staged changes of cluster
+func (m *ModelSyncManager) ApproveModelSync(ctx context.Context, product *ibasic.Product, cluster *Cluster,
+	approvedBy string) (rst *ModelSync, err error) {
+
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		rst, err = m.storager.FetchModelSync(ctx, cluster.ID)
+		if err != nil {
+			return err
+		}
+		if rst == nil || rst.Status != ModelSyncStatusPending {
+			return xerror.WrapParamErrorWithMsg("Cluster %s Has No Pending Model Changes", cluster.Name)
+		}
+
+		cur, err := lockCluster(ctx, m.clusterStorager, cluster.ID)
+		if err != nil {
+			return err
+		}
+		if err = m.applyModelSync(ctx, product, cur, rst); err != nil {
+			return err
+		}
+
+		rst.Status = ModelSyncStatusApplied
+		rst.ApprovedBy = approvedBy
+		return m.storager.UpsertModelSync(ctx, rst)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rst.ClusterName = cluster.Name
+	return rst, nil
+}
+
+// applyModelSync writes changes into llm_config.models, models added by user after the sync are kept.
+// cluster must be locked in txn of caller, other fields of llm_config are saved as they are
+func (m *ModelSyncManager) applyModelSync(ctx context.Context, product *ibasic.Product, cluster *Cluster,
+	rst *ModelSync) error {
+
+	if cluster.LLMConfig == nil {
+		return xerror.WrapParamErrorWithMsg("Cluster %s Not Set llm_config", cluster.Name)
+	}
+
+	removed := lib.StringSlice2Map(rst.Removed)
+	var models []string
+	for _, one := range cluster.LLMConfig.Models {
+		if !removed[one] {
+			models = append(models, one)
+		}
+	}
+	exist := lib.StringSlice2Map(models)
+	for _, one := range rst.Added {
+		if !exist[one] {
+			models = append(models, one)
+		}
+	}
+
+	conf := *cluster.LLMConfig
+	conf.Models = models
+	if conf.Enable != nil && *conf.Enable && len(conf.Models) == 0 {
+		return xerror.WrapParamErrorWithMsg("Cluster %s Would Have No llm_config.models", cluster.Name)
+	}
+
+	return m.clusterStorager.ClusterUpdate(ctx, product, cluster, &ClusterParam{
+		LLMConfig: &conf,
+	})
+}
+
+func (m *ModelSyncManager) fetchProduct(ctx context.Context, id int64) (*ibasic.Product, error) {
+	products, err := m.productStorager.FetchProducts(ctx, &ibasic.ProductFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, xerror.WrapRecordNotExist("Product")
+	}
+
+	return products[0], nil
+}
+
+// fetchModels requests model endpoint on instances of cluster, returns sorted model ids.
+// An empty model list is an error, it's more likely a wrong response or field mapping
+func (m *ModelSyncManager) fetchModels(ctx context.Context, cluster *Cluster) ([]string, error) {
+	conf := cluster.LLMConfig
+	providerType := ""
+	if conf.ProviderType != nil {
+		providerType = *conf.ProviderType
+	}
+
+	var hosts []string
+	for _, sub := range cluster.SubClusters {
+		if sub.InstancePool == nil {
+			continue
+		}
+		for _, instance := range sub.InstancePool.Instances {
+			if !instance.Disable {
+				hosts = append(hosts, instance.IPWithPort())
+			}
+		}
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no instance in cluster")
+	}
+
+	headers := map[string]string{}
+	for k, v := range conf.ModelEndpoint.Headers {
+		headers[k] = v
+	}
+	_, protocol, err := resolveProviderProtocol(ctx, m.providerManager.ProviderProtocol, conf)
+	if err != nil {
+		return nil, err
+	}
+	if err := setModelAPIAuth(ctx, headers, conf, protocol); err != nil {
+		return nil, err
+	}
+
+	parserConf, err := m.providerManager.ParserConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := m.callModelAPI(ctx, &ModelEndpointRequest{
+		Schema:  conf.ModelEndpoint.Schema,
+		URI:     conf.ModelEndpoint.URI,
+		Hosts:   hosts,
+		Headers: headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := ParseModelsWithConfig(body, providerType, parserConf)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := map[string]bool{}
+	for _, one := range list {
+		if id := fmt.Sprint(one["id"]); id != "" {
+			ids[id] = true
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no model in model list")
+	}
+
+	rst := append([]string{}, lib.StringBoolMap2Slice(ids)...)
+	sort.Strings(rst)
+
+	return rst, nil
+}
+
+// setModelAPIAuth sets provider key into auth header of provider protocol if not set by user
+func setModelAPIAuth(ctx context.Context, headers map[string]string, conf *LLMConfig, protocol *ProviderProtocol) error {
+	for k := range headers {
+		if http.CanonicalHeaderKey(k) == http.CanonicalHeaderKey(protocol.AuthHeader) {
+			return nil
+		}
+	}
+
+	key, err := OpenLLMConfigKey(ctx, conf)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		keys, err := exportProviderKeys(ctx, conf.Keys)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		key = &keys[0].Key
+	}
+
+	value := *key
+	if protocol.AuthScheme != "" {
+		value = protocol.AuthScheme + " " + value
+	}
+	headers[protocol.AuthHeader] = value
+	for k, v := range providerVersionHeaders(protocol, conf) {
+		headers[k] = v
+	}
+
+	return nil
+}
+
+// diffModels compares models of provider with llm_config.models, and finds references to missing models.
+// Models in llm_config.models matched by model mappings are aliases, they are not removed if mapped model exists.
+func diffModels(cluster *Cluster, discovered []string, keys []*APIKeyParam) *ModelSync {
+	conf := cluster.LLMConfig
+	rst := &ModelSync{
+		ClusterID:         cluster.ID,
+		ProductID:         cluster.ProductID,
+		Discovered:        discovered,
+		Added:             []string{},
+		Removed:           []string{},
+		MissingReferences: []*ModelReference{},
+	}
+
+	provided := lib.StringSlice2Map(discovered)
+
+	// mappings are checked when saved, invalid ones of legacy data are ignored
+	mapper, _ := loadModelMapper(conf.ModelMappings)
+
+	// target of mapping referencing capture groups depends on model, it is checked by models using it
+	for _, one := range conf.ModelMappings {
+		if one == nil || one.Key == nil || one.Value == nil {
+			continue
+		}
+		if mappingGroupRef.MatchString(*one.Value) && one.Type != nil && *one.Type != MappingTypeExact {
+			continue
+		}
+		if !provided[*one.Value] {
+			rst.MissingReferences = append(rst.MissingReferences, &ModelReference{
+				Model:  *one.Value,
+				Source: ModelReferenceSourceMapping,
+				Name:   *one.Key,
+			})
+		}
+	}
+
+	available := func(model string) bool {
+		if provided[model] {
+			return true
+		}
+		target, rule := mapper.Resolve(model)
+		return rule != nil && provided[target]
+	}
+
+	current := lib.StringSlice2Map(conf.Models)
+	for _, one := range conf.Models {
+		if !available(one) {
+			rst.Removed = append(rst.Removed, one)
+		}
+	}
+	for _, one := range discovered {
+		if !current[one] {
+			rst.Added = append(rst.Added, one)
+		}
+	}
+
+	// only models served by this cluster are checked, models of other clusters may be allowed too
+	removed := lib.StringSlice2Map(rst.Removed)
+	for _, key := range keys {
+		if key.Name == nil {
+			continue
+		}
+		for _, model := range key.AllowedModels {
+			if removed[model] {
+				rst.MissingReferences = append(rst.MissingReferences, &ModelReference{
+					Model:  model,
+					Source: ModelReferenceSourceAPIKey,
+					Name:   *key.Name,
+				})
+			}
+		}
+	}
+
+	sort.Strings(rst.Removed)
+	return rst
+}
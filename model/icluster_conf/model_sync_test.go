@@ -0,0 +1,297 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+type fakeModelSyncStorager struct {
+	rst *ModelSync
+}
+
+func (s *fakeModelSyncStorager) FetchModelSync(ctx context.Context, clusterID int64) (*ModelSync, error) {
+	if s.rst == nil {
+		return nil, nil
+	}
+	one := *s.rst
+	return &one, nil
+}
+
+func (s *fakeModelSyncStorager) UpsertModelSync(ctx context.Context, param *ModelSync) error {
+	s.rst = param
+	return nil
+}
+
+// TestApproveModelSyncStaleCluster makes sure approving writes models into the stored llm_config,
+// not the one of cluster fetched before others changed it
+func TestApproveModelSyncStaleCluster(t *testing.T) {
+	cases := []struct {
+		name    string
+		added   []string
+		removed []string
+		want    []string
+	}{
+		{name: "add", added: []string{"c"}, want: []string{"a", "b", "c"}},
+		{name: "remove", removed: []string{"a"}, want: []string{"b"}},
+		{name: "add existing", added: []string{"b"}, want: []string{"a", "b"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusterStorager := newTestClusterStorager()
+			clusterStorager.cluster.LLMConfig = &LLMConfig{Models: []string{"a"}}
+			stale := *clusterStorager.cluster
+
+			// model b and provider key added by user after cluster fetched
+			clusterStorager.cluster.LLMConfig = &LLMConfig{
+				Models: []string{"a", "b"},
+				Keys:   []*ProviderKey{{Name: lib.PString("k"), Key: lib.PString("sk-k")}},
+			}
+
+			syncStorager := &fakeModelSyncStorager{
+				rst: &ModelSync{ClusterID: 1, Status: ModelSyncStatusPending, Added: c.added, Removed: c.removed},
+			}
+			m := NewModelSyncManager(fakeTxn{}, syncStorager, clusterStorager, nil, nil, nil, nil)
+
+			rst, err := m.ApproveModelSync(context.Background(), &ibasic.Product{ID: 1}, &stale, "admin")
+			if err != nil {
+				t.Fatalf("ApproveModelSync() error = %v", err)
+			}
+			if rst.Status != ModelSyncStatusApplied {
+				t.Errorf("status = %s, want %s", rst.Status, ModelSyncStatusApplied)
+			}
+
+			conf := clusterStorager.cluster.LLMConfig
+			if !reflect.DeepEqual(conf.Models, c.want) {
+				t.Errorf("models = %v, want %v", conf.Models, c.want)
+			}
+			if len(conf.Keys) != 1 {
+				t.Errorf("provider keys = %d, want kept", len(conf.Keys))
+			}
+		})
+	}
+}
+
+func TestDiffModels(t *testing.T) {
+	mapping := func(typ, key, value string) *Mapping {
+		return &Mapping{Type: lib.PString(typ), Key: lib.PString(key), Value: lib.PString(value)}
+	}
+
+	cases := []struct {
+		name        string
+		models      []string
+		mappings    []*Mapping
+		discovered  []string
+		keys        []*APIKeyParam
+		wantAdded   []string
+		wantRemoved []string
+		wantMissing []*ModelReference
+	}{
+		{
+			name:        "unchanged",
+			models:      []string{"a", "b"},
+			discovered:  []string{"a", "b"},
+			wantAdded:   []string{},
+			wantRemoved: []string{},
+		},
+		{
+			name:        "added and removed",
+			models:      []string{"c", "a", "b"},
+			discovered:  []string{"a", "d"},
+			wantAdded:   []string{"d"},
+			wantRemoved: []string{"b", "c"},
+		},
+		{
+			name:        "alias kept through mapping",
+			models:      []string{"gpt4", "gpt-4o"},
+			mappings:    []*Mapping{mapping(MappingTypeExact, "gpt4", "gpt-4o")},
+			discovered:  []string{"gpt-4o"},
+			wantAdded:   []string{},
+			wantRemoved: []string{},
+		},
+		{
+			name:        "alias kept through prefix mapping",
+			models:      []string{"claude-latest"},
+			mappings:    []*Mapping{mapping(MappingTypePrefix, "claude-", "claude-3-5-sonnet")},
+			discovered:  []string{"claude-3-5-sonnet"},
+			wantAdded:   []string{"claude-3-5-sonnet"},
+			wantRemoved: []string{},
+		},
+		{
+			name:        "mapping to missing model",
+			models:      []string{"gpt4"},
+			mappings:    []*Mapping{mapping(MappingTypeExact, "gpt4", "gpt-4")},
+			discovered:  []string{"gpt-4o"},
+			wantAdded:   []string{"gpt-4o"},
+			wantRemoved: []string{"gpt4"},
+			wantMissing: []*ModelReference{
+				{Model: "gpt-4", Source: ModelReferenceSourceMapping, Name: "gpt4"},
+			},
+		},
+		{
+			name:        "mapping referencing capture group not checked",
+			models:      []string{"a"},
+			mappings:    []*Mapping{mapping(MappingTypeRegex, "^x-(.*)$", "$1")},
+			discovered:  []string{"a"},
+			wantAdded:   []string{},
+			wantRemoved: []string{},
+		},
+		{
+			name:       "api key allowing removed model",
+			models:     []string{"a", "b"},
+			discovered: []string{"a"},
+			keys: []*APIKeyParam{
+				{Name: lib.PString("k1"), AllowedModels: []string{"a", "b"}},
+				{Name: lib.PString("k2"), AllowedModels: []string{"a", "other"}},
+				{AllowedModels: []string{"b"}},
+			},
+			wantAdded:   []string{},
+			wantRemoved: []string{"b"},
+			wantMissing: []*ModelReference{
+				{Model: "b", Source: ModelReferenceSourceAPIKey, Name: "k1"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := &Cluster{ID: 1, LLMConfig: &LLMConfig{Models: c.models, ModelMappings: c.mappings}}
+			if c.wantMissing == nil {
+				c.wantMissing = []*ModelReference{}
+			}
+
+			rst := diffModels(cluster, c.discovered, c.keys)
+			if !reflect.DeepEqual(rst.Added, c.wantAdded) {
+				t.Errorf("added = %v, want %v", rst.Added, c.wantAdded)
+			}
+			if !reflect.DeepEqual(rst.Removed, c.wantRemoved) {
+				t.Errorf("removed = %v, want %v", rst.Removed, c.wantRemoved)
+			}
+			if !reflect.DeepEqual(rst.MissingReferences, c.wantMissing) {
+				got, _ := json.Marshal(rst.MissingReferences)
+				want, _ := json.Marshal(c.wantMissing)
+				t.Errorf("missing references = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+type fakeModelSyncAPIKeyStorager struct {
+	APIKeyStorager
+}
+
+func (s *fakeModelSyncAPIKeyStorager) FetchAPIKeyList(ctx context.Context, filter *APIKeyFilter) ([]*APIKeyParam, error) {
+	return nil, nil
+}
+
+type fakeModelSyncProductStorager struct {
+	ibasic.ProductStorager
+}
+
+func (s *fakeModelSyncProductStorager) FetchProducts(ctx context.Context, filter *ibasic.ProductFilter) ([]*ibasic.Product, error) {
+	return []*ibasic.Product{{ID: 1, Name: "product"}}, nil
+}
+
+func TestSyncClusterAutoApply(t *testing.T) {
+	cases := []struct {
+		name       string
+		body       string
+		wantStatus string
+		wantModels []string
+	}{
+		{
+			name:       "applied",
+			body:       `{"data": [{"id": "a"}, {"id": "c"}]}`,
+			wantStatus: ModelSyncStatusApplied,
+			wantModels: []string{"a", "c"},
+		},
+		{
+			name:       "empty model list fails",
+			body:       `{"data": []}`,
+			wantStatus: ModelSyncStatusFailed,
+			wantModels: []string{"a", "b"},
+		},
+		{
+			name:       "model list parsed wrong fails",
+			body:       `{"models": [{"name": "a"}]}`,
+			wantStatus: ModelSyncStatusFailed,
+			wantModels: []string{"a", "b"},
+		},
+		{
+			name:       "all models removed waits for approval",
+			body:       `{"data": [{"id": "x"}]}`,
+			wantStatus: ModelSyncStatusPending,
+			wantModels: []string{"a", "b"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusterStorager := newTestClusterStorager()
+			clusterStorager.cluster.ProductID = 1
+			clusterStorager.cluster.SubClusters = []*SubCluster{{
+				Name:         "sub",
+				InstancePool: &Pool{Instances: []Instance{{IP: "10.0.0.1", Port: 80}}},
+			}}
+			clusterStorager.cluster.LLMConfig = &LLMConfig{
+				Enable:        lib.PBool(true),
+				ModelEndpoint: &Endpoint{Schema: "http", URI: "/v1/models"},
+				Models:        []string{"a", "b"},
+			}
+
+			syncStorager := &fakeModelSyncStorager{}
+			m := NewModelSyncManager(fakeTxn{}, syncStorager, clusterStorager, &fakeModelSyncAPIKeyStorager{},
+				&fakeModelSyncProductStorager{}, newTestModelProviderManager(), &stateful.ModelSyncConfig{AutoApply: true})
+			m.callModelAPI = func(ctx context.Context, param *ModelEndpointRequest) ([]byte, error) {
+				return []byte(c.body), nil
+			}
+
+			rst, err := m.SyncCluster(context.Background(), clusterStorager.cluster)
+			if err != nil {
+				t.Fatalf("SyncCluster() error = %v", err)
+			}
+			if rst.Status != c.wantStatus {
+				t.Errorf("status = %s, want %s, err_msg %s", rst.Status, c.wantStatus, rst.ErrMsg)
+			}
+			if models := clusterStorager.cluster.LLMConfig.Models; !reflect.DeepEqual(models, c.wantModels) {
+				t.Errorf("models = %v, want %v", models, c.wantModels)
+			}
+		})
+	}
+}
+
+func TestApproveModelSyncRemovingAll(t *testing.T) {
+	clusterStorager := newTestClusterStorager()
+	clusterStorager.cluster.LLMConfig = &LLMConfig{Enable: lib.PBool(true), Models: []string{"a"}}
+	syncStorager := &fakeModelSyncStorager{
+		rst: &ModelSync{ClusterID: 1, Status: ModelSyncStatusPending, Added: []string{}, Removed: []string{"a"}},
+	}
+	m := NewModelSyncManager(fakeTxn{}, syncStorager, clusterStorager, nil, nil, nil, nil)
+
+	if _, err := m.ApproveModelSync(context.Background(), &ibasic.Product{ID: 1}, clusterStorager.cluster, "admin"); err == nil {
+		t.Fatalf("ApproveModelSync() want error when no model left")
+	}
+	if models := clusterStorager.cluster.LLMConfig.Models; !reflect.DeepEqual(models, []string{"a"}) {
+		t.Errorf("models = %v, want unchanged", models)
+	}
+}
@@ -34,6 +34,9 @@ type PoolFilter struct {
 	IDs       []int64
 	ID        *int64
 	ProductID *int64
+
+	// ForUpdate locks fetched pools until txn ends
+	ForUpdate bool
 }
 
 type PoolParam struct {
@@ -62,6 +65,9 @@ type Instance struct {
 	Tags     map[string]string `json:"tags,omitempty"`
 	Weight   int64             `json:"Weight"`
 	Disable  bool              `json:"Disable"`
+
+	// Drain is not nil after drain be started, cleared when weight be set again
+	Drain *InstanceDrain `json:"Drain,omitempty"`
 }
 
 func (i *Instance) IPWithPort() string {
@@ -243,13 +249,26 @@ func (rppm *PoolManager) CreateProductPool(ctx context.Context, product *ibasic.
 	return
 }
 
-func (rppm *PoolManager) UpdateBFEPool(ctx context.Context, pool *Pool, diff *PoolParam) (err error) {
+func (rppm *PoolManager) UpdateBFEPool(ctx context.Context, pool *Pool, diff *PoolParam) (*Pool, error) {
 	return rppm.UpdateProductPool(ctx, ibasic.BuildinProduct, pool, diff)
 }
 
-func (rppm *PoolManager) UpdateProductPool(ctx context.Context, product *ibasic.Product, pool *Pool, diff *PoolParam) (err error) {
+// UpdateProductPool replaces instances of pool with the ones of diff, see keepInstanceDrains.
+// It returns the pool saved
+func (rppm *PoolManager) UpdateProductPool(ctx context.Context, product *ibasic.Product, pool *Pool,
+	diff *PoolParam) (rst *Pool, err error) {
+
 	err = rppm.txn.AtomExecute(ctx, func(ctx context.Context) error {
-		return rppm.storager.UpdatePool(ctx, pool, diff)
+		rst, err = lockPool(ctx, rppm.storager, pool.Name)
+		if err != nil {
+			return err
+		}
+		if rst == nil {
+			return xerror.WrapRecordNotExist("Instance Pool")
+		}
+
+		rst.Instances = keepInstanceDrains(rst.Instances, diff.Instances)
+		return rppm.savePoolInstances(ctx, rst)
 	})
 
 	return
@@ -0,0 +1,756 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/itxn"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+const (
+	DiscoverySourceDNS        = "dns"
+	DiscoverySourceFile       = "file"
+	DiscoverySourceKubernetes = "kubernetes"
+
+	DNSRecordA    = "A"
+	DNSRecordAAAA = "AAAA"
+	DNSRecordSRV  = "SRV"
+
+	DiscoveryFileJSON = "json"
+	DiscoveryFileYAML = "yaml"
+
+	PoolDiscoveryStatusSynced = "synced" // instances of pool same as discovered
+	PoolDiscoveryStatusFailed = "failed" // resolve source fail or discovered instances can not serve traffic
+
+	MaxDiscoverySources     = 16
+	DefaultDiscoveredWeight = 1
+)
+
+// DiscoverySource is where instances of pool are discovered from
+type DiscoverySource struct {
+	Type string `json:"type"` // see DiscoverySourceXXX
+
+	// dns source, A/AAAA records are resolved to instances on Port,
+	// SRV records are resolved to instances on targets with port and weight of records
+	Name       string `json:"name,omitempty"`
+	RecordType string `json:"record_type,omitempty"` // see DNSRecordXXX
+	Port       int    `json:"port,omitempty"`
+	Server     string `json:"server,omitempty"` // one of PoolDiscovery.DNSServers, system resolver is used if empty
+
+	// file source, a json/yaml list of instances, see DiscoveryFileEntry
+	Path   string `json:"path,omitempty"`   // relative to sub directory of product in PoolDiscovery.FileDir
+	Format string `json:"format,omitempty"` // see DiscoveryFileXXX, by extension of Path if empty
+
+	// kubernetes source, ready endpoints of services in Namespace selected by Service and LabelSelector
+	// are resolved to instances on port named PortName or numbered Port, see KubernetesDiscoverer
+	Namespace     string `json:"namespace,omitempty"`
+	Service       string `json:"service,omitempty"`
+	LabelSelector string `json:"label_selector,omitempty"`
+	PortName      string `json:"port_name,omitempty"`
+
+	// Weight of instances resolved from A/AAAA records, kubernetes endpoints or file entries without weight
+	Weight int64             `json:"weight,omitempty"`
+	Tags   map[string]string `json:"tags,omitempty"`
+}
+
+// DiscoveryFileEntry is one instance in file source
+type DiscoveryFileEntry struct {
+	Name   string            `json:"name" yaml:"name"` // addr:port if empty
+	Addr   string            `json:"addr" yaml:"addr"`
+	Port   int               `json:"port" yaml:"port"`
+	Weight *int64            `json:"weight" yaml:"weight"`
+	Tags   map[string]string `json:"tags" yaml:"tags"`
+}
+
+// DiscoveredInstance is an instance resolved from discovery sources
+type DiscoveredInstance struct {
+	Name   string            `json:"name"`
+	IP     string            `json:"ip"`
+	Port   int               `json:"port"`
+	Weight int64             `json:"weight"`
+	Tags   map[string]string `json:"tags,omitempty"`
+}
+
+// PoolDiscovery is the discovery sources of pool and result of the latest sync
+type PoolDiscovery struct {
+	PoolID    int64  `json:"-"`
+	ProductID int64  `json:"-"`
+	PoolName  string `json:"pool_name"`
+
+	Sources    []*DiscoverySource    `json:"sources"`
+	Status     string                `json:"status"`
+	Discovered []*DiscoveredInstance `json:"discovered"`
+	ErrMsg     string                `json:"err_msg,omitempty"`
+	SyncedAt   time.Time             `json:"synced_at"`
+}
+
+type PoolDiscoveryStorager interface {
+	// FetchPoolDiscovery return (nil, nil) if discovery of pool not set
+	FetchPoolDiscovery(ctx context.Context, poolID int64) (*PoolDiscovery, error)
+	FetchPoolDiscoveries(ctx context.Context) ([]*PoolDiscovery, error)
+	UpsertPoolDiscovery(ctx context.Context, param *PoolDiscovery) error
+	DeletePoolDiscovery(ctx context.Context, poolID int64) error
+}
+
+// PoolDiscoveryManager syncs instances of pools from their discovery sources
+type PoolDiscoveryManager struct {
+	txn         itxn.TxnStorager
+	storager    PoolDiscoveryStorager
+	poolManager *PoolManager
+	conf        *stateful.PoolDiscoveryConfig
+
+	// kubernetes source is not allowed if nil
+	kubernetes *KubernetesDiscoverer
+
+	resolve func(ctx context.Context, product string, source *DiscoverySource) ([]*DiscoveredInstance, error)
+	now     func() time.Time
+}
+
+// NewPoolDiscoveryManager creates manager, kubernetes is nil if KubernetesDiscovery not enabled
+func NewPoolDiscoveryManager(txn itxn.TxnStorager, storager PoolDiscoveryStorager, poolManager *PoolManager,
+	kubernetes *KubernetesDiscoverer, conf *stateful.PoolDiscoveryConfig) *PoolDiscoveryManager {
+
+	m := &PoolDiscoveryManager{
+		txn:         txn,
+		storager:    storager,
+		poolManager: poolManager,
+		kubernetes:  kubernetes,
+		conf:        conf,
+		now:         time.Now,
+	}
+	m.resolve = m.resolveSource
+
+	return m
+}
+
+// checkDiscoverySources checks sources of pool in product and fills default values
+func (m *PoolDiscoveryManager) checkDiscoverySources(product string, sources []*DiscoverySource) error {
+	if len(sources) == 0 || len(sources) > MaxDiscoverySources {
+		return xerror.WrapParamErrorWithMsg("Sources Size Must Be In [1, %d]", MaxDiscoverySources)
+	}
+
+	for i, one := range sources {
+		if one == nil {
+			return xerror.WrapParamErrorWithMsg("Source %d Is Null", i)
+		}
+		if one.Weight < 0 || one.Weight > MaxInstanceWeight {
+			return xerror.WrapParamErrorWithMsg("Source %d Weight Must Be In [0, %d]", i, MaxInstanceWeight)
+		}
+		if one.Weight == 0 {
+			one.Weight = DefaultDiscoveredWeight
+		}
+		if err := checkSourceFields(i, one); err != nil {
+			return err
+		}
+
+		var err error
+		switch one.Type {
+		case DiscoverySourceDNS:
+			err = m.checkDNSSource(i, one)
+		case DiscoverySourceFile:
+			err = m.checkFileSource(i, one)
+		case DiscoverySourceKubernetes:
+			if m.kubernetes == nil {
+				return xerror.WrapParamErrorWithMsg("Kubernetes Source Not Allowed, KubernetesDiscovery Not Enabled")
+			}
+			err = m.kubernetes.checkSource(i, product, one)
+		default:
+			err = xerror.WrapParamErrorWithMsg("Source %d Type Must Be One Of %s, %s, %s", i,
+				DiscoverySourceDNS, DiscoverySourceFile, DiscoverySourceKubernetes)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkSourceFields makes sure fields only for other types of sources are not set
+func checkSourceFields(i int, source *DiscoverySource) error {
+	fields := []struct {
+		typ string
+		set bool
+	}{
+		{DiscoverySourceDNS, source.Name != "" || source.RecordType != "" || source.Server != ""},
+		{DiscoverySourceFile, source.Path != "" || source.Format != ""},
+		{DiscoverySourceKubernetes, source.Namespace != "" || source.Service != "" ||
+			source.LabelSelector != "" || source.PortName != ""},
+	}
+	for _, one := range fields {
+		if one.set && one.typ != source.Type {
+			return xerror.WrapParamErrorWithMsg("Source %d Is %s Source, But Fields Of %s Source Are Set", i,
+				source.Type, one.typ)
+		}
+	}
+
+	if source.Type == DiscoverySourceFile && source.Port != 0 {
+		return xerror.WrapParamErrorWithMsg("Source %d Port Not For %s Source", i, DiscoverySourceFile)
+	}
+
+	return nil
+}
+
+func (m *PoolDiscoveryManager) checkDNSSource(i int, source *DiscoverySource) error {
+	if source.Name == "" {
+		return xerror.WrapParamErrorWithMsg("Source %d Name Required", i)
+	}
+	if !m.dnsServerAllowed(source.Server) {
+		return xerror.WrapParamErrorWithMsg("Source %d Server Must Be One Of PoolDiscovery.DNSServers", i)
+	}
+
+	source.RecordType = strings.ToUpper(source.RecordType)
+	switch source.RecordType {
+	case DNSRecordA, DNSRecordAAAA:
+		if source.Port <= 0 || source.Port > 65535 {
+			return xerror.WrapParamErrorWithMsg("Source %d Port Must Be In [1, 65535]", i)
+		}
+	case DNSRecordSRV:
+		if source.Port != 0 {
+			return xerror.WrapParamErrorWithMsg("Source %d Port Is Given By SRV Records", i)
+		}
+	default:
+		return xerror.WrapParamErrorWithMsg("Source %d Record Type Must Be One Of %s, %s, %s", i,
+			DNSRecordA, DNSRecordAAAA, DNSRecordSRV)
+	}
+
+	return nil
+}
+
+// dnsServerAllowed tells whether dns server chosen by tenant is allowed, it could be any address
+// reachable from API server otherwise
+func (m *PoolDiscoveryManager) dnsServerAllowed(server string) bool {
+	return server == "" || lib.StringSliceHasElement(m.conf.DNSServers, server)
+}
+
+func (m *PoolDiscoveryManager) checkFileSource(i int, source *DiscoverySource) error {
+	if m.conf.FileDir == "" {
+		return xerror.WrapParamErrorWithMsg("File Source Not Allowed, PoolDiscovery.FileDir Not Set")
+	}
+	if !filepath.IsLocal(source.Path) {
+		return xerror.WrapParamErrorWithMsg("Source %d Path Must Be A Relative Path In Directory Of Product", i)
+	}
+
+	if source.Format == "" {
+		switch strings.ToLower(filepath.Ext(source.Path)) {
+		case ".json":
+			source.Format = DiscoveryFileJSON
+		case ".yaml", ".yml":
+			source.Format = DiscoveryFileYAML
+		}
+	}
+	if source.Format != DiscoveryFileJSON && source.Format != DiscoveryFileYAML {
+		return xerror.WrapParamErrorWithMsg("Source %d Format Must Be %s Or %s", i, DiscoveryFileJSON, DiscoveryFileYAML)
+	}
+
+	return nil
+}
+
+// FetchPoolDiscovery return discovery of pool, (nil, nil) if not set
+func (m *PoolDiscoveryManager) FetchPoolDiscovery(ctx context.Context, pool *Pool) (rst *PoolDiscovery, err error) {
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		rst, err = m.storager.FetchPoolDiscovery(ctx, pool.ID)
+		return err
+	})
+	if rst != nil {
+		rst.PoolName = pool.Name
+	}
+
+	return
+}
+
+// SetPoolDiscovery sets discovery sources of pool and syncs pool immediately.
+// Instances discovered by old sources are removed at the sync if not discovered by new ones.
+func (m *PoolDiscoveryManager) SetPoolDiscovery(ctx context.Context, pool *Pool,
+	sources []*DiscoverySource) (*PoolDiscovery, error) {
+
+	if err := m.checkDiscoverySources(pool.Product.Name, sources); err != nil {
+		return nil, err
+	}
+
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		rst, err := m.storager.FetchPoolDiscovery(ctx, pool.ID)
+		if err != nil {
+			return err
+		}
+		if rst == nil {
+			rst = &PoolDiscovery{
+				PoolID:     pool.ID,
+				ProductID:  pool.Product.ID,
+				Status:     PoolDiscoveryStatusSynced,
+				Discovered: []*DiscoveredInstance{},
+			}
+		}
+
+		rst.Sources = sources
+		return m.storager.UpsertPoolDiscovery(ctx, rst)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.SyncPool(ctx, pool)
+}
+
+// DeletePoolDiscovery removes discovery sources of pool, instances of pool are kept
+func (m *PoolDiscoveryManager) DeletePoolDiscovery(ctx context.Context, pool *Pool) (rst *PoolDiscovery, err error) {
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		rst, err = m.storager.FetchPoolDiscovery(ctx, pool.ID)
+		if err != nil {
+			return err
+		}
+		if rst == nil {
+			return xerror.WrapRecordNotExist("Pool Discovery")
+		}
+
+		return m.storager.DeletePoolDiscovery(ctx, pool.ID)
+	})
+	if rst != nil {
+		rst.PoolName = pool.Name
+	}
+
+	return
+}
+
+// Run sync until ctx done
+func (m *PoolDiscoveryManager) Run(ctx context.Context) {
+	defer lib.Recover("PoolDiscoveryManager")
+
+	ticker := time.NewTicker(time.Duration(m.conf.IntervalInS) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := m.SyncAll(lib.NewLogContext(ctx)); err != nil {
+			stateful.AccessLogger.Warn("PoolDiscoveryManager sync fail: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncAll syncs all pools with discovery sources, fail of one pool is recorded and not stop others
+func (m *PoolDiscoveryManager) SyncAll(ctx context.Context) error {
+	return m.syncPools(ctx, func(*PoolDiscovery) bool {
+		return true
+	})
+}
+
+// SyncKubernetesPools syncs pools with kubernetes sources
+func (m *PoolDiscoveryManager) SyncKubernetesPools(ctx context.Context) error {
+	return m.syncPools(ctx, func(one *PoolDiscovery) bool {
+		for _, source := range one.Sources {
+			if source.Type == DiscoverySourceKubernetes {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// syncPools syncs pools whose discovery matched by filter
+func (m *PoolDiscoveryManager) syncPools(ctx context.Context, filter func(*PoolDiscovery) bool) error {
+	var pools []*Pool
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := m.storager.FetchPoolDiscoveries(ctx)
+		if err != nil {
+			return err
+		}
+
+		var ids []int64
+		for _, one := range list {
+			if filter(one) {
+				ids = append(ids, one.PoolID)
+			}
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		pools, err = m.poolManager.storager.FetchPools(ctx, &PoolFilter{
+			IDs: ids,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		if _, err := m.SyncPool(ctx, pool); err != nil {
+			stateful.AccessLogger.Warn("PoolDiscoveryManager sync pool %s fail: %v", pool.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// SyncPool resolves discovery sources of pool and syncs instances of pool with them.
+// Pool is not changed if any source fails, so a broken source will not remove instances.
+func (m *PoolDiscoveryManager) SyncPool(ctx context.Context, pool *Pool) (*PoolDiscovery, error) {
+	old, err := m.FetchPoolDiscovery(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	if old == nil {
+		return nil, xerror.WrapRecordNotExist("Pool Discovery")
+	}
+
+	// resolve sources out of transaction
+	discovered, resolveErr := m.resolveAll(ctx, pool.Product.Name, old.Sources)
+
+	var rst *PoolDiscovery
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		rst, err = m.storager.FetchPoolDiscovery(ctx, pool.ID)
+		if err != nil {
+			return err
+		}
+		if rst == nil {
+			return xerror.WrapRecordNotExist("Pool Discovery")
+		}
+		rst.SyncedAt = m.now()
+
+		// sources changed after resolving, the newer sync will take effect
+		if !reflect.DeepEqual(rst.Sources, old.Sources) {
+			return nil
+		}
+
+		if resolveErr != nil {
+			rst.Status, rst.ErrMsg = PoolDiscoveryStatusFailed, resolveErr.Error()
+			return m.storager.UpsertPoolDiscovery(ctx, rst)
+		}
+
+		one, err := lockPool(ctx, m.poolManager.storager, pool.Name)
+		if err != nil {
+			return err
+		}
+		if one == nil {
+			return xerror.WrapRecordNotExist("Instance Pool")
+		}
+
+		instances, changed := mergeDiscovered(one.Instances, rst.Discovered, discovered)
+		if changed {
+			one.Instances = instances
+			if err := checkPoolAvailable(one); err != nil {
+				rst.Status, rst.ErrMsg = PoolDiscoveryStatusFailed, xerror.Cause(err).Error()
+				return m.storager.UpsertPoolDiscovery(ctx, rst)
+			}
+
+			if err := m.poolManager.savePoolInstances(ctx, one); err != nil {
+				return err
+			}
+		}
+
+		rst.Status, rst.ErrMsg = PoolDiscoveryStatusSynced, ""
+		rst.Discovered = discovered
+		return m.storager.UpsertPoolDiscovery(ctx, rst)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rst.PoolName = pool.Name
+	return rst, nil
+}
+
+// mergeDiscovered syncs instances of pool with discovered ones, return true if instances changed.
+// Instances discovered last time but not any more are removed, instances never discovered
+// are added by user and kept. Disable, drain and weight set by user (differs from the weight
+// discovered last time) are kept.
+func mergeDiscovered(instances []Instance, last, discovered []*DiscoveredInstance) ([]Instance, bool) {
+	lastMap := map[string]*DiscoveredInstance{}
+	for _, one := range last {
+		lastMap[one.Name] = one
+	}
+	discoveredMap := map[string]*DiscoveredInstance{}
+	for _, one := range discovered {
+		discoveredMap[one.Name] = one
+	}
+
+	rst := []Instance{}
+	exist := map[string]bool{}
+	for _, instance := range instances {
+		one, ok := discoveredMap[instance.HostName]
+		if !ok {
+			if lastMap[instance.HostName] == nil {
+				rst = append(rst, instance)
+			}
+			continue
+		}
+
+		exist[one.Name] = true
+		ports := map[string]int{}
+		for k, v := range instance.Ports {
+			ports[k] = v
+		}
+		ports["Default"] = one.Port
+		instance.IP, instance.Port, instance.Ports = one.IP, one.Port, ports
+
+		if prev := lastMap[one.Name]; prev != nil && prev.Weight == instance.Weight && instance.Drain == nil {
+			instance.Weight = one.Weight
+		}
+
+		rst = append(rst, instance)
+	}
+
+	for _, one := range discovered {
+		if exist[one.Name] {
+			continue
+		}
+
+		rst = append(rst, Instance{
+			HostName: one.Name,
+			IP:       one.IP,
+			Port:     one.Port,
+			Ports:    map[string]int{"Default": one.Port},
+			Tags:     one.Tags,
+			Weight:   one.Weight,
+		})
+	}
+
+	return rst, !reflect.DeepEqual(rst, instances)
+}
+
+// resolveAll resolves all sources, instances with same name are merged and the first one is used
+func (m *PoolDiscoveryManager) resolveAll(ctx context.Context, product string,
+	sources []*DiscoverySource) ([]*DiscoveredInstance, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(m.conf.TimeoutInS)*time.Second)
+	defer cancel()
+
+	rst := []*DiscoveredInstance{}
+	exist := map[string]bool{}
+	for i, source := range sources {
+		list, err := m.resolve(ctx, product, source)
+		if err != nil {
+			return nil, fmt.Errorf("source %d: %v", i, err)
+		}
+
+		for _, one := range list {
+			if !exist[one.Name] {
+				exist[one.Name] = true
+				rst = append(rst, one)
+			}
+		}
+	}
+
+	if len(rst) == 0 {
+		return nil, fmt.Errorf("no instance discovered")
+	}
+
+	return rst, nil
+}
+
+func (m *PoolDiscoveryManager) resolveSource(ctx context.Context, product string,
+	source *DiscoverySource) ([]*DiscoveredInstance, error) {
+
+	switch source.Type {
+	case DiscoverySourceDNS:
+		// sources saved before the server be removed from config
+		if !m.dnsServerAllowed(source.Server) {
+			return nil, fmt.Errorf("dns server %s not allowed", source.Server)
+		}
+		return resolveDNSSource(ctx, source)
+	case DiscoverySourceFile:
+		return m.resolveFileSource(product, source)
+	case DiscoverySourceKubernetes:
+		if m.kubernetes == nil {
+			return nil, fmt.Errorf("kubernetes discovery not enabled")
+		}
+		return m.kubernetes.resolve(product, source)
+	}
+
+	return nil, fmt.Errorf("unknown source type %s", source.Type)
+}
+
+func newResolver(server string) *net.Resolver {
+	if server == "" {
+		return net.DefaultResolver
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{}
+			return d.DialContext(ctx, network, server)
+		},
+	}
+}
+
+func resolveDNSSource(ctx context.Context, source *DiscoverySource) ([]*DiscoveredInstance, error) {
+	resolver := newResolver(source.Server)
+
+	if source.RecordType == DNSRecordSRV {
+		_, records, err := resolver.LookupSRV(ctx, "", "", source.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		var rst []*DiscoveredInstance
+		for _, record := range records {
+			target := strings.TrimSuffix(record.Target, ".")
+			ips, err := resolver.LookupIP(ctx, "ip", target)
+			if err != nil {
+				return nil, err
+			}
+
+			ip := ips[0]
+			for _, one := range ips {
+				if one.To4() != nil {
+					ip = one
+					break
+				}
+			}
+
+			weight := int64(record.Weight)
+			if weight == 0 {
+				weight = 1
+			}
+			if weight > MaxInstanceWeight {
+				weight = MaxInstanceWeight
+			}
+
+			rst = append(rst, &DiscoveredInstance{
+				Name:   net.JoinHostPort(target, fmt.Sprint(record.Port)),
+				IP:     ip.String(),
+				Port:   int(record.Port),
+				Weight: weight,
+				Tags:   source.Tags,
+			})
+		}
+
+		return rst, nil
+	}
+
+	network := "ip4"
+	if source.RecordType == DNSRecordAAAA {
+		network = "ip6"
+	}
+	ips, err := resolver.LookupIP(ctx, network, source.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []*DiscoveredInstance
+	for _, ip := range ips {
+		rst = append(rst, &DiscoveredInstance{
+			Name:   net.JoinHostPort(ip.String(), fmt.Sprint(source.Port)),
+			IP:     ip.String(),
+			Port:   source.Port,
+			Weight: source.Weight,
+			Tags:   source.Tags,
+		})
+	}
+
+	return rst, nil
+}
+
+// openDiscoveryFile returns content of file source in directory of product,
+// symbolic links pointing out of the directory are refused
+func openDiscoveryFile(fileDir, product, path string) ([]byte, error) {
+	if fileDir == "" || !filepath.IsLocal(product) || !filepath.IsLocal(path) {
+		return nil, fmt.Errorf("file source not allowed")
+	}
+
+	root, err := filepath.EvalSymlinks(filepath.Join(fileDir, product))
+	if err != nil {
+		return nil, err
+	}
+	file, err := filepath.EvalSymlinks(filepath.Join(root, path))
+	if err != nil {
+		return nil, err
+	}
+	if rel, err := filepath.Rel(root, file); err != nil || !filepath.IsLocal(rel) {
+		return nil, fmt.Errorf("path %s is out of directory of product", path)
+	}
+
+	return os.ReadFile(file)
+}
+
+func (m *PoolDiscoveryManager) resolveFileSource(product string, source *DiscoverySource) ([]*DiscoveredInstance, error) {
+	bs, err := openDiscoveryFile(m.conf.FileDir, product, source.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*DiscoveryFileEntry
+	if source.Format == DiscoveryFileYAML {
+		err = yaml.Unmarshal(bs, &entries)
+	} else {
+		err = json.Unmarshal(bs, &entries)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s fail: %v", source.Path, err)
+	}
+
+	var rst []*DiscoveredInstance
+	for i, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		ip := net.ParseIP(entry.Addr)
+		if ip == nil {
+			return nil, fmt.Errorf("entry %d: addr %q is not an ip", i, entry.Addr)
+		}
+		if entry.Port <= 0 || entry.Port > 65535 {
+			return nil, fmt.Errorf("entry %d: port must be in [1, 65535]", i)
+		}
+
+		weight := source.Weight
+		if entry.Weight != nil {
+			weight = *entry.Weight
+		}
+		if weight < 0 || weight > MaxInstanceWeight {
+			return nil, fmt.Errorf("entry %d: weight must be in [0, %d]", i, MaxInstanceWeight)
+		}
+
+		name := entry.Name
+		if name == "" {
+			name = net.JoinHostPort(ip.String(), fmt.Sprint(entry.Port))
+		}
+
+		tags := entry.Tags
+		if tags == nil {
+			tags = source.Tags
+		}
+
+		rst = append(rst, &DiscoveredInstance{
+			Name:   name,
+			IP:     ip.String(),
+			Port:   entry.Port,
+			Weight: weight,
+			Tags:   tags,
+		})
+	}
+
+	return rst, nil
+}
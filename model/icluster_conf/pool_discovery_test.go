@@ -0,0 +1,231 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+// startDNSStub serves A and SRV records on a local udp port, returns its address
+func startDNSStub(t *testing.T, a map[string][]string, srv map[string][]dnsmessage.SRVResource) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var req dnsmessage.Message
+			if err := req.Unpack(buf[:n]); err != nil || len(req.Questions) == 0 {
+				continue
+			}
+			q := req.Questions[0]
+			name := q.Name.String()
+
+			rsp := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: req.ID, Response: true, Authoritative: true},
+				Questions: req.Questions,
+			}
+			hdr := dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: 60}
+			switch q.Type {
+			case dnsmessage.TypeA:
+				for _, ip := range a[name] {
+					var one [4]byte
+					copy(one[:], net.ParseIP(ip).To4())
+					hdr.Type = dnsmessage.TypeA
+					rsp.Answers = append(rsp.Answers, dnsmessage.Resource{Header: hdr, Body: &dnsmessage.AResource{A: one}})
+				}
+			case dnsmessage.TypeSRV:
+				for i := range srv[name] {
+					hdr.Type = dnsmessage.TypeSRV
+					rsp.Answers = append(rsp.Answers, dnsmessage.Resource{Header: hdr, Body: &srv[name][i]})
+				}
+			}
+			if len(rsp.Answers) == 0 && a[name] == nil && srv[name] == nil {
+				rsp.RCode = dnsmessage.RCodeNameError
+			}
+
+			bs, err := rsp.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(bs, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestResolveDNSSource(t *testing.T) {
+	server := startDNSStub(t,
+		map[string][]string{
+			"svc.test.":   {"10.0.0.1", "10.0.0.2"},
+			"node1.test.": {"10.0.0.3"},
+		},
+		map[string][]dnsmessage.SRVResource{
+			"_http._tcp.svc.test.": {{Target: dnsmessage.MustNewName("node1.test."), Port: 8080, Weight: 5}},
+		})
+
+	m := NewPoolDiscoveryManager(nil, nil, nil, nil, &stateful.PoolDiscoveryConfig{
+		DNSServers: []string{server},
+	})
+
+	cases := []struct {
+		name    string
+		source  *DiscoverySource
+		want    []*DiscoveredInstance
+		wantErr bool
+	}{
+		{
+			name:   "A records",
+			source: &DiscoverySource{Type: DiscoverySourceDNS, Name: "svc.test.", RecordType: DNSRecordA, Port: 80, Weight: 2, Server: server},
+			want: []*DiscoveredInstance{
+				{Name: "10.0.0.1:80", IP: "10.0.0.1", Port: 80, Weight: 2},
+				{Name: "10.0.0.2:80", IP: "10.0.0.2", Port: 80, Weight: 2},
+			},
+		},
+		{
+			name:   "SRV records",
+			source: &DiscoverySource{Type: DiscoverySourceDNS, Name: "_http._tcp.svc.test.", RecordType: DNSRecordSRV, Server: server},
+			want: []*DiscoveredInstance{
+				{Name: "node1.test:8080", IP: "10.0.0.3", Port: 8080, Weight: 5},
+			},
+		},
+		{
+			name:    "name not exist",
+			source:  &DiscoverySource{Type: DiscoverySourceDNS, Name: "none.test.", RecordType: DNSRecordA, Port: 80, Server: server},
+			wantErr: true,
+		},
+		{
+			name:    "server not allowed",
+			source:  &DiscoverySource{Type: DiscoverySourceDNS, Name: "svc.test.", RecordType: DNSRecordA, Port: 80, Server: "127.0.0.1:1"},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := m.resolveSource(context.Background(), "product", c.source)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("resolveSource() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if !c.wantErr && !reflect.DeepEqual(got, c.want) {
+				t.Errorf("resolveSource() = %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestCheckDNSSourceServer(t *testing.T) {
+	m := NewPoolDiscoveryManager(nil, nil, nil, nil, &stateful.PoolDiscoveryConfig{
+		DNSServers: []string{"10.0.0.53:53"},
+	})
+
+	cases := []struct {
+		server  string
+		wantErr bool
+	}{
+		{server: ""},
+		{server: "10.0.0.53:53"},
+		{server: "10.0.0.53:54", wantErr: true},
+		{server: "169.254.169.254:53", wantErr: true},
+	}
+
+	for _, c := range cases {
+		source := &DiscoverySource{Type: DiscoverySourceDNS, Name: "svc.test", RecordType: DNSRecordA, Port: 80, Server: c.server}
+		if err := m.checkDiscoverySources("product", []*DiscoverySource{source}); (err != nil) != c.wantErr {
+			t.Errorf("server %q error = %v, wantErr %v", c.server, err, c.wantErr)
+		}
+	}
+}
+
+func TestResolveFileSource(t *testing.T) {
+	dir := t.TempDir()
+	write := func(path, content string) {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("product/pool.json", `[{"addr": "10.0.0.1", "port": 80}, {"name": "b", "addr": "10.0.0.2", "port": 81, "weight": 3}]`)
+	write("product/sub/pool.yaml", "- addr: 10.0.0.4\n  port: 80\n")
+	write("product/bad_port.json", `[{"addr": "10.0.0.1", "port": 0}]`)
+	write("other/pool.json", `[{"addr": "10.0.0.9", "port": 80}]`)
+	write("secret.json", `[{"addr": "10.0.0.8", "port": 80}]`)
+	if err := os.Symlink(filepath.Join(dir, "secret.json"), filepath.Join(dir, "product", "escape.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("pool.json", filepath.Join(dir, "product", "inner.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewPoolDiscoveryManager(nil, nil, nil, nil, &stateful.PoolDiscoveryConfig{FileDir: dir})
+
+	cases := []struct {
+		name    string
+		path    string
+		format  string
+		want    []string
+		wantErr bool
+	}{
+		{name: "json", path: "pool.json", format: DiscoveryFileJSON, want: []string{"10.0.0.1:80", "b"}},
+		{name: "yaml in sub directory", path: "sub/pool.yaml", format: DiscoveryFileYAML, want: []string{"10.0.0.4:80"}},
+		{name: "symlink in product directory", path: "inner.json", format: DiscoveryFileJSON, want: []string{"10.0.0.1:80", "b"}},
+		{name: "symlink out of product directory", path: "escape.json", format: DiscoveryFileJSON, wantErr: true},
+		{name: "file of other product", path: "../other/pool.json", format: DiscoveryFileJSON, wantErr: true},
+		{name: "absolute path", path: filepath.Join(dir, "secret.json"), format: DiscoveryFileJSON, wantErr: true},
+		{name: "invalid entry", path: "bad_port.json", format: DiscoveryFileJSON, wantErr: true},
+		{name: "not exist", path: "none.json", format: DiscoveryFileJSON, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			source := &DiscoverySource{Type: DiscoverySourceFile, Path: c.path, Format: c.format, Weight: 1}
+			got, err := m.resolveSource(context.Background(), "product", source)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("resolveSource() error = %v, wantErr %v", err, c.wantErr)
+			}
+
+			var names []string
+			for _, one := range got {
+				names = append(names, one.Name)
+			}
+			if !reflect.DeepEqual(names, c.want) {
+				t.Errorf("resolveSource() = %v, want %v", names, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,477 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/itxn"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+const (
+	MaxInstanceWeight = 100
+
+	DefaultDrainSteps   = 10
+	MaxDrainSteps       = 100
+	MaxDrainDurationInS = 86400
+)
+
+// InstanceDrain records a drain of instance, weight of instance is stepped down
+// from OriginalWeight to zero in Steps during DurationInS
+type InstanceDrain struct {
+	OriginalWeight int64      `json:"OriginalWeight"`
+	Steps          int64      `json:"Steps"`
+	DurationInS    int64      `json:"DurationInS"`
+	StartedAt      time.Time  `json:"StartedAt"`
+	FinishedAt     *time.Time `json:"FinishedAt,omitempty"`
+}
+
+// InstanceDrainProgress is the progress of drain at some time
+type InstanceDrainProgress struct {
+	OriginalWeight int64      `json:"original_weight"`
+	CurrentWeight  int64      `json:"current_weight"`
+	Steps          int64      `json:"steps"`
+	CurrentStep    int64      `json:"current_step"`
+	DurationInS    int64      `json:"duration_in_s"`
+	Percent        int64      `json:"percent"`
+	Finished       bool       `json:"finished"`
+	StartedAt      time.Time  `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+}
+
+// stepAt return the step reached at now, range [0, Steps]
+func (d *InstanceDrain) stepAt(now time.Time) int64 {
+	if d.FinishedAt != nil {
+		return d.Steps
+	}
+
+	elapsed := now.Sub(d.StartedAt)
+	if elapsed <= 0 {
+		return 0
+	}
+
+	duration := time.Duration(d.DurationInS) * time.Second
+	if elapsed >= duration {
+		return d.Steps
+	}
+
+	return int64(elapsed) * d.Steps / int64(duration)
+}
+
+// weightAt return the weight of instance at step
+func (d *InstanceDrain) weightAt(step int64) int64 {
+	return d.OriginalWeight * (d.Steps - step) / d.Steps
+}
+
+// Progress return the progress of drain at now
+func (d *InstanceDrain) Progress(now time.Time) *InstanceDrainProgress {
+	step := d.stepAt(now)
+	return &InstanceDrainProgress{
+		OriginalWeight: d.OriginalWeight,
+		CurrentWeight:  d.weightAt(step),
+		Steps:          d.Steps,
+		CurrentStep:    step,
+		DurationInS:    d.DurationInS,
+		Percent:        step * 100 / d.Steps,
+		Finished:       step == d.Steps,
+		StartedAt:      d.StartedAt,
+		FinishedAt:     d.FinishedAt,
+	}
+}
+
+// Draining return true if drain of instance is started and not finished
+func (i *Instance) Draining() bool {
+	return i.Drain != nil && i.Drain.FinishedAt == nil
+}
+
+// stepDrain sets weight of draining instance by now, return true if instance be changed
+func (i *Instance) stepDrain(now time.Time) bool {
+	if !i.Draining() {
+		return false
+	}
+
+	step := i.Drain.stepAt(now)
+	weight := i.Drain.weightAt(step)
+	if step == i.Drain.Steps {
+		i.Drain.FinishedAt = &now
+	} else if weight == i.Weight {
+		return false
+	}
+
+	i.Weight = weight
+	return true
+}
+
+// available return true if instance will still receive traffic after drains finished
+func (i *Instance) available() bool {
+	return !i.Disable && i.Weight > 0 && !i.Draining()
+}
+
+// keepInstanceDrains returns instances replacing olds. Drained instances whose address and switch
+// are unchanged keep their drain and weight, which belong to PoolDrainRunner. Set weight of instance
+// by UpdatePoolInstance to cancel its drain
+func keepInstanceDrains(olds, instances []Instance) []Instance {
+	drained := map[string]*Instance{}
+	for i := range olds {
+		if olds[i].Drain != nil {
+			drained[olds[i].HostName] = &olds[i]
+		}
+	}
+
+	rst := make([]Instance, 0, len(instances))
+	for _, one := range instances {
+		one.Drain = nil
+		if old := drained[one.HostName]; old != nil && old.IP == one.IP && old.Port == one.Port &&
+			old.Disable == one.Disable {
+			one.Weight, one.Drain = old.Weight, old.Drain
+		}
+		rst = append(rst, one)
+	}
+
+	return rst
+}
+
+// InstanceParam is the diff of instance, nil field means not change
+type InstanceParam struct {
+	Weight  *int64
+	Disable *bool
+}
+
+// InstanceDrainParam defines how to drain instance
+type InstanceDrainParam struct {
+	DurationInS int64
+	Steps       *int64
+}
+
+func findInstance(pool *Pool, hostName string) (int, error) {
+	idx := -1
+	for i := range pool.Instances {
+		if pool.Instances[i].HostName != hostName {
+			continue
+		}
+		if idx != -1 {
+			return -1, xerror.WrapDirtyDataErrorWithMsg("Instance Name %s Duplicated In Pool %s", hostName, pool.Name)
+		}
+		idx = i
+	}
+
+	if idx == -1 {
+		return -1, xerror.WrapRecordNotExist("Instance")
+	}
+
+	return idx, nil
+}
+
+// FindInstance return the instance whose name is hostName
+func (p *Pool) FindInstance(hostName string) (*Instance, error) {
+	idx, err := findInstance(p, hostName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p.Instances[idx], nil
+}
+
+// checkPoolAvailable make sure pool can still serve traffic,
+// cluster table conf will be rejected by BFE if no backend has positive weight
+func checkPoolAvailable(pool *Pool) error {
+	for i := range pool.Instances {
+		if pool.Instances[i].available() {
+			return nil
+		}
+	}
+
+	return xerror.WrapParamErrorWithMsg("Pool %s Must Keep At Least One Enabled Instance With Positive Weight", pool.Name)
+}
+
+// updatePoolInstances fetches pool in txn, changes instances by process and saves them,
+// so editors of different instances will not overwrite each other
+func (rppm *PoolManager) updatePoolInstances(ctx context.Context, product *ibasic.Product, poolName string,
+	process func(pool *Pool) error) (pool *Pool, err error) {
+
+	poolName, err = poolNameJudger(product.Name, poolName)
+	if err != nil {
+		return
+	}
+
+	err = rppm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		pool, err = lockPool(ctx, rppm.storager, poolName)
+		if err != nil {
+			return err
+		}
+		if pool == nil {
+			return xerror.WrapRecordNotExist("Instance Pool")
+		}
+
+		if err = process(pool); err != nil {
+			return err
+		}
+
+		return rppm.savePoolInstances(ctx, pool)
+	})
+
+	return
+}
+
+// lockPool fetches pool by name with row lock, it must be called in txn
+func lockPool(ctx context.Context, storager PoolStorager, poolName string) (*Pool, error) {
+	list, err := storager.FetchPools(ctx, &PoolFilter{
+		Name:      &poolName,
+		ForUpdate: true,
+	})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	return list[0], nil
+}
+
+// savePoolInstances checks and saves instances of pool, it must be called in txn.
+// All changes of instances go through it, so pools exported to BFE always have available instances.
+// PoolDrainRunner only steps down drains checked here.
+func (rppm *PoolManager) savePoolInstances(ctx context.Context, pool *Pool) error {
+	if err := checkPoolAvailable(pool); err != nil {
+		return err
+	}
+
+	return rppm.storager.UpdatePool(ctx, pool, &PoolParam{
+		Instances: pool.Instances,
+	})
+}
+
+// AddPoolInstance appends instance to pool, name of instance must be unique in pool
+func (rppm *PoolManager) AddPoolInstance(ctx context.Context, product *ibasic.Product, poolName string,
+	instance *Instance) (*Pool, error) {
+
+	return rppm.updatePoolInstances(ctx, product, poolName, func(pool *Pool) error {
+		for _, one := range pool.Instances {
+			if one.HostName == instance.HostName {
+				return xerror.WrapRecordExisted("Instance")
+			}
+		}
+
+		pool.Instances = append(pool.Instances, *instance)
+		return nil
+	})
+}
+
+// DeletePoolInstance removes instance from pool
+func (rppm *PoolManager) DeletePoolInstance(ctx context.Context, product *ibasic.Product, poolName string,
+	hostName string) (*Pool, error) {
+
+	return rppm.updatePoolInstances(ctx, product, poolName, func(pool *Pool) error {
+		idx, err := findInstance(pool, hostName)
+		if err != nil {
+			return err
+		}
+
+		pool.Instances = append(pool.Instances[:idx], pool.Instances[idx+1:]...)
+		return nil
+	})
+}
+
+// UpdatePoolInstance enables/disables instance or sets weight of instance,
+// setting weight cancels the drain of instance
+func (rppm *PoolManager) UpdatePoolInstance(ctx context.Context, product *ibasic.Product, poolName string,
+	hostName string, diff *InstanceParam) (*Pool, error) {
+
+	if diff.Weight != nil && (*diff.Weight < 0 || *diff.Weight > MaxInstanceWeight) {
+		return nil, xerror.WrapParamErrorWithMsg("Weight Must Be In [0, %d]", MaxInstanceWeight)
+	}
+
+	return rppm.updatePoolInstances(ctx, product, poolName, func(pool *Pool) error {
+		idx, err := findInstance(pool, hostName)
+		if err != nil {
+			return err
+		}
+
+		instance := &pool.Instances[idx]
+		if diff.Disable != nil {
+			instance.Disable = *diff.Disable
+		}
+		if diff.Weight != nil {
+			instance.Weight = *diff.Weight
+			instance.Drain = nil
+		}
+
+		return nil
+	})
+}
+
+// DrainPoolInstance starts drain of instance, weight of instance will be stepped down to zero
+// by PoolDrainRunner
+func (rppm *PoolManager) DrainPoolInstance(ctx context.Context, product *ibasic.Product, poolName string,
+	hostName string, param *InstanceDrainParam) (*Pool, error) {
+
+	if param.DurationInS <= 0 || param.DurationInS > MaxDrainDurationInS {
+		return nil, xerror.WrapParamErrorWithMsg("Duration Must Be In [1, %d] Seconds", MaxDrainDurationInS)
+	}
+	steps := int64(DefaultDrainSteps)
+	if param.Steps != nil {
+		steps = *param.Steps
+	}
+	if steps <= 0 || steps > MaxDrainSteps {
+		return nil, xerror.WrapParamErrorWithMsg("Steps Must Be In [1, %d]", MaxDrainSteps)
+	}
+
+	return rppm.updatePoolInstances(ctx, product, poolName, func(pool *Pool) error {
+		idx, err := findInstance(pool, hostName)
+		if err != nil {
+			return err
+		}
+
+		instance := &pool.Instances[idx]
+		if instance.Draining() {
+			return xerror.WrapParamErrorWithMsg("Instance %s Is Draining", hostName)
+		}
+		if instance.Disable || instance.Weight == 0 {
+			return xerror.WrapParamErrorWithMsg("Instance %s Receives No Traffic", hostName)
+		}
+
+		instance.Drain = &InstanceDrain{
+			OriginalWeight: instance.Weight,
+			Steps:          steps,
+			DurationInS:    param.DurationInS,
+			StartedAt:      time.Now(),
+		}
+
+		return nil
+	})
+}
+
+// CancelPoolInstanceDrain stops drain of instance and restores its original weight,
+// it works for finished drain too
+func (rppm *PoolManager) CancelPoolInstanceDrain(ctx context.Context, product *ibasic.Product, poolName string,
+	hostName string) (*Pool, error) {
+
+	return rppm.updatePoolInstances(ctx, product, poolName, func(pool *Pool) error {
+		idx, err := findInstance(pool, hostName)
+		if err != nil {
+			return err
+		}
+
+		instance := &pool.Instances[idx]
+		if instance.Drain == nil {
+			return xerror.WrapRecordNotExist("Instance Drain")
+		}
+
+		instance.Weight = instance.Drain.OriginalWeight
+		instance.Drain = nil
+		return nil
+	})
+}
+
+// PoolDrainRunner steps down weight of draining instances periodically
+type PoolDrainRunner struct {
+	txn      itxn.TxnStorager
+	storager PoolStorager
+	conf     *stateful.PoolDrainConfig
+
+	now func() time.Time
+}
+
+func NewPoolDrainRunner(txn itxn.TxnStorager, storager PoolStorager, conf *stateful.PoolDrainConfig) *PoolDrainRunner {
+	return &PoolDrainRunner{
+		txn:      txn,
+		storager: storager,
+		conf:     conf,
+		now:      time.Now,
+	}
+}
+
+// Run steps drains until ctx done
+func (r *PoolDrainRunner) Run(ctx context.Context) {
+	defer lib.Recover("PoolDrainRunner")
+
+	ticker := time.NewTicker(time.Duration(r.conf.IntervalInS) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := r.StepAll(lib.NewLogContext(ctx)); err != nil {
+			stateful.AccessLogger.Warn("PoolDrainRunner step fail: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// StepAll sets weight of all draining instances by current time.
+// Weight is calculated from drain start time, so running it on many servers is safe.
+func (r *PoolDrainRunner) StepAll(ctx context.Context) error {
+	var pools []*Pool
+	err := r.txn.AtomExecute(ctx, func(ctx context.Context) (err error) {
+		pools, err = r.storager.FetchPools(ctx, &PoolFilter{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		if !poolDraining(pool) {
+			continue
+		}
+
+		if err := r.step(ctx, pool.Name); err != nil {
+			stateful.AccessLogger.Warn("PoolDrainRunner step pool %s fail: %v", pool.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func poolDraining(pool *Pool) bool {
+	for i := range pool.Instances {
+		if pool.Instances[i].Draining() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// step re-fetches pool in txn to avoid overwriting changes made after scanning
+func (r *PoolDrainRunner) step(ctx context.Context, poolName string) error {
+	return r.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		pool, err := lockPool(ctx, r.storager, poolName)
+		if err != nil || pool == nil {
+			return err
+		}
+
+		now := r.now()
+		changed := false
+		for i := range pool.Instances {
+			if pool.Instances[i].stepDrain(now) {
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+
+		return r.storager.UpdatePool(ctx, pool, &PoolParam{
+			Instances: pool.Instances,
+		})
+	})
+}
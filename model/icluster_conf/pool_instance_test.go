@@ -0,0 +1,273 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+)
+
+func TestInstanceDrainStepAt(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	finished := start.Add(time.Second)
+
+	cases := []struct {
+		name     string
+		finished *time.Time
+		elapsed  time.Duration
+		want     int64
+	}{
+		{name: "not started", elapsed: -time.Second, want: 0},
+		{name: "just started", elapsed: 0, want: 0},
+		{name: "within first step", elapsed: 59 * time.Second, want: 0},
+		{name: "first step", elapsed: 60 * time.Second, want: 1},
+		{name: "half", elapsed: 5 * time.Minute, want: 5},
+		{name: "last step", elapsed: 10 * time.Minute, want: 10},
+		{name: "after duration", elapsed: time.Hour, want: 10},
+		{name: "finished", finished: &finished, elapsed: 0, want: 10},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := &InstanceDrain{OriginalWeight: 10, Steps: 10, DurationInS: 600, StartedAt: start, FinishedAt: c.finished}
+			if got := d.stepAt(start.Add(c.elapsed)); got != c.want {
+				t.Errorf("stepAt() = %d, want %d", got, c.want)
+			}
+		})
+	}
+}
+
+func TestInstanceDrainWeightAt(t *testing.T) {
+	cases := []struct {
+		original int64
+		steps    int64
+		step     int64
+		want     int64
+	}{
+		{original: 10, steps: 10, step: 0, want: 10},
+		{original: 10, steps: 10, step: 3, want: 7},
+		{original: 10, steps: 10, step: 10, want: 0},
+		{original: 7, steps: 3, step: 1, want: 4},
+		{original: 7, steps: 3, step: 2, want: 2},
+		{original: 1, steps: 10, step: 1, want: 0},
+	}
+
+	for _, c := range cases {
+		d := &InstanceDrain{OriginalWeight: c.original, Steps: c.steps}
+		if got := d.weightAt(c.step); got != c.want {
+			t.Errorf("weightAt(%d) of %d in %d steps = %d, want %d", c.step, c.original, c.steps, got, c.want)
+		}
+	}
+}
+
+func TestInstanceStepDrain(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newDrain := func() *InstanceDrain {
+		return &InstanceDrain{OriginalWeight: 10, Steps: 10, DurationInS: 600, StartedAt: start}
+	}
+	finishedAt := start.Add(time.Minute)
+
+	cases := []struct {
+		name         string
+		weight       int64
+		drain        *InstanceDrain
+		elapsed      time.Duration
+		wantChanged  bool
+		wantWeight   int64
+		wantFinished bool
+	}{
+		{name: "no drain", weight: 10, elapsed: time.Hour, wantWeight: 10},
+		{name: "finished drain", weight: 0, drain: &InstanceDrain{OriginalWeight: 10, Steps: 10, FinishedAt: &finishedAt},
+			elapsed: time.Hour, wantWeight: 0, wantFinished: true},
+		{name: "same step", weight: 10, drain: newDrain(), elapsed: 30 * time.Second, wantWeight: 10},
+		{name: "stepped", weight: 10, drain: newDrain(), elapsed: 3 * time.Minute, wantChanged: true, wantWeight: 7},
+		{name: "finished now", weight: 1, drain: newDrain(), elapsed: time.Hour, wantChanged: true, wantWeight: 0, wantFinished: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			instance := &Instance{Weight: c.weight, Drain: c.drain}
+			if changed := instance.stepDrain(start.Add(c.elapsed)); changed != c.wantChanged {
+				t.Errorf("stepDrain() = %v, want %v", changed, c.wantChanged)
+			}
+			if instance.Weight != c.wantWeight {
+				t.Errorf("weight = %d, want %d", instance.Weight, c.wantWeight)
+			}
+			if finished := instance.Drain != nil && instance.Drain.FinishedAt != nil; finished != c.wantFinished {
+				t.Errorf("finished = %v, want %v", finished, c.wantFinished)
+			}
+		})
+	}
+}
+
+func TestCheckPoolAvailable(t *testing.T) {
+	draining := &InstanceDrain{OriginalWeight: 10, Steps: 10, DurationInS: 600, StartedAt: time.Now()}
+
+	cases := []struct {
+		name      string
+		instances []Instance
+		wantErr   bool
+	}{
+		{name: "empty", wantErr: true},
+		{name: "enabled with weight", instances: []Instance{{Weight: 0}, {Weight: 1}}},
+		{name: "all disabled", instances: []Instance{{Weight: 1, Disable: true}}, wantErr: true},
+		{name: "all zero weight", instances: []Instance{{Weight: 0}, {Weight: 0}}, wantErr: true},
+		{name: "all draining", instances: []Instance{{Weight: 10, Drain: draining}}, wantErr: true},
+		{name: "draining and available", instances: []Instance{{Weight: 10, Drain: draining}, {Weight: 1}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkPoolAvailable(&Pool{Name: "pool", Instances: c.instances})
+			if (err != nil) != c.wantErr {
+				t.Errorf("checkPoolAvailable() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeepInstanceDrains(t *testing.T) {
+	drain := &InstanceDrain{OriginalWeight: 10, Steps: 10, DurationInS: 600, StartedAt: time.Now()}
+	olds := []Instance{
+		{HostName: "a", IP: "10.0.0.1", Port: 80, Weight: 6, Drain: drain},
+		{HostName: "b", IP: "10.0.0.2", Port: 80, Weight: 10},
+	}
+
+	cases := []struct {
+		name      string
+		instances []Instance
+		want      []Instance
+	}{
+		{
+			name: "unchanged keeps drain and weight",
+			instances: []Instance{
+				{HostName: "a", IP: "10.0.0.1", Port: 80, Weight: 10},
+				{HostName: "b", IP: "10.0.0.2", Port: 80, Weight: 5},
+			},
+			want: []Instance{
+				{HostName: "a", IP: "10.0.0.1", Port: 80, Weight: 6, Drain: drain},
+				{HostName: "b", IP: "10.0.0.2", Port: 80, Weight: 5},
+			},
+		},
+		{
+			name:      "address changed drops drain",
+			instances: []Instance{{HostName: "a", IP: "10.0.0.9", Port: 80, Weight: 10}},
+			want:      []Instance{{HostName: "a", IP: "10.0.0.9", Port: 80, Weight: 10}},
+		},
+		{
+			name:      "disabled drops drain",
+			instances: []Instance{{HostName: "a", IP: "10.0.0.1", Port: 80, Weight: 10, Disable: true}},
+			want:      []Instance{{HostName: "a", IP: "10.0.0.1", Port: 80, Weight: 10, Disable: true}},
+		},
+		{
+			name:      "drain in param ignored",
+			instances: []Instance{{HostName: "b", IP: "10.0.0.2", Port: 80, Weight: 10, Drain: drain}},
+			want:      []Instance{{HostName: "b", IP: "10.0.0.2", Port: 80, Weight: 10}},
+		},
+		{
+			name: "removed",
+			want: []Instance{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := keepInstanceDrains(olds, c.instances); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("keepInstanceDrains() = %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+// fakeLockedPoolStorager keeps one pool, it counts fetches without lock
+type fakeLockedPoolStorager struct {
+	PoolStorager
+
+	pool     *Pool
+	unlocked int
+}
+
+func (s *fakeLockedPoolStorager) FetchPools(ctx context.Context, filter *PoolFilter) ([]*Pool, error) {
+	if filter == nil || !filter.ForUpdate {
+		s.unlocked++
+	}
+	one := *s.pool
+	one.Instances = append([]Instance{}, s.pool.Instances...)
+	return []*Pool{&one}, nil
+}
+
+func (s *fakeLockedPoolStorager) UpdatePool(ctx context.Context, old *Pool, diff *PoolParam) error {
+	s.pool.Instances = diff.Instances
+	return nil
+}
+
+func TestUpdateProductPool(t *testing.T) {
+	drain := &InstanceDrain{OriginalWeight: 10, Steps: 10, DurationInS: 600, StartedAt: time.Now()}
+
+	cases := []struct {
+		name      string
+		instances []Instance
+		wantErr   bool
+	}{
+		{
+			name:      "drain kept",
+			instances: []Instance{{HostName: "a", Weight: 10}, {HostName: "b", Weight: 3}},
+		},
+		{
+			name:      "no available instance",
+			instances: []Instance{{HostName: "a", Weight: 10}, {HostName: "b", Weight: 1, Disable: true}},
+			wantErr:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			storager := &fakeLockedPoolStorager{pool: &Pool{Name: "product.pool", Instances: []Instance{
+				{HostName: "a", Weight: 6, Drain: drain},
+				{HostName: "b", Weight: 10},
+			}}}
+			m := NewPoolManager(fakeTxn{}, storager, nil, nil)
+
+			// pool of caller is stale, drain of a started after it fetched
+			stale := &Pool{Name: "product.pool", Instances: []Instance{{HostName: "a", Weight: 10}, {HostName: "b", Weight: 10}}}
+			pool, err := m.UpdateProductPool(context.Background(), &ibasic.Product{Name: "product"}, stale,
+				&PoolParam{Instances: c.instances})
+			if (err != nil) != c.wantErr {
+				t.Fatalf("UpdateProductPool() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if storager.unlocked != 0 {
+				t.Errorf("pool fetched without ForUpdate %d times", storager.unlocked)
+			}
+			if c.wantErr {
+				if storager.pool.Instances[1].Weight != 10 {
+					t.Errorf("instances = %+v, want unchanged", storager.pool.Instances)
+				}
+				return
+			}
+
+			a := storager.pool.Instances[0]
+			if a.Drain != drain || a.Weight != 6 {
+				t.Errorf("instance a = %+v, want drain kept", a)
+			}
+			if storager.pool.Instances[1].Weight != 3 || !reflect.DeepEqual(pool.Instances, storager.pool.Instances) {
+				t.Errorf("instances = %+v, returned %+v", storager.pool.Instances, pool.Instances)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+const (
+	KubernetesResourceEndpointSlices = "endpointslices"
+	KubernetesResourceEndpoints      = "endpoints"
+)
+
+// NewKubernetesClient creates client by kubeconfig file, in-cluster config is used if file not set
+func NewKubernetesClient(conf *stateful.KubernetesDiscoveryConfig) (kubernetes.Interface, error) {
+	var restConf *rest.Config
+	var err error
+	if conf.Kubeconfig == "" {
+		restConf, err = rest.InClusterConfig()
+	} else {
+		restConf, err = clientcmd.BuildConfigFromFlags("", conf.Kubeconfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(restConf)
+}
+
+// KubernetesDiscoverer watches EndpointSlices (or Endpoints) by informer, resolves kubernetes sources
+// of pools from the informer cache, and syncs pools with kubernetes sources once they are changed.
+// Pools are still synced by PoolDiscoveryManager periodically, in case any event is missed.
+type KubernetesDiscoverer struct {
+	conf *stateful.KubernetesDiscoveryConfig
+
+	// informer and listers are created by NewKubernetesDiscoverer, never changed after that
+	factory         informers.SharedInformerFactory
+	informer        cache.SharedIndexInformer
+	sliceLister     discoverylisters.EndpointSliceLister
+	endpointsLister corelisters.EndpointsLister
+	synced          atomic.Bool
+
+	changed chan struct{}
+}
+
+// NewKubernetesDiscoverer creates discoverer, pass it to NewPoolDiscoveryManager to enable kubernetes source.
+// Client can be a fake clientset in tests
+func NewKubernetesDiscoverer(client kubernetes.Interface, conf *stateful.KubernetesDiscoveryConfig) *KubernetesDiscoverer {
+	var options []informers.SharedInformerOption
+	if conf.Namespace != "" {
+		options = append(options, informers.WithNamespace(conf.Namespace))
+	}
+
+	d := &KubernetesDiscoverer{
+		conf:    conf,
+		factory: informers.NewSharedInformerFactoryWithOptions(client, time.Duration(conf.ResyncInS)*time.Second, options...),
+		changed: make(chan struct{}, 1),
+	}
+
+	if conf.Resource == KubernetesResourceEndpoints {
+		d.informer = d.factory.Core().V1().Endpoints().Informer()
+		d.endpointsLister = d.factory.Core().V1().Endpoints().Lister()
+	} else {
+		d.informer = d.factory.Discovery().V1().EndpointSlices().Informer()
+		d.sliceLister = d.factory.Discovery().V1().EndpointSlices().Lister()
+	}
+
+	return d
+}
+
+// Run watches kubernetes until ctx done, sync is called once watched resources changed
+func (d *KubernetesDiscoverer) Run(ctx context.Context, sync func(ctx context.Context) error) {
+	defer lib.Recover("KubernetesDiscoverer")
+
+	_, err := d.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { d.notify() },
+		UpdateFunc: func(interface{}, interface{}) { d.notify() },
+		DeleteFunc: func(interface{}) { d.notify() },
+	})
+	if err != nil {
+		stateful.AccessLogger.Warn("KubernetesDiscoverer add event handler fail: %v", err)
+		return
+	}
+
+	if !d.start(ctx) {
+		return
+	}
+	defer d.factory.Shutdown()
+	d.notify()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.changed:
+		}
+
+		if err := sync(lib.NewLogContext(ctx)); err != nil {
+			stateful.AccessLogger.Warn("KubernetesDiscoverer sync fail: %v", err)
+		}
+
+		// events during sync are merged into one, sync at most once per second
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// start starts informer and waits for cache synced, return false if ctx done before that
+func (d *KubernetesDiscoverer) start(ctx context.Context) bool {
+	d.factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), d.informer.HasSynced) {
+		return false
+	}
+
+	d.synced.Store(true)
+	return true
+}
+
+func (d *KubernetesDiscoverer) notify() {
+	select {
+	case d.changed <- struct{}{}:
+	default:
+	}
+}
+
+// namespaceAllowed tells whether kubernetes sources of product may select endpoints in namespace
+func (d *KubernetesDiscoverer) namespaceAllowed(product, namespace string) bool {
+	if d.conf.Namespace != "" && namespace != d.conf.Namespace {
+		return false
+	}
+
+	return lib.StringSliceHasElement(d.conf.ProductNamespaces[product], namespace)
+}
+
+func (d *KubernetesDiscoverer) checkSource(i int, product string, source *DiscoverySource) error {
+	if source.Namespace == "" {
+		return xerror.WrapParamErrorWithMsg("Source %d Namespace Required", i)
+	}
+	if !d.namespaceAllowed(product, source.Namespace) {
+		return xerror.WrapParamErrorWithMsg("Source %d Namespace %s Not Allowed For Product %s, See KubernetesDiscovery.ProductNamespaces",
+			i, source.Namespace, product)
+	}
+	if source.Service == "" && source.LabelSelector == "" {
+		return xerror.WrapParamErrorWithMsg("Source %d Service Or LabelSelector Required", i)
+	}
+	if len(validation.IsValidLabelValue(source.Service)) != 0 {
+		return xerror.WrapParamErrorWithMsg("Source %d Service Illegal", i)
+	}
+	if _, err := labels.Parse(source.LabelSelector); err != nil {
+		return xerror.WrapParamErrorWithMsg("Source %d LabelSelector Illegal: %v", i, err)
+	}
+	if source.Port < 0 || source.Port > 65535 {
+		return xerror.WrapParamErrorWithMsg("Source %d Port Must Be In [0, 65535]", i)
+	}
+
+	return nil
+}
+
+// resolve lists ready endpoints of source in product from informer cache, sorted by name
+func (d *KubernetesDiscoverer) resolve(product string, source *DiscoverySource) ([]*DiscoveredInstance, error) {
+	if !d.synced.Load() {
+		return nil, fmt.Errorf("kubernetes cache not synced")
+	}
+	// sources saved before the namespace be removed from config
+	if !d.namespaceAllowed(product, source.Namespace) {
+		return nil, fmt.Errorf("namespace %s not allowed for product %s", source.Namespace, product)
+	}
+
+	selector, err := labels.Parse(source.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []*DiscoveredInstance
+	if d.endpointsLister != nil {
+		rst, err = d.resolveEndpoints(source, selector)
+	} else {
+		rst, err = d.resolveEndpointSlices(source, selector)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(rst, func(i, j int) bool {
+		return rst[i].Name < rst[j].Name
+	})
+	return rst, nil
+}
+
+func (d *KubernetesDiscoverer) resolveEndpointSlices(source *DiscoverySource,
+	selector labels.Selector) ([]*DiscoveredInstance, error) {
+
+	if source.Service != "" {
+		req, err := labels.NewRequirement(discoveryv1.LabelServiceName, selection.Equals, []string{source.Service})
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*req)
+	}
+
+	slices, err := d.sliceLister.EndpointSlices(source.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []*DiscoveredInstance
+	for _, slice := range slices {
+		if slice.AddressType == discoveryv1.AddressTypeFQDN {
+			continue
+		}
+
+		var ports []kubernetesPort
+		for _, one := range slice.Ports {
+			if one.Port == nil {
+				continue
+			}
+			port := kubernetesPort{port: int(*one.Port)}
+			if one.Name != nil {
+				port.name = *one.Name
+			}
+			ports = append(ports, port)
+		}
+		port, err := selectKubernetesPort(source, ports)
+		if err != nil {
+			return nil, fmt.Errorf("endpointslice %s/%s: %v", slice.Namespace, slice.Name, err)
+		}
+		if port == 0 {
+			continue
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			if len(endpoint.Addresses) == 0 {
+				continue
+			}
+
+			rst = append(rst, newKubernetesInstance(source, endpoint.Addresses[0], port, endpoint.TargetRef))
+		}
+	}
+
+	return rst, nil
+}
+
+func (d *KubernetesDiscoverer) resolveEndpoints(source *DiscoverySource,
+	selector labels.Selector) ([]*DiscoveredInstance, error) {
+
+	list, err := d.endpointsLister.Endpoints(source.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []*DiscoveredInstance
+	for _, endpoints := range list {
+		if source.Service != "" && endpoints.Name != source.Service {
+			continue
+		}
+
+		for _, subset := range endpoints.Subsets {
+			var ports []kubernetesPort
+			for _, one := range subset.Ports {
+				ports = append(ports, kubernetesPort{name: one.Name, port: int(one.Port)})
+			}
+			port, err := selectKubernetesPort(source, ports)
+			if err != nil {
+				return nil, fmt.Errorf("endpoints %s/%s: %v", endpoints.Namespace, endpoints.Name, err)
+			}
+			if port == 0 {
+				continue
+			}
+
+			// not ready addresses are in subset.NotReadyAddresses
+			for _, address := range subset.Addresses {
+				rst = append(rst, newKubernetesInstance(source, address.IP, port, address.TargetRef))
+			}
+		}
+	}
+
+	return rst, nil
+}
+
+type kubernetesPort struct {
+	name string
+	port int
+}
+
+// selectKubernetesPort return port matched by PortName and Port of source, the only port is used if
+// both not set. Zero is returned if no port matched.
+func selectKubernetesPort(source *DiscoverySource, ports []kubernetesPort) (int, error) {
+	if source.PortName == "" && source.Port == 0 {
+		if len(ports) > 1 {
+			return 0, fmt.Errorf("more than one port, port_name or port must be set")
+		}
+		if len(ports) == 1 {
+			return ports[0].port, nil
+		}
+		return 0, nil
+	}
+
+	for _, one := range ports {
+		if source.PortName != "" && one.name != source.PortName {
+			continue
+		}
+		if source.Port != 0 && one.port != source.Port {
+			continue
+		}
+		return one.port, nil
+	}
+
+	return 0, nil
+}
+
+// newKubernetesInstance names instance by pod if endpoint is a pod, so instance keeps its
+// disable and weight when ip of pod changed
+func newKubernetesInstance(source *DiscoverySource, ip string, port int,
+	ref *corev1.ObjectReference) *DiscoveredInstance {
+
+	name := net.JoinHostPort(ip, fmt.Sprint(port))
+	if ref != nil && ref.Kind == "Pod" && ref.Name != "" {
+		name = fmt.Sprintf("%s:%d", ref.Name, port)
+	}
+
+	return &DiscoveredInstance{
+		Name:   name,
+		IP:     ip,
+		Port:   port,
+		Weight: source.Weight,
+		Tags:   source.Tags,
+	}
+}
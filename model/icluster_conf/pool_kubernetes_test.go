@@ -0,0 +1,181 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+func newTestKubernetesDiscoverer(t *testing.T, resource string, objects ...runtime.Object) *KubernetesDiscoverer {
+	t.Helper()
+
+	d := NewKubernetesDiscoverer(fake.NewSimpleClientset(objects...), &stateful.KubernetesDiscoveryConfig{
+		Resource:          resource,
+		ResyncInS:         300,
+		ProductNamespaces: map[string][]string{"product": {"llm"}},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		d.factory.Shutdown()
+	})
+	if !d.start(ctx) {
+		t.Fatal("informer not synced")
+	}
+
+	return d
+}
+
+func testEndpointSlice(namespace, name, service string, ready bool, ip string) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service, "app": service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports: []discoveryv1.EndpointPort{
+			{Name: lib.PString("http"), Port: lib.PInt32(8000)},
+			{Name: lib.PString("metrics"), Port: lib.PInt32(9090)},
+		},
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{ip},
+			Conditions: discoveryv1.EndpointConditions{Ready: lib.PBool(ready)},
+			TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: name + "-pod"},
+		}},
+	}
+}
+
+func TestKubernetesResolveEndpointSlices(t *testing.T) {
+	d := newTestKubernetesDiscoverer(t, KubernetesResourceEndpointSlices,
+		testEndpointSlice("llm", "vllm-a", "vllm", true, "10.1.0.1"),
+		testEndpointSlice("llm", "vllm-b", "vllm", false, "10.1.0.2"),
+		testEndpointSlice("llm", "other", "other", true, "10.1.0.3"),
+		testEndpointSlice("secret", "vllm-c", "vllm", true, "10.1.0.4"),
+	)
+
+	cases := []struct {
+		name    string
+		product string
+		source  *DiscoverySource
+		want    []*DiscoveredInstance
+		wantErr bool
+	}{
+		{
+			name:    "service and port name",
+			product: "product",
+			source:  &DiscoverySource{Namespace: "llm", Service: "vllm", PortName: "http", Weight: 2},
+			want:    []*DiscoveredInstance{{Name: "vllm-a-pod:8000", IP: "10.1.0.1", Port: 8000, Weight: 2}},
+		},
+		{
+			name:    "label selector and port",
+			product: "product",
+			source:  &DiscoverySource{Namespace: "llm", LabelSelector: "app=other", Port: 9090, Weight: 1},
+			want:    []*DiscoveredInstance{{Name: "other-pod:9090", IP: "10.1.0.3", Port: 9090, Weight: 1}},
+		},
+		{
+			name:    "more than one port",
+			product: "product",
+			source:  &DiscoverySource{Namespace: "llm", Service: "vllm"},
+			wantErr: true,
+		},
+		{
+			name:    "namespace not allowed for product",
+			product: "product",
+			source:  &DiscoverySource{Namespace: "secret", Service: "vllm", PortName: "http"},
+			wantErr: true,
+		},
+		{
+			name:    "product not listed",
+			product: "other",
+			source:  &DiscoverySource{Namespace: "llm", Service: "vllm", PortName: "http"},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := d.resolve(c.product, c.source)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("resolve() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if !c.wantErr && !reflect.DeepEqual(got, c.want) {
+				t.Errorf("resolve() = %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestKubernetesResolveEndpoints(t *testing.T) {
+	d := newTestKubernetesDiscoverer(t, KubernetesResourceEndpoints, &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "llm", Name: "vllm"},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses:         []corev1.EndpointAddress{{IP: "10.1.0.1"}},
+			NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.1.0.2"}},
+			Ports:             []corev1.EndpointPort{{Name: "http", Port: 8000}},
+		}},
+	})
+
+	got, err := d.resolve("product", &DiscoverySource{Namespace: "llm", Service: "vllm", Weight: 1})
+	if err != nil {
+		t.Fatalf("resolve() error = %v", err)
+	}
+	want := []*DiscoveredInstance{{Name: "10.1.0.1:8000", IP: "10.1.0.1", Port: 8000, Weight: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resolve() = %+v, want %+v", got, want)
+	}
+}
+
+func TestKubernetesCheckSource(t *testing.T) {
+	d := NewKubernetesDiscoverer(fake.NewSimpleClientset(), &stateful.KubernetesDiscoveryConfig{
+		ResyncInS:         300,
+		ProductNamespaces: map[string][]string{"product": {"llm", "llm-canary"}},
+	})
+
+	cases := []struct {
+		name    string
+		product string
+		source  *DiscoverySource
+		wantErr bool
+	}{
+		{name: "allowed", product: "product", source: &DiscoverySource{Namespace: "llm", Service: "vllm"}},
+		{name: "second namespace", product: "product", source: &DiscoverySource{Namespace: "llm-canary", Service: "vllm"}},
+		{name: "namespace required", product: "product", source: &DiscoverySource{Service: "vllm"}, wantErr: true},
+		{name: "namespace of others", product: "product", source: &DiscoverySource{Namespace: "kube-system", Service: "vllm"}, wantErr: true},
+		{name: "product not listed", product: "other", source: &DiscoverySource{Namespace: "llm", Service: "vllm"}, wantErr: true},
+		{name: "service or selector required", product: "product", source: &DiscoverySource{Namespace: "llm"}, wantErr: true},
+		{name: "illegal selector", product: "product", source: &DiscoverySource{Namespace: "llm", LabelSelector: "a in ("}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := d.checkSource(0, c.product, c.source); (err != nil) != c.wantErr {
+				t.Errorf("checkSource() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
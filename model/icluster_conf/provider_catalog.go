@@ -0,0 +1,413 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/itxn"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+const (
+	// DefaultModelProviderID is the field mapping used when provider type not in catalog
+	DefaultModelProviderID = "default"
+
+	// seed files of provider catalog, provider catalog was defined by them before
+	ModelProvidersSeedFile  = "conf/ai/models.json"
+	ModelDefinitionSeedFile = "conf/ai/model_definition.json"
+
+	// catalog cached by each api server, changes made on other servers are seen after it
+	modelProviderCacheTTL = time.Minute
+)
+
+// ModelProvider is provider definition in catalog
+type ModelProvider struct {
+	ProviderID  string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// see NewProviderProtocol
+	Protocol   string `json:"protocol"`
+	BaseURL    string `json:"base_url"`
+	AuthHeader string `json:"auth_header"`
+	AuthScheme string `json:"auth_scheme"`
+
+	// FieldMapping parses model list response, custom fields included
+	FieldMapping *FieldMapping `json:"field_mapping"`
+}
+
+type ModelProviderFilter struct {
+	ProviderID *string
+}
+
+type ModelProviderParam struct {
+	ProviderID   *string
+	Name         *string
+	Description  *string
+	Protocol     *string
+	BaseURL      *string
+	AuthHeader   *string
+	AuthScheme   *string
+	FieldMapping *FieldMapping
+}
+
+type ModelProviderStorager interface {
+	FetchModelProviders(ctx context.Context, filter *ModelProviderFilter) ([]*ModelProvider, error)
+	CreateModelProvider(ctx context.Context, param *ModelProviderParam) error
+	UpdateModelProvider(ctx context.Context, old *ModelProvider, param *ModelProviderParam) error
+	DeleteModelProvider(ctx context.Context, old *ModelProvider) error
+}
+
+// ModelProviderManager manages provider catalog, reads are served by cache
+type ModelProviderManager struct {
+	txn             itxn.TxnStorager
+	storager        ModelProviderStorager
+	clusterStorager ClusterStorager
+
+	lock     sync.Mutex
+	cache    []*ModelProvider
+	expireAt time.Time
+}
+
+func NewModelProviderManager(txn itxn.TxnStorager, storager ModelProviderStorager,
+	clusterStorager ClusterStorager) *ModelProviderManager {
+
+	return &ModelProviderManager{
+		txn:             txn,
+		storager:        storager,
+		clusterStorager: clusterStorager,
+	}
+}
+
+// copy returns a deep copy, callers get copies so cached providers are never changed by them
+func (mp *ModelProvider) copy() *ModelProvider {
+	one := *mp
+	if mp.FieldMapping != nil {
+		mapping := *mp.FieldMapping
+		if mp.FieldMapping.Custom != nil {
+			mapping.Custom = make(map[string]string, len(mp.FieldMapping.Custom))
+			for k, v := range mp.FieldMapping.Custom {
+				mapping.Custom[k] = v
+			}
+		}
+		one.FieldMapping = &mapping
+	}
+
+	return &one
+}
+
+// FetchModelProviders returns copies of all providers in catalog, sorted by id
+func (m *ModelProviderManager) FetchModelProviders(ctx context.Context) ([]*ModelProvider, error) {
+	list, err := m.cachedModelProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := make([]*ModelProvider, 0, len(list))
+	for _, one := range list {
+		rst = append(rst, one.copy())
+	}
+
+	return rst, nil
+}
+
+// cachedModelProviders returns providers shared by callers, they must not be changed
+func (m *ModelProviderManager) cachedModelProviders(ctx context.Context) ([]*ModelProvider, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.cache != nil && time.Now().Before(m.expireAt) {
+		return m.cache, nil
+	}
+
+	var list []*ModelProvider
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) (err error) {
+		list, err = m.storager.FetchModelProviders(ctx, &ModelProviderFilter{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ProviderID < list[j].ProviderID
+	})
+	if list == nil {
+		list = []*ModelProvider{}
+	}
+
+	m.cache, m.expireAt = list, time.Now().Add(modelProviderCacheTTL)
+	return list, nil
+}
+
+// FetchModelProvider returns copy of provider, nil if not existed
+func (m *ModelProviderManager) FetchModelProvider(ctx context.Context, providerID string) (*ModelProvider, error) {
+	list, err := m.cachedModelProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, one := range list {
+		if one.ProviderID == providerID {
+			return one.copy(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *ModelProviderManager) invalidate() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.cache = nil
+}
+
+func (m *ModelProviderManager) CreateModelProvider(ctx context.Context, param *ModelProviderParam) error {
+	defer m.invalidate()
+
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		old, err := m.storager.FetchModelProviders(ctx, &ModelProviderFilter{
+			ProviderID: param.ProviderID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(old) > 0 {
+			return xerror.WrapRecordExisted("Model Provider")
+		}
+
+		return m.storager.CreateModelProvider(ctx, param)
+	})
+}
+
+func (m *ModelProviderManager) UpdateModelProvider(ctx context.Context, old *ModelProvider, param *ModelProviderParam) error {
+	defer m.invalidate()
+
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		return m.storager.UpdateModelProvider(ctx, old, param)
+	})
+}
+
+// DeleteModelProvider deletes provider not used by any cluster
+func (m *ModelProviderManager) DeleteModelProvider(ctx context.Context, old *ModelProvider) error {
+	if old.ProviderID == DefaultModelProviderID {
+		return xerror.WrapParamErrorWithMsg("Model Provider %s Can't Be Deleted", DefaultModelProviderID)
+	}
+
+	defer m.invalidate()
+
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		clusters, err := m.clusterStorager.FetchClusterList(ctx, &ClusterFilter{})
+		if err != nil {
+			return err
+		}
+		for _, one := range clusters {
+			if conf := one.LLMConfig; conf != nil && conf.ProviderType != nil && *conf.ProviderType == old.ProviderID {
+				return xerror.WrapParamErrorWithMsg("Model Provider Used By Cluster %s", one.Name)
+			}
+		}
+
+		return m.storager.DeleteModelProvider(ctx, old)
+	})
+}
+
+// ProviderTypes returns provider types can be used by llm config
+func (m *ModelProviderManager) ProviderTypes(ctx context.Context) ([]string, error) {
+	list, err := m.cachedModelProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []string
+	for _, one := range list {
+		if one.ProviderID != DefaultModelProviderID {
+			rst = append(rst, one.ProviderID)
+		}
+	}
+
+	return rst, nil
+}
+
+// ProviderProtocol is the ProviderProtocolGetter of catalog
+func (m *ModelProviderManager) ProviderProtocol(ctx context.Context, providerType string) (*ProviderProtocol, error) {
+	if providerType == "" {
+		providerType = ProviderTypeOpenAI
+	}
+	if providerType == DefaultModelProviderID {
+		return nil, nil
+	}
+
+	one, err := m.FetchModelProvider(ctx, providerType)
+	if err != nil || one == nil {
+		return nil, err
+	}
+
+	return NewProviderProtocol(one.Protocol, one.AuthHeader, one.AuthScheme, one.BaseURL)
+}
+
+// ParserConfig returns field mappings of providers in catalog
+func (m *ModelProviderManager) ParserConfig(ctx context.Context) (*ParserConfig, error) {
+	list, err := m.FetchModelProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := &ParserConfig{
+		Providers: map[string]FieldMapping{},
+	}
+	for _, one := range list {
+		if one.FieldMapping == nil {
+			continue
+		}
+		if one.ProviderID == DefaultModelProviderID {
+			conf.DefaultParser = *one.FieldMapping
+			continue
+		}
+		conf.Providers[one.ProviderID] = *one.FieldMapping
+	}
+
+	return conf, nil
+}
+
+// SeedModelProviders imports providers from seed files if catalog is empty,
+// returns count of providers imported, nothing is imported if seed files not exist
+func (m *ModelProviderManager) SeedModelProviders(ctx context.Context, providersFile, definitionFile string) (int, error) {
+	defer m.invalidate()
+
+	count := 0
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := m.storager.FetchModelProviders(ctx, &ModelProviderFilter{})
+		if err != nil || len(list) > 0 {
+			return err
+		}
+
+		params, err := loadModelProviderSeeds(providersFile, definitionFile)
+		if err != nil {
+			return err
+		}
+		if params == nil {
+			stateful.AccessLogger.Warn("seed files %s and %s not exist, model provider catalog not seeded",
+				providersFile, definitionFile)
+			return nil
+		}
+		for _, one := range params {
+			if err := m.storager.CreateModelProvider(ctx, one); err != nil {
+				return err
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// loadModelProviderSeeds returns nil if any seed file not exist, seed files are optional on fresh database
+func loadModelProviderSeeds(providersFile, definitionFile string) ([]*ModelProviderParam, error) {
+	for _, file := range []string{providersFile, definitionFile} {
+		if _, err := os.Stat(file); errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+	}
+
+	bs, err := os.ReadFile(providersFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", providersFile, err)
+	}
+	var providers []struct {
+		Name string `json:"name"`
+		ID   string `json:"id"`
+	}
+	if err := json.Unmarshal(bs, &providers); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", providersFile, err)
+	}
+
+	// description is not a field of FieldMapping
+	type definition struct {
+		FieldMapping
+		Description string `json:"description"`
+	}
+	bs, err = os.ReadFile(definitionFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", definitionFile, err)
+	}
+	var definitions struct {
+		Providers     map[string]definition `json:"providers"`
+		DefaultParser definition            `json:"default_parser"`
+	}
+	if err := json.Unmarshal(bs, &definitions); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", definitionFile, err)
+	}
+
+	names := map[string]string{}
+	for _, one := range providers {
+		names[one.ID] = one.Name
+	}
+
+	newParam := func(id, name, description string, mapping FieldMapping) *ModelProviderParam {
+		p := &ModelProviderParam{
+			ProviderID:   &id,
+			Name:         &name,
+			Description:  &description,
+			Protocol:     new(string),
+			BaseURL:      new(string),
+			AuthHeader:   new(string),
+			AuthScheme:   new(string),
+			FieldMapping: &mapping,
+		}
+		*p.Protocol = ProtocolOpenAI
+		if builtin, ok := builtinProviders[id]; ok {
+			*p.Protocol, *p.BaseURL = builtin.Protocol, builtin.BaseURL
+			*p.AuthHeader, *p.AuthScheme = builtin.AuthHeader, builtin.AuthScheme
+		}
+
+		return p
+	}
+
+	var rst []*ModelProviderParam
+	for id, one := range definitions.Providers {
+		name := names[id]
+		if name == "" {
+			name = id
+		}
+		rst = append(rst, newParam(id, name, one.Description, one.FieldMapping))
+	}
+	// providers listed but without field mapping use the default one
+	for _, one := range providers {
+		if _, ok := definitions.Providers[one.ID]; !ok {
+			rst = append(rst, newParam(one.ID, one.Name, "", definitions.DefaultParser.FieldMapping))
+		}
+	}
+	rst = append(rst, newParam(DefaultModelProviderID, "Default",
+		definitions.DefaultParser.Description, definitions.DefaultParser.FieldMapping))
+
+	sort.Slice(rst, func(i, j int) bool {
+		return *rst[i].ProviderID < *rst[j].ProviderID
+	})
+
+	return rst, nil
+}
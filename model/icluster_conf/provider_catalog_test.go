@@ -0,0 +1,113 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestModelProviderCacheCopies(t *testing.T) {
+	m := newTestModelProviderManager()
+	ctx := context.Background()
+
+	list, err := m.FetchModelProviders(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list[0].Name = "changed"
+	list[0].FieldMapping.ListPath = "changed"
+
+	one, err := m.FetchModelProvider(ctx, DefaultModelProviderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	one.FieldMapping.IDField = "changed"
+
+	conf, err := m.ParserConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf.DefaultParser.Type = "changed"
+
+	list, err = m.FetchModelProviders(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := list[0]; got.Name != "" || got.FieldMapping.ListPath != "data" || got.FieldMapping.IDField != "id" ||
+		got.FieldMapping.Type != "array" {
+		t.Errorf("cached provider changed by caller: %+v %+v", got, got.FieldMapping)
+	}
+}
+
+func TestLoadModelProviderSeeds(t *testing.T) {
+	cases := []struct {
+		name        string
+		providers   string // content, not created if empty
+		definitions string
+		wantNil     bool
+		wantErr     bool
+	}{
+		{
+			name:        "seeded",
+			providers:   `[{"id": "openai", "name": "OpenAI"}]`,
+			definitions: `{"providers": {}, "default_parser": {"list_path": "data"}}`,
+		},
+		{
+			name:    "no seed files",
+			wantNil: true,
+		},
+		{
+			name:        "providers file missing",
+			definitions: `{"providers": {}}`,
+			wantNil:     true,
+		},
+		{
+			name:        "invalid providers file",
+			providers:   `{`,
+			definitions: `{"providers": {}}`,
+			wantErr:     true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dir := t.TempDir()
+			providersFile := filepath.Join(dir, "models.json")
+			definitionFile := filepath.Join(dir, "model_definition.json")
+			for file, content := range map[string]string{providersFile: c.providers, definitionFile: c.definitions} {
+				if content == "" {
+					continue
+				}
+				if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			params, err := loadModelProviderSeeds(providersFile, definitionFile)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("loadModelProviderSeeds() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if c.wantErr {
+				return
+			}
+			if (params == nil) != c.wantNil {
+				t.Errorf("params = %v, wantNil %v", params, c.wantNil)
+			}
+		})
+	}
+}
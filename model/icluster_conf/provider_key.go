@@ -0,0 +1,194 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
+)
+
+// ProviderKey is one of the provider accounts of cluster, data plane picks enabled keys
+// by weight in turn and skips the ones rate limited by provider
+type ProviderKey struct {
+	Name   *string            `json:"name"`   // unique in cluster
+	Key    *string            `json:"key"`    // sealed when stored, redacted when read
+	Weight *int               `json:"weight"` // 0 means not be picked unless others be rate limited
+	Enable *bool              `json:"enable"`
+	Limits *ProviderKeyLimits `json:"limits,omitempty"`
+}
+
+// ProviderKeyLimits are rate limits of the provider account, data plane stops picking the key
+// when reaching them in current minute
+type ProviderKeyLimits struct {
+	RPM *int64 `json:"rpm,omitempty"` // requests per minute
+	TPM *int64 `json:"tpm,omitempty"` // tokens per minute
+}
+
+// ExportProviderKey is the provider key exported to data plane
+type ExportProviderKey struct {
+	Name   string
+	Key    string
+	Weight int
+	RPM    int64 `json:",omitempty"`
+	TPM    int64 `json:",omitempty"`
+}
+
+const DefaultProviderKeyWeight = 1
+
+// exportProviderKeys decrypts enabled keys
+func exportProviderKeys(ctx context.Context, keys []*ProviderKey) ([]*ExportProviderKey, error) {
+	var rst []*ExportProviderKey
+	for _, one := range keys {
+		if (one.Enable != nil && !*one.Enable) || one.Key == nil {
+			continue
+		}
+
+		key, err := isecret.Open(ctx, *one.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		ek := &ExportProviderKey{
+			Name:   *one.Name,
+			Key:    key,
+			Weight: DefaultProviderKeyWeight,
+		}
+		if one.Weight != nil {
+			ek.Weight = *one.Weight
+		}
+		if limits := one.Limits; limits != nil {
+			if limits.RPM != nil {
+				ek.RPM = *limits.RPM
+			}
+			if limits.TPM != nil {
+				ek.TPM = *limits.TPM
+			}
+		}
+
+		rst = append(rst, ek)
+	}
+
+	return rst, nil
+}
+
+func findProviderKey(keys []*ProviderKey, name string) (int, *ProviderKey) {
+	for i, one := range keys {
+		if one.Name != nil && *one.Name == name {
+			return i, one
+		}
+	}
+
+	return -1, nil
+}
+
+// updateProviderKeys applies fn on the provider keys of cluster and saves them,
+// cluster is re-fetched with lock so concurrent editors of keys will not overwrite each other
+func (cm *ClusterManager) updateProviderKeys(ctx context.Context, product *ibasic.Product, cluster *Cluster,
+	fn func(conf *LLMConfig) error) error {
+
+	return cm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		cluster, err := lockCluster(ctx, cm.storager, cluster.ID)
+		if err != nil {
+			return err
+		}
+		if cluster.LLMConfig == nil {
+			return xerror.WrapParamErrorWithMsg("Cluster %s Not Set llm_config", cluster.Name)
+		}
+
+		conf := *cluster.LLMConfig
+		conf.Keys = append([]*ProviderKey{}, cluster.LLMConfig.Keys...)
+		if err := fn(&conf); err != nil {
+			return err
+		}
+
+		return cm.storager.ClusterUpdate(ctx, product, cluster, &ClusterParam{
+			LLMConfig: &conf,
+		})
+	})
+}
+
+// CreateProviderKey appends a provider key to cluster
+func (cm *ClusterManager) CreateProviderKey(ctx context.Context, product *ibasic.Product, cluster *Cluster,
+	param *ProviderKey) error {
+
+	return cm.updateProviderKeys(ctx, product, cluster, func(conf *LLMConfig) (err error) {
+		if _, old := findProviderKey(conf.Keys, *param.Name); old != nil {
+			return xerror.WrapRecordExisted("Provider Key")
+		}
+
+		if param.Key, err = isecret.SealOrKeep(ctx, nil, param.Key); err != nil {
+			return err
+		}
+		if param.Weight == nil {
+			param.Weight = lib.PInt(DefaultProviderKeyWeight)
+		}
+		if param.Enable == nil {
+			param.Enable = lib.PBool(true)
+		}
+
+		conf.Keys = append(conf.Keys, param)
+		return nil
+	})
+}
+
+// UpdateProviderKey updates the fields set in param, eg: disable the key by setting Enable false
+func (cm *ClusterManager) UpdateProviderKey(ctx context.Context, product *ibasic.Product, cluster *Cluster,
+	name string, param *ProviderKey) error {
+
+	return cm.updateProviderKeys(ctx, product, cluster, func(conf *LLMConfig) (err error) {
+		i, old := findProviderKey(conf.Keys, name)
+		if old == nil {
+			return xerror.WrapRecordNotExist("Provider Key")
+		}
+
+		tmp := *old
+		if param.Key != nil {
+			if tmp.Key, err = isecret.SealOrKeep(ctx, old.Key, param.Key); err != nil {
+				return err
+			}
+		}
+		if param.Weight != nil {
+			tmp.Weight = param.Weight
+		}
+		if param.Enable != nil {
+			tmp.Enable = param.Enable
+		}
+		if param.Limits != nil {
+			tmp.Limits = param.Limits
+		}
+
+		conf.Keys[i] = &tmp
+		return nil
+	})
+}
+
+// DeleteProviderKey removes the provider key from cluster
+func (cm *ClusterManager) DeleteProviderKey(ctx context.Context, product *ibasic.Product, cluster *Cluster,
+	name string) error {
+
+	return cm.updateProviderKeys(ctx, product, cluster, func(conf *LLMConfig) error {
+		i, old := findProviderKey(conf.Keys, name)
+		if old == nil {
+			return xerror.WrapRecordNotExist("Provider Key")
+		}
+
+		conf.Keys = append(conf.Keys[:i], conf.Keys[i+1:]...)
+		return nil
+	})
+}
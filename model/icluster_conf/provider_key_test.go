@@ -0,0 +1,170 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
+)
+
+func setTestSecret(t *testing.T) {
+	t.Helper()
+
+	isecret.Init(isecret.NewLocalKeyManager([]byte("master-key")))
+	t.Cleanup(func() {
+		isecret.Init(isecret.NewLocalKeyManager(nil))
+	})
+}
+
+func providerKeyNames(conf *LLMConfig) []string {
+	var names []string
+	if conf == nil {
+		return names
+	}
+	for _, one := range conf.Keys {
+		names = append(names, *one.Name)
+	}
+	return names
+}
+
+func TestMergeLLMConfig(t *testing.T) {
+	setTestSecret(t)
+	ctx := context.Background()
+
+	sealed, err := isecret.Seal(ctx, "sk-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := &LLMConfig{
+		Keys: []*ProviderKey{{Name: lib.PString("a"), Key: &sealed}},
+	}
+
+	cases := []struct {
+		name string
+		old  *LLMConfig
+		keys []*ProviderKey
+		want []string
+	}{
+		{name: "omitted keys kept", old: old, keys: nil, want: []string{"a"}},
+		{name: "empty keys remove all", old: old, keys: []*ProviderKey{}, want: nil},
+		{name: "keys replaced", old: old, keys: []*ProviderKey{{Name: lib.PString("b"), Key: lib.PString("sk-b")}}, want: []string{"b"}},
+		{name: "redacted key kept", old: old, keys: []*ProviderKey{{Name: lib.PString("a"), Key: lib.PString(isecret.RedactedValue)}}, want: []string{"a"}},
+		{name: "no old config", old: nil, keys: nil, want: nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			conf := &LLMConfig{Keys: c.keys}
+			if err := mergeLLMConfig(ctx, c.old, conf); err != nil {
+				t.Fatalf("mergeLLMConfig() error = %v", err)
+			}
+
+			got := providerKeyNames(conf)
+			if len(got) != len(c.want) {
+				t.Fatalf("keys = %v, want %v", got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Fatalf("keys = %v, want %v", got, c.want)
+				}
+			}
+			for _, one := range conf.Keys {
+				if plain, err := isecret.Open(ctx, *one.Key); err != nil || plain == isecret.RedactedValue {
+					t.Errorf("key %s not sealed: %v", *one.Name, err)
+				}
+			}
+		})
+	}
+}
+
+// TestProviderKeyStaleCluster makes sure provider key API edits the stored keys,
+// not the ones of cluster fetched before others changed them
+func TestProviderKeyStaleCluster(t *testing.T) {
+	setTestSecret(t)
+	ctx := context.Background()
+	product := &ibasic.Product{ID: 1, Name: "product"}
+
+	cases := []struct {
+		name   string
+		change func(m *ClusterManager, stale *Cluster) error
+		want   []string
+	}{
+		{
+			name: "create",
+			change: func(m *ClusterManager, stale *Cluster) error {
+				return m.CreateProviderKey(ctx, product, stale, &ProviderKey{Name: lib.PString("c"), Key: lib.PString("sk-c")})
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "update",
+			change: func(m *ClusterManager, stale *Cluster) error {
+				return m.UpdateProviderKey(ctx, product, stale, "b", &ProviderKey{Enable: lib.PBool(false)})
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "delete",
+			change: func(m *ClusterManager, stale *Cluster) error {
+				return m.DeleteProviderKey(ctx, product, stale, "a")
+			},
+			want: []string{"b"},
+		},
+		{
+			name: "full update without keys",
+			change: func(m *ClusterManager, stale *Cluster) error {
+				return m.UpdateCluster(ctx, product, stale, &ClusterParam{LLMConfig: &LLMConfig{Models: []string{"gpt"}}})
+			},
+			want: []string{"a", "b"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusterStorager := newTestClusterStorager()
+			clusterStorager.cluster.LLMConfig = &LLMConfig{
+				Keys: []*ProviderKey{{Name: lib.PString("a"), Key: lib.PString("sk-a")}},
+			}
+			m := NewClusterManager(fakeTxn{}, clusterStorager, nil, &fakeBFEClusterStorager{}, nil, nil, nil)
+
+			// cluster fetched before key b added by others
+			stale := *clusterStorager.cluster
+			clusterStorager.cluster.LLMConfig = &LLMConfig{
+				Keys: []*ProviderKey{
+					{Name: lib.PString("a"), Key: lib.PString("sk-a")},
+					{Name: lib.PString("b"), Key: lib.PString("sk-b")},
+				},
+			}
+
+			if err := c.change(m, &stale); err != nil {
+				t.Fatalf("change error = %v", err)
+			}
+
+			got := providerKeyNames(clusterStorager.cluster.LLMConfig)
+			if len(got) != len(c.want) {
+				t.Fatalf("keys = %v, want %v", got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Fatalf("keys = %v, want %v", got, c.want)
+				}
+			}
+		})
+	}
+}
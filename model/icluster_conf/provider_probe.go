@@ -0,0 +1,495 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
+)
+
+const (
+	DefaultProviderProbeTimeout = 10 * time.Second
+
+	providerProbePrompt        = "ping"
+	providerProbeMaxBodyLength = 4 << 20
+)
+
+// ProviderProbeParam defines a connection test of llm config against one target
+type ProviderProbeParam struct {
+	// Cluster is the saved cluster whose llm config is tested, its saved provider keys
+	// are only sent to its own instances. LLMConfig is tested if Cluster is nil,
+	// provider keys in it must be in plain text
+	Cluster   *Cluster
+	LLMConfig *LLMConfig
+
+	// Target is "host:port" of instance, or url like "http://10.0.0.1:8080"
+	// whose scheme overrides schema of model endpoint. It is the first enabled instance
+	// of Cluster if empty
+	Target string
+
+	// Completion sends a minimal completion request of Model besides requesting model list,
+	// Model is the first one of LLMConfig.Models if not set
+	Completion bool
+	Model      string
+
+	Timeout time.Duration
+}
+
+// ProviderProbeStep is the diagnostic of one step, skipped steps are nil
+type ProviderProbeStep struct {
+	OK        bool   `json:"ok"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+
+	// dns step
+	Addrs []string `json:"addrs,omitempty"`
+
+	// tls step
+	Version    string `json:"version,omitempty"`
+	ServerName string `json:"server_name,omitempty"`
+
+	// http steps
+	URL        string `json:"url,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+	AuthFailed bool   `json:"auth_failed,omitempty"`
+}
+
+// ProviderProbeResult is the diagnostics of connection test, OK means provider is reachable
+// with the credential, missing models do not fail the test
+type ProviderProbeResult struct {
+	OK     bool   `json:"ok"`
+	Target string `json:"target"`
+
+	DNS        *ProviderProbeStep `json:"dns"`
+	TLS        *ProviderProbeStep `json:"tls,omitempty"`
+	ModelList  *ProviderProbeStep `json:"model_list"`
+	Completion *ProviderProbeStep `json:"completion,omitempty"`
+
+	// Models are models returned by provider, MissingModels are models of llm_config.models
+	// not provided, see diffModels
+	Models        []string `json:"models"`
+	MissingModels []string `json:"missing_models"`
+}
+
+// ProbeProvider tests the connection, credential and models of llm config.
+// Failures of the provider are reported in result, error is returned only for invalid param
+func (m *ModelProviderManager) ProbeProvider(ctx context.Context, param *ProviderProbeParam) (*ProviderProbeResult, error) {
+	conf, base, err := providerProbeConf(param)
+	if err != nil {
+		return nil, err
+	}
+
+	_, protocol, err := resolveProviderProtocol(ctx, m.ProviderProtocol, conf)
+	if err != nil {
+		return nil, err
+	}
+	parserConf, err := m.ParserConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{}
+	if conf.ModelEndpoint != nil {
+		for k, v := range conf.ModelEndpoint.Headers {
+			headers[k] = v
+		}
+	}
+	if err := setModelAPIAuth(ctx, headers, conf, protocol); err != nil {
+		return nil, err
+	}
+
+	timeout := param.Timeout
+	if timeout <= 0 {
+		timeout = DefaultProviderProbeTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rst := &ProviderProbeResult{
+		Target:        base.Host,
+		Models:        []string{},
+		MissingModels: []string{},
+	}
+
+	rst.DNS = probeDNS(ctx, base.Hostname())
+	if !rst.DNS.OK {
+		return rst, nil
+	}
+
+	for _, addr := range rst.DNS.Addrs {
+		if ip := net.ParseIP(addr); ip != nil && !providerProbeAddrAllowed(ip) {
+			rst.DNS.OK, rst.DNS.Error = false, fmt.Sprintf("address %s not allowed", addr)
+			return rst, nil
+		}
+	}
+
+	client := newProviderProbeClient()
+
+	var body []byte
+	rst.ModelList, rst.TLS, body = probeHTTP(ctx, client, http.MethodGet, base, providerModelListURI(protocol, conf), headers, nil)
+	if rst.ModelList.OK {
+		providerType := ""
+		if conf.ProviderType != nil {
+			providerType = *conf.ProviderType
+		}
+		list, err := ParseModelsWithConfig(body, providerType, parserConf)
+		if err != nil {
+			rst.ModelList.OK = false
+			rst.ModelList.Error = err.Error()
+		}
+		for _, one := range list {
+			rst.Models = append(rst.Models, fmt.Sprint(one["id"]))
+		}
+
+		diff := diffModels(&Cluster{LLMConfig: conf}, rst.Models, nil)
+		rst.MissingModels = diff.Removed
+	}
+
+	rst.OK = rst.ModelList.OK
+
+	if param.Completion {
+		model := param.Model
+		if model == "" && len(conf.Models) > 0 {
+			model = conf.Models[0]
+		}
+		if model == "" {
+			return nil, xerror.WrapParamErrorWithMsg("Model Of Completion Not Set")
+		}
+
+		uri, payload := providerProbeCompletion(protocol, providerBasePath(protocol, conf), backendModel(conf, model))
+
+		completionHeaders := map[string]string{"Content-Type": "application/json"}
+		for k, v := range headers {
+			completionHeaders[k] = v
+		}
+		rst.Completion, _, _ = probeHTTP(ctx, client, http.MethodPost, base, uri, completionHeaders, payload)
+		rst.OK = rst.OK && rst.Completion.OK
+	}
+
+	return rst, nil
+}
+
+// providerProbeConf returns llm config and target url of connection test
+func providerProbeConf(param *ProviderProbeParam) (*LLMConfig, *url.URL, error) {
+	cluster := param.Cluster
+	if cluster == nil {
+		conf := param.LLMConfig
+		if conf == nil {
+			return nil, nil, xerror.WrapParamErrorWithMsg("llm_config Not Set")
+		}
+		if err := checkPlainProviderKeys(conf); err != nil {
+			return nil, nil, err
+		}
+
+		base, err := providerProbeBaseURL(conf, param.Target)
+		return conf, base, err
+	}
+
+	conf := cluster.LLMConfig
+	if conf == nil {
+		return nil, nil, xerror.WrapParamErrorWithMsg("Cluster %s Not Set llm_config", cluster.Name)
+	}
+
+	target := param.Target
+	if target == "" {
+		var err error
+		if target, err = ProbeTarget(cluster); err != nil {
+			return nil, nil, err
+		}
+	}
+	base, err := providerProbeBaseURL(conf, target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// saved provider keys are sent to instances of cluster by schema of model endpoint only
+	schema := "https"
+	if conf.ModelEndpoint != nil && conf.ModelEndpoint.Schema != "" {
+		schema = conf.ModelEndpoint.Schema
+	}
+	if base.Scheme != schema || !isClusterInstance(cluster, base.Host) {
+		return nil, nil, xerror.WrapParamErrorWithMsg("target %s Is Not %s Instance Of Cluster %s", target, schema, cluster.Name)
+	}
+
+	return conf, base, nil
+}
+
+// checkPlainProviderKeys returns error if provider keys of conf are sealed,
+// sealed keys come from storage and must not be sent to targets chosen by user
+func checkPlainProviderKeys(conf *LLMConfig) error {
+	if conf.Key != nil && isecret.IsSealed(*conf.Key) {
+		return xerror.WrapParamErrorWithMsg("llm_config.key Must Be Plain Text")
+	}
+	for _, one := range conf.Keys {
+		if one.Key != nil && isecret.IsSealed(*one.Key) {
+			return xerror.WrapParamErrorWithMsg("llm_config.keys Must Be Plain Text")
+		}
+	}
+
+	return nil
+}
+
+// isClusterInstance returns true if hostPort is an enabled instance of cluster
+func isClusterInstance(cluster *Cluster, hostPort string) bool {
+	for _, sub := range cluster.SubClusters {
+		if sub.InstancePool == nil {
+			continue
+		}
+		for _, instance := range sub.InstancePool.Instances {
+			if !instance.Disable && instance.IPWithPort() == hostPort {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// providerProbeAddrAllowed reports whether connection test may connect to ip,
+// addresses of the api server itself and cloud metadata services are denied
+var providerProbeAddrAllowed = func(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+// providerProbeDialControl checks the address actually dialed, so that dns rebinding is denied too
+func providerProbeDialControl(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !providerProbeAddrAllowed(ip) {
+		return fmt.Errorf("address %s not allowed", host)
+	}
+
+	return nil
+}
+
+// newProviderProbeClient returns the client of probes, it dials the target only through providerProbeDialControl.
+// It uses no proxy, otherwise the dial guard would check the address of the proxy instead of the target.
+func newProviderProbeClient() *http.Client {
+	dialer := &net.Dialer{
+		Control: providerProbeDialControl,
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:             nil,
+			DialContext:       dialer.DialContext,
+			DisableKeepAlives: true,
+		},
+		// redirects may lead to addresses not allowed, provider api does not redirect
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// ProbeTarget returns the first enabled instance of cluster as target of connection test
+func ProbeTarget(cluster *Cluster) (string, error) {
+	for _, sub := range cluster.SubClusters {
+		if sub.InstancePool == nil {
+			continue
+		}
+		for _, instance := range sub.InstancePool.Instances {
+			if !instance.Disable {
+				return instance.IPWithPort(), nil
+			}
+		}
+	}
+
+	return "", xerror.WrapParamErrorWithMsg("Cluster %s Has No Enabled Instance", cluster.Name)
+}
+
+// providerProbeBaseURL builds url of target with schema of model endpoint
+func providerProbeBaseURL(conf *LLMConfig, target string) (*url.URL, error) {
+	if target == "" {
+		return nil, xerror.WrapParamErrorWithMsg("target Not Set")
+	}
+
+	if !strings.Contains(target, "://") {
+		schema := "https"
+		if conf.ModelEndpoint != nil && conf.ModelEndpoint.Schema != "" {
+			schema = conf.ModelEndpoint.Schema
+		}
+		target = schema + "://" + target
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, xerror.WrapParamErrorWithMsg("target %s Is Invalid", target)
+	}
+
+	return u, nil
+}
+
+// providerBasePath returns base path of provider api, base_path of llm config takes precedence
+func providerBasePath(protocol *ProviderProtocol, conf *LLMConfig) string {
+	if conf.BasePath != nil && *conf.BasePath != "" {
+		return *conf.BasePath
+	}
+
+	return protocol.BasePath
+}
+
+// providerModelListURI returns uri of model list api, uri of model endpoint takes precedence
+func providerModelListURI(protocol *ProviderProtocol, conf *LLMConfig) string {
+	if conf.ModelEndpoint != nil && conf.ModelEndpoint.URI != "" {
+		return conf.ModelEndpoint.URI
+	}
+
+	return path.Join(providerBasePath(protocol, conf), "models")
+}
+
+// backendModel returns the model name in provider of model in request
+func backendModel(conf *LLMConfig, model string) string {
+	mapper, _ := loadModelMapper(conf.ModelMappings)
+	target, _ := mapper.Resolve(model)
+	return target
+}
+
+// providerProbeCompletion returns uri and body of the minimal completion request
+func providerProbeCompletion(protocol *ProviderProtocol, basePath, model string) (string, []byte) {
+	var uri string
+	var payload interface{}
+	switch protocol.Mode {
+	case ProtocolModeAnthropic:
+		uri = path.Join(basePath, "messages")
+		payload = map[string]interface{}{
+			"model":      model,
+			"max_tokens": 1,
+			"messages":   []map[string]string{{"role": "user", "content": providerProbePrompt}},
+		}
+	case ProtocolModeGemini:
+		uri = path.Join(basePath, "models", model) + ":generateContent"
+		payload = map[string]interface{}{
+			"contents":         []map[string]interface{}{{"parts": []map[string]string{{"text": providerProbePrompt}}}},
+			"generationConfig": map[string]int{"maxOutputTokens": 1},
+		}
+	default:
+		uri = path.Join(basePath, "chat", "completions")
+		payload = map[string]interface{}{
+			"model":      model,
+			"max_tokens": 1,
+			"messages":   []map[string]string{{"role": "user", "content": providerProbePrompt}},
+		}
+	}
+
+	bs, _ := json.Marshal(payload)
+	return uri, bs
+}
+
+func probeDNS(ctx context.Context, host string) *ProviderProbeStep {
+	step := &ProviderProbeStep{}
+	if ip := net.ParseIP(host); ip != nil {
+		step.OK, step.Addrs = true, []string{ip.String()}
+		return step
+	}
+
+	start := time.Now()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	step.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		step.Error = err.Error()
+		return step
+	}
+
+	step.OK, step.Addrs = true, addrs
+	return step
+}
+
+// probeHTTP sends request, returns diagnostics of http and tls step, and body when success
+func probeHTTP(ctx context.Context, client *http.Client, method string, base *url.URL, uri string,
+	headers map[string]string, payload []byte) (*ProviderProbeStep, *ProviderProbeStep, []byte) {
+
+	u := *base
+	u.Path = path.Join("/", u.Path, strings.SplitN(uri, "?", 2)[0])
+	if i := strings.Index(uri, "?"); i >= 0 {
+		u.RawQuery = uri[i+1:]
+	}
+	step := &ProviderProbeStep{URL: u.String()}
+
+	var tlsStep *ProviderProbeStep
+	var tlsStart time.Time
+	trace := &httptrace.ClientTrace{
+		TLSHandshakeStart: func() {
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			tlsStep = &ProviderProbeStep{
+				OK:         err == nil,
+				LatencyMs:  time.Since(tlsStart).Milliseconds(),
+				ServerName: state.ServerName,
+			}
+			if err != nil {
+				tlsStep.Error = err.Error()
+			} else {
+				tlsStep.Version = tls.VersionName(state.Version)
+			}
+		},
+	}
+
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), method, u.String(), bytes.NewReader(payload))
+	if err != nil {
+		step.Error = err.Error()
+		return step, nil, nil
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		step.LatencyMs = time.Since(start).Milliseconds()
+		step.Error = err.Error()
+		return step, tlsStep, nil
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, providerProbeMaxBodyLength))
+	step.LatencyMs = time.Since(start).Milliseconds()
+	step.StatusCode = resp.StatusCode
+	step.AuthFailed = resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden
+	if err != nil {
+		step.Error = err.Error()
+		return step, tlsStep, nil
+	}
+
+	// body of failed response is not returned, target may be any http service
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		step.Error = resp.Status
+		return step, tlsStep, nil
+	}
+
+	step.OK = true
+	return step, tlsStep, body
+}
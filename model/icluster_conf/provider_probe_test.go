@@ -0,0 +1,262 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
+)
+
+type fakeTxn struct{}
+
+func (fakeTxn) AtomExecute(ctx context.Context, do func(context.Context) error) error {
+	return do(ctx)
+}
+
+type fakeModelProviderStorager struct {
+	ModelProviderStorager
+
+	list []*ModelProvider
+}
+
+func (s *fakeModelProviderStorager) FetchModelProviders(ctx context.Context, filter *ModelProviderFilter) ([]*ModelProvider, error) {
+	return s.list, nil
+}
+
+func newTestModelProviderManager() *ModelProviderManager {
+	return NewModelProviderManager(fakeTxn{}, &fakeModelProviderStorager{
+		list: []*ModelProvider{
+			{
+				ProviderID: DefaultModelProviderID,
+				FieldMapping: &FieldMapping{
+					ListPath: "data",
+					IDField:  "id",
+					Type:     "array",
+				},
+			},
+		},
+	}, nil)
+}
+
+// newMockProvider returns an openai compatible provider accepting key
+func newMockProvider(t *testing.T, key string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	auth := func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Header.Get("Authorization") != "Bearer "+key {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"message":"secret detail of upstream"}}`))
+			return false
+		}
+		return true
+	}
+	mux.HandleFunc("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if auth(w, r) {
+			w.Write([]byte(`{"object":"list","data":[{"id":"gpt-4o"},{"id":"gpt-4o-mini"}]}`))
+		}
+	})
+	mux.HandleFunc("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		if auth(w, r) {
+			w.Write([]byte(`{"choices":[{"message":{"content":"pong"}}]}`))
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func newTestCluster(t *testing.T, server *httptest.Server, key string) *Cluster {
+	t.Helper()
+
+	u, _ := url.Parse(server.URL)
+	host, port, _ := net.SplitHostPort(u.Host)
+	portN, _ := strconv.Atoi(port)
+
+	return &Cluster{
+		Name: "cluster_a",
+		LLMConfig: &LLMConfig{
+			ModelEndpoint: &Endpoint{Schema: "http"},
+			Models:        []string{"gpt-4o", "gpt-3.5"},
+			Key:           lib.PString(key),
+		},
+		SubClusters: []*SubCluster{
+			{
+				InstancePool: &Pool{
+					Instances: []Instance{
+						{IP: host, Port: portN},
+					},
+				},
+			},
+		},
+	}
+}
+
+// allowLoopback lets connection test reach httptest servers
+func allowLoopback(t *testing.T) {
+	old := providerProbeAddrAllowed
+	providerProbeAddrAllowed = func(ip net.IP) bool { return true }
+	t.Cleanup(func() { providerProbeAddrAllowed = old })
+}
+
+func TestProbeProvider(t *testing.T) {
+	server := newMockProvider(t, "sk-good")
+	defer server.Close()
+	allowLoopback(t)
+
+	m := newTestModelProviderManager()
+	ctx := context.Background()
+
+	cases := []struct {
+		name       string
+		param      func() *ProviderProbeParam
+		ok         bool
+		authFailed bool
+		models     []string
+		missing    []string
+	}{
+		{
+			name: "saved cluster",
+			param: func() *ProviderProbeParam {
+				return &ProviderProbeParam{Cluster: newTestCluster(t, server, "sk-good"), Completion: true}
+			},
+			ok:      true,
+			models:  []string{"gpt-4o", "gpt-4o-mini"},
+			missing: []string{"gpt-3.5"},
+		},
+		{
+			name: "wrong key",
+			param: func() *ProviderProbeParam {
+				return &ProviderProbeParam{Cluster: newTestCluster(t, server, "sk-bad")}
+			},
+			authFailed: true,
+			models:     []string{},
+			missing:    []string{},
+		},
+		{
+			name: "unsaved llm config",
+			param: func() *ProviderProbeParam {
+				return &ProviderProbeParam{
+					LLMConfig: newTestCluster(t, server, "sk-good").LLMConfig,
+					Target:    server.URL,
+				}
+			},
+			ok:      true,
+			models:  []string{"gpt-4o", "gpt-4o-mini"},
+			missing: []string{"gpt-3.5"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rst, err := m.ProbeProvider(ctx, c.param())
+			if err != nil {
+				t.Fatalf("ProbeProvider() error: %v", err)
+			}
+			if rst.OK != c.ok {
+				t.Errorf("OK = %v, want %v, result: %+v", rst.OK, c.ok, rst.ModelList)
+			}
+			if rst.ModelList.AuthFailed != c.authFailed {
+				t.Errorf("AuthFailed = %v, want %v", rst.ModelList.AuthFailed, c.authFailed)
+			}
+			if strings.Join(rst.Models, ",") != strings.Join(c.models, ",") {
+				t.Errorf("Models = %v, want %v", rst.Models, c.models)
+			}
+			if strings.Join(rst.MissingModels, ",") != strings.Join(c.missing, ",") {
+				t.Errorf("MissingModels = %v, want %v", rst.MissingModels, c.missing)
+			}
+			if rst.Completion != nil && !rst.Completion.OK {
+				t.Errorf("Completion failed: %+v", rst.Completion)
+			}
+		})
+	}
+}
+
+func TestProbeProviderReject(t *testing.T) {
+	server := newMockProvider(t, "sk-good")
+	defer server.Close()
+
+	m := newTestModelProviderManager()
+	ctx := context.Background()
+
+	cases := []struct {
+		name  string
+		param *ProviderProbeParam
+	}{
+		{
+			name:  "saved key to other target",
+			param: &ProviderProbeParam{Cluster: newTestCluster(t, server, "sk-good"), Target: "10.0.0.1:80"},
+		},
+		{
+			name:  "saved key by other schema",
+			param: &ProviderProbeParam{Cluster: newTestCluster(t, server, "sk-good"), Target: strings.Replace(server.URL, "http", "https", 1)},
+		},
+		{
+			name: "sealed key in unsaved llm config",
+			param: &ProviderProbeParam{
+				LLMConfig: newTestCluster(t, server, isecret.SealedPrefix+"e30=").LLMConfig,
+				Target:    server.URL,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := m.ProbeProvider(ctx, c.param); err == nil {
+				t.Errorf("ProbeProvider() want error")
+			}
+		})
+	}
+}
+
+func TestProbeProviderDenyLoopback(t *testing.T) {
+	server := newMockProvider(t, "sk-good")
+	defer server.Close()
+
+	rst, err := newTestModelProviderManager().ProbeProvider(context.Background(), &ProviderProbeParam{
+		Cluster: newTestCluster(t, server, "sk-good"),
+	})
+	if err != nil {
+		t.Fatalf("ProbeProvider() error: %v", err)
+	}
+	if rst.OK || rst.DNS.OK || rst.ModelList != nil {
+		t.Errorf("loopback target should be denied, got %+v", rst)
+	}
+}
+
+func TestProviderProbeClientNoProxy(t *testing.T) {
+	server := newMockProvider(t, "sk-good")
+	defer server.Close()
+	t.Setenv("HTTP_PROXY", server.URL)
+	t.Setenv("HTTPS_PROXY", server.URL)
+
+	client := newProviderProbeClient()
+	if client.Transport.(*http.Transport).Proxy != nil {
+		t.Fatalf("probe client should not use a proxy")
+	}
+
+	// dial guard checks the target itself, a proxy on an allowed address would hide it
+	if _, err := client.Get(server.URL + "/v1/models"); err == nil {
+		t.Errorf("loopback target should be denied by dial guard")
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// provider types of cluster llm config
+const (
+	ProviderTypeOpenAI    = "openai"
+	ProviderTypeDeepSeek  = "deepseek"
+	ProviderTypeQwen      = "qwen"
+	ProviderTypeAnthropic = "anthropic"
+	ProviderTypeGoogle    = "google"
+)
+
+// protocol modes of data plane, exported as AIConf.Type
+const (
+	ProtocolModeOpenAI    = 0 // openai compatible, request forwarded as is
+	ProtocolModeAnthropic = 1 // openai request converted to anthropic messages api
+	ProtocolModeGemini    = 2 // openai request converted to gemini generateContent api
+)
+
+// protocol names used by provider catalog
+const (
+	ProtocolOpenAI    = "openai"
+	ProtocolAnthropic = "anthropic"
+	ProtocolGemini    = "gemini"
+)
+
+var protocolModes = map[string]int{
+	ProtocolOpenAI:    ProtocolModeOpenAI,
+	ProtocolAnthropic: ProtocolModeAnthropic,
+	ProtocolGemini:    ProtocolModeGemini,
+}
+
+// ProviderProtocol describes how data plane talks with the provider
+type ProviderProtocol struct {
+	Mode int
+
+	// AuthHeader carries provider key, value is "<AuthScheme> <key>" if AuthScheme not empty
+	AuthHeader string
+	AuthScheme string
+
+	// BasePath is prefixed to the api path, eg: /v1 + /chat/completions
+	BasePath string
+
+	// VersionHeader carries api version, empty means version is part of BasePath
+	VersionHeader  string
+	DefaultVersion string
+}
+
+// ProviderProtocolGetter returns protocol of provider type, nil if provider type unknown
+type ProviderProtocolGetter func(ctx context.Context, providerType string) (*ProviderProtocol, error)
+
+// NewProviderProtocol builds protocol of provider defined in catalog, base path is the path of baseURL
+func NewProviderProtocol(protocol, authHeader, authScheme, baseURL string) (*ProviderProtocol, error) {
+	if protocol == "" {
+		protocol = ProtocolOpenAI
+	}
+	mode, ok := protocolModes[protocol]
+	if !ok {
+		return nil, fmt.Errorf("protocol must be one of %s, %s, %s", ProtocolOpenAI, ProtocolAnthropic, ProtocolGemini)
+	}
+
+	p := &ProviderProtocol{
+		Mode:       mode,
+		AuthHeader: authHeader,
+		AuthScheme: authScheme,
+	}
+	if p.AuthHeader == "" {
+		p.AuthHeader, p.AuthScheme = "Authorization", "Bearer"
+	}
+	if mode == ProtocolModeAnthropic {
+		p.VersionHeader, p.DefaultVersion = "anthropic-version", "2023-06-01"
+	}
+
+	if baseURL != "" {
+		u, err := url.Parse(baseURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("base_url %s is invalid", baseURL)
+		}
+		p.BasePath = strings.TrimRight(u.Path, "/")
+	}
+
+	return p, nil
+}
+
+// builtinProvider is the provider supported before provider catalog, used to seed the catalog
+type builtinProvider struct {
+	Protocol   string
+	AuthHeader string
+	AuthScheme string
+	BaseURL    string
+}
+
+var builtinProviders = map[string]*builtinProvider{
+	ProviderTypeOpenAI: {
+		Protocol:   ProtocolOpenAI,
+		AuthHeader: "Authorization",
+		AuthScheme: "Bearer",
+		BaseURL:    "https://api.openai.com/v1",
+	},
+	ProviderTypeDeepSeek: {
+		Protocol:   ProtocolOpenAI,
+		AuthHeader: "Authorization",
+		AuthScheme: "Bearer",
+		BaseURL:    "https://api.deepseek.com/v1",
+	},
+	ProviderTypeQwen: {
+		Protocol:   ProtocolOpenAI,
+		AuthHeader: "Authorization",
+		AuthScheme: "Bearer",
+		BaseURL:    "https://dashscope.aliyuncs.com/compatible-mode/v1",
+	},
+	ProviderTypeAnthropic: {
+		Protocol:   ProtocolAnthropic,
+		AuthHeader: "x-api-key",
+		BaseURL:    "https://api.anthropic.com/v1",
+	},
+	ProviderTypeGoogle: {
+		Protocol:   ProtocolGemini,
+		AuthHeader: "x-goog-api-key",
+		BaseURL:    "https://generativelanguage.googleapis.com/v1beta",
+	},
+}
+
+// BuiltinProviderProtocol is the ProviderProtocolGetter of builtin providers
+func BuiltinProviderProtocol(_ context.Context, providerType string) (*ProviderProtocol, error) {
+	if providerType == "" {
+		providerType = ProviderTypeOpenAI
+	}
+
+	p, ok := builtinProviders[providerType]
+	if !ok {
+		return nil, nil
+	}
+
+	return NewProviderProtocol(p.Protocol, p.AuthHeader, p.AuthScheme, p.BaseURL)
+}
+
+// resolveProviderProtocol returns provider type and its protocol of llm config,
+// unknown provider type saved before validation or removed from catalog is treated as openai
+func resolveProviderProtocol(ctx context.Context, getter ProviderProtocolGetter, llmConfig *LLMConfig) (string, *ProviderProtocol, error) {
+	if getter == nil {
+		getter = BuiltinProviderProtocol
+	}
+
+	providerType := ProviderTypeOpenAI
+	if llmConfig.ProviderType != nil && *llmConfig.ProviderType != "" {
+		providerType = *llmConfig.ProviderType
+	}
+
+	protocol, err := getter(ctx, providerType)
+	if err != nil {
+		return "", nil, err
+	}
+	if protocol == nil {
+		providerType = ProviderTypeOpenAI
+		if protocol, err = BuiltinProviderProtocol(ctx, providerType); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return providerType, protocol, nil
+}
+
+// exportProtocol sets protocol fields of AIConf
+func exportProtocol(conf *AIConf, llmConfig *LLMConfig, providerType string, protocol *ProviderProtocol) {
+	conf.Type = protocol.Mode
+	conf.Provider = providerType
+	conf.AuthHeader = protocol.AuthHeader
+	conf.AuthScheme = protocol.AuthScheme
+
+	conf.BasePath = protocol.BasePath
+	if llmConfig.BasePath != nil && *llmConfig.BasePath != "" {
+		conf.BasePath = *llmConfig.BasePath
+	}
+
+	conf.Headers = providerVersionHeaders(protocol, llmConfig)
+}
+
+// providerVersionHeaders returns the api version header sent to provider, nil if version is part of BasePath
+func providerVersionHeaders(protocol *ProviderProtocol, conf *LLMConfig) map[string]string {
+	if protocol.VersionHeader == "" {
+		return nil
+	}
+
+	version := protocol.DefaultVersion
+	if conf.APIVersion != nil && *conf.APIVersion != "" {
+		version = *conf.APIVersion
+	}
+	return map[string]string{
+		protocol.VersionHeader: version,
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+)
+
+func TestResolveProviderProtocol(t *testing.T) {
+	catalog := func(ctx context.Context, providerType string) (*ProviderProtocol, error) {
+		switch providerType {
+		case "gateway":
+			return NewProviderProtocol(ProtocolAnthropic, "", "", "https://gw.example.com/anthropic/")
+		case "broken":
+			return nil, errors.New("catalog unavailable")
+		}
+		return BuiltinProviderProtocol(ctx, providerType)
+	}
+
+	type want struct {
+		Type       int
+		Provider   string
+		AuthHeader string
+		AuthScheme string
+		BasePath   string
+		Headers    map[string]string
+	}
+
+	cases := []struct {
+		name      string
+		getter    ProviderProtocolGetter
+		llmConfig *LLMConfig
+		want      want
+		wantErr   bool
+	}{
+		{
+			name:      "provider type not set",
+			llmConfig: &LLMConfig{},
+			want:      want{ProtocolModeOpenAI, ProviderTypeOpenAI, "Authorization", "Bearer", "/v1", nil},
+		},
+		{
+			name:      "empty provider type",
+			llmConfig: &LLMConfig{ProviderType: lib.PString("")},
+			want:      want{ProtocolModeOpenAI, ProviderTypeOpenAI, "Authorization", "Bearer", "/v1", nil},
+		},
+		{
+			name:      "deepseek",
+			llmConfig: &LLMConfig{ProviderType: lib.PString(ProviderTypeDeepSeek)},
+			want:      want{ProtocolModeOpenAI, ProviderTypeDeepSeek, "Authorization", "Bearer", "/v1", nil},
+		},
+		{
+			name:      "qwen",
+			llmConfig: &LLMConfig{ProviderType: lib.PString(ProviderTypeQwen)},
+			want:      want{ProtocolModeOpenAI, ProviderTypeQwen, "Authorization", "Bearer", "/compatible-mode/v1", nil},
+		},
+		{
+			name:      "anthropic",
+			llmConfig: &LLMConfig{ProviderType: lib.PString(ProviderTypeAnthropic)},
+			want: want{ProtocolModeAnthropic, ProviderTypeAnthropic, "x-api-key", "", "/v1",
+				map[string]string{"anthropic-version": "2023-06-01"}},
+		},
+		{
+			name: "anthropic version and base path overridden",
+			llmConfig: &LLMConfig{ProviderType: lib.PString(ProviderTypeAnthropic),
+				APIVersion: lib.PString("2024-10-22"), BasePath: lib.PString("/proxy/v1")},
+			want: want{ProtocolModeAnthropic, ProviderTypeAnthropic, "x-api-key", "", "/proxy/v1",
+				map[string]string{"anthropic-version": "2024-10-22"}},
+		},
+		{
+			name:      "google",
+			llmConfig: &LLMConfig{ProviderType: lib.PString(ProviderTypeGoogle)},
+			want:      want{ProtocolModeGemini, ProviderTypeGoogle, "x-goog-api-key", "", "/v1beta", nil},
+		},
+		{
+			name:      "google version in base path",
+			llmConfig: &LLMConfig{ProviderType: lib.PString(ProviderTypeGoogle), APIVersion: lib.PString("v1")},
+			want:      want{ProtocolModeGemini, ProviderTypeGoogle, "x-goog-api-key", "", "/v1beta", nil},
+		},
+		{
+			name:      "unknown provider treated as openai",
+			llmConfig: &LLMConfig{ProviderType: lib.PString("removed")},
+			want:      want{ProtocolModeOpenAI, ProviderTypeOpenAI, "Authorization", "Bearer", "/v1", nil},
+		},
+		{
+			name:      "catalog provider",
+			getter:    catalog,
+			llmConfig: &LLMConfig{ProviderType: lib.PString("gateway")},
+			want: want{ProtocolModeAnthropic, "gateway", "Authorization", "Bearer", "/anthropic",
+				map[string]string{"anthropic-version": "2023-06-01"}},
+		},
+		{
+			name:      "builtin provider through catalog",
+			getter:    catalog,
+			llmConfig: &LLMConfig{ProviderType: lib.PString(ProviderTypeGoogle)},
+			want:      want{ProtocolModeGemini, ProviderTypeGoogle, "x-goog-api-key", "", "/v1beta", nil},
+		},
+		{
+			name:      "catalog error",
+			getter:    catalog,
+			llmConfig: &LLMConfig{ProviderType: lib.PString("broken")},
+			wantErr:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			providerType, protocol, err := resolveProviderProtocol(context.Background(), c.getter, c.llmConfig)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("resolveProviderProtocol() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if c.wantErr {
+				return
+			}
+
+			conf := &AIConf{}
+			exportProtocol(conf, c.llmConfig, providerType, protocol)
+			got := want{conf.Type, conf.Provider, conf.AuthHeader, conf.AuthScheme, conf.BasePath, conf.Headers}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("exported protocol = %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestNewProviderProtocol(t *testing.T) {
+	cases := []struct {
+		name       string
+		protocol   string
+		authHeader string
+		authScheme string
+		baseURL    string
+		want       *ProviderProtocol
+		wantErr    bool
+	}{
+		{
+			name: "default openai",
+			want: &ProviderProtocol{Mode: ProtocolModeOpenAI, AuthHeader: "Authorization", AuthScheme: "Bearer"},
+		},
+		{
+			name:     "default auth header replaces scheme",
+			protocol: ProtocolGemini, authScheme: "Token", baseURL: "https://example.com/v1beta/",
+			want: &ProviderProtocol{Mode: ProtocolModeGemini, AuthHeader: "Authorization", AuthScheme: "Bearer", BasePath: "/v1beta"},
+		},
+		{
+			name:     "anthropic version header",
+			protocol: ProtocolAnthropic, authHeader: "x-api-key", baseURL: "https://example.com",
+			want: &ProviderProtocol{Mode: ProtocolModeAnthropic, AuthHeader: "x-api-key",
+				VersionHeader: "anthropic-version", DefaultVersion: "2023-06-01"},
+		},
+		{name: "unknown protocol", protocol: "grpc", wantErr: true},
+		{name: "base url without scheme", baseURL: "example.com/v1", wantErr: true},
+		{name: "invalid base url", baseURL: "https://exa mple.com", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := NewProviderProtocol(c.protocol, c.authHeader, c.authScheme, c.baseURL)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("NewProviderProtocol() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("NewProviderProtocol() = %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
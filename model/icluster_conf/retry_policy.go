@@ -0,0 +1,219 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+)
+
+const (
+	DefaultMaxRetryAfterInS int32 = 10
+
+	maxRetryAfterInS       = 300
+	maxConsecutiveFailures = 1000
+	maxCoolDownInS         = 3600
+)
+
+// ClusterRetryPolicy decides which responses are retried and when sub cluster stops receiving requests,
+// retry times are limited by ClusterBasicRetries
+type ClusterRetryPolicy struct {
+	// RetryOn are status codes retried, only 408, 429 and 5xx are allowed, connect failure is always retried
+	RetryOn []int `json:"retry_on"`
+
+	// CrossSubcluster retries on another sub cluster instead of another instance of same sub cluster
+	CrossSubcluster bool `json:"cross_subcluster"`
+
+	// HonorRetryAfter waits as Retry-After header of response before retry,
+	// response is returned to client if Retry-After is longer than MaxRetryAfterInS
+	HonorRetryAfter  bool  `json:"honor_retry_after"`
+	MaxRetryAfterInS int32 `json:"max_retry_after_in_s,omitempty"`
+
+	CircuitBreaker *ClusterCircuitBreaker `json:"circuit_breaker,omitempty"`
+}
+
+// ClusterCircuitBreaker stops sending requests to sub cluster for CoolDownInS
+// after ConsecutiveFailures failures, then one request is sent to probe
+type ClusterCircuitBreaker struct {
+	ConsecutiveFailures int32 `json:"consecutive_failures"`
+	CoolDownInS         int32 `json:"cool_down_in_s"`
+
+	// FailureStatus are status codes counted as failure besides connect failure, 429 and 5xx are allowed.
+	// RetryOn is used if empty
+	FailureStatus []int `json:"failure_status,omitempty"`
+}
+
+// CheckRetryPolicy validates retry policy, retries is retry times of cluster, nil if unknown
+func CheckRetryPolicy(policy *ClusterRetryPolicy, retries *ClusterBasicRetriesParam) error {
+	if err := checkStatusCodes("retry_on", policy.RetryOn, retryableStatus); err != nil {
+		return err
+	}
+
+	if retries != nil && len(policy.RetryOn) > 0 {
+		if policy.CrossSubcluster && retries.MaxRetryCrossSubcluster != nil && *retries.MaxRetryCrossSubcluster == 0 {
+			return fmt.Errorf("cross_subcluster requires basic.retries.max_retry_cross_subcluster greater than 0")
+		}
+		if !policy.CrossSubcluster && retries.MaxRetryInSubcluster != nil && *retries.MaxRetryInSubcluster == 0 {
+			return fmt.Errorf("retry_on requires basic.retries.max_retry_in_subcluster greater than 0")
+		}
+	}
+
+	if policy.HonorRetryAfter {
+		if policy.MaxRetryAfterInS == 0 {
+			policy.MaxRetryAfterInS = DefaultMaxRetryAfterInS
+		}
+		if policy.MaxRetryAfterInS < 0 || policy.MaxRetryAfterInS > maxRetryAfterInS {
+			return fmt.Errorf("max_retry_after_in_s must between 1 and %d", maxRetryAfterInS)
+		}
+	} else if policy.MaxRetryAfterInS != 0 {
+		return fmt.Errorf("max_retry_after_in_s requires honor_retry_after")
+	}
+
+	if cb := policy.CircuitBreaker; cb != nil {
+		if cb.ConsecutiveFailures < 1 || cb.ConsecutiveFailures > maxConsecutiveFailures {
+			return fmt.Errorf("circuit_breaker.consecutive_failures must between 1 and %d", maxConsecutiveFailures)
+		}
+		if cb.CoolDownInS < 1 || cb.CoolDownInS > maxCoolDownInS {
+			return fmt.Errorf("circuit_breaker.cool_down_in_s must between 1 and %d", maxCoolDownInS)
+		}
+		if err := checkStatusCodes("circuit_breaker.failure_status", cb.FailureStatus, failureStatus); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkUpdateRetryPolicy validates retry policy after update against retry times after update,
+// retry policy and basic.retries omitted from param are the stored ones
+func checkUpdateRetryPolicy(cluster *Cluster, param *ClusterParam) error {
+	var paramRetries *ClusterBasicRetriesParam
+	if param.Basic != nil {
+		paramRetries = param.Basic.Retries
+	}
+	if param.RetryPolicy == nil && paramRetries == nil {
+		return nil
+	}
+
+	policy := param.RetryPolicy
+	if policy == nil && !param.ClearRetryPolicy {
+		policy = cluster.RetryPolicy
+	}
+	if policy == nil {
+		return nil
+	}
+
+	retries := &ClusterBasicRetriesParam{}
+	if cluster.Basic != nil && cluster.Basic.Retries != nil {
+		retries.MaxRetryInSubcluster = &cluster.Basic.Retries.MaxRetryInSubcluster
+		retries.MaxRetryCrossSubcluster = &cluster.Basic.Retries.MaxRetryCrossSubcluster
+	}
+	if paramRetries != nil {
+		if paramRetries.MaxRetryInSubcluster != nil {
+			retries.MaxRetryInSubcluster = paramRetries.MaxRetryInSubcluster
+		}
+		if paramRetries.MaxRetryCrossSubcluster != nil {
+			retries.MaxRetryCrossSubcluster = paramRetries.MaxRetryCrossSubcluster
+		}
+	}
+
+	// defaults filled by check are not saved for stored policy
+	checked := *policy
+	if err := CheckRetryPolicy(&checked, retries); err != nil {
+		return xerror.WrapParamErrorWithMsg("retry_policy.%v", err)
+	}
+
+	return nil
+}
+
+// client errors except timeout and rate limit are never retried
+func retryableStatus(code int) bool {
+	return code == 408 || code == 429 || (code >= 500 && code <= 599)
+}
+
+func failureStatus(code int) bool {
+	return code == 429 || (code >= 500 && code <= 599)
+}
+
+func checkStatusCodes(field string, codes []int, allowed func(int) bool) error {
+	existed := map[int]bool{}
+	for _, code := range codes {
+		if !allowed(code) {
+			return fmt.Errorf("%s: status code %d not allowed", field, code)
+		}
+		if existed[code] {
+			return fmt.Errorf("%s: duplicate status code %d", field, code)
+		}
+		existed[code] = true
+	}
+
+	return nil
+}
+
+// ExportRetryPolicy is retry policy of data plane
+type ExportRetryPolicy struct {
+	RetryOn         []int `json:",omitempty"`
+	CrossSubcluster bool
+	HonorRetryAfter bool
+	MaxRetryAfter   int                   `json:",omitempty"` // in seconds
+	CircuitBreaker  *ExportCircuitBreaker `json:",omitempty"`
+}
+
+type ExportCircuitBreaker struct {
+	ConsecutiveFailures int
+	CoolDown            int // in seconds
+	FailureStatus       []int
+}
+
+func exportRetryPolicy(policy *ClusterRetryPolicy) *ExportRetryPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	rst := &ExportRetryPolicy{
+		RetryOn:         policy.RetryOn,
+		CrossSubcluster: policy.CrossSubcluster,
+		HonorRetryAfter: policy.HonorRetryAfter,
+		MaxRetryAfter:   int(policy.MaxRetryAfterInS),
+	}
+
+	if cb := policy.CircuitBreaker; cb != nil {
+		rst.CircuitBreaker = &ExportCircuitBreaker{
+			ConsecutiveFailures: int(cb.ConsecutiveFailures),
+			CoolDown:            int(cb.CoolDownInS),
+			FailureStatus:       circuitBreakerFailureStatus(policy),
+		}
+	}
+
+	return rst
+}
+
+// circuitBreakerFailureStatus returns failure status of circuit breaker, retryable ones of RetryOn if not set
+func circuitBreakerFailureStatus(policy *ClusterRetryPolicy) []int {
+	codes := policy.CircuitBreaker.FailureStatus
+	if len(codes) == 0 {
+		for _, code := range policy.RetryOn {
+			if failureStatus(code) {
+				codes = append(codes, code)
+			}
+		}
+	}
+
+	rst := append([]int{}, codes...)
+	sort.Ints(rst)
+	return rst
+}
@@ -0,0 +1,86 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"testing"
+)
+
+func TestCheckUpdateRetryPolicy(t *testing.T) {
+	int8p := func(i int8) *int8 { return &i }
+
+	cluster := &Cluster{
+		Basic: &ClusterBasic{
+			Retries: &ClusterBasicRetries{MaxRetryInSubcluster: 0, MaxRetryCrossSubcluster: 2},
+		},
+		RetryPolicy: &ClusterRetryPolicy{RetryOn: []int{502}, CrossSubcluster: true, HonorRetryAfter: true},
+	}
+
+	cases := []struct {
+		name    string
+		param   *ClusterParam
+		wantErr bool
+	}{
+		{
+			name:  "nothing changed",
+			param: &ClusterParam{},
+		},
+		{
+			name:    "policy checked with stored retries",
+			param:   &ClusterParam{RetryPolicy: &ClusterRetryPolicy{RetryOn: []int{502}}},
+			wantErr: true,
+		},
+		{
+			name: "policy checked with new retries",
+			param: &ClusterParam{
+				Basic:       &ClusterBasicParam{Retries: &ClusterBasicRetriesParam{MaxRetryInSubcluster: int8p(1)}},
+				RetryPolicy: &ClusterRetryPolicy{RetryOn: []int{502}},
+			},
+		},
+		{
+			name: "stored policy checked with new retries",
+			param: &ClusterParam{
+				Basic: &ClusterBasicParam{Retries: &ClusterBasicRetriesParam{MaxRetryInSubcluster: int8p(1), MaxRetryCrossSubcluster: int8p(0)}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "stored policy cleared",
+			param: &ClusterParam{
+				Basic:            &ClusterBasicParam{Retries: &ClusterBasicRetriesParam{MaxRetryCrossSubcluster: int8p(0)}},
+				ClearRetryPolicy: true,
+			},
+		},
+		{
+			name: "stored policy still valid",
+			param: &ClusterParam{
+				Basic: &ClusterBasicParam{Retries: &ClusterBasicRetriesParam{MaxRetryCrossSubcluster: int8p(1)}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkUpdateRetryPolicy(cluster, c.param)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("checkUpdateRetryPolicy() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+
+	if cluster.RetryPolicy.MaxRetryAfterInS != 0 {
+		t.Errorf("stored policy changed by check")
+	}
+}
@@ -67,6 +67,7 @@ type SubClusterParam struct {
 	ClusterIDs  []int64
 
 	Description *string
+	Capacity    *int64
 }
 
 type SubClusterStorager interface {
@@ -83,17 +84,21 @@ type SubClusterManager struct {
 	productStorager ibasic.ProductStorager
 	poolStorager    PoolStorager
 	clusterStorager ClusterStorager
+
+	// changeHooks are called in the same txn after sub cluster be updated
+	changeHooks map[string]func(context.Context, *SubCluster) error
 }
 
 func NewSubClusterManager(txn itxn.TxnStorager, storager SubClusterStorager,
 	productStorager ibasic.ProductStorager, poolStorager PoolStorager,
-	clusterStorager ClusterStorager) *SubClusterManager {
+	clusterStorager ClusterStorager, changeHooks map[string]func(context.Context, *SubCluster) error) *SubClusterManager {
 	return &SubClusterManager{
 		txn:             txn,
 		storager:        storager,
 		productStorager: productStorager,
 		poolStorager:    poolStorager,
 		clusterStorager: clusterStorager,
+		changeHooks:     changeHooks,
 	}
 }
 
@@ -217,7 +222,30 @@ func (scm *SubClusterManager) DeleteSubCluster(ctx context.Context, subCluster *
 
 func (scm *SubClusterManager) UpdateSubCluster(ctx context.Context, subCluster *SubCluster, param *SubClusterParam) (err error) {
 	err = scm.txn.AtomExecute(ctx, func(ctx context.Context) error {
-		return scm.storager.UpdateSubCluster(ctx, subCluster, param)
+		if err := scm.storager.UpdateSubCluster(ctx, subCluster, param); err != nil {
+			return err
+		}
+		if len(scm.changeHooks) == 0 {
+			return nil
+		}
+
+		list, err := scm.storager.FetchSubClusterList(ctx, &SubClusterFilter{
+			ID: &subCluster.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return xerror.WrapRecordNotExist("SubCluster")
+		}
+
+		for _, hook := range scm.changeHooks {
+			if err := hook(ctx, list[0]); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 
 	return
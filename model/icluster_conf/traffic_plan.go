@@ -0,0 +1,534 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/itxn"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+const (
+	TrafficPlanStatusRunning  = "running"
+	TrafficPlanStatusPaused   = "paused"
+	TrafficPlanStatusFinished = "finished"
+	TrafficPlanStatusAborted  = "aborted"
+	TrafficPlanStatusFailed   = "failed"
+
+	TrafficPlanActionCreate = "create"
+	TrafficPlanActionStep   = "step"
+	TrafficPlanActionPause  = "pause"
+	TrafficPlanActionResume = "resume"
+	TrafficPlanActionAbort  = "abort"
+	TrafficPlanActionFinish = "finish"
+	TrafficPlanActionFail   = "fail"
+
+	// TrafficPlanOperatorSystem is the operator of logs written by TrafficPlanManager.Run
+	TrafficPlanOperatorSystem = "system"
+
+	MaxTrafficPlanSteps          = 100
+	MaxTrafficPlanIntervalInS    = 7 * 86400
+	MaxTrafficPlanDescriptionLen = 255
+)
+
+// TrafficPlanStep is one lb matrix applied to cluster, exactly one of StartAt and IntervalInS
+// should be set. IntervalInS is counted from the time previous step applied,
+// or from the time plan created for the first step
+type TrafficPlanStep struct {
+	LbMatrix    map[string]map[string]int `json:"lb_matrix"`
+	StartAt     *time.Time                `json:"start_at,omitempty"`
+	IntervalInS *int64                    `json:"interval_in_s,omitempty"`
+}
+
+// dueAt returns the time step should be applied, base is the time previous step applied
+func (s *TrafficPlanStep) dueAt(base time.Time) time.Time {
+	if s.StartAt != nil {
+		return *s.StartAt
+	}
+
+	return base.Add(time.Duration(*s.IntervalInS) * time.Second)
+}
+
+// TrafficPlan shifts traffic of cluster step by step, steps are applied in order
+// by TrafficPlanManager.Run
+type TrafficPlan struct {
+	ID          int64
+	ProductID   int64
+	ClusterID   int64
+	ClusterName string
+
+	Name        string
+	Description string
+	Status      string
+	Steps       []*TrafficPlanStep
+
+	// NextStep is index of step to be applied, equals len(Steps) if plan finished
+	NextStep   int
+	NextStepAt time.Time
+	// PausedAt only makes sense when Status is paused
+	PausedAt time.Time
+
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Active returns true if plan may apply steps in future
+func (p *TrafficPlan) Active() bool {
+	return p.Status == TrafficPlanStatusRunning || p.Status == TrafficPlanStatusPaused
+}
+
+func (p *TrafficPlan) due(now time.Time) bool {
+	return p.Status == TrafficPlanStatusRunning && p.NextStep < len(p.Steps) && !p.NextStepAt.After(now)
+}
+
+type TrafficPlanLog struct {
+	ID        int64
+	PlanID    int64
+	Step      int
+	Action    string
+	Message   string
+	Operator  string
+	CreatedAt time.Time
+}
+
+type TrafficPlanParam struct {
+	Name        *string
+	Description *string
+	Steps       []*TrafficPlanStep
+}
+
+type TrafficPlanFilter struct {
+	ID         *int64
+	ProductID  *int64
+	ClusterID  *int64
+	ClusterIDs []int64
+	Name       *string
+	Statuses   []string
+
+	// ForUpdate locks fetched plans until txn ends
+	ForUpdate bool
+}
+
+type TrafficPlanStorager interface {
+	FetchTrafficPlans(ctx context.Context, filter *TrafficPlanFilter) ([]*TrafficPlan, error)
+	CreateTrafficPlan(ctx context.Context, cluster *Cluster, data *TrafficPlan) (int64, error)
+	// UpdateTrafficPlan saves Status, NextStep, NextStepAt and PausedAt of plan
+	UpdateTrafficPlan(ctx context.Context, data *TrafficPlan) error
+	// DeleteTrafficPlan deletes plan together with its logs
+	DeleteTrafficPlan(ctx context.Context, data *TrafficPlan) error
+
+	CreateTrafficPlanLog(ctx context.Context, log *TrafficPlanLog) error
+	FetchTrafficPlanLogs(ctx context.Context, plan *TrafficPlan) ([]*TrafficPlanLog, error)
+}
+
+// TrafficPlanManager manages traffic plans and applies due steps of running plans
+type TrafficPlanManager struct {
+	txn            itxn.TxnStorager
+	storager       TrafficPlanStorager
+	canaryStorager CanaryStorager
+	clusterManager *ClusterManager
+	conf           *stateful.TrafficPlanConfig
+
+	now func() time.Time
+}
+
+func NewTrafficPlanManager(txn itxn.TxnStorager, storager TrafficPlanStorager, canaryStorager CanaryStorager,
+	clusterManager *ClusterManager, conf *stateful.TrafficPlanConfig) *TrafficPlanManager {
+
+	return &TrafficPlanManager{
+		txn:            txn,
+		storager:       storager,
+		canaryStorager: canaryStorager,
+		clusterManager: clusterManager,
+		conf:           conf,
+		now:            time.Now,
+	}
+}
+
+// FetchTrafficPlans returns plans of cluster
+func (m *TrafficPlanManager) FetchTrafficPlans(ctx context.Context, cluster *Cluster) (list []*TrafficPlan, err error) {
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err = m.storager.FetchTrafficPlans(ctx, &TrafficPlanFilter{
+			ClusterID: &cluster.ID,
+		})
+		return err
+	})
+
+	return
+}
+
+// FetchTrafficPlan returns nil if plan not exist
+func (m *TrafficPlanManager) FetchTrafficPlan(ctx context.Context, cluster *Cluster, name string) (*TrafficPlan, error) {
+	var list []*TrafficPlan
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) (err error) {
+		list, err = m.storager.FetchTrafficPlans(ctx, &TrafficPlanFilter{
+			ClusterID: &cluster.ID,
+			Name:      &name,
+		})
+		return err
+	})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	return list[0], nil
+}
+
+func (m *TrafficPlanManager) FetchTrafficPlanLogs(ctx context.Context, plan *TrafficPlan) (list []*TrafficPlanLog, err error) {
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err = m.storager.FetchTrafficPlanLogs(ctx, plan)
+		return err
+	})
+
+	return
+}
+
+// checkTrafficPlanSteps checks timing of steps, lb matrix is checked by ClusterManager
+func checkTrafficPlanSteps(steps []*TrafficPlanStep) error {
+	if len(steps) == 0 || len(steps) > MaxTrafficPlanSteps {
+		return fmt.Errorf("steps size must be in [1, %d]", MaxTrafficPlanSteps)
+	}
+
+	for i, step := range steps {
+		if step == nil || step.LbMatrix == nil {
+			return fmt.Errorf("steps[%d].lb_matrix must be set", i)
+		}
+		if (step.StartAt == nil) == (step.IntervalInS == nil) {
+			return fmt.Errorf("steps[%d] want exactly one of start_at and interval_in_s", i)
+		}
+		if step.IntervalInS != nil && (*step.IntervalInS < 0 || *step.IntervalInS > MaxTrafficPlanIntervalInS) {
+			return fmt.Errorf("steps[%d].interval_in_s must be in [0, %d]", i, MaxTrafficPlanIntervalInS)
+		}
+	}
+
+	return nil
+}
+
+// CreateTrafficPlan creates plan in running status, the first step is applied when due.
+// A cluster can have only one active plan and should not be in auto scheduler mode or running canary
+func (m *TrafficPlanManager) CreateTrafficPlan(ctx context.Context, cluster *Cluster, param *TrafficPlanParam,
+	operator string) (*TrafficPlan, error) {
+
+	if cluster.AutoScheduler.Enabled() {
+		return nil, xerror.WrapParamErrorWithMsg("Cluster %s Scheduler Is In Auto Mode, Disable It Before Creating Traffic Plan", cluster.Name)
+	}
+	if err := checkTrafficPlanSteps(param.Steps); err != nil {
+		return nil, xerror.WrapParamError(err)
+	}
+
+	now := m.now()
+	plan := &TrafficPlan{
+		ProductID:   cluster.ProductID,
+		ClusterID:   cluster.ID,
+		ClusterName: cluster.Name,
+		Name:        *param.Name,
+		Status:      TrafficPlanStatusRunning,
+		Steps:       param.Steps,
+		NextStepAt:  param.Steps[0].dueAt(now),
+		PausedAt:    now,
+		CreatedBy:   operator,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if param.Description != nil {
+		plan.Description = *param.Description
+	}
+	if len(plan.Description) > MaxTrafficPlanDescriptionLen {
+		return nil, xerror.WrapParamErrorWithMsg("Description Too Long, Max Length Is %d", MaxTrafficPlanDescriptionLen)
+	}
+
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := m.storager.FetchTrafficPlans(ctx, &TrafficPlanFilter{
+			ClusterID: &cluster.ID,
+		})
+		if err != nil {
+			return err
+		}
+		for _, one := range list {
+			if one.Name == plan.Name {
+				return xerror.WrapRecordExisted("Traffic Plan")
+			}
+			if one.Active() {
+				return xerror.WrapParamErrorWithMsg("Cluster %s Has Active Traffic Plan %s", cluster.Name, one.Name)
+			}
+		}
+
+		canaries, err := m.canaryStorager.FetchCanaries(ctx, &CanaryFilter{
+			ClusterID: &cluster.ID,
+			Statuses:  []string{CanaryStatusRunning, CanaryStatusPaused},
+		})
+		if err != nil {
+			return err
+		}
+		if len(canaries) != 0 {
+			return xerror.WrapParamErrorWithMsg("Cluster %s Has Active Canary", cluster.Name)
+		}
+
+		for i, step := range plan.Steps {
+			err = m.clusterManager.checkManualLB(ctx, cluster, &ClusterParam{Scheduler: step.LbMatrix})
+			if err != nil {
+				return xerror.WrapParamErrorWithMsg("Steps[%d]: %s", i, xerror.Cause(err).Error())
+			}
+		}
+
+		if plan.ID, err = m.storager.CreateTrafficPlan(ctx, cluster, plan); err != nil {
+			return err
+		}
+
+		return m.storager.CreateTrafficPlanLog(ctx, &TrafficPlanLog{
+			PlanID:   plan.ID,
+			Action:   TrafficPlanActionCreate,
+			Message:  fmt.Sprintf("%d steps, first step at %s", len(plan.Steps), plan.NextStepAt.Format(time.RFC3339)),
+			Operator: operator,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// changeTrafficPlanStatus re-fetches plan locked in txn, calls change and saves plan with a log.
+// The lock keeps steps applied by the runner meanwhile from being overwritten
+func (m *TrafficPlanManager) changeTrafficPlanStatus(ctx context.Context, id int64, action string,
+	operator string, change func(plan *TrafficPlan) (string, error)) (plan *TrafficPlan, err error) {
+
+	err = m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := m.storager.FetchTrafficPlans(ctx, &TrafficPlanFilter{
+			ID:        &id,
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return xerror.WrapRecordNotExist("Traffic Plan")
+		}
+
+		plan = list[0]
+		msg, err := change(plan)
+		if err != nil {
+			return err
+		}
+		if err = m.storager.UpdateTrafficPlan(ctx, plan); err != nil {
+			return err
+		}
+
+		return m.storager.CreateTrafficPlanLog(ctx, &TrafficPlanLog{
+			PlanID:   plan.ID,
+			Step:     plan.NextStep,
+			Action:   action,
+			Message:  msg,
+			Operator: operator,
+		})
+	})
+
+	return
+}
+
+// PauseTrafficPlan stops applying steps until resumed
+func (m *TrafficPlanManager) PauseTrafficPlan(ctx context.Context, plan *TrafficPlan, operator string) (*TrafficPlan, error) {
+	return m.changeTrafficPlanStatus(ctx, plan.ID, TrafficPlanActionPause, operator, func(plan *TrafficPlan) (string, error) {
+		if plan.Status != TrafficPlanStatusRunning {
+			return "", xerror.WrapParamErrorWithMsg("Traffic Plan %s Is %s, Only Running Plan Can Be Paused", plan.Name, plan.Status)
+		}
+
+		plan.Status = TrafficPlanStatusPaused
+		plan.PausedAt = m.now()
+		return "", nil
+	})
+}
+
+// ResumeTrafficPlan continues paused or failed plan. Due time of interval based step
+// is delayed by the paused duration, failed step is retried immediately
+func (m *TrafficPlanManager) ResumeTrafficPlan(ctx context.Context, plan *TrafficPlan, operator string) (*TrafficPlan, error) {
+	return m.changeTrafficPlanStatus(ctx, plan.ID, TrafficPlanActionResume, operator, func(plan *TrafficPlan) (string, error) {
+		now := m.now()
+		switch plan.Status {
+		case TrafficPlanStatusPaused:
+			if step := plan.Steps[plan.NextStep]; step.StartAt == nil {
+				plan.NextStepAt = plan.NextStepAt.Add(now.Sub(plan.PausedAt))
+			}
+		case TrafficPlanStatusFailed:
+			plan.NextStepAt = now
+		default:
+			return "", xerror.WrapParamErrorWithMsg("Traffic Plan %s Is %s, Only Paused Or Failed Plan Can Be Resumed", plan.Name, plan.Status)
+		}
+
+		plan.Status = TrafficPlanStatusRunning
+		return fmt.Sprintf("next step at %s", plan.NextStepAt.Format(time.RFC3339)), nil
+	})
+}
+
+// AbortTrafficPlan ends plan, lb matrix applied by previous steps is kept
+func (m *TrafficPlanManager) AbortTrafficPlan(ctx context.Context, plan *TrafficPlan, operator string) (*TrafficPlan, error) {
+	return m.changeTrafficPlanStatus(ctx, plan.ID, TrafficPlanActionAbort, operator, func(plan *TrafficPlan) (string, error) {
+		if plan.Status != TrafficPlanStatusRunning && plan.Status != TrafficPlanStatusPaused && plan.Status != TrafficPlanStatusFailed {
+			return "", xerror.WrapParamErrorWithMsg("Traffic Plan %s Is %s, Can Not Be Aborted", plan.Name, plan.Status)
+		}
+
+		plan.Status = TrafficPlanStatusAborted
+		return "", nil
+	})
+}
+
+// DeleteTrafficPlan deletes plan and its logs, active plan should be aborted first
+func (m *TrafficPlanManager) DeleteTrafficPlan(ctx context.Context, plan *TrafficPlan) error {
+	if plan.Active() {
+		return xerror.WrapParamErrorWithMsg("Traffic Plan %s Is %s, Abort It Before Deleting", plan.Name, plan.Status)
+	}
+
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		return m.storager.DeleteTrafficPlan(ctx, plan)
+	})
+}
+
+// Run applies due steps until ctx done
+func (m *TrafficPlanManager) Run(ctx context.Context) {
+	defer lib.Recover("TrafficPlanManager")
+
+	ticker := time.NewTicker(time.Duration(m.conf.IntervalInS) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := m.RunOnce(lib.NewLogContext(ctx)); err != nil {
+			stateful.AccessLogger.Warn("TrafficPlanManager run fail: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce applies due step of every running plan
+func (m *TrafficPlanManager) RunOnce(ctx context.Context) error {
+	var plans []*TrafficPlan
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) (err error) {
+		plans, err = m.storager.FetchTrafficPlans(ctx, &TrafficPlanFilter{
+			Statuses: []string{TrafficPlanStatusRunning},
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	now := m.now()
+	for _, plan := range plans {
+		if !plan.due(now) {
+			continue
+		}
+
+		if err := m.applyStep(ctx, plan); err != nil {
+			stateful.AccessLogger.Warn("TrafficPlanManager apply plan %s of cluster %s fail: %v", plan.Name, plan.ClusterName, err)
+		}
+	}
+
+	return nil
+}
+
+// applyStep updates lb matrix of cluster and records progress of plan in one txn, plan is
+// locked and only advanced if it is still running at the same step. Failure is recorded in another txn
+func (m *TrafficPlanManager) applyStep(ctx context.Context, plan *TrafficPlan) error {
+	idx := plan.NextStep
+
+	var stepErr error
+	err := m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		cur, err := m.lockRunningPlan(ctx, plan.ID, idx)
+		if err != nil || cur == nil {
+			return err
+		}
+
+		if err = m.clusterManager.updateLbMatrix(ctx, cur.ClusterID, cur.Steps[idx].LbMatrix); err != nil {
+			stepErr = err
+			return err
+		}
+
+		cur.NextStep = idx + 1
+		if cur.NextStep < len(cur.Steps) {
+			cur.NextStepAt = cur.Steps[cur.NextStep].dueAt(m.now())
+		} else {
+			cur.Status = TrafficPlanStatusFinished
+		}
+
+		return m.recordStep(ctx, cur, idx, TrafficPlanActionStep, fmt.Sprintf("step %d of %d applied", idx+1, len(cur.Steps)))
+	})
+	if stepErr == nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("step %d of %d fail: %s", idx+1, len(plan.Steps), xerror.Cause(stepErr).Error())
+	return m.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		cur, err := m.lockRunningPlan(ctx, plan.ID, idx)
+		if err != nil || cur == nil {
+			return err
+		}
+
+		cur.Status = TrafficPlanStatusFailed
+		return m.recordStep(ctx, cur, idx, TrafficPlanActionFail, msg)
+	})
+}
+
+// lockRunningPlan re-fetches and locks plan in txn, returns nil if plan deleted, not running
+// or step idx recorded by another server
+func (m *TrafficPlanManager) lockRunningPlan(ctx context.Context, id int64, idx int) (*TrafficPlan, error) {
+	list, err := m.storager.FetchTrafficPlans(ctx, &TrafficPlanFilter{
+		ID:        &id,
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 || list[0].Status != TrafficPlanStatusRunning || list[0].NextStep != idx {
+		return nil, nil
+	}
+
+	return list[0], nil
+}
+
+// recordStep saves progress of plan with log of step idx
+func (m *TrafficPlanManager) recordStep(ctx context.Context, plan *TrafficPlan, idx int, action, msg string) error {
+	if err := m.storager.UpdateTrafficPlan(ctx, plan); err != nil {
+		return err
+	}
+
+	err := m.storager.CreateTrafficPlanLog(ctx, &TrafficPlanLog{
+		PlanID:   plan.ID,
+		Step:     idx,
+		Action:   action,
+		Message:  msg,
+		Operator: TrafficPlanOperatorSystem,
+	})
+	if err != nil || plan.Status != TrafficPlanStatusFinished {
+		return err
+	}
+
+	return m.storager.CreateTrafficPlanLog(ctx, &TrafficPlanLog{
+		PlanID:   plan.ID,
+		Step:     plan.NextStep,
+		Action:   TrafficPlanActionFinish,
+		Operator: TrafficPlanOperatorSystem,
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+// fakeTrafficPlanStorager keeps one plan, FetchTrafficPlans returns copies like a database does
+type fakeTrafficPlanStorager struct {
+	TrafficPlanStorager
+
+	plan *TrafficPlan
+	logs []*TrafficPlanLog
+
+	// unlocked counts fetches without ForUpdate
+	unlocked int
+}
+
+func (s *fakeTrafficPlanStorager) FetchTrafficPlans(ctx context.Context, filter *TrafficPlanFilter) ([]*TrafficPlan, error) {
+	if filter == nil || !filter.ForUpdate {
+		s.unlocked++
+	}
+	one := *s.plan
+	return []*TrafficPlan{&one}, nil
+}
+
+func (s *fakeTrafficPlanStorager) UpdateTrafficPlan(ctx context.Context, data *TrafficPlan) error {
+	one := *data
+	s.plan = &one
+	return nil
+}
+
+func (s *fakeTrafficPlanStorager) CreateTrafficPlanLog(ctx context.Context, log *TrafficPlanLog) error {
+	s.logs = append(s.logs, log)
+	return nil
+}
+
+func TestTrafficPlanApplyStep(t *testing.T) {
+	step := func(rate int) *TrafficPlanStep {
+		return &TrafficPlanStep{
+			LbMatrix:    map[string]map[string]int{"bfe": {"old": 100 - rate, "new": rate}},
+			IntervalInS: lib.PInt64(60),
+		}
+	}
+
+	cases := []struct {
+		name     string
+		stored   string
+		nextStep int
+		steps    []*TrafficPlanStep
+		want     string
+		wantNext int
+		wantRate int
+	}{
+		{name: "apply", stored: TrafficPlanStatusRunning, steps: []*TrafficPlanStep{step(50), step(100)},
+			want: TrafficPlanStatusRunning, wantNext: 1, wantRate: 50},
+		{name: "apply last", stored: TrafficPlanStatusRunning, nextStep: 1, steps: []*TrafficPlanStep{step(50), step(100)},
+			want: TrafficPlanStatusFinished, wantNext: 2, wantRate: 100},
+		{name: "paused meanwhile", stored: TrafficPlanStatusPaused, steps: []*TrafficPlanStep{step(50)},
+			want: TrafficPlanStatusPaused, wantRate: 10},
+		{name: "aborted meanwhile", stored: TrafficPlanStatusAborted, steps: []*TrafficPlanStep{step(50)},
+			want: TrafficPlanStatusAborted, wantRate: 10},
+		{name: "illegal lb matrix", stored: TrafficPlanStatusRunning, steps: []*TrafficPlanStep{step(200)},
+			want: TrafficPlanStatusFailed, wantRate: 10},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusterStorager := newTestClusterStorager()
+			clusterManager := NewClusterManager(fakeTxn{}, clusterStorager, nil, &fakeBFEClusterStorager{}, nil, nil, nil)
+
+			plan := &TrafficPlan{
+				ID:        1,
+				ClusterID: 1,
+				Status:    TrafficPlanStatusRunning,
+				Steps:     c.steps,
+				NextStep:  c.nextStep,
+			}
+			stored := *plan
+			stored.Status = c.stored
+			storager := &fakeTrafficPlanStorager{plan: &stored}
+
+			m := NewTrafficPlanManager(fakeTxn{}, storager, nil, clusterManager, &stateful.TrafficPlanConfig{})
+			if err := m.applyStep(context.Background(), plan); err != nil {
+				t.Fatalf("applyStep() error: %v", err)
+			}
+
+			got := storager.plan
+			if got.Status != c.want || got.NextStep != c.wantNext {
+				t.Errorf("plan status %s next step %d, want %s next step %d", got.Status, got.NextStep, c.want, c.wantNext)
+			}
+			if rate := clusterStorager.cluster.Scheduler["bfe"]["new"]; rate != c.wantRate {
+				t.Errorf("rate of sub cluster %d, want %d", rate, c.wantRate)
+			}
+		})
+	}
+}
+
+// TestChangeTrafficPlanStatus makes sure status changes lock the plan and keep the step
+// advanced by the runner after plan of caller fetched
+func TestChangeTrafficPlanStatus(t *testing.T) {
+	cases := []struct {
+		name   string
+		stored string
+		change func(m *TrafficPlanManager, plan *TrafficPlan) (*TrafficPlan, error)
+		want   string
+	}{
+		{
+			name:   "pause",
+			stored: TrafficPlanStatusRunning,
+			change: func(m *TrafficPlanManager, plan *TrafficPlan) (*TrafficPlan, error) {
+				return m.PauseTrafficPlan(context.Background(), plan, "admin")
+			},
+			want: TrafficPlanStatusPaused,
+		},
+		{
+			name:   "resume",
+			stored: TrafficPlanStatusPaused,
+			change: func(m *TrafficPlanManager, plan *TrafficPlan) (*TrafficPlan, error) {
+				return m.ResumeTrafficPlan(context.Background(), plan, "admin")
+			},
+			want: TrafficPlanStatusRunning,
+		},
+		{
+			name:   "abort",
+			stored: TrafficPlanStatusRunning,
+			change: func(m *TrafficPlanManager, plan *TrafficPlan) (*TrafficPlan, error) {
+				return m.AbortTrafficPlan(context.Background(), plan, "admin")
+			},
+			want: TrafficPlanStatusAborted,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			steps := []*TrafficPlanStep{{IntervalInS: lib.PInt64(60)}, {IntervalInS: lib.PInt64(60)}}
+			now := time.Now()
+			stale := &TrafficPlan{ID: 1, Status: c.stored, Steps: steps, NextStepAt: now}
+
+			// the runner applied the first step after stale fetched
+			stored := *stale
+			stored.NextStep, stored.NextStepAt = 1, now.Add(time.Minute)
+			storager := &fakeTrafficPlanStorager{plan: &stored}
+
+			m := NewTrafficPlanManager(fakeTxn{}, storager, nil, nil, &stateful.TrafficPlanConfig{})
+			if _, err := c.change(m, stale); err != nil {
+				t.Fatalf("change error: %v", err)
+			}
+
+			if storager.unlocked != 0 {
+				t.Errorf("plan fetched without ForUpdate %d times", storager.unlocked)
+			}
+			if got := storager.plan; got.Status != c.want || got.NextStep != 1 {
+				t.Errorf("plan status %s next step %d, want %s next step 1", got.Status, got.NextStep, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+)
+
+const (
+	// TrafficWarningZeroCapacity BFE cluster or sub cluster receiving traffic has zero capacity
+	TrafficWarningZeroCapacity = "zero_capacity"
+	// TrafficWarningBlackholedRegion all traffic of BFE cluster goes to GSLB_BLACKHOLE
+	TrafficWarningBlackholedRegion = "blackholed_region"
+	// TrafficWarningDisabledWithWeight disabled sub cluster, or pool without available instance, still has weight
+	TrafficWarningDisabledWithWeight = "disabled_with_weight"
+	// TrafficWarningMissingRegion BFE cluster not found in lb matrix
+	TrafficWarningMissingRegion = "missing_region"
+)
+
+// TrafficPreview is the estimated distribution of cluster traffic, traffic of each
+// BFE cluster is assumed in proportion to its capacity. Shares are in percent of cluster traffic
+type TrafficPreview struct {
+	ClusterName    string                   `json:"cluster_name"`
+	Mode           string                   `json:"mode"`
+	BlackholeShare float64                  `json:"blackhole_share"`
+	Regions        []*RegionTrafficPreview  `json:"regions"`
+	SubClusters    []*SubClusterTrafficView `json:"sub_clusters"`
+	Warnings       []*TrafficPreviewWarning `json:"warnings"`
+}
+
+type RegionTrafficPreview struct {
+	BFECluster string         `json:"bfe_cluster"`
+	Capacity   int64          `json:"capacity"`
+	Share      float64        `json:"share"`
+	LbMatrix   map[string]int `json:"lb_matrix"`
+}
+
+type SubClusterTrafficView struct {
+	Name      string                 `json:"name"`
+	Enabled   bool                   `json:"enabled"`
+	Capacity  int64                  `json:"capacity"`
+	Share     float64                `json:"share"`
+	Instances []*InstanceTrafficView `json:"instances"`
+}
+
+type InstanceTrafficView struct {
+	Name     string  `json:"name"`
+	Addr     string  `json:"addr"`
+	Weight   int64   `json:"weight"` // weight exported to data plane, 0 if disabled
+	Disable  bool    `json:"disable"`
+	Draining bool    `json:"draining"`
+	Share    float64 `json:"share"`
+}
+
+type TrafficPreviewWarning struct {
+	Type    string `json:"type"` // see TrafficWarningXXX
+	Target  string `json:"target"`
+	Message string `json:"message"`
+}
+
+func roundShare(share float64) float64 {
+	return math.Round(share*10000) / 100
+}
+
+// PreviewTraffic resolves lb matrix, BFE cluster capacities and instance weights to
+// the share of cluster traffic received by each sub cluster and instance
+func (cm *ClusterManager) PreviewTraffic(ctx context.Context, cluster *Cluster) (preview *TrafficPreview, err error) {
+	var bfeClusters []*ibasic.BFECluster
+	err = cm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		bfeClusters, err = cm.bfeClusterStorager.FetchBFEClusters(ctx, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return PreviewTraffic(cluster, bfeClusters), nil
+}
+
+// PreviewTraffic is the calculation of ClusterManager.PreviewTraffic
+func PreviewTraffic(cluster *Cluster, bfeClusters []*ibasic.BFECluster) *TrafficPreview {
+	preview := &TrafficPreview{
+		ClusterName: cluster.Name,
+		Mode:        "manual",
+		Regions:     []*RegionTrafficPreview{},
+		SubClusters: []*SubClusterTrafficView{},
+		Warnings:    []*TrafficPreviewWarning{},
+	}
+	if cluster.AutoScheduler.Enabled() {
+		preview.Mode = "auto"
+	}
+	warn := func(typ, target, format string, args ...interface{}) {
+		preview.Warnings = append(preview.Warnings, &TrafficPreviewWarning{
+			Type:    typ,
+			Target:  target,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	bfeClusters = append([]*ibasic.BFECluster{}, bfeClusters...)
+	sort.Slice(bfeClusters, func(i, j int) bool {
+		return bfeClusters[i].Name < bfeClusters[j].Name
+	})
+
+	var totalCapacity int64
+	for _, one := range bfeClusters {
+		totalCapacity += one.Capacity
+	}
+	if totalCapacity == 0 && len(bfeClusters) > 0 {
+		warn(TrafficWarningZeroCapacity, "", "all BFE clusters have zero capacity, traffic is assumed evenly distributed")
+	}
+
+	// share of cluster traffic received by sub cluster, and weights set by region
+	shares := map[string]float64{}
+	weighted := map[string][]string{}
+	for _, one := range bfeClusters {
+		regionShare := 1 / float64(len(bfeClusters))
+		if totalCapacity > 0 {
+			regionShare = float64(one.Capacity) / float64(totalCapacity)
+		}
+
+		rates := cluster.Scheduler[one.Name]
+		preview.Regions = append(preview.Regions, &RegionTrafficPreview{
+			BFECluster: one.Name,
+			Capacity:   one.Capacity,
+			Share:      roundShare(regionShare),
+			LbMatrix:   rates,
+		})
+
+		if totalCapacity > 0 && one.Capacity == 0 {
+			warn(TrafficWarningZeroCapacity, one.Name, "BFE cluster %s has zero capacity, its traffic is not counted", one.Name)
+		}
+		if rates == nil {
+			warn(TrafficWarningMissingRegion, one.Name, "BFE cluster %s not in lb matrix", one.Name)
+			continue
+		}
+		if rates[BlackHole] >= 100 {
+			warn(TrafficWarningBlackholedRegion, one.Name, "all traffic of BFE cluster %s goes to %s", one.Name, BlackHole)
+		}
+
+		for name, rate := range rates {
+			if rate <= 0 {
+				continue
+			}
+			shares[name] += regionShare * float64(rate) / 100
+			weighted[name] = append(weighted[name], one.Name)
+		}
+	}
+	preview.BlackholeShare = roundShare(shares[BlackHole])
+
+	for _, sc := range cluster.SubClusters {
+		view := &SubClusterTrafficView{
+			Name:      sc.Name,
+			Enabled:   sc.Enabled,
+			Capacity:  sc.Capacity,
+			Share:     roundShare(shares[sc.Name]),
+			Instances: []*InstanceTrafficView{},
+		}
+
+		var totalWeight int64
+		if sc.InstancePool != nil {
+			for i := range sc.InstancePool.Instances {
+				instance := &sc.InstancePool.Instances[i]
+				// the same as exported to data plane
+				weight := instance.Weight
+				if instance.Disable {
+					weight = 0
+				}
+				totalWeight += weight
+
+				view.Instances = append(view.Instances, &InstanceTrafficView{
+					Name:     instance.HostName,
+					Addr:     instance.IPWithPort(),
+					Weight:   weight,
+					Disable:  instance.Disable,
+					Draining: instance.Draining(),
+				})
+			}
+		}
+		for _, one := range view.Instances {
+			if totalWeight > 0 {
+				one.Share = roundShare(shares[sc.Name] * float64(one.Weight) / float64(totalWeight))
+			}
+		}
+		preview.SubClusters = append(preview.SubClusters, view)
+
+		regions := weighted[sc.Name]
+		if len(regions) == 0 {
+			continue
+		}
+		if !sc.Enabled {
+			warn(TrafficWarningDisabledWithWeight, sc.Name, "sub cluster %s is disabled but has weight in BFE clusters %v", sc.Name, regions)
+		}
+		if totalWeight == 0 {
+			warn(TrafficWarningDisabledWithWeight, sc.Name, "pool of sub cluster %s has no instance with weight but has weight in BFE clusters %v", sc.Name, regions)
+		}
+		if sc.Capacity == 0 && shares[sc.Name] > 0 {
+			warn(TrafficWarningZeroCapacity, sc.Name, "sub cluster %s has zero capacity but receives %.2f%% traffic", sc.Name, roundShare(shares[sc.Name]))
+		}
+	}
+
+	return preview
+}
@@ -0,0 +1,184 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+)
+
+func TestPreviewTraffic(t *testing.T) {
+	pool := func(instances ...Instance) *Pool {
+		return &Pool{Instances: instances}
+	}
+
+	cases := []struct {
+		name        string
+		bfeClusters []*ibasic.BFECluster
+		scheduler   map[string]map[string]int
+		subClusters []*SubCluster
+
+		wantRegions   map[string]float64
+		wantBlackhole float64
+		wantShares    map[string]float64 // sub cluster or instance => share
+		wantWarnings  []string           // type@target
+	}{
+		{
+			name:        "shares across regions",
+			bfeClusters: []*ibasic.BFECluster{{Name: "gz", Capacity: 100}, {Name: "bj", Capacity: 300}},
+			scheduler: map[string]map[string]int{
+				"bj": {"s1": 50, "s2": 50},
+				"gz": {"s1": 100},
+			},
+			subClusters: []*SubCluster{
+				{Name: "s1", Enabled: true, Capacity: 10, InstancePool: pool(Instance{HostName: "a", Weight: 1}, Instance{HostName: "b", Weight: 3})},
+				{Name: "s2", Enabled: true, Capacity: 10, InstancePool: pool(Instance{HostName: "c", Weight: 1})},
+			},
+			wantRegions: map[string]float64{"bj": 75, "gz": 25},
+			wantShares:  map[string]float64{"s1": 62.5, "a": 15.63, "b": 46.88, "s2": 37.5, "c": 37.5},
+		},
+		{
+			name:        "zero total capacity split evenly",
+			bfeClusters: []*ibasic.BFECluster{{Name: "bj"}, {Name: "gz"}, {Name: "sh"}, {Name: "sz"}},
+			scheduler: map[string]map[string]int{
+				"bj": {"s1": 100},
+				"gz": {"s1": 50, BlackHole: 50},
+				"sh": {"s1": 100},
+				"sz": {"s1": 100},
+			},
+			subClusters: []*SubCluster{
+				{Name: "s1", Enabled: true, Capacity: 10, InstancePool: pool(Instance{HostName: "a", Weight: 1})},
+			},
+			wantRegions:   map[string]float64{"bj": 25, "gz": 25, "sh": 25, "sz": 25},
+			wantBlackhole: 12.5,
+			wantShares:    map[string]float64{"s1": 87.5, "a": 87.5},
+			wantWarnings:  []string{TrafficWarningZeroCapacity + "@"},
+		},
+		{
+			name:        "blackholed region",
+			bfeClusters: []*ibasic.BFECluster{{Name: "bj", Capacity: 100}, {Name: "gz", Capacity: 100}},
+			scheduler: map[string]map[string]int{
+				"bj": {"s1": 100},
+				"gz": {BlackHole: 100},
+			},
+			subClusters: []*SubCluster{
+				{Name: "s1", Enabled: true, Capacity: 10, InstancePool: pool(Instance{HostName: "a", Weight: 1})},
+			},
+			wantRegions:   map[string]float64{"bj": 50, "gz": 50},
+			wantBlackhole: 50,
+			wantShares:    map[string]float64{"s1": 50, "a": 50},
+			wantWarnings:  []string{TrafficWarningBlackholedRegion + "@gz"},
+		},
+		{
+			name:        "disabled sub cluster with weight",
+			bfeClusters: []*ibasic.BFECluster{{Name: "bj", Capacity: 100}},
+			scheduler:   map[string]map[string]int{"bj": {"s1": 100, "s2": 0}},
+			subClusters: []*SubCluster{
+				{Name: "s1", Capacity: 10, InstancePool: pool(Instance{HostName: "a", Weight: 1})},
+				{Name: "s2", Capacity: 10, InstancePool: pool(Instance{HostName: "b", Weight: 1})},
+			},
+			wantRegions:  map[string]float64{"bj": 100},
+			wantShares:   map[string]float64{"s1": 100, "a": 100, "s2": 0, "b": 0},
+			wantWarnings: []string{TrafficWarningDisabledWithWeight + "@s1"},
+		},
+		{
+			name:        "pool without weighted instance",
+			bfeClusters: []*ibasic.BFECluster{{Name: "bj", Capacity: 100}},
+			scheduler:   map[string]map[string]int{"bj": {"s1": 100}},
+			subClusters: []*SubCluster{
+				{Name: "s1", Enabled: true, Capacity: 10, InstancePool: pool(
+					Instance{HostName: "a", Weight: 1, Disable: true},
+					Instance{HostName: "b", Weight: 0},
+				)},
+			},
+			wantRegions:  map[string]float64{"bj": 100},
+			wantShares:   map[string]float64{"s1": 100, "a": 0, "b": 0},
+			wantWarnings: []string{TrafficWarningDisabledWithWeight + "@s1"},
+		},
+		{
+			name:        "region missing from lb matrix",
+			bfeClusters: []*ibasic.BFECluster{{Name: "bj", Capacity: 100}, {Name: "gz", Capacity: 100}},
+			scheduler:   map[string]map[string]int{"bj": {"s1": 100}},
+			subClusters: []*SubCluster{
+				{Name: "s1", Enabled: true, Capacity: 10, InstancePool: pool(Instance{HostName: "a", Weight: 1})},
+			},
+			wantRegions:  map[string]float64{"bj": 50, "gz": 50},
+			wantShares:   map[string]float64{"s1": 50, "a": 50},
+			wantWarnings: []string{TrafficWarningMissingRegion + "@gz"},
+		},
+		{
+			name:        "zero capacity",
+			bfeClusters: []*ibasic.BFECluster{{Name: "bj", Capacity: 100}, {Name: "gz"}},
+			scheduler: map[string]map[string]int{
+				"bj": {"s1": 100},
+				"gz": {"s1": 100},
+			},
+			subClusters: []*SubCluster{
+				{Name: "s1", Enabled: true, InstancePool: pool(Instance{HostName: "a", Weight: 1})},
+			},
+			wantRegions:  map[string]float64{"bj": 100, "gz": 0},
+			wantShares:   map[string]float64{"s1": 100, "a": 100},
+			wantWarnings: []string{TrafficWarningZeroCapacity + "@gz", TrafficWarningZeroCapacity + "@s1"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			preview := PreviewTraffic(&Cluster{
+				Name:        "cluster",
+				Scheduler:   c.scheduler,
+				SubClusters: c.subClusters,
+			}, c.bfeClusters)
+
+			regions := map[string]float64{}
+			for i, one := range preview.Regions {
+				regions[one.BFECluster] = one.Share
+				if i > 0 && preview.Regions[i-1].BFECluster > one.BFECluster {
+					t.Errorf("regions not sorted by name: %s before %s", preview.Regions[i-1].BFECluster, one.BFECluster)
+				}
+			}
+			if !reflect.DeepEqual(regions, c.wantRegions) {
+				t.Errorf("region shares = %v, want %v", regions, c.wantRegions)
+			}
+			if preview.BlackholeShare != c.wantBlackhole {
+				t.Errorf("blackhole share = %v, want %v", preview.BlackholeShare, c.wantBlackhole)
+			}
+
+			shares := map[string]float64{}
+			for _, sc := range preview.SubClusters {
+				shares[sc.Name] = sc.Share
+				for _, one := range sc.Instances {
+					shares[one.Name] = one.Share
+				}
+			}
+			if !reflect.DeepEqual(shares, c.wantShares) {
+				t.Errorf("shares = %v, want %v", shares, c.wantShares)
+			}
+
+			warnings := []string{}
+			for _, one := range preview.Warnings {
+				warnings = append(warnings, one.Type+"@"+one.Target)
+			}
+			if c.wantWarnings == nil {
+				c.wantWarnings = []string{}
+			}
+			if !reflect.DeepEqual(warnings, c.wantWarnings) {
+				t.Errorf("warnings = %v, want %v", warnings, c.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bfenetworks/bfe/bfe_config/bfe_cluster_conf/cluster_conf"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/iprotocol"
+)
+
+const maxServerNameLen = 255
+
+// ClusterUpstreamTLS is tls setting of https cluster, certificates are referred by name in certificate store
+type ClusterUpstreamTLS struct {
+	Verify     bool     `json:"verify"`                // verify certificate of server
+	ServerName string   `json:"server_name,omitempty"` // sni and host name to verify, host of request if empty
+	CACerts    []string `json:"ca_certs,omitempty"`    // ca certificates, system ca list is used if empty
+	ClientCert string   `json:"client_cert,omitempty"` // client certificate of mtls, must has private key
+}
+
+// CertificateFetcher fetches certificates from certificate store
+type CertificateFetcher func(ctx context.Context, filter *iprotocol.CertificateFilter) ([]*iprotocol.Certificate, error)
+
+// CheckUpstreamTLS validates tls setting and certificates it refers
+func CheckUpstreamTLS(ctx context.Context, upstreamTLS *ClusterUpstreamTLS, fetcher CertificateFetcher) error {
+	if len(upstreamTLS.ServerName) > maxServerNameLen {
+		return fmt.Errorf("server_name length must be lower than %d", maxServerNameLen)
+	}
+	if !upstreamTLS.Verify && len(upstreamTLS.CACerts) > 0 {
+		return fmt.Errorf("ca_certs requires verify")
+	}
+
+	certs, err := fetchCertificateMap(ctx, fetcher)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range upstreamTLS.CACerts {
+		if certs[name] == nil {
+			return fmt.Errorf("ca_certs: certificate %s not exist", name)
+		}
+	}
+
+	if name := upstreamTLS.ClientCert; name != "" {
+		cert := certs[name]
+		if cert == nil {
+			return fmt.Errorf("client_cert: certificate %s not exist", name)
+		}
+		if cert.IsCA() {
+			return fmt.Errorf("client_cert: certificate %s has no private key", name)
+		}
+	}
+
+	return nil
+}
+
+func fetchCertificateMap(ctx context.Context, fetcher CertificateFetcher) (map[string]*iprotocol.Certificate, error) {
+	list, err := fetcher(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	certs := map[string]*iprotocol.Certificate{}
+	for _, one := range list {
+		certs[one.CertName] = one
+	}
+
+	return certs, nil
+}
+
+// exportBackendHTTPS builds https conf of data plane, certificates are exported as path of extra files.
+// Clusters without tls setting skip verifying, which is the behavior before tls setting be supported
+func exportBackendHTTPS(upstreamTLS *ClusterUpstreamTLS, certs map[string]*iprotocol.Certificate) (*cluster_conf.BackendHTTPS, error) {
+	if upstreamTLS == nil {
+		return &cluster_conf.BackendHTTPS{
+			RSHost:               lib.PString(""),
+			RSInsecureSkipVerify: lib.PBool(true),
+		}, nil
+	}
+
+	conf := &cluster_conf.BackendHTTPS{
+		RSHost:               lib.PString(upstreamTLS.ServerName),
+		RSInsecureSkipVerify: lib.PBool(!upstreamTLS.Verify),
+	}
+
+	if len(upstreamTLS.CACerts) > 0 {
+		caList := []string{}
+		for _, name := range upstreamTLS.CACerts {
+			cert := certs[name]
+			if cert == nil {
+				return nil, fmt.Errorf("ca certificate %s not exist", name)
+			}
+			caList = append(caList, cert.CertFilePath)
+		}
+		conf.RSCAList = &caList
+	}
+
+	if name := upstreamTLS.ClientCert; name != "" {
+		cert := certs[name]
+		if cert == nil || cert.IsCA() {
+			return nil, fmt.Errorf("client certificate %s not exist or has no private key", name)
+		}
+		conf.BFECertFile = lib.PString(cert.CertFilePath)
+		conf.BFEKeyFile = lib.PString(cert.KeyFilePath)
+	}
+
+	return conf, nil
+}
+
+// CertificateDeleteChecker refuses deleting certificate referred by tls setting of cluster
+func (cm *ClusterManager) CertificateDeleteChecker(ctx context.Context, cert *iprotocol.Certificate) error {
+	clusters, err := cm.storager.FetchClusterList(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range clusters {
+		upstreamTLS := cluster.UpstreamTLS
+		if upstreamTLS == nil {
+			continue
+		}
+		if upstreamTLS.ClientCert == cert.CertName || lib.StringSlice2Map(upstreamTLS.CACerts)[cert.CertName] {
+			return xerror.WrapModelErrorWithMsg("Cluster %s Refer To This Certificate", cluster.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package icluster_conf
+
+import (
+	"testing"
+
+	"github.com/bfenetworks/bfe/bfe_config/bfe_cluster_conf/cluster_conf"
+
+	"github.com/yf-networks/ai-gateway-api/model/iprotocol"
+)
+
+func TestBfeClusterConfUpdateVersion(t *testing.T) {
+	certs := map[string]*iprotocol.Certificate{
+		"ca": {CertName: "ca", CertFilePath: "tls_conf/buildin/ca.crt"},
+		"client": {
+			CertName:     "client",
+			CertFileName: "client.crt",
+			CertFilePath: "tls_conf/buildin/client.crt",
+			KeyFileName:  "client.key",
+			KeyFilePath:  "tls_conf/buildin/client.key",
+		},
+	}
+
+	cases := []struct {
+		name        string
+		upstreamTLS *ClusterUpstreamTLS
+		wantCAList  []string
+		wantCert    string
+		wantKey     string
+	}{
+		{
+			name:        "ca and client cert",
+			upstreamTLS: &ClusterUpstreamTLS{Verify: true, CACerts: []string{"ca"}, ClientCert: "client"},
+			wantCAList:  []string{"tls_conf_v2/buildin/ca.crt"},
+			wantCert:    "tls_conf_v2/buildin/client.crt",
+			wantKey:     "tls_conf_v2/buildin/client.key",
+		},
+		{
+			name:        "system ca",
+			upstreamTLS: &ClusterUpstreamTLS{Verify: true},
+		},
+		{
+			name: "skip verify",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			httpsConf, err := exportBackendHTTPS(c.upstreamTLS, certs)
+			if err != nil {
+				t.Fatalf("exportBackendHTTPS() error = %v", err)
+			}
+			conf := &BfeClusterConf{
+				Config: &map[string]ClusterConf{
+					"c": {ClusterConf: cluster_conf.ClusterConf{HTTPSConf: httpsConf}},
+				},
+			}
+
+			// snapshot is exported with zero version then re-versioned
+			for _, version := range []string{"0", "v2"} {
+				if err := conf.UpdateVersion(version); err != nil {
+					t.Fatalf("UpdateVersion(%s) error = %v", version, err)
+				}
+			}
+
+			if *conf.Version != "v2" {
+				t.Errorf("Version = %s, want v2", *conf.Version)
+			}
+			got := (*conf.Config)["c"].HTTPSConf
+			if c.wantCAList == nil {
+				if got.RSCAList != nil {
+					t.Errorf("RSCAList = %v, want nil", *got.RSCAList)
+				}
+			} else if got.RSCAList == nil || len(*got.RSCAList) != 1 || (*got.RSCAList)[0] != c.wantCAList[0] {
+				t.Errorf("RSCAList = %v, want %v", got.RSCAList, c.wantCAList)
+			}
+			if gotCert := derefString(got.BFECertFile); gotCert != c.wantCert {
+				t.Errorf("BFECertFile = %s, want %s", gotCert, c.wantCert)
+			}
+			if gotKey := derefString(got.BFEKeyFile); gotKey != c.wantKey {
+				t.Errorf("BFEKeyFile = %s, want %s", gotKey, c.wantKey)
+			}
+		})
+	}
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -21,7 +21,7 @@ import (
 	"time"
 
 	"github.com/bfenetworks/bfe/bfe_modules/mod_ai_token_auth"
-	"github.com/yf-networks/ai-gateway-api/lib"
+
 	"github.com/yf-networks/ai-gateway-api/model/iai_route"
 	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
 	"github.com/yf-networks/ai-gateway-api/model/iversion_control"
@@ -49,6 +49,17 @@ type ExportContent struct {
 	RemainQuota    int64  `json:"remain_quota"`     // Remaining quota
 	Models         string `json:"models,omitempty"` // Allowed models (comma-separated)
 	Subnet         string `json:"subnet,omitempty"` // Allowed subnets (comma-separated)
+
+	// Upstream credentials replace the cluster key on requests carrying this api key
+	UpstreamCredentials []*ExportUpstreamCredential `json:"upstream_credentials,omitempty"`
+}
+
+// ExportUpstreamCredential defines the tenant owned provider credential exported to BFE,
+// the one bound to cluster takes precedence over the one bound to provider type
+type ExportUpstreamCredential struct {
+	Cluster      string `json:"cluster,omitempty"`       // Cluster name
+	ProviderType string `json:"provider_type,omitempty"` // Provider type of cluster llm config
+	Key          string `json:"key"`                     // Provider credential
 }
 
 // ExportAPIKeyRule defines the structure for API key routing rules exported to BFE
@@ -68,10 +79,10 @@ func (conf *ModAPIKeyRuleConf) UpdateVersion(version string) error {
 	return nil
 }
 
-// ConfigExport exports API key rule configuration for BFE
-func (rcm *APIKeyRuleManager) ConfigExport(ctx context.Context, lastVersion string) (*ModAPIKeyRuleConf, error) {
+// ConfigExport exports API key rule configuration for BFE, the version targeted by bfeCluster is exported
+func (rcm *APIKeyRuleManager) ConfigExport(ctx context.Context, lastVersion, bfeCluster string) (*ModAPIKeyRuleConf, error) {
 	// Export configuration using version control manager
-	rst, err := rcm.versionControlManager.ExportConfig(ctx, ConfigTopicProductAPIKeyRule, rcm.APIKeyRuleGenerator)
+	rst, err := rcm.versionControlManager.ExportTargetConfig(ctx, ConfigTopicProductAPIKeyRule, bfeCluster, rcm.APIKeyRuleGenerator)
 	if err != nil {
 		return nil, err
 	}
@@ -170,6 +181,7 @@ func (rlm *APIKeyRuleManager) APIKeyRuleGenerator(ctx context.Context) (*iversio
 	}
 
 	// Build token configuration for each product
+	now := time.Now()
 	apiKey2Config := make(map[string]map[string]ExportContent)
 	for _, one := range apiKeyList {
 		// Initialize product map if not exists
@@ -178,46 +190,26 @@ func (rlm *APIKeyRuleManager) APIKeyRuleGenerator(ctx context.Context) (*iversio
 			apiKey2Config[*one.ProductName] = items
 		}
 
-		// Parse expiration time
+		// Evaluate key status
+		state, err := icluster_conf.EvaluateAPIKeyStatus(one, now, icluster_conf.GetRemainingQuota)
+		if err != nil {
+			return nil, err
+		}
+
 		expiredTime := int64(UnlimitedQuota) // Default to unlimited
-		if one.ExpiredTime != nil && *one.ExpiredTime != "" {
-			t, err := time.ParseInLocation(lib.FormatTimeYYMMDD_HHMMSS, *one.ExpiredTime, time.Local)
-			if err != nil {
-				return nil, fmt.Errorf("parse expired time:%s is error:%s", *one.ExpiredTime, err.Error())
-			}
-			expiredTime = t.Unix()
+		if state.ExpiredAt != nil {
+			expiredTime = state.ExpiredAt.Unix()
 		}
 
-		// Determine key status and quota
 		limit := int64(0)
-		status := mod_ai_token_auth.TokenStatusDisabled
-		if one.Enable != nil && *one.Enable {
-			if *one.IsLimit {
-				limit = *one.Limit
-				status = mod_ai_token_auth.TokenStatusEnabled
-				remainingQuota, err := icluster_conf.GetRemainingQuota(one)
-				if err != nil {
-					return nil, err
-				}
-
-				if remainingQuota != nil {
-					// Check if key has expired
-					if expiredTime != int64(UnlimitedQuota) && time.Now().Local().Unix() >= expiredTime {
-						status = mod_ai_token_auth.TokenStatusExpired
-					}
-				} else {
-					status = mod_ai_token_auth.TokenStatusExhausted
-				}
-			} else {
-				status = mod_ai_token_auth.TokenStatusEnabled
-			}
+		if one.Enable != nil && *one.Enable && *one.IsLimit {
+			limit = *one.Limit
 		}
-
 		// Build export content
 		items := apiKey2Config[*one.ProductName]
 		ec := ExportContent{
 			Key:         *one.Key,
-			Status:      status,
+			Status:      state.TokenStatus(),
 			Name:        *one.Name,
 			ExpiredTime: expiredTime,
 			UpdatedTime: one.KeyCreateAt.Unix(),
@@ -241,6 +233,25 @@ func (rlm *APIKeyRuleManager) APIKeyRuleGenerator(ctx context.Context) (*iversio
 			ec.Subnet = strings.Join(one.AllowedCIDR, ",")
 		}
 
+		// Decrypt upstream credentials
+		for _, credential := range one.UpstreamCredentials {
+			key, err := icluster_conf.OpenUpstreamCredential(ctx, credential)
+			if err != nil {
+				return nil, err
+			}
+
+			euc := &ExportUpstreamCredential{
+				Key: key,
+			}
+			if credential.Cluster != nil {
+				euc.Cluster = *credential.Cluster
+			}
+			if credential.ProviderType != nil {
+				euc.ProviderType = *credential.ProviderType
+			}
+			ec.UpstreamCredentials = append(ec.UpstreamCredentials, euc)
+		}
+
 		// Add to configuration
 		items[*one.Key] = ec
 		apiKey2Config[*one.ProductName] = items
@@ -261,6 +272,45 @@ func (rlm *APIKeyRuleManager) APIKeyRuleGenerator(ctx context.Context) (*iversio
 	}, nil
 }
 
+// RevokeAPIKeys applies revocations of the latest api keys to api keys of the targeted version: keys deleted since
+// are removed, keys not enabled now take the latest status and shortened expiries apply. Other changes of keys,
+// like new keys, allowed models and quotas, are staged with the targeted version
+func RevokeAPIKeys(latest, targeted iversion_control.VersionValuable) (bool, error) {
+	latestConf, ok := latest.(*ModAPIKeyRuleConf)
+	if !ok {
+		return false, fmt.Errorf("convert latest data to ModAPIKeyRuleConf is error")
+	}
+	targetedConf, ok := targeted.(*ModAPIKeyRuleConf)
+	if !ok {
+		return false, fmt.Errorf("convert targeted data to ModAPIKeyRuleConf is error")
+	}
+
+	revoked := false
+	for productName, tokens := range targetedConf.Tokens {
+		for key, token := range tokens {
+			now, ok := latestConf.Tokens[productName][key]
+			if !ok {
+				delete(tokens, key)
+				revoked = true
+				continue
+			}
+
+			if now.Status != mod_ai_token_auth.TokenStatusEnabled && token.Status != now.Status {
+				token.Status = now.Status
+				revoked = true
+			}
+			if now.ExpiredTime != UnlimitedQuota &&
+				(token.ExpiredTime == UnlimitedQuota || now.ExpiredTime < token.ExpiredTime) {
+				token.ExpiredTime = now.ExpiredTime
+				revoked = true
+			}
+			tokens[key] = token
+		}
+	}
+
+	return revoked, nil
+}
+
 // convertAPIKeyRulesToBfeRules converts internal API key rules to BFE format
 func convertAPIKeyRulesToBfeRules(oldRules []*APIKeyRule) []*ExportAPIKeyRule {
 	exportRules := make([]*ExportAPIKeyRule, len(oldRules))
@@ -0,0 +1,127 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package imods
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bfenetworks/bfe/bfe_modules/mod_ai_token_auth"
+)
+
+func TestRevokeAPIKeys(t *testing.T) {
+	enabled := ExportContent{Key: "sk-1", Status: mod_ai_token_auth.TokenStatusEnabled, ExpiredTime: 2000, RemainQuota: 10}
+	with := func(one ExportContent, change func(*ExportContent)) ExportContent {
+		change(&one)
+		return one
+	}
+	conf := func(tokens ...ExportContent) *ModAPIKeyRuleConf {
+		items := map[string]ExportContent{}
+		for _, one := range tokens {
+			items[one.Key] = one
+		}
+		return &ModAPIKeyRuleConf{Tokens: map[string]map[string]ExportContent{"p": items}}
+	}
+
+	cases := []struct {
+		name        string
+		latest      *ModAPIKeyRuleConf
+		targeted    *ModAPIKeyRuleConf
+		want        *ModAPIKeyRuleConf
+		wantRevoked bool
+	}{
+		{
+			name:     "unchanged",
+			latest:   conf(enabled),
+			targeted: conf(enabled),
+			want:     conf(enabled),
+		},
+		{
+			name:     "new key and quota staged",
+			latest:   conf(with(enabled, func(c *ExportContent) { c.RemainQuota = 20 }), ExportContent{Key: "sk-2"}),
+			targeted: conf(enabled),
+			want:     conf(enabled),
+		},
+		{
+			name:     "enabled again staged",
+			latest:   conf(enabled),
+			targeted: conf(with(enabled, func(c *ExportContent) { c.Status = mod_ai_token_auth.TokenStatusDisabled })),
+			want:     conf(with(enabled, func(c *ExportContent) { c.Status = mod_ai_token_auth.TokenStatusDisabled })),
+		},
+		{
+			name:     "later expiry staged",
+			latest:   conf(with(enabled, func(c *ExportContent) { c.ExpiredTime = 3000 })),
+			targeted: conf(enabled),
+			want:     conf(enabled),
+		},
+		{
+			name:        "deleted",
+			latest:      conf(),
+			targeted:    conf(enabled),
+			want:        conf(),
+			wantRevoked: true,
+		},
+		{
+			name:        "disabled",
+			latest:      conf(with(enabled, func(c *ExportContent) { c.Status = mod_ai_token_auth.TokenStatusDisabled; c.RemainQuota = 20 })),
+			targeted:    conf(enabled),
+			want:        conf(with(enabled, func(c *ExportContent) { c.Status = mod_ai_token_auth.TokenStatusDisabled })),
+			wantRevoked: true,
+		},
+		{
+			name:        "expired",
+			latest:      conf(with(enabled, func(c *ExportContent) { c.Status = mod_ai_token_auth.TokenStatusExpired })),
+			targeted:    conf(enabled),
+			want:        conf(with(enabled, func(c *ExportContent) { c.Status = mod_ai_token_auth.TokenStatusExpired })),
+			wantRevoked: true,
+		},
+		{
+			name:        "exhausted",
+			latest:      conf(with(enabled, func(c *ExportContent) { c.Status = mod_ai_token_auth.TokenStatusExhausted })),
+			targeted:    conf(enabled),
+			want:        conf(with(enabled, func(c *ExportContent) { c.Status = mod_ai_token_auth.TokenStatusExhausted })),
+			wantRevoked: true,
+		},
+		{
+			name:        "expiry shortened",
+			latest:      conf(with(enabled, func(c *ExportContent) { c.ExpiredTime = 1000 })),
+			targeted:    conf(enabled),
+			want:        conf(with(enabled, func(c *ExportContent) { c.ExpiredTime = 1000 })),
+			wantRevoked: true,
+		},
+		{
+			name:        "expiry set on key never expired",
+			latest:      conf(enabled),
+			targeted:    conf(with(enabled, func(c *ExportContent) { c.ExpiredTime = UnlimitedQuota })),
+			want:        conf(enabled),
+			wantRevoked: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			revoked, err := RevokeAPIKeys(c.latest, c.targeted)
+			if err != nil {
+				t.Fatalf("RevokeAPIKeys() error: %v", err)
+			}
+			if revoked != c.wantRevoked {
+				t.Errorf("RevokeAPIKeys() = %v, want %v", revoked, c.wantRevoked)
+			}
+			if !reflect.DeepEqual(c.targeted, c.want) {
+				t.Errorf("targeted = %+v, want %+v", c.targeted.Tokens, c.want.Tokens)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package inotify
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+// FileChannel append event as json line to local file, mostly used for test
+type FileChannel struct {
+	conf *stateful.FileChannelConfig
+	lock sync.Mutex
+}
+
+var _ Channel = &FileChannel{}
+
+func NewFileChannel(conf *stateful.FileChannelConfig) *FileChannel {
+	return &FileChannel{
+		conf: conf,
+	}
+}
+
+func (fc *FileChannel) Name() string {
+	return "file"
+}
+
+func (fc *FileChannel) Send(ctx context.Context, event *Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+
+	f, err := os.OpenFile(fc.conf.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
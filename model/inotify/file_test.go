@@ -0,0 +1,89 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package inotify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+func TestFileChannelSend(t *testing.T) {
+	cases := []struct {
+		name    string
+		path    func(dir string) string
+		events  []*Event
+		wantErr bool
+	}{
+		{
+			name:   "append json lines",
+			path:   func(dir string) string { return filepath.Join(dir, "notification.log") },
+			events: []*Event{{Type: "a", Subject: "first"}, {Type: "b", Subject: "second", Labels: map[string]string{"k": "v"}}},
+		},
+		{
+			name:    "dir not exist",
+			path:    func(dir string) string { return filepath.Join(dir, "missing", "notification.log") },
+			events:  []*Event{{Type: "a"}},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := c.path(t.TempDir())
+			channel := NewFileChannel(&stateful.FileChannelConfig{Path: path})
+
+			for _, event := range c.events {
+				err := channel.Send(context.Background(), event)
+				if (err != nil) != c.wantErr {
+					t.Fatalf("Send() error = %v, wantErr %v", err, c.wantErr)
+				}
+			}
+			if c.wantErr {
+				return
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			var got []*Event
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				event := &Event{}
+				if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+					t.Fatalf("line %q is not json: %v", scanner.Text(), err)
+				}
+				got = append(got, event)
+			}
+
+			if len(got) != len(c.events) {
+				t.Fatalf("got %d lines, want %d", len(got), len(c.events))
+			}
+			for i, event := range c.events {
+				if got[i].Type != event.Type || got[i].Subject != event.Subject || len(got[i].Labels) != len(event.Labels) {
+					t.Errorf("line %d = %+v, want %+v", i, got[i], event)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package inotify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+// Event is the message delivered by channels
+type Event struct {
+	Type        string            `json:"type"`
+	ProductName string            `json:"product_name"`
+	Subject     string            `json:"subject"`
+	Content     string            `json:"content"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	OccurredAt  time.Time         `json:"occurred_at"`
+
+	// Receivers are mail addresses of the owner, ignored by channels not based on mail
+	Receivers []string `json:"receivers,omitempty"`
+}
+
+// Channel delivers events to somewhere, implement it to add new channel
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, event *Event) error
+}
+
+// Notifier dispatch event to all channels
+type Notifier struct {
+	channels []Channel
+}
+
+func NewNotifier(channels ...Channel) *Notifier {
+	return &Notifier{
+		channels: channels,
+	}
+}
+
+// NewNotifierFromConfig creates channels configured in conf
+func NewNotifierFromConfig(conf *stateful.NotificationConfig) *Notifier {
+	var channels []Channel
+	if conf.Webhook != nil {
+		channels = append(channels, NewWebhookChannel(conf.Webhook))
+	}
+	if conf.SMTP != nil {
+		channels = append(channels, NewSMTPChannel(conf.SMTP))
+	}
+	if conf.File != nil {
+		channels = append(channels, NewFileChannel(conf.File))
+	}
+
+	return NewNotifier(channels...)
+}
+
+func (n *Notifier) ChannelCount() int {
+	return len(n.channels)
+}
+
+// Notify send event to all channels, the event is treated as delivered when
+// at least one channel succeed
+func (n *Notifier) Notify(ctx context.Context, event *Event) error {
+	if len(n.channels) == 0 {
+		return fmt.Errorf("no notification channel")
+	}
+
+	var errs []string
+	for _, channel := range n.channels {
+		if err := channel.Send(ctx, event); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", channel.Name(), err))
+		}
+	}
+
+	if len(errs) == len(n.channels) {
+		return fmt.Errorf("notify fail, %s", strings.Join(errs, "; "))
+	}
+	if len(errs) > 0 {
+		stateful.AccessLogger.Warn("notify partial fail, %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package inotify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+// SMTPChannel send event as plain text mail to the owner
+type SMTPChannel struct {
+	conf *stateful.SMTPChannelConfig
+}
+
+var _ Channel = &SMTPChannel{}
+
+func NewSMTPChannel(conf *stateful.SMTPChannelConfig) *SMTPChannel {
+	return &SMTPChannel{
+		conf: conf,
+	}
+}
+
+func (sc *SMTPChannel) Name() string {
+	return "smtp"
+}
+
+func (sc *SMTPChannel) Send(ctx context.Context, event *Event) error {
+	to := append(append([]string{}, event.Receivers...), sc.conf.To...)
+	if len(to) == 0 {
+		return fmt.Errorf("no receiver")
+	}
+
+	var auth smtp.Auth
+	if sc.conf.User != "" {
+		host, _, err := net.SplitHostPort(sc.conf.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", sc.conf.User, sc.conf.Password, host)
+	}
+
+	msg := strings.Join([]string{
+		"From: " + sc.conf.From,
+		"To: " + strings.Join(to, ","),
+		"Subject: " + event.Subject,
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		event.Content,
+	}, "\r\n")
+
+	return smtp.SendMail(sc.conf.Addr, auth, sc.conf.From, to, []byte(msg))
+}
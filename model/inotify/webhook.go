@@ -0,0 +1,82 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package inotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+const defaultWebhookTimeout = 5 * time.Second
+
+// WebhookChannel post event as json
+type WebhookChannel struct {
+	conf   *stateful.WebhookChannelConfig
+	client *http.Client
+}
+
+var _ Channel = &WebhookChannel{}
+
+func NewWebhookChannel(conf *stateful.WebhookChannelConfig) *WebhookChannel {
+	timeout := defaultWebhookTimeout
+	if conf.TimeoutInMs > 0 {
+		timeout = time.Duration(conf.TimeoutInMs) * time.Millisecond
+	}
+
+	return &WebhookChannel{
+		conf: conf,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+func (wc *WebhookChannel) Name() string {
+	return "webhook"
+}
+
+func (wc *WebhookChannel) Send(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wc.conf.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range wc.conf.Headers {
+		req.Header.Set(k, v)
+	}
+
+	rsp, err := wc.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", rsp.StatusCode)
+	}
+
+	return nil
+}
@@ -16,12 +16,15 @@ package iprotocol
 
 import (
 	"context"
+	"crypto/md5"
 	"encoding/pem"
+	"fmt"
 	"strings"
 
 	"github.com/yf-networks/ai-gateway-api/lib"
 	"github.com/yf-networks/ai-gateway-api/lib/xerror"
 	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
 	"github.com/yf-networks/ai-gateway-api/model/itxn"
 	"github.com/yf-networks/ai-gateway-api/model/iversion_control"
 	"github.com/bfenetworks/bfe/bfe_tls"
@@ -41,6 +44,11 @@ type Certificate struct {
 	Products []*ibasic.Product
 }
 
+// IsCA tells whether certificate has no private key, which is only used to verify upstream servers
+func (c *Certificate) IsCA() bool {
+	return c.KeyFileName == ""
+}
+
 type CertificateFilter struct {
 	CertName  *string
 	IsDefault *bool
@@ -73,17 +81,22 @@ type CertificateManager struct {
 	extraFileStorager ibasic.ExtraFileStorager
 
 	versionControlManager *iversion_control.VersionControlManager
+
+	deleteCheckers map[string]func(context.Context, *Certificate) error
 }
 
 func NewCertificateManager(txn itxn.TxnStorager, storager CertificateStorager,
 	versionControlManager *iversion_control.VersionControlManager,
-	extraFileStorager ibasic.ExtraFileStorager) *CertificateManager {
+	extraFileStorager ibasic.ExtraFileStorager,
+	deleteCheckers map[string]func(context.Context, *Certificate) error) *CertificateManager {
 	return &CertificateManager{
 		txn:               txn,
 		storager:          storager,
 		extraFileStorager: extraFileStorager,
 
 		versionControlManager: versionControlManager,
+
+		deleteCheckers: deleteCheckers,
 	}
 }
 
@@ -106,8 +119,18 @@ func (pm *CertificateManager) DeleteCertificate(ctx context.Context, certificate
 	}
 
 	return pm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		for _, checker := range pm.deleteCheckers {
+			if err := checker(ctx, certificate); err != nil {
+				return err
+			}
+		}
+
+		names := []string{certificate.CertFilePath}
+		if !certificate.IsCA() {
+			names = append(names, certificate.KeyFilePath)
+		}
 		if err := pm.extraFileStorager.DeleteExtraFile(ctx, &ibasic.ExtraFileFilter{
-			Names: []string{certificate.CertFilePath, certificate.KeyFilePath},
+			Names: names,
 		}); err != nil {
 			return err
 		}
@@ -136,6 +159,14 @@ func validateCertPair(certFileName string, certFileContent string, keyFileName s
 		return err
 	}
 
+	// ca certificate
+	if keyFileName == "" {
+		if block, _ := pem.Decode([]byte(certFileContent)); block == nil {
+			return xerror.WrapParamErrorWithMsg("Certificate File Format Must Be PEM")
+		}
+		return nil
+	}
+
 	checkKeyFileInfo := func(keyPEMBlock []byte) error {
 		var keyDERBlock *pem.Block
 		keyDERBlock, _ = pem.Decode(keyPEMBlock)
@@ -164,14 +195,25 @@ func validateCertPair(certFileName string, certFileContent string, keyFileName s
 	return nil
 }
 
+// CreateCertificate creates certificate, it is a ca certificate if key file not set
 func (pm *CertificateManager) CreateCertificate(ctx context.Context, param *CertificateParam) (err error) {
+	isCA := param.KeyFileName == nil || *param.KeyFileName == ""
+	if isCA {
+		if param.IsDefault != nil && *param.IsDefault {
+			return xerror.WrapParamErrorWithMsg("CA Certificate Cant Be Default")
+		}
+		param.KeyFileName, param.KeyFileContent = lib.PString(""), lib.PString("")
+	}
+
 	if err = validateCertPair(*param.CertFileName, *param.CertFileContent, *param.KeyFileName, *param.KeyFileContent); err != nil {
 		return err
 	}
 
 	names := []string{
 		*param.CertFileName,
-		*param.KeyFileName,
+	}
+	if !isCA {
+		names = append(names, *param.KeyFileName)
 	}
 
 	existedNames := map[string]bool{}
@@ -220,14 +262,32 @@ func (pm *CertificateManager) CreateCertificate(ctx context.Context, param *Cert
 		}
 
 		param.CertFilePath = lib.PString(ibasic.ExtraFilePath(tlsConfDir, ibasic.BuildinProduct, *param.CertFileName))
+		if isCA {
+			param.KeyFilePath = lib.PString("")
+			if err := pm.extraFileStorager.CreateExtraFile(ctx, ibasic.BuildinProduct, &ibasic.ExtraFileParam{
+				Name:    param.CertFilePath,
+				Content: []byte(*param.CertFileContent),
+			}); err != nil {
+				return err
+			}
+
+			return pm.storager.CreateCertificate(ctx, param)
+		}
 		param.KeyFilePath = lib.PString(ibasic.ExtraFilePath(tlsConfDir, ibasic.BuildinProduct, *param.KeyFileName))
 
+		// private key is sealed, md5 is calculated with plain text which is exported to data plane
+		sealedKeyFileContent, err := isecret.Seal(ctx, *param.KeyFileContent)
+		if err != nil {
+			return err
+		}
+
 		if err := pm.extraFileStorager.CreateExtraFile(ctx, ibasic.BuildinProduct, &ibasic.ExtraFileParam{
 			Name:    param.CertFilePath,
 			Content: []byte(*param.CertFileContent),
 		}, &ibasic.ExtraFileParam{
 			Name:    param.KeyFilePath,
-			Content: []byte(*param.KeyFileContent),
+			Md5:     []byte(fmt.Sprintf("%x", md5.Sum([]byte(*param.KeyFileContent)))),
+			Content: []byte(sealedKeyFileContent),
 		}); err != nil {
 			return err
 		}
@@ -242,6 +302,9 @@ func (pm *CertificateManager) UpdateAsDefaultCertificate(ctx context.Context, ce
 	if cert.IsDefault {
 		return nil
 	}
+	if cert.IsCA() {
+		return xerror.WrapParamErrorWithMsg("CA Certificate Cant Be Default")
+	}
 
 	return pm.txn.AtomExecute(ctx, func(ctx context.Context) error {
 
@@ -264,3 +327,76 @@ func (pm *CertificateManager) UpdateAsDefaultCertificate(ctx context.Context, ce
 		})
 	})
 }
+
+// FetchCertificateKey return the plain text private key of certificate
+func (pm *CertificateManager) FetchCertificateKey(ctx context.Context, cert *Certificate) (key string, err error) {
+	if cert.IsCA() {
+		return "", xerror.WrapParamErrorWithMsg("CA Certificate %s Has No Private Key", cert.CertName)
+	}
+
+	err = pm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		files, err := pm.extraFileStorager.FetchExtraFiles(ctx, &ibasic.ExtraFileFilter{
+			Name: &cert.KeyFilePath,
+		})
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return xerror.WrapDirtyDataErrorWithMsg("Certificate %s Key File Not Exist", cert.CertName)
+		}
+
+		key, err = isecret.Open(ctx, string(files[0].Content))
+		return err
+	})
+
+	return
+}
+
+// RekeySecrets seals private keys of all certificates with the current master key,
+// keys stored in plain text before encryption be enabled are sealed too
+func (pm *CertificateManager) RekeySecrets(ctx context.Context) (count int, err error) {
+	err = pm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := pm.storager.FetchCertificates(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		names := []string{}
+		for _, one := range list {
+			if !one.IsCA() {
+				names = append(names, one.KeyFilePath)
+			}
+		}
+		if len(names) == 0 {
+			return nil
+		}
+
+		files, err := pm.extraFileStorager.FetchExtraFiles(ctx, &ibasic.ExtraFileFilter{
+			Names: names,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			content, changed, err := isecret.Rekey(ctx, string(file.Content))
+			if err != nil {
+				return xerror.WrapModelErrorWithMsg("Rekey %s: %v", file.Name, err)
+			}
+			if !changed {
+				continue
+			}
+
+			if err = pm.extraFileStorager.UpdateExtraFile(ctx, file, &ibasic.ExtraFileParam{
+				Content: []byte(content),
+			}); err != nil {
+				return err
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return
+}
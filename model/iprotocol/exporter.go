@@ -37,17 +37,13 @@ func (scc *ServerCertConf) UpdateVersion(version string) error {
 	scc.Version = version
 
 	for certFileName, certConfig := range scc.BfeServerCertConf.Config.CertConf {
-		i := strings.Index(certConfig.ServerCertFile, "/")
-		if i == -1 { // impossible
-			return xerror.WrapDirtyDataErrorWithMsg("ServerCertFile must has /, path: %s", certConfig.ServerCertFile)
+		var err error
+		if certConfig.ServerCertFile, err = VersionedTLSFilePath(certConfig.ServerCertFile, version); err != nil {
+			return err
 		}
-		certConfig.ServerCertFile = tlsConfDir + "_" + version + certConfig.ServerCertFile[i:]
-
-		i = strings.Index(certConfig.ServerKeyFile, "/")
-		if i == -1 { // impossible
-			return xerror.WrapDirtyDataErrorWithMsg("ServerKeyFile must has /, path: %s", certConfig.ServerKeyFile)
+		if certConfig.ServerKeyFile, err = VersionedTLSFilePath(certConfig.ServerKeyFile, version); err != nil {
+			return err
 		}
-		certConfig.ServerKeyFile = tlsConfDir + "_" + version + certConfig.ServerKeyFile[i:]
 
 		scc.Config.CertConf[certFileName] = certConfig
 	}
@@ -55,6 +51,16 @@ func (scc *ServerCertConf) UpdateVersion(version string) error {
 	return nil
 }
 
+// VersionedTLSFilePath moves certificate file to tls conf dir of version, data plane loads files of each version from its own dir
+func VersionedTLSFilePath(path, version string) (string, error) {
+	i := strings.Index(path, "/")
+	if i == -1 { // impossible
+		return "", xerror.WrapDirtyDataErrorWithMsg("tls file path must has /, path: %s", path)
+	}
+
+	return tlsConfDir + "_" + version + path[i:], nil
+}
+
 func (pm *CertificateManager) certificateGenerator(ctx context.Context) (*iversion_control.ExportData, error) {
 	certificates, err := pm.storager.FetchCertificates(ctx, nil)
 	if err != nil {
@@ -65,6 +71,11 @@ func (pm *CertificateManager) certificateGenerator(ctx context.Context) (*iversi
 	certConf := map[string]server_cert_conf.ServerCertConf{}
 
 	for _, cert := range certificates {
+		// ca certificates are exported by clusters refer them
+		if cert.IsCA() {
+			continue
+		}
+
 		if cert.IsDefault {
 			defaultCertName = cert.CertName
 		}
@@ -91,8 +102,9 @@ func (pm *CertificateManager) certificateGenerator(ctx context.Context) (*iversi
 	}, nil
 }
 
-func (pm *CertificateManager) ExportServerCert(ctx context.Context, lastVersion string) (*ServerCertConf, error) {
-	ed, err := pm.versionControlManager.ExportConfig(ctx, ConfigTopicServerCert, pm.certificateGenerator)
+// ExportServerCert exports the version targeted by bfeCluster, returns nil if it is lastVersion
+func (pm *CertificateManager) ExportServerCert(ctx context.Context, lastVersion, bfeCluster string) (*ServerCertConf, error) {
+	ed, err := pm.versionControlManager.ExportTargetConfig(ctx, ConfigTopicServerCert, bfeCluster, pm.certificateGenerator)
 	if err != nil {
 		return nil, err
 	}
@@ -30,8 +30,9 @@ package iroute_conf
 
 import (
 	"context"
+	"sort"
+	"strings"
 
-	"github.com/bfenetworks/bfe/bfe_config/bfe_cluster_conf/cluster_conf"
 	"github.com/bfenetworks/bfe/bfe_config/bfe_route_conf/host_rule_conf"
 	"github.com/bfenetworks/bfe/bfe_config/bfe_route_conf/route_rule_conf"
 
@@ -46,24 +47,48 @@ type RouteRuleExportData struct {
 	Version     string
 	HostTable   *host_rule_conf.HostTableConf
 	RouteTable  *route_rule_conf.RouteTableFile
-	ClusterConf *cluster_conf.BfeClusterConf
+	ClusterConf *icluster_conf.BfeClusterConf
 }
 
 func (rred *RouteRuleExportData) UpdateVersion(version string) error {
 	rred.Version = version
 	rred.RouteTable.Version = &version
 	rred.HostTable.Version = &version
-	rred.ClusterConf.Version = &version
 
-	return nil
+	return rred.ClusterConf.UpdateVersion(version)
 }
 
 const (
 	ConfigTopicRouteRule = "route_rule"
 )
 
-func (rm *RouteRuleManager) ExportRouteRule(ctx context.Context, lastVersion string) (*RouteRuleExportData, error) {
-	ed, err := rm.versionControlManager.ExportConfig(ctx, ConfigTopicRouteRule, rm.exportRouteRule)
+// CheckClusterTableTarget makes sure clusters of route rule are in the cluster table served together,
+// datas are decoded snapshots of route rule and cluster table
+func CheckClusterTableTarget(datas map[string]interface{}) error {
+	routeRule, _ := datas[ConfigTopicRouteRule].(map[string]interface{})
+	clusterConf, _ := routeRule["ClusterConf"].(map[string]interface{})
+	clusters, _ := clusterConf["Config"].(map[string]interface{})
+
+	clusterTable, _ := datas[icluster_conf.ConfigTopicClusterTable].(map[string]interface{})
+	backends, _ := clusterTable["Config"].(map[string]interface{})
+
+	missing := []string{}
+	for name := range clusters {
+		if _, ok := backends[name]; !ok && name != icluster_conf.RouteAdvancedModeClusterName4DP {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return xerror.WrapParamErrorWithMsg("Clusters %s Of Route Rule Not In Cluster Table", strings.Join(missing, ","))
+	}
+
+	return nil
+}
+
+// ExportRouteRule exports the version targeted by bfeCluster, returns nil if it is lastVersion
+func (rm *RouteRuleManager) ExportRouteRule(ctx context.Context, lastVersion, bfeCluster string) (*RouteRuleExportData, error) {
+	ed, err := rm.versionControlManager.ExportTargetConfig(ctx, ConfigTopicRouteRule, bfeCluster, rm.exportRouteRule)
 	if err != nil {
 		return nil, err
 	}
@@ -109,11 +134,16 @@ func (rm *RouteRuleManager) exportRouteRule(ctx context.Context) (*iversion_cont
 	}
 
 	emptyVersion := iversion_control.ZeroVersion
+	clusterConf, err := icluster_conf.NewBfeClusterConf(ctx, emptyVersion, clusters, rm.providerProtocols, rm.certificates)
+	if err != nil {
+		return nil, err
+	}
+
 	rred := &RouteRuleExportData{
 		Version:     emptyVersion,
 		RouteTable:  newRouteTableFile(emptyVersion, productMapID2Name, routeRules),
 		HostTable:   newHostTableConf(emptyVersion, productMapID2Name, domains),
-		ClusterConf: icluster_conf.NewBfeClusterConf(emptyVersion, clusters),
+		ClusterConf: clusterConf,
 	}
 
 	return &iversion_control.ExportData{
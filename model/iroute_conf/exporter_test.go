@@ -0,0 +1,81 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package iroute_conf
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+)
+
+func TestCheckClusterTableTarget(t *testing.T) {
+	routeRule := func(clusters ...string) interface{} {
+		config := map[string]icluster_conf.ClusterConf{}
+		for _, one := range clusters {
+			config[one] = icluster_conf.ClusterConf{}
+		}
+		return decode(t, &RouteRuleExportData{
+			ClusterConf: &icluster_conf.BfeClusterConf{Version: lib.PString("1"), Config: &config},
+		})
+	}
+	clusterTable := func(clusters ...string) interface{} {
+		config := map[string]interface{}{}
+		for _, one := range clusters {
+			config[one] = map[string]interface{}{}
+		}
+		return decode(t, map[string]interface{}{"Version": "1", "Config": config})
+	}
+
+	cases := []struct {
+		name    string
+		route   interface{}
+		table   interface{}
+		wantErr bool
+	}{
+		{name: "same clusters", route: routeRule("a", "b"), table: clusterTable("a", "b")},
+		{name: "cluster table has more", route: routeRule("a"), table: clusterTable("a", "b")},
+		{name: "advanced rule cluster", route: routeRule("a", icluster_conf.RouteAdvancedModeClusterName4DP), table: clusterTable("a")},
+		{name: "cluster missing", route: routeRule("a", "b"), table: clusterTable("a"), wantErr: true},
+		{name: "cluster table empty", route: routeRule("a"), table: clusterTable(), wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := CheckClusterTableTarget(map[string]interface{}{
+				ConfigTopicRouteRule:                  c.route,
+				icluster_conf.ConfigTopicClusterTable: c.table,
+			})
+			if (err != nil) != c.wantErr {
+				t.Errorf("CheckClusterTableTarget() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
+
+// decode returns v decoded without type, as snapshots are
+func decode(t *testing.T, v interface{}) interface{} {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rst interface{}
+	if err = json.Unmarshal(bs, &rst); err != nil {
+		t.Fatal(err)
+	}
+	return rst
+}
@@ -177,7 +177,8 @@ type RouteRuleStorager interface {
 
 func NewRouteRuleManager(txn itxn.TxnStorager, storager RouteRuleStorager, clusterStorager icluster_conf.ClusterStorager,
 	productStorager ibasic.ProductStorager, versionControlManager *iversion_control.VersionControlManager,
-	domainStorager DomainStorager) *RouteRuleManager {
+	domainStorager DomainStorager, providerProtocols icluster_conf.ProviderProtocolGetter,
+	certificates icluster_conf.CertificateFetcher) *RouteRuleManager {
 	return &RouteRuleManager{
 		txn:                   txn,
 		storager:              storager,
@@ -185,6 +186,8 @@ func NewRouteRuleManager(txn itxn.TxnStorager, storager RouteRuleStorager, clust
 		productStorager:       productStorager,
 		versionControlManager: versionControlManager,
 		domainStorager:        domainStorager,
+		providerProtocols:     providerProtocols,
+		certificates:          certificates,
 	}
 }
 
@@ -195,6 +198,8 @@ type RouteRuleManager struct {
 	clusterStorager       icluster_conf.ClusterStorager
 	productStorager       ibasic.ProductStorager
 	domainStorager        DomainStorager
+	providerProtocols     icluster_conf.ProviderProtocolGetter
+	certificates          icluster_conf.CertificateFetcher
 }
 
 func (rm *RouteRuleManager) ExpressionVerify(ctx context.Context, expression string) (err error) {
@@ -0,0 +1,132 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package isecret
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xcrypto"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+const KMSLocal = "local"
+
+// KeyManager protects the data keys used by envelope encryption.
+// The data key never leaves the process in plain text, only the wrapped one is stored with the secret.
+// Implement this interface to plug in an external KMS.
+type KeyManager interface {
+	// Name identifies the backend, stored with the secret
+	Name() string
+	// WrapKey encrypts data key with the current key encryption key
+	WrapKey(ctx context.Context, dataKey []byte) (string, error)
+	// UnwrapKey decrypts data key wrapped by WrapKey, maybe with a retired key encryption key
+	UnwrapKey(ctx context.Context, wrappedKey string) ([]byte, error)
+}
+
+// LocalKeyManager wraps data keys with master keys from local file or env var
+type LocalKeyManager struct {
+	currentID string
+	keys      map[string][]byte
+}
+
+var _ KeyManager = &LocalKeyManager{}
+
+// NewLocalKeyManager creates LocalKeyManager, masterKey wraps new data keys,
+// oldMasterKeys are only used to unwrap data keys wrapped before rotation
+func NewLocalKeyManager(masterKey []byte, oldMasterKeys ...[]byte) *LocalKeyManager {
+	km := &LocalKeyManager{
+		keys: map[string][]byte{},
+	}
+
+	for _, key := range oldMasterKeys {
+		if len(key) != 0 {
+			km.keys[xcrypto.KeyID(key)] = key
+		}
+	}
+
+	if len(masterKey) != 0 {
+		km.currentID = xcrypto.KeyID(masterKey)
+		km.keys[km.currentID] = masterKey
+	}
+
+	return km
+}
+
+func (km *LocalKeyManager) Name() string {
+	return KMSLocal
+}
+
+// WrapKey return format: <master key id>:<sealed data key>
+func (km *LocalKeyManager) WrapKey(ctx context.Context, dataKey []byte) (string, error) {
+	if km.currentID == "" {
+		return "", xcrypto.ErrKeyNotSet
+	}
+
+	sealed, err := xcrypto.Seal(km.keys[km.currentID], dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	return km.currentID + ":" + sealed, nil
+}
+
+func (km *LocalKeyManager) UnwrapKey(ctx context.Context, wrappedKey string) ([]byte, error) {
+	ss := strings.SplitN(wrappedKey, ":", 2)
+	if len(ss) != 2 {
+		return nil, fmt.Errorf("illegal wrapped key")
+	}
+
+	key, ok := km.keys[ss[0]]
+	if !ok {
+		return nil, fmt.Errorf("master key %s not configured", ss[0])
+	}
+
+	return xcrypto.Open(key, ss[1])
+}
+
+// OpenLegacy opens secret sealed by xcrypto.Seal with master key directly, the format
+// used before envelope encryption, old master key is tried too
+func (km *LocalKeyManager) OpenLegacy(value string) ([]byte, error) {
+	if len(km.keys) == 0 {
+		return nil, xcrypto.ErrKeyNotSet
+	}
+
+	var err error
+	for _, key := range km.keys {
+		var plain []byte
+		if plain, err = xcrypto.Open(key, value); err == nil {
+			return plain, nil
+		}
+	}
+
+	return nil, err
+}
+
+// IsCurrent check whether the data key is wrapped by the current master key
+func (km *LocalKeyManager) IsCurrent(wrappedKey string) bool {
+	return km.currentID != "" && strings.HasPrefix(wrappedKey, km.currentID+":")
+}
+
+// NewKeyManagerFromConfig creates KeyManager according to [Secret] config
+func NewKeyManagerFromConfig(conf *stateful.SecretConfig) (KeyManager, error) {
+	switch conf.KMS {
+	case "", KMSLocal:
+		return NewLocalKeyManager(conf.MasterKey(), conf.OldMasterKey()), nil
+	}
+
+	return nil, fmt.Errorf("unsupported kms %s", conf.KMS)
+}
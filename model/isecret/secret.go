@@ -0,0 +1,264 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package isecret
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xcrypto"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+)
+
+// SealedPrefix marks secrets produced by SecretManager.Seal
+const SealedPrefix = "enc:v2:"
+
+// RedactedValue replaces secrets in API responses, sending it back on update keeps the stored secret
+const RedactedValue = "******"
+
+// envelope is the stored format of a sealed secret
+type envelope struct {
+	KMS        string `json:"kms"`
+	WrappedKey string `json:"key"`
+	Data       string `json:"data"`
+}
+
+// SecretManager does envelope encryption: every secret is encrypted by a random data key,
+// and the data key is wrapped by KeyManager
+type SecretManager struct {
+	keyManager KeyManager
+}
+
+func NewSecretManager(keyManager KeyManager) *SecretManager {
+	return &SecretManager{
+		keyManager: keyManager,
+	}
+}
+
+// IsSealed check whether value produced by Seal, or by xcrypto.Seal with the master key
+// before envelope encryption (legacy format)
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, SealedPrefix) || xcrypto.IsSealed(value)
+}
+
+// CheckPlain returns param error if secret from API looks sealed. Sealed values are
+// only accepted from storage, a forged one can't be opened and would break exporting
+func CheckPlain(field, value string) error {
+	if IsSealed(value) {
+		return xerror.WrapParamErrorWithMsg("%s must be plain text", field)
+	}
+
+	return nil
+}
+
+// IsRedacted check whether value is the redacted one returned by API
+func IsRedacted(value string) bool {
+	return value == RedactedValue
+}
+
+// Redact hides the secret
+func Redact(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return RedactedValue
+}
+
+// Seal encrypts plain text, empty and sealed value (read from storage) are returned as is,
+// secrets from API must be checked by CheckPlain
+func (sm *SecretManager) Seal(ctx context.Context, plainText string) (string, error) {
+	if plainText == "" || IsSealed(plainText) {
+		return plainText, nil
+	}
+
+	dataKey, err := xcrypto.NewKey()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := xcrypto.Encrypt(dataKey, []byte(plainText))
+	if err != nil {
+		return "", err
+	}
+
+	wrappedKey, err := sm.keyManager.WrapKey(ctx, dataKey)
+	if err != nil {
+		return "", xerror.WrapModelErrorWithMsg("seal secret fail: %v", err)
+	}
+
+	b, err := json.Marshal(&envelope{
+		KMS:        sm.keyManager.Name(),
+		WrappedKey: wrappedKey,
+		Data:       base64.StdEncoding.EncodeToString(data),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return SealedPrefix + base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Open decrypts value produced by Seal or legacy format, value not sealed
+// (stored before encryption be enabled) is returned as is
+func (sm *SecretManager) Open(ctx context.Context, value string) (string, error) {
+	if xcrypto.IsSealed(value) {
+		return sm.openLegacy(value)
+	}
+	if !IsSealed(value) {
+		return value, nil
+	}
+
+	env, err := decodeEnvelope(value)
+	if err != nil {
+		return "", err
+	}
+	if env.KMS != sm.keyManager.Name() {
+		return "", fmt.Errorf("secret sealed by kms %s, but %s configured", env.KMS, sm.keyManager.Name())
+	}
+
+	dataKey, err := sm.keyManager.UnwrapKey(ctx, env.WrappedKey)
+	if err != nil {
+		return "", fmt.Errorf("unwrap data key fail: %v", err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(env.Data)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := xcrypto.Decrypt(dataKey, data)
+	if err != nil {
+		return "", fmt.Errorf("decrypt secret fail: %v", err)
+	}
+
+	return string(plain), nil
+}
+
+// legacyOpener is optional for KeyManager, it opens secrets sealed by xcrypto.Seal with the master key
+type legacyOpener interface {
+	OpenLegacy(value string) ([]byte, error)
+}
+
+func (sm *SecretManager) openLegacy(value string) (string, error) {
+	opener, ok := sm.keyManager.(legacyOpener)
+	if !ok {
+		return "", fmt.Errorf("kms %s can't open secret of legacy format", sm.keyManager.Name())
+	}
+
+	plain, err := opener.OpenLegacy(value)
+	if err != nil {
+		return "", fmt.Errorf("decrypt legacy secret fail: %v", err)
+	}
+
+	return string(plain), nil
+}
+
+// currentKeyChecker is optional for KeyManager, without it Rekey always seals secret again
+type currentKeyChecker interface {
+	IsCurrent(wrappedKey string) bool
+}
+
+// Rekey seals value again with the current key, changed is false when nothing to do
+func (sm *SecretManager) Rekey(ctx context.Context, value string) (rst string, changed bool, err error) {
+	if value == "" {
+		return value, false, nil
+	}
+
+	// secrets of legacy format are always sealed again
+	if strings.HasPrefix(value, SealedPrefix) {
+		env, err := decodeEnvelope(value)
+		if err != nil {
+			return "", false, err
+		}
+
+		checker, ok := sm.keyManager.(currentKeyChecker)
+		if ok && env.KMS == sm.keyManager.Name() && checker.IsCurrent(env.WrappedKey) {
+			return value, false, nil
+		}
+	}
+
+	plain, err := sm.Open(ctx, value)
+	if err != nil {
+		return "", false, err
+	}
+
+	rst, err = sm.Seal(ctx, plain)
+	if err != nil {
+		return "", false, err
+	}
+
+	return rst, true, nil
+}
+
+func decodeEnvelope(value string) (*envelope, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, SealedPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("illegal sealed secret: %v", err)
+	}
+
+	env := &envelope{}
+	if err = json.Unmarshal(b, env); err != nil {
+		return nil, fmt.Errorf("illegal sealed secret: %v", err)
+	}
+
+	return env, nil
+}
+
+var defaultManager = NewSecretManager(NewLocalKeyManager(nil))
+
+// Init sets the SecretManager used by package level functions
+func Init(keyManager KeyManager) {
+	defaultManager = NewSecretManager(keyManager)
+}
+
+// Seal encrypts plain text with the default SecretManager
+func Seal(ctx context.Context, plainText string) (string, error) {
+	return defaultManager.Seal(ctx, plainText)
+}
+
+// Open decrypts value with the default SecretManager
+func Open(ctx context.Context, value string) (string, error) {
+	return defaultManager.Open(ctx, value)
+}
+
+// Rekey seals value again with the default SecretManager
+func Rekey(ctx context.Context, value string) (string, bool, error) {
+	return defaultManager.Rekey(ctx, value)
+}
+
+// SealOrKeep seals value, RedactedValue keeps the old sealed one, nil means not set
+func SealOrKeep(ctx context.Context, old *string, value *string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if IsRedacted(*value) {
+		if old == nil {
+			return nil, xerror.WrapParamErrorWithMsg("secret not exist, can't keep it")
+		}
+		return old, nil
+	}
+
+	sealed, err := Seal(ctx, *value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sealed, nil
+}
@@ -0,0 +1,134 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package isecret
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xcrypto"
+)
+
+func TestSealOpen(t *testing.T) {
+	ctx := context.Background()
+	current, old := []byte("current-master-key"), []byte("old-master-key")
+	sm := NewSecretManager(NewLocalKeyManager(current, old))
+
+	legacyCurrent, _ := xcrypto.Seal(current, []byte("sk-legacy"))
+	legacyOld, _ := xcrypto.Seal(old, []byte("sk-legacy-old"))
+	byOld, _ := NewSecretManager(NewLocalKeyManager(old)).Seal(ctx, "sk-rotated")
+
+	cases := []struct {
+		name    string
+		value   func() string
+		plain   string
+		wantErr bool
+	}{
+		{name: "sealed", value: func() string { v, _ := sm.Seal(ctx, "sk-123"); return v }, plain: "sk-123"},
+		{name: "plain text stored before encryption", value: func() string { return "sk-plain" }, plain: "sk-plain"},
+		{name: "empty", value: func() string { return "" }, plain: ""},
+		{name: "legacy by master key", value: func() string { return legacyCurrent }, plain: "sk-legacy"},
+		{name: "legacy by old master key", value: func() string { return legacyOld }, plain: "sk-legacy-old"},
+		{name: "sealed by old master key", value: func() string { return byOld }, plain: "sk-rotated"},
+		{name: "forged", value: func() string { return SealedPrefix + "junk" }, wantErr: true},
+		{name: "forged legacy", value: func() string { return xcrypto.SealedPrefix + "junk" }, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			value := c.value()
+			if value != "" && value == c.plain && IsSealed(value) {
+				t.Fatalf("plain text %q treated as sealed", value)
+			}
+
+			plain, err := sm.Open(ctx, value)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Open() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if !c.wantErr && plain != c.plain {
+				t.Errorf("Open() = %q, want %q", plain, c.plain)
+			}
+		})
+	}
+}
+
+func TestRekey(t *testing.T) {
+	ctx := context.Background()
+	current, old := []byte("current-master-key"), []byte("old-master-key")
+	sm := NewSecretManager(NewLocalKeyManager(current, old))
+
+	sealed, _ := sm.Seal(ctx, "sk-current")
+	byOld, _ := NewSecretManager(NewLocalKeyManager(old)).Seal(ctx, "sk-old")
+	legacy, _ := xcrypto.Seal(current, []byte("sk-legacy"))
+
+	cases := []struct {
+		name    string
+		value   string
+		plain   string
+		changed bool
+	}{
+		{name: "current", value: sealed, plain: "sk-current", changed: false},
+		{name: "old master key", value: byOld, plain: "sk-old", changed: true},
+		{name: "legacy", value: legacy, plain: "sk-legacy", changed: true},
+		{name: "plain text", value: "sk-plain", plain: "sk-plain", changed: true},
+		{name: "empty", value: "", plain: "", changed: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rst, changed, err := sm.Rekey(ctx, c.value)
+			if err != nil {
+				t.Fatalf("Rekey() error: %v", err)
+			}
+			if changed != c.changed {
+				t.Errorf("Rekey() changed = %v, want %v", changed, c.changed)
+			}
+			if c.value != "" && !strings.HasPrefix(rst, SealedPrefix) {
+				t.Errorf("Rekey() = %q, want sealed by envelope", rst)
+			}
+
+			plain, err := sm.Open(ctx, rst)
+			if err != nil || plain != c.plain {
+				t.Errorf("Open(Rekey()) = %q, %v, want %q", plain, err, c.plain)
+			}
+		})
+	}
+}
+
+func TestSealWithoutMasterKey(t *testing.T) {
+	if _, err := NewSecretManager(NewLocalKeyManager(nil)).Seal(context.Background(), "sk-123"); err == nil {
+		t.Errorf("Seal() without master key want error")
+	}
+}
+
+func TestCheckPlain(t *testing.T) {
+	cases := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "sk-123"},
+		{value: ""},
+		{value: RedactedValue},
+		{value: SealedPrefix + "junk", wantErr: true},
+		{value: xcrypto.SealedPrefix + "junk", wantErr: true},
+	}
+
+	for _, c := range cases {
+		if err := CheckPlain("key", c.value); (err != nil) != c.wantErr {
+			t.Errorf("CheckPlain(%q) error = %v, wantErr %v", c.value, err, c.wantErr)
+		}
+	}
+}
@@ -0,0 +1,423 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package iversion_control
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+)
+
+const (
+	ConfigChannelCanary = "canary"
+	ConfigChannelStable = "stable"
+)
+
+var ConfigChannels = map[string]bool{
+	ConfigChannelCanary: true,
+	ConfigChannelStable: true,
+}
+
+// ConfigChannel is the release channel of topic, bfe clusters following it are served its version
+type ConfigChannel struct {
+	Topic     string
+	Channel   string
+	Version   string
+	UpdatedAt time.Time
+}
+
+type ConfigChannelFilter struct {
+	Topic   *string
+	Channel *string
+}
+
+// ConfigTarget makes bfe cluster be served a pinned version of topic, or the version of a channel,
+// only one of Channel and Version is set
+type ConfigTarget struct {
+	BFECluster string
+	Topic      string
+	Channel    string
+	Version    string
+	UpdatedAt  time.Time
+
+	// ResolvedVersion is the version served now, filled by VersionControlManager.FetchConfigTargets
+	ResolvedVersion string
+}
+
+// ConfigTargetGroup is topics depending on each other, like route rules referring clusters of cluster table,
+// they are pinned, follow channels and are promoted together. Check validates decoded data of the versions
+// served together, it is optional
+type ConfigTargetGroup struct {
+	Topics []string
+	Check  func(datas map[string]interface{}) error
+
+	// Revoke applies changes of the latest data which are never staged, like revoked api keys, to data of
+	// the targeted version, it returns true if targeted is changed. It is optional, topics of groups with it
+	// can not be rolled back
+	Revoke func(latest, targeted VersionValuable) (bool, error)
+}
+
+// RevokedVersion is the version of data of version with revocations of latestVersion applied
+func RevokedVersion(version, latestVersion string) string {
+	return version + "+" + latestVersion
+}
+
+// BaseVersion returns the version revocations are applied to, see RevokedVersion
+func BaseVersion(version string) string {
+	if i := strings.Index(version, "+"); i >= 0 {
+		return version[:i]
+	}
+	return version
+}
+
+type ConfigTargetFilter struct {
+	BFECluster *string
+	Topic      *string
+}
+
+type ConfigTargetStorager interface {
+	FetchConfigChannels(ctx context.Context, filter *ConfigChannelFilter) ([]*ConfigChannel, error)
+	UpsertConfigChannel(ctx context.Context, channel *ConfigChannel) error
+
+	FetchConfigTargets(ctx context.Context, filter *ConfigTargetFilter) ([]*ConfigTarget, error)
+	UpsertConfigTarget(ctx context.Context, target *ConfigTarget) error
+	DeleteConfigTarget(ctx context.Context, target *ConfigTarget) error
+}
+
+// FetchTargetVersions returns topic => version served to bfeCluster, topics not targeted are not included
+func FetchTargetVersions(ctx context.Context, storager ConfigTargetStorager, bfeCluster string) (map[string]string, error) {
+	targets, err := storager.FetchConfigTargets(ctx, &ConfigTargetFilter{
+		BFECluster: &bfeCluster,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	channels, err := storager.FetchConfigChannels(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	channelVersions := map[string]string{}
+	for _, one := range channels {
+		channelVersions[one.Topic+"."+one.Channel] = one.Version
+	}
+
+	rst := map[string]string{}
+	for _, one := range targets {
+		version := one.Version
+		if one.Channel != "" {
+			version = channelVersions[one.Topic+"."+one.Channel]
+		}
+		if version != "" {
+			rst[one.Topic] = version
+		}
+	}
+
+	return rst, nil
+}
+
+// targetVersion returns version of topic served to bfeCluster, empty means the latest
+func (vcm *VersionControlManager) targetVersion(ctx context.Context, topic, bfeCluster string) (string, error) {
+	if vcm.targetGroups[topic] == nil {
+		return "", nil
+	}
+
+	versions, err := FetchTargetVersions(ctx, vcm.targetStorager, bfeCluster)
+	if err != nil {
+		return "", err
+	}
+
+	return versions[topic], nil
+}
+
+func (vcm *VersionControlManager) checkTopic(topic string) (*ConfigTargetGroup, error) {
+	group := vcm.targetGroups[topic]
+	if group == nil {
+		return nil, xerror.WrapParamErrorWithMsg("Topic %s Can Not Be Targeted", topic)
+	}
+
+	return group, nil
+}
+
+// checkVersion makes sure version can be served, versions exported before snapshot be recorded can't
+func (vcm *VersionControlManager) checkVersion(ctx context.Context, topic, version string) error {
+	list, err := vcm.storager.FetchConfigSnapshots(ctx, &ConfigSnapshotFilter{
+		Topic:   &topic,
+		Version: &version,
+	})
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return xerror.WrapParamErrorWithMsg("Version %s Of %s Has No Snapshot", version, topic)
+	}
+
+	return nil
+}
+
+// groupVersions returns versions of topics in group served together with version of topic,
+// other topics take their versions current when version exported
+func (vcm *VersionControlManager) groupVersions(ctx context.Context, group *ConfigTargetGroup,
+	topic, version string) (map[string]string, error) {
+
+	rst := map[string]string{}
+	for _, one := range group.Topics {
+		if one == topic {
+			rst[one] = version
+			continue
+		}
+
+		versions, err := vcm.storager.FetchConfigVersions(ctx, &ConfigVersionFilter{
+			Topic: &one,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// the latest first
+		for _, v := range versions {
+			if v.Version <= version {
+				rst[one] = v.Version
+				break
+			}
+		}
+		if rst[one] == "" {
+			return nil, xerror.WrapParamErrorWithMsg("Topic %s Has No Version Before %s", one, version)
+		}
+	}
+
+	for one, v := range rst {
+		if err := vcm.checkVersion(ctx, one, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return rst, vcm.checkGroup(ctx, group, rst)
+}
+
+// checkGroup validates data of versions served together by Check of group
+func (vcm *VersionControlManager) checkGroup(ctx context.Context, group *ConfigTargetGroup, versions map[string]string) error {
+	if group.Check == nil {
+		return nil
+	}
+
+	datas := map[string]interface{}{}
+	for topic, version := range versions {
+		snapshot, err := vcm.fetchConfigSnapshot(ctx, topic, version)
+		if err != nil {
+			return err
+		}
+
+		var data interface{}
+		if err = decodeSnapshot(ctx, snapshot, &data); err != nil {
+			return err
+		}
+		datas[topic] = data
+	}
+
+	return group.Check(datas)
+}
+
+func (vcm *VersionControlManager) FetchConfigChannels(ctx context.Context) (list []*ConfigChannel, err error) {
+	err = vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err = vcm.targetStorager.FetchConfigChannels(ctx, nil)
+		return err
+	})
+
+	return
+}
+
+// PromoteConfigChannel advances channel of topic to version, the latest version is used if version is empty
+func (vcm *VersionControlManager) PromoteConfigChannel(ctx context.Context, channel, topic,
+	version string) (rst *ConfigChannel, err error) {
+
+	if !ConfigChannels[channel] {
+		return nil, xerror.WrapParamErrorWithMsg("Channel %s Not Supported", channel)
+	}
+	group, err := vcm.checkTopic(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	err = vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		if version == "" {
+			latest, err := vcm.storager.FetchLastExportedVersions(ctx, []string{topic})
+			if err != nil {
+				return err
+			}
+			if version = latest[topic]; version == "" {
+				return xerror.WrapParamErrorWithMsg("Topic %s Never Be Exported", topic)
+			}
+		}
+
+		versions, err := vcm.groupVersions(ctx, group, topic, version)
+		if err != nil {
+			return err
+		}
+
+		for _, one := range group.Topics {
+			promoted := &ConfigChannel{
+				Topic:   one,
+				Channel: channel,
+				Version: versions[one],
+			}
+			if err = vcm.targetStorager.UpsertConfigChannel(ctx, promoted); err != nil {
+				return err
+			}
+			if one == topic {
+				rst = promoted
+			}
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// FetchConfigTargets returns targets of bfeCluster with version served now
+func (vcm *VersionControlManager) FetchConfigTargets(ctx context.Context, bfeCluster string) (list []*ConfigTarget, err error) {
+	err = vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err = vcm.targetStorager.FetchConfigTargets(ctx, &ConfigTargetFilter{
+			BFECluster: &bfeCluster,
+		})
+		if err != nil {
+			return err
+		}
+
+		versions, err := FetchTargetVersions(ctx, vcm.targetStorager, bfeCluster)
+		if err != nil {
+			return err
+		}
+		for _, one := range list {
+			one.ResolvedVersion = versions[one.Topic]
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// SetConfigTarget pins bfe cluster to a version of topic, or makes it follow a channel,
+// other topics in the group of topic are targeted too
+func (vcm *VersionControlManager) SetConfigTarget(ctx context.Context, target *ConfigTarget) error {
+	group, err := vcm.checkTopic(target.Topic)
+	if err != nil {
+		return err
+	}
+	if (target.Channel == "") == (target.Version == "") {
+		return xerror.WrapParamErrorWithMsg("One Of Channel And Version Must Be Set")
+	}
+	if target.Channel != "" && !ConfigChannels[target.Channel] {
+		return xerror.WrapParamErrorWithMsg("Channel %s Not Supported", target.Channel)
+	}
+
+	return vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		if target.Version != "" {
+			versions, err := vcm.groupVersions(ctx, group, target.Topic, target.Version)
+			if err != nil {
+				return err
+			}
+
+			for _, topic := range group.Topics {
+				err = vcm.targetStorager.UpsertConfigTarget(ctx, &ConfigTarget{
+					BFECluster: target.BFECluster,
+					Topic:      topic,
+					Version:    versions[topic],
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		// following a channel never promoted would serve the latest one, which is not expected
+		versions := map[string]string{}
+		for _, topic := range group.Topics {
+			channels, err := vcm.targetStorager.FetchConfigChannels(ctx, &ConfigChannelFilter{
+				Topic:   &topic,
+				Channel: &target.Channel,
+			})
+			if err != nil {
+				return err
+			}
+			if len(channels) == 0 {
+				return xerror.WrapParamErrorWithMsg("Channel %s Of %s Not Promoted", target.Channel, topic)
+			}
+			versions[topic] = channels[0].Version
+		}
+		if err := vcm.checkGroup(ctx, group, versions); err != nil {
+			return err
+		}
+
+		for _, topic := range group.Topics {
+			err := vcm.targetStorager.UpsertConfigTarget(ctx, &ConfigTarget{
+				BFECluster: target.BFECluster,
+				Topic:      topic,
+				Channel:    target.Channel,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteConfigTarget makes bfe cluster be served the latest version of topic and topics in its group again
+func (vcm *VersionControlManager) DeleteConfigTarget(ctx context.Context, bfeCluster, topic string) error {
+	return vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := vcm.targetStorager.FetchConfigTargets(ctx, &ConfigTargetFilter{
+			BFECluster: &bfeCluster,
+			Topic:      &topic,
+		})
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return xerror.WrapRecordNotExist("Config Target")
+		}
+
+		// a topic not targetable any more has no group, its target is deleted alone
+		topics := []string{topic}
+		if group := vcm.targetGroups[topic]; group != nil {
+			topics = group.Topics
+		}
+
+		for _, one := range topics {
+			list, err = vcm.targetStorager.FetchConfigTargets(ctx, &ConfigTargetFilter{
+				BFECluster: &bfeCluster,
+				Topic:      &one,
+			})
+			if err != nil {
+				return err
+			}
+			for _, target := range list {
+				if err = vcm.targetStorager.DeleteConfigTarget(ctx, target); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
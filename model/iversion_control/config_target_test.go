@@ -0,0 +1,470 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package iversion_control
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type fakeTxn struct{}
+
+func (fakeTxn) AtomExecute(ctx context.Context, do func(context.Context) error) error {
+	return do(ctx)
+}
+
+// fakeTargetStorager keeps channels and targets in memory
+type fakeTargetStorager struct {
+	channels []*ConfigChannel
+	targets  []*ConfigTarget
+}
+
+func (s *fakeTargetStorager) FetchConfigChannels(ctx context.Context, filter *ConfigChannelFilter) ([]*ConfigChannel, error) {
+	rst := []*ConfigChannel{}
+	for _, one := range s.channels {
+		if filter != nil && ((filter.Topic != nil && *filter.Topic != one.Topic) ||
+			(filter.Channel != nil && *filter.Channel != one.Channel)) {
+			continue
+		}
+		rst = append(rst, one)
+	}
+	return rst, nil
+}
+
+func (s *fakeTargetStorager) UpsertConfigChannel(ctx context.Context, channel *ConfigChannel) error {
+	for i, one := range s.channels {
+		if one.Topic == channel.Topic && one.Channel == channel.Channel {
+			s.channels[i] = channel
+			return nil
+		}
+	}
+	s.channels = append(s.channels, channel)
+	return nil
+}
+
+func (s *fakeTargetStorager) FetchConfigTargets(ctx context.Context, filter *ConfigTargetFilter) ([]*ConfigTarget, error) {
+	rst := []*ConfigTarget{}
+	for _, one := range s.targets {
+		if filter != nil && ((filter.BFECluster != nil && *filter.BFECluster != one.BFECluster) ||
+			(filter.Topic != nil && *filter.Topic != one.Topic)) {
+			continue
+		}
+		rst = append(rst, one)
+	}
+	return rst, nil
+}
+
+func (s *fakeTargetStorager) UpsertConfigTarget(ctx context.Context, target *ConfigTarget) error {
+	if err := s.DeleteConfigTarget(ctx, target); err != nil {
+		return err
+	}
+	s.targets = append(s.targets, target)
+	return nil
+}
+
+func (s *fakeTargetStorager) DeleteConfigTarget(ctx context.Context, target *ConfigTarget) error {
+	rst := []*ConfigTarget{}
+	for _, one := range s.targets {
+		if one.BFECluster != target.BFECluster || one.Topic != target.Topic {
+			rst = append(rst, one)
+		}
+	}
+	s.targets = rst
+	return nil
+}
+
+// fakeVersionStorager serves versions and snapshots of topics, the latest version first
+type fakeVersionStorager struct {
+	VersionControlStorager
+
+	versions  map[string][]string
+	snapshots map[string]string
+	rollbacks map[string]*ConfigRollback
+}
+
+func (s *fakeVersionStorager) CreateConfigVersion(ctx context.Context, version *ConfigVersion) error {
+	s.versions[version.Topic] = append([]string{version.Version}, s.versions[version.Topic]...)
+	return nil
+}
+
+func (s *fakeVersionStorager) CreateConfigSnapshot(ctx context.Context, snapshot *ConfigSnapshot) error {
+	s.snapshots[snapshot.Topic+"@"+snapshot.Version] = snapshot.Data
+	return nil
+}
+
+func (s *fakeVersionStorager) FetchConfigRollback(ctx context.Context, topic string) (*ConfigRollback, error) {
+	return s.rollbacks[topic], nil
+}
+
+func (s *fakeVersionStorager) CreateConfigRollback(ctx context.Context, rollback *ConfigRollback) error {
+	s.rollbacks[rollback.Topic] = rollback
+	return nil
+}
+
+func (s *fakeVersionStorager) DeleteConfigRollback(ctx context.Context, topic string) error {
+	delete(s.rollbacks, topic)
+	return nil
+}
+
+func (s *fakeVersionStorager) FetchConfigVersions(ctx context.Context, filter *ConfigVersionFilter) ([]*ConfigVersion, error) {
+	rst := []*ConfigVersion{}
+	for _, version := range s.versions[*filter.Topic] {
+		rst = append(rst, &ConfigVersion{Topic: *filter.Topic, Version: version})
+	}
+	return rst, nil
+}
+
+func (s *fakeVersionStorager) FetchConfigSnapshots(ctx context.Context, filter *ConfigSnapshotFilter) ([]*ConfigSnapshot, error) {
+	data, ok := s.snapshots[*filter.Topic+"@"+*filter.Version]
+	if !ok {
+		return nil, nil
+	}
+	return []*ConfigSnapshot{{Topic: *filter.Topic, Version: *filter.Version, Data: data}}, nil
+}
+
+// UpsertConfigLastExportedVersion serves exported data as the latest version of topic
+func (s *fakeVersionStorager) UpsertConfigLastExportedVersion(ctx context.Context, css *ExportData) (string, bool, error) {
+	return s.versions[css.Topic][0], false, nil
+}
+
+func (s *fakeVersionStorager) FetchLastExportedVersions(ctx context.Context, topics []string) (map[string]string, error) {
+	rst := map[string]string{}
+	for _, topic := range topics {
+		if versions := s.versions[topic]; len(versions) > 0 {
+			rst[topic] = versions[0]
+		}
+	}
+	return rst, nil
+}
+
+func TestFetchTargetVersions(t *testing.T) {
+	storager := &fakeTargetStorager{
+		channels: []*ConfigChannel{
+			{Topic: "a", Channel: ConfigChannelCanary, Version: "20240102000000"},
+			{Topic: "a", Channel: ConfigChannelStable, Version: "20240101000000"},
+		},
+		targets: []*ConfigTarget{
+			{BFECluster: "pinned", Topic: "a", Version: "20231231000000"},
+			{BFECluster: "pinned", Topic: "b", Version: "20231230000000"},
+			{BFECluster: "canary", Topic: "a", Channel: ConfigChannelCanary},
+			{BFECluster: "canary", Topic: "b", Channel: ConfigChannelCanary},
+			{BFECluster: "stable", Topic: "a", Channel: ConfigChannelStable},
+		},
+	}
+
+	cases := []struct {
+		bfeCluster string
+		want       map[string]string
+	}{
+		{bfeCluster: "pinned", want: map[string]string{"a": "20231231000000", "b": "20231230000000"}},
+		// channel of b never promoted, b is not included
+		{bfeCluster: "canary", want: map[string]string{"a": "20240102000000"}},
+		{bfeCluster: "stable", want: map[string]string{"a": "20240101000000"}},
+		{bfeCluster: "none", want: nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.bfeCluster, func(t *testing.T) {
+			got, err := FetchTargetVersions(context.Background(), storager, c.bfeCluster)
+			if err != nil {
+				t.Fatalf("FetchTargetVersions() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("FetchTargetVersions() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+// newTestTargetManager returns manager of topics route and cluster in a group, and cert alone.
+// Snapshots of cluster carry clusters of it, the group check fails if route refers missing clusters
+func newTestTargetManager(t *testing.T) (*VersionControlManager, *fakeTargetStorager) {
+	vcm, _, targetStorager := newTestVersionManager(t)
+	return vcm, targetStorager
+}
+
+func newTestVersionManager(t *testing.T) (*VersionControlManager, *fakeVersionStorager, *fakeTargetStorager) {
+	setTestMasterKey(t, "master-key")
+
+	snapshot := func(data interface{}) string {
+		encoded, err := encodeSnapshot(context.Background(), &testVersionValuable{Data: map[string]interface{}{"v": data}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return encoded
+	}
+
+	storager := &fakeVersionStorager{
+		versions: map[string][]string{
+			"route":   {"20240103000000", "20240102000000", "20240101000000"},
+			"cluster": {"20240103000001", "20240101000001", "20240101000000"},
+			"cert":    {"20240102000000", "20240101000000"},
+			"key":     {"20240102000000", "20240101000000"},
+		},
+		snapshots: map[string]string{
+			"route@20240103000000":   snapshot([]string{"c1", "c2"}),
+			"route@20240102000000":   snapshot([]string{"c1", "c2"}),
+			"route@20240101000000":   snapshot([]string{"c1"}),
+			"cluster@20240103000001": snapshot([]string{"c1", "c2"}),
+			"cluster@20240101000001": snapshot([]string{"c1"}),
+			"cluster@20240101000000": snapshot([]string{"c1"}),
+			"cert@20240102000000":    snapshot(nil),
+			"key@20240102000000":     snapshot([]string{"k1", "k3"}),
+			"key@20240101000000":     snapshot([]string{"k1", "k2"}),
+		},
+		rollbacks: map[string]*ConfigRollback{},
+	}
+
+	clusters := func(data interface{}) []interface{} {
+		v, _ := data.(map[string]interface{})["data"].(map[string]interface{})["v"].([]interface{})
+		return v
+	}
+	check := func(datas map[string]interface{}) error {
+		existed := map[interface{}]bool{}
+		for _, one := range clusters(datas["cluster"]) {
+			existed[one] = true
+		}
+		for _, one := range clusters(datas["route"]) {
+			if !existed[one] {
+				return fmt.Errorf("cluster %v missing", one)
+			}
+		}
+		return nil
+	}
+
+	targetStorager := &fakeTargetStorager{}
+	vcm := NewVersionControllerManager(fakeTxn{}, storager, targetStorager, []*ConfigTargetGroup{
+		{Topics: []string{"route", "cluster"}, Check: check},
+		{Topics: []string{"cert"}},
+		{Topics: []string{"key"}, Revoke: revokeTestKeys},
+	})
+
+	return vcm, storager, targetStorager
+}
+
+func TestSetConfigTarget(t *testing.T) {
+	cases := []struct {
+		name    string
+		target  *ConfigTarget
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			// cluster current then is 20240101000001, which misses c2
+			name:    "group check fail",
+			target:  &ConfigTarget{Topic: "route", Version: "20240102000000"},
+			wantErr: true,
+		},
+		{
+			name:   "group pinned by route",
+			target: &ConfigTarget{Topic: "route", Version: "20240101000000"},
+			want:   map[string]string{"route": "20240101000000", "cluster": "20240101000000"},
+		},
+		{
+			name:   "group pinned by cluster",
+			target: &ConfigTarget{Topic: "cluster", Version: "20240103000001"},
+			want:   map[string]string{"route": "20240103000000", "cluster": "20240103000001"},
+		},
+		{
+			name:   "topic alone",
+			target: &ConfigTarget{Topic: "cert", Version: "20240102000000"},
+			want:   map[string]string{"cert": "20240102000000"},
+		},
+		{
+			name:    "version without snapshot",
+			target:  &ConfigTarget{Topic: "cert", Version: "20240101000000"},
+			wantErr: true,
+		},
+		{
+			name:    "no version of other topic before",
+			target:  &ConfigTarget{Topic: "cluster", Version: "20231231000000"},
+			wantErr: true,
+		},
+		{
+			name:    "not targetable",
+			target:  &ConfigTarget{Topic: "mod_api_key_rule", Version: "20240101000000"},
+			wantErr: true,
+		},
+		{
+			name:    "channel not promoted",
+			target:  &ConfigTarget{Topic: "route", Channel: ConfigChannelCanary},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			vcm, targetStorager := newTestTargetManager(t)
+			c.target.BFECluster = "bfe"
+
+			err := vcm.SetConfigTarget(context.Background(), c.target)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("SetConfigTarget() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if c.wantErr {
+				if len(targetStorager.targets) != 0 {
+					t.Errorf("targets = %v, want none", targetStorager.targets)
+				}
+				return
+			}
+
+			got, _ := FetchTargetVersions(context.Background(), targetStorager, "bfe")
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("target versions = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestConfigChannelGroup(t *testing.T) {
+	ctx := context.Background()
+	vcm, targetStorager := newTestTargetManager(t)
+
+	// the latest route is promoted with the latest cluster before it, which misses c2
+	if _, err := vcm.PromoteConfigChannel(ctx, ConfigChannelCanary, "route", ""); err == nil {
+		t.Fatalf("PromoteConfigChannel() want error of group check")
+	}
+
+	promoted, err := vcm.PromoteConfigChannel(ctx, ConfigChannelCanary, "cluster", "")
+	if err != nil {
+		t.Fatalf("PromoteConfigChannel() error: %v", err)
+	}
+	if promoted.Topic != "cluster" || promoted.Version != "20240103000001" {
+		t.Errorf("PromoteConfigChannel() = %+v", promoted)
+	}
+
+	if err = vcm.SetConfigTarget(ctx, &ConfigTarget{BFECluster: "bfe", Topic: "route", Channel: ConfigChannelCanary}); err != nil {
+		t.Fatalf("SetConfigTarget() error: %v", err)
+	}
+
+	topics := []string{}
+	for _, one := range targetStorager.targets {
+		if one.Channel != ConfigChannelCanary {
+			t.Errorf("target %+v, want following canary", one)
+		}
+		topics = append(topics, one.Topic)
+	}
+	sort.Strings(topics)
+	if !reflect.DeepEqual(topics, []string{"cluster", "route"}) {
+		t.Errorf("targeted topics = %v, want cluster and route", topics)
+	}
+
+	got, _ := FetchTargetVersions(ctx, targetStorager, "bfe")
+	want := map[string]string{"route": "20240103000000", "cluster": "20240103000001"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("target versions = %v, want %v", got, want)
+	}
+
+	if err = vcm.DeleteConfigTarget(ctx, "bfe", "cluster"); err != nil {
+		t.Fatalf("DeleteConfigTarget() error: %v", err)
+	}
+	if len(targetStorager.targets) != 0 {
+		t.Errorf("targets = %v, want none after deleted", targetStorager.targets)
+	}
+}
+
+// revokeTestKeys removes keys of targeted not in latest
+func revokeTestKeys(latest, targeted VersionValuable) (bool, error) {
+	existed := map[string]bool{}
+	for _, one := range latest.(*testVersionValuable).Data["v"].([]interface{}) {
+		existed[fmt.Sprint(one)] = true
+	}
+
+	data := targeted.(*testVersionValuable).Data
+	keys := []interface{}{}
+	for _, one := range data["v"].([]interface{}) {
+		if existed[fmt.Sprint(one)] {
+			keys = append(keys, one)
+		}
+	}
+	revoked := len(keys) != len(data["v"].([]interface{}))
+	data["v"] = keys
+	return revoked, nil
+}
+
+func TestExportTargetConfigRevoke(t *testing.T) {
+	cases := []struct {
+		name        string
+		bfeCluster  string
+		latest      []interface{}
+		wantVersion string
+		wantKeys    []interface{}
+	}{
+		{
+			name:        "not targeted",
+			bfeCluster:  "bj",
+			latest:      []interface{}{"k1", "k3"},
+			wantVersion: "20240102000000",
+			wantKeys:    []interface{}{"k1", "k3"},
+		},
+		{
+			name:        "nothing revoked",
+			bfeCluster:  "gz",
+			latest:      []interface{}{"k1", "k2", "k3"},
+			wantVersion: "20240101000000",
+			wantKeys:    []interface{}{"k1", "k2"},
+		},
+		{
+			name:        "revoked",
+			bfeCluster:  "gz",
+			latest:      []interface{}{"k1", "k3"},
+			wantVersion: "20240101000000+20240102000000",
+			wantKeys:    []interface{}{"k1"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			vcm, _, targetStorager := newTestVersionManager(t)
+			targetStorager.targets = []*ConfigTarget{{BFECluster: "gz", Topic: "key", Version: "20240101000000"}}
+
+			generator := func(ctx context.Context) (*ExportData, error) {
+				return &ExportData{
+					Topic:              "key",
+					DataWithoutVersion: &testVersionValuable{Data: map[string]interface{}{"v": c.latest}},
+				}, nil
+			}
+			rst, err := vcm.ExportTargetConfig(context.Background(), "key", c.bfeCluster, generator)
+			if err != nil {
+				t.Fatalf("ExportTargetConfig() error: %v", err)
+			}
+
+			data := rst.DataWithoutVersion.(*testVersionValuable)
+			if rst.Version() != c.wantVersion || data.Version != c.wantVersion {
+				t.Errorf("version = %s in data %s, want %s", rst.Version(), data.Version, c.wantVersion)
+			}
+			if !reflect.DeepEqual(data.Data["v"], c.wantKeys) {
+				t.Errorf("keys = %v, want %v", data.Data["v"], c.wantKeys)
+			}
+		})
+	}
+}
+
+func TestBaseVersion(t *testing.T) {
+	cases := map[string]string{
+		"":                              "",
+		"20240101000000":                "20240101000000",
+		"20240101000000+20240102000000": "20240101000000",
+	}
+
+	for version, want := range cases {
+		if got := BaseVersion(version); got != want {
+			t.Errorf("BaseVersion(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package iversion_control
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+)
+
+// ConfigVersion is one exported version of topic
+type ConfigVersion struct {
+	Topic    string
+	Version  string
+	DataSign string
+
+	// TriggeredBy tells who or what generated this version, like "export by bfe_agent"
+	TriggeredBy string
+	CreatedAt   time.Time
+
+	// HasSnapshot is false for versions exported before snapshots were stored,
+	// filled by VersionControlManager.FetchConfigHistory
+	HasSnapshot bool
+}
+
+type ConfigVersionFilter struct {
+	Topic   *string
+	Version *string
+}
+
+// ConfigRollback makes the snapshot of FromVersion be served as Version,
+// until the data generated from source differs from the one when rolled back
+type ConfigRollback struct {
+	Topic       string
+	Version     string
+	FromVersion string
+	SourceSign  string
+	CreatedBy   string
+	CreatedAt   time.Time
+}
+
+// ConfigHistory is versions of topic, the latest first
+type ConfigHistory struct {
+	Topic    string
+	Versions []*ConfigVersion
+
+	// Rollback is the active rollback of topic, nil if none
+	Rollback *ConfigRollback
+}
+
+const (
+	ConfigDiffAdded   = "added"
+	ConfigDiffRemoved = "removed"
+	ConfigDiffChanged = "changed"
+)
+
+// ConfigDiff is one difference between two versions, Path is a json pointer (RFC 6901)
+type ConfigDiff struct {
+	Path string
+	Type string
+	From interface{}
+	To   interface{}
+}
+
+type triggerContextKey struct{}
+
+// NewTriggerContext returns a context which versions exported in it are recorded as triggered by trigger
+func NewTriggerContext(ctx context.Context, trigger string) context.Context {
+	return context.WithValue(ctx, triggerContextKey{}, trigger)
+}
+
+func triggerFromContext(ctx context.Context) string {
+	trigger, _ := ctx.Value(triggerContextKey{}).(string)
+	return trigger
+}
+
+// newVersionValuable returns a zero value of the same type as data, used to decode snapshots
+func newVersionValuable(data VersionValuable) VersionValuable {
+	return reflect.New(reflect.TypeOf(data).Elem()).Interface().(VersionValuable)
+}
+
+// loadConfigSnapshot decodes snapshot of version into a zero value of the same type as like
+func (vcm *VersionControlManager) loadConfigSnapshot(ctx context.Context, topic, version string,
+	like VersionValuable) (VersionValuable, error) {
+
+	one, err := vcm.fetchConfigSnapshot(ctx, topic, version)
+	if err != nil {
+		return nil, err
+	}
+
+	data := newVersionValuable(like)
+	if err = decodeSnapshot(ctx, one, data); err != nil {
+		return nil, err
+	}
+
+	// snapshot of rollback version is copied from an old version, the version in it is the old one
+	if err = data.UpdateVersion(version); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// exportRollback returns the rolled back data if rollback of topic is still active
+// the rollback ends once the data generated from source changed, rollbacks of its group end too
+func (vcm *VersionControlManager) exportRollback(ctx context.Context, lrv *ExportData) (*ExportData, error) {
+	rollback, err := vcm.storager.FetchConfigRollback(ctx, lrv.Topic)
+	if err != nil || rollback == nil {
+		return nil, err
+	}
+
+	if rollback.SourceSign != lrv.DataSignWithoutVersion {
+		return nil, vcm.deleteGroupRollback(ctx, lrv.Topic)
+	}
+
+	data, err := vcm.loadConfigSnapshot(ctx, lrv.Topic, rollback.Version, lrv.DataWithoutVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportData{
+		Topic:                  lrv.Topic,
+		DataWithoutVersion:     data,
+		version:                rollback.Version,
+		DataSignWithoutVersion: lrv.DataSignWithoutVersion,
+	}, nil
+}
+
+// FetchConfigHistory returns versions of topic, the latest first
+func (vcm *VersionControlManager) FetchConfigHistory(ctx context.Context, topic string) (history *ConfigHistory, err error) {
+	err = vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		versions, err := vcm.storager.FetchConfigVersions(ctx, &ConfigVersionFilter{
+			Topic: &topic,
+		})
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			return xerror.WrapRecordNotExist("Config Topic")
+		}
+
+		snapshots, err := vcm.storager.FetchConfigSnapshots(ctx, &ConfigSnapshotFilter{
+			Topic: &topic,
+		})
+		if err != nil {
+			return err
+		}
+		snapshotted := map[string]bool{}
+		for _, one := range snapshots {
+			snapshotted[one.Version] = true
+		}
+		for _, one := range versions {
+			one.HasSnapshot = snapshotted[one.Version]
+		}
+
+		rollback, err := vcm.storager.FetchConfigRollback(ctx, topic)
+		if err != nil {
+			return err
+		}
+
+		history = &ConfigHistory{
+			Topic:    topic,
+			Versions: versions,
+			Rollback: rollback,
+		}
+		return nil
+	})
+
+	return
+}
+
+// DiffConfigVersions returns differences from version from to version to of topic
+func (vcm *VersionControlManager) DiffConfigVersions(ctx context.Context, topic, from, to string) (diffs []*ConfigDiff, err error) {
+	err = vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		var datas [2]interface{}
+		for i, version := range []string{from, to} {
+			one, err := vcm.fetchConfigSnapshot(ctx, topic, version)
+			if err != nil {
+				return err
+			}
+			if err = decodeSnapshot(ctx, one, &datas[i]); err != nil {
+				return err
+			}
+		}
+
+		diffs = diffConfig("", datas[0], datas[1], nil)
+		return nil
+	})
+
+	return
+}
+
+// rollbackGroup returns group of topic rolled back together. Topics without group or with Revoke of group
+// can't be rolled back, like api key rules, which would serve revoked keys as the latest again
+func (vcm *VersionControlManager) rollbackGroup(topic string) (*ConfigTargetGroup, error) {
+	group := vcm.targetGroups[topic]
+	if group == nil || group.Revoke != nil {
+		return nil, xerror.WrapParamErrorWithMsg("Topic %s Can Not Be Rolled Back", topic)
+	}
+
+	return group, nil
+}
+
+// RollbackConfig serves the snapshot of version as a new version of topic, until the data
+// generated from source changed. Other topics in the group of topic are rolled back to their
+// versions current when version exported
+func (vcm *VersionControlManager) RollbackConfig(ctx context.Context, topic, version, operator string) (rollback *ConfigRollback, err error) {
+	group, err := vcm.rollbackGroup(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	err = vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		latest, err := vcm.storager.FetchLastExportedVersions(ctx, group.Topics)
+		if err != nil {
+			return err
+		}
+		if latest[topic] == "" {
+			return xerror.WrapRecordNotExist("Config Version")
+		}
+		if latest[topic] == version {
+			return xerror.WrapParamErrorWithMsg("Version %s Is The Latest", version)
+		}
+
+		versions, err := vcm.groupVersions(ctx, group, topic, version)
+		if err != nil {
+			return err
+		}
+
+		newVersion := Version(time.Now())
+		for _, one := range group.Topics {
+			if newVersion <= latest[one] {
+				return xerror.WrapParamErrorWithMsg("Version %s Exported Just Now, Please Retry Later", latest[one])
+			}
+		}
+
+		for _, one := range group.Topics {
+			if err = vcm.rollbackTopic(ctx, one, versions[one], newVersion, operator); err != nil {
+				return err
+			}
+		}
+
+		rollback, err = vcm.storager.FetchConfigRollback(ctx, topic)
+		return err
+	})
+
+	return
+}
+
+// rollbackTopic records snapshot of version as newVersion of topic and makes it served
+func (vcm *VersionControlManager) rollbackTopic(ctx context.Context, topic, version, newVersion, operator string) error {
+	versions, err := vcm.storager.FetchConfigVersions(ctx, &ConfigVersionFilter{
+		Topic: &topic,
+	})
+	if err != nil {
+		return err
+	}
+
+	var from *ConfigVersion
+	for _, one := range versions {
+		if one.Version == version {
+			from = one
+			break
+		}
+	}
+	if from == nil {
+		return xerror.WrapRecordNotExist("Config Version")
+	}
+
+	snapshot, err := vcm.fetchConfigSnapshot(ctx, topic, version)
+	if err != nil {
+		return err
+	}
+
+	// data generated from source is still the one when first rolled back
+	sourceSign := versions[0].DataSign
+	active, err := vcm.storager.FetchConfigRollback(ctx, topic)
+	if err != nil {
+		return err
+	}
+	if active != nil {
+		sourceSign = active.SourceSign
+		if err = vcm.storager.DeleteConfigRollback(ctx, topic); err != nil {
+			return err
+		}
+	}
+
+	err = vcm.storager.CreateConfigVersion(ctx, &ConfigVersion{
+		Topic:       topic,
+		Version:     newVersion,
+		DataSign:    from.DataSign,
+		TriggeredBy: fmt.Sprintf("rollback to %s by %s", version, operator),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = vcm.storager.CreateConfigSnapshot(ctx, &ConfigSnapshot{
+		Topic:   topic,
+		Version: newVersion,
+		Data:    snapshot.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	return vcm.storager.CreateConfigRollback(ctx, &ConfigRollback{
+		Topic:       topic,
+		Version:     newVersion,
+		FromVersion: version,
+		SourceSign:  sourceSign,
+		CreatedBy:   operator,
+	})
+}
+
+// deleteGroupRollback ends rollbacks of topic and topics in its group, they are rolled back together
+func (vcm *VersionControlManager) deleteGroupRollback(ctx context.Context, topic string) error {
+	topics := []string{topic}
+	if group := vcm.targetGroups[topic]; group != nil {
+		topics = group.Topics
+	}
+
+	for _, one := range topics {
+		if err := vcm.storager.DeleteConfigRollback(ctx, one); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CancelConfigRollback ends the active rollback of topic and topics in its group, data generated
+// from source is exported next time
+func (vcm *VersionControlManager) CancelConfigRollback(ctx context.Context, topic string) error {
+	return vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		one, err := vcm.storager.FetchConfigRollback(ctx, topic)
+		if err != nil {
+			return err
+		}
+		if one == nil {
+			return xerror.WrapRecordNotExist("Config Rollback")
+		}
+
+		return vcm.deleteGroupRollback(ctx, topic)
+	})
+}
+
+// diffConfig appends differences between decoded json a and b to diffs
+func diffConfig(path string, a, b interface{}, diffs []*ConfigDiff) []*ConfigDiff {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(av)+len(bv))
+		for k := range av {
+			keys = append(keys, k)
+		}
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			p := path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(k)
+			aa, aok := av[k]
+			bb, bok := bv[k]
+			switch {
+			case !aok:
+				diffs = append(diffs, &ConfigDiff{Path: p, Type: ConfigDiffAdded, To: bb})
+			case !bok:
+				diffs = append(diffs, &ConfigDiff{Path: p, Type: ConfigDiffRemoved, From: aa})
+			default:
+				diffs = diffConfig(p, aa, bb, diffs)
+			}
+		}
+		return diffs
+
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(av) || i < len(bv); i++ {
+			p := path + "/" + strconv.Itoa(i)
+			switch {
+			case i >= len(av):
+				diffs = append(diffs, &ConfigDiff{Path: p, Type: ConfigDiffAdded, To: bv[i]})
+			case i >= len(bv):
+				diffs = append(diffs, &ConfigDiff{Path: p, Type: ConfigDiffRemoved, From: av[i]})
+			default:
+				diffs = diffConfig(p, av[i], bv[i], diffs)
+			}
+		}
+		return diffs
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		diffs = append(diffs, &ConfigDiff{Path: path, Type: ConfigDiffChanged, From: a, To: b})
+	}
+	return diffs
+}
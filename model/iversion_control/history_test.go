@@ -0,0 +1,306 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package iversion_control
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+func decodeJSON(t *testing.T, s string) interface{} {
+	t.Helper()
+
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader([]byte(s)))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestDiffConfig(t *testing.T) {
+	cases := []struct {
+		name string
+		from string
+		to   string
+		want []*ConfigDiff
+	}{
+		{
+			name: "same",
+			from: `{"a": 1, "b": [1, 2]}`,
+			to:   `{"b": [1, 2], "a": 1}`,
+		},
+		{
+			name: "changed value",
+			from: `{"a": {"b": 1}}`,
+			to:   `{"a": {"b": 2}}`,
+			want: []*ConfigDiff{
+				{Path: "/a/b", Type: ConfigDiffChanged, From: json.Number("1"), To: json.Number("2")},
+			},
+		},
+		{
+			name: "added and removed keys sorted",
+			from: `{"z": 1, "a": true}`,
+			to:   `{"a": true, "m": "x"}`,
+			want: []*ConfigDiff{
+				{Path: "/m", Type: ConfigDiffAdded, To: "x"},
+				{Path: "/z", Type: ConfigDiffRemoved, From: json.Number("1")},
+			},
+		},
+		{
+			name: "array compared by index",
+			from: `{"l": [1, 2, 3]}`,
+			to:   `{"l": [1, 5]}`,
+			want: []*ConfigDiff{
+				{Path: "/l/1", Type: ConfigDiffChanged, From: json.Number("2"), To: json.Number("5")},
+				{Path: "/l/2", Type: ConfigDiffRemoved, From: json.Number("3")},
+			},
+		},
+		{
+			name: "type changed",
+			from: `{"a": {"b": 1}}`,
+			to:   `{"a": [1]}`,
+			want: []*ConfigDiff{
+				{Path: "/a", Type: ConfigDiffChanged,
+					From: map[string]interface{}{"b": json.Number("1")}, To: []interface{}{json.Number("1")}},
+			},
+		},
+		{
+			name: "json pointer escaped",
+			from: `{"a/b": {"c~d": 1}}`,
+			to:   `{"a/b": {"c~d": null}}`,
+			want: []*ConfigDiff{
+				{Path: "/a~1b/c~0d", Type: ConfigDiffChanged, From: json.Number("1"), To: nil},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := diffConfig("", decodeJSON(t, c.from), decodeJSON(t, c.to), nil)
+			if !reflect.DeepEqual(got, c.want) {
+				gotBs, _ := json.Marshal(got)
+				wantBs, _ := json.Marshal(c.want)
+				t.Errorf("diffConfig() = %s, want %s", gotBs, wantBs)
+			}
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	data := map[string]interface{}{"key": "sk-123", "weight": 10}
+
+	cases := []struct {
+		name      string
+		masterKey string
+		enabled   bool
+	}{
+		{name: "without master key", enabled: false},
+		{name: "with master key", masterKey: "master-key", enabled: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setTestMasterKey(t, c.masterKey)
+
+			if snapshotEnabled() != c.enabled {
+				t.Fatalf("snapshotEnabled() = %v, want %v", snapshotEnabled(), c.enabled)
+			}
+			if !c.enabled {
+				return
+			}
+
+			encoded, err := encodeSnapshot(ctx, &testVersionValuable{Data: data})
+			if err != nil {
+				t.Fatalf("encodeSnapshot() error: %v", err)
+			}
+			if !isecret.IsSealed(encoded) || bytes.Contains([]byte(encoded), []byte("sk-123")) {
+				t.Fatalf("encodeSnapshot() = %q, want sealed", encoded)
+			}
+
+			got := &testVersionValuable{}
+			if err = decodeSnapshot(ctx, &ConfigSnapshot{Data: encoded}, got); err != nil {
+				t.Fatalf("decodeSnapshot() error: %v", err)
+			}
+			if got.Data["key"] != "sk-123" || got.Data["weight"] != json.Number("10") {
+				t.Errorf("decodeSnapshot() = %v, want %v", got.Data, data)
+			}
+		})
+	}
+}
+
+// setTestMasterKey configures master key of snapshots, empty masterKey means not configured
+func setTestMasterKey(t *testing.T, masterKey string) {
+	t.Helper()
+
+	t.Setenv("TEST_SNAPSHOT_MASTER_KEY", masterKey)
+	stateful.DefaultConfig = &stateful.Config{}
+	if masterKey != "" {
+		stateful.DefaultConfig.Secret.MasterKeyEnv = "TEST_SNAPSHOT_MASTER_KEY"
+	}
+	if err := stateful.DefaultConfig.Secret.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	isecret.Init(isecret.NewLocalKeyManager(stateful.DefaultConfig.Secret.MasterKey()))
+	t.Cleanup(func() {
+		isecret.Init(isecret.NewLocalKeyManager(nil))
+	})
+}
+
+type testVersionValuable struct {
+	Version string                 `json:"version"`
+	Data    map[string]interface{} `json:"data"`
+}
+
+func (v *testVersionValuable) UpdateVersion(version string) error {
+	v.Version = version
+	return nil
+}
+
+func TestRollbackConfig(t *testing.T) {
+	cases := []struct {
+		name    string
+		topic   string
+		version string
+		want    map[string]string // topic => version rolled back to
+		wantErr bool
+	}{
+		{
+			name:    "topic without group",
+			topic:   "mod_api_key_rule",
+			version: "20240101000000",
+			wantErr: true,
+		},
+		{
+			name:    "topic with revocations",
+			topic:   "key",
+			version: "20240101000000",
+			wantErr: true,
+		},
+		{
+			name:    "latest version",
+			topic:   "route",
+			version: "20240103000000",
+			wantErr: true,
+		},
+		{
+			// cluster current then is 20240101000001, which misses c2
+			name:    "group check fail",
+			topic:   "route",
+			version: "20240102000000",
+			wantErr: true,
+		},
+		{
+			name:    "group rolled back by route",
+			topic:   "route",
+			version: "20240101000000",
+			want:    map[string]string{"route": "20240101000000", "cluster": "20240101000000"},
+		},
+		{
+			name:    "group rolled back by cluster",
+			topic:   "cluster",
+			version: "20240101000001",
+			want:    map[string]string{"route": "20240101000000", "cluster": "20240101000001"},
+		},
+		{
+			name:    "version without snapshot",
+			topic:   "cert",
+			version: "20240101000000",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			vcm, storager, _ := newTestVersionManager(t)
+
+			rollback, err := vcm.RollbackConfig(context.Background(), c.topic, c.version, "admin")
+			if (err != nil) != c.wantErr {
+				t.Fatalf("RollbackConfig() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if c.wantErr {
+				if len(storager.rollbacks) != 0 {
+					t.Errorf("rollbacks = %v, want none", storager.rollbacks)
+				}
+				return
+			}
+
+			if rollback.Topic != c.topic || rollback.FromVersion != c.version {
+				t.Errorf("RollbackConfig() = %+v, want rollback of %s to %s", rollback, c.topic, c.version)
+			}
+			got := map[string]string{}
+			for topic, one := range storager.rollbacks {
+				got[topic] = one.FromVersion
+				if one.Version != rollback.Version || storager.versions[topic][0] != rollback.Version {
+					t.Errorf("rollback of %s served as %s, want %s", topic, one.Version, rollback.Version)
+				}
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("rolled back versions = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestRollbackConfigEndedTogether(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name string
+		end  func(vcm *VersionControlManager) error
+	}{
+		{
+			name: "source of one topic changed",
+			end: func(vcm *VersionControlManager) error {
+				_, err := vcm.exportRollback(ctx, &ExportData{Topic: "cluster", DataSignWithoutVersion: "changed"})
+				return err
+			},
+		},
+		{
+			name: "cancelled by one topic",
+			end: func(vcm *VersionControlManager) error {
+				return vcm.CancelConfigRollback(ctx, "route")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			vcm, storager, _ := newTestVersionManager(t)
+			if _, err := vcm.RollbackConfig(ctx, "route", "20240101000000", "admin"); err != nil {
+				t.Fatalf("RollbackConfig() error: %v", err)
+			}
+			if len(storager.rollbacks) != 2 {
+				t.Fatalf("rollbacks = %v, want route and cluster", storager.rollbacks)
+			}
+
+			if err := c.end(vcm); err != nil {
+				t.Fatalf("end rollback error: %v", err)
+			}
+			if len(storager.rollbacks) != 0 {
+				t.Errorf("rollbacks = %v, want none", storager.rollbacks)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package iversion_control
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
+	"github.com/yf-networks/ai-gateway-api/stateful"
+)
+
+// ConfigSnapshot is the exported data of one version, used to serve versions other than the latest
+type ConfigSnapshot struct {
+	Topic   string
+	Version string
+
+	// Data is gzip compressed json of exported data, exported data contains secrets in plain text,
+	// so it is always sealed, snapshots are not stored until master key configured
+	Data string
+
+	CreatedAt time.Time
+}
+
+type ConfigSnapshotFilter struct {
+	Topic   *string
+	Version *string
+}
+
+// encodeSnapshot compresses data then seals it
+func encodeSnapshot(ctx context.Context, data VersionValuable) (string, error) {
+	bs, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err = w.Write(bs); err != nil {
+		return "", err
+	}
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+
+	return isecret.Seal(ctx, base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
+// snapshotEnabled reports whether snapshots can be stored, they hold secrets in plain text
+// before sealed, so no snapshot is stored without master key
+func snapshotEnabled() bool {
+	return len(stateful.DefaultConfig.Secret.MasterKey()) > 0
+}
+
+// decodeSnapshot is the reverse of encodeSnapshot, data is filled with the exported one
+func decodeSnapshot(ctx context.Context, snapshot *ConfigSnapshot, data interface{}) error {
+	encoded, err := isecret.Open(ctx, snapshot.Data)
+	if err != nil {
+		return xerror.WrapModelErrorWithMsg("open snapshot %s of %s fail: %v", snapshot.Version, snapshot.Topic, err)
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return xerror.WrapDirtyDataErrorWithMsg("snapshot %s of %s, err: %v", snapshot.Version, snapshot.Topic, err)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return xerror.WrapDirtyDataErrorWithMsg("snapshot %s of %s, err: %v", snapshot.Version, snapshot.Topic, err)
+	}
+	bs, err := io.ReadAll(r)
+	if err != nil {
+		return xerror.WrapDirtyDataErrorWithMsg("snapshot %s of %s, err: %v", snapshot.Version, snapshot.Topic, err)
+	}
+
+	// keep numbers as they are when decoded without type, like diff
+	d := json.NewDecoder(bytes.NewReader(bs))
+	d.UseNumber()
+	if err = d.Decode(data); err != nil {
+		return xerror.WrapDirtyDataErrorWithMsg("snapshot %s of %s, err: %v", snapshot.Version, snapshot.Topic, err)
+	}
+
+	return nil
+}
@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/isecret"
 	"github.com/yf-networks/ai-gateway-api/model/itxn"
 )
 
@@ -44,6 +46,9 @@ type ExportData struct {
 
 	version                string
 	DataSignWithoutVersion string
+
+	// TriggeredBy is recorded with the version created by this export
+	TriggeredBy string
 }
 
 type VersionValuable interface {
@@ -62,21 +67,53 @@ func (cs *ExportData) CalculateVersion() (string, error) {
 type VersionControlStorager interface {
 
 	// UpsertConfigLastExportedVersion will got last export data
-	// if config changed, create new version and return
+	// if config changed, create new version and return, created is true
 	// if not, return last version
-	UpsertConfigLastExportedVersion(ctx context.Context, css *ExportData) (string, error)
+	UpsertConfigLastExportedVersion(ctx context.Context, css *ExportData) (version string, created bool, err error)
+
+	// FetchLastExportedVersions returns topic => last exported version
+	// topic never exported is not included
+	FetchLastExportedVersions(ctx context.Context, topics []string) (map[string]string, error)
+
+	CreateConfigSnapshot(ctx context.Context, snapshot *ConfigSnapshot) error
+	FetchConfigSnapshots(ctx context.Context, filter *ConfigSnapshotFilter) ([]*ConfigSnapshot, error)
+	UpdateConfigSnapshotData(ctx context.Context, snapshot *ConfigSnapshot, data string) error
+
+	// FetchConfigVersions returns versions matched, the latest first
+	FetchConfigVersions(ctx context.Context, filter *ConfigVersionFilter) ([]*ConfigVersion, error)
+	CreateConfigVersion(ctx context.Context, version *ConfigVersion) error
+
+	// FetchConfigRollback returns nil if topic has no active rollback
+	FetchConfigRollback(ctx context.Context, topic string) (*ConfigRollback, error)
+	CreateConfigRollback(ctx context.Context, rollback *ConfigRollback) error
+	DeleteConfigRollback(ctx context.Context, topic string) error
 }
 
 type VersionControlManager struct {
-	storager VersionControlStorager
-	txn      itxn.TxnStorager
+	storager       VersionControlStorager
+	targetStorager ConfigTargetStorager
+	txn            itxn.TxnStorager
+
+	// targetGroups maps topics can be targeted per bfe cluster to their groups
+	targetGroups map[string]*ConfigTargetGroup
 }
 
-func NewVersionControllerManager(txn itxn.TxnStorager, storager VersionControlStorager) *VersionControlManager {
-	return &VersionControlManager{
-		storager: storager,
-		txn:      txn,
+func NewVersionControllerManager(txn itxn.TxnStorager, storager VersionControlStorager,
+	targetStorager ConfigTargetStorager, targetGroups []*ConfigTargetGroup) *VersionControlManager {
+
+	vcm := &VersionControlManager{
+		storager:       storager,
+		targetStorager: targetStorager,
+		txn:            txn,
+		targetGroups:   map[string]*ConfigTargetGroup{},
+	}
+	for _, group := range targetGroups {
+		for _, topic := range group.Topics {
+			vcm.targetGroups[topic] = group
+		}
 	}
+
+	return vcm
 }
 
 type ConfigGenerator func(ctx context.Context) (*ExportData, error)
@@ -85,26 +122,152 @@ func (vcm *VersionControlManager) ExportConfig(ctx context.Context, configTopic
 	generaotr ConfigGenerator) (lrv *ExportData, err error) {
 
 	err = vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
-		lrv, err = generaotr(ctx)
-		if err != nil {
+		lrv, err = vcm.exportConfig(ctx, generaotr)
+		return err
+	})
+
+	return
+}
+
+// ExportTargetConfig exports config of topic targeted by bfeCluster, the latest version is exported
+// if bfeCluster is empty or not targeted. DataWithoutVersion is decoded from snapshot if the targeted one is not the latest,
+// revocations of the latest one are applied to it by Revoke of the group
+func (vcm *VersionControlManager) ExportTargetConfig(ctx context.Context, configTopic, bfeCluster string,
+	generaotr ConfigGenerator) (lrv *ExportData, err error) {
+
+	err = vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		// always generate the latest one, so that changes are recorded even if all bfe clusters are pinned
+		lrv, err = vcm.exportConfig(ctx, generaotr)
+		if err != nil || bfeCluster == "" {
 			return err
 		}
 
-		if err = lrv.DataWithoutVersion.UpdateVersion(ZeroVersion); err != nil {
+		version, err := vcm.targetVersion(ctx, configTopic, bfeCluster)
+		if err != nil {
 			return err
 		}
+		if version == "" || version == lrv.Version() {
+			return nil
+		}
 
-		lrv.DataSignWithoutVersion, err = Sign(lrv.DataWithoutVersion)
+		snapshot, err := vcm.loadConfigSnapshot(ctx, configTopic, version, lrv.DataWithoutVersion)
 		if err != nil {
 			return err
 		}
 
-		lrv.version, err = vcm.storager.UpsertConfigLastExportedVersion(ctx, lrv)
+		if revoke := vcm.targetGroups[configTopic].Revoke; revoke != nil {
+			revoked, err := revoke(lrv.DataWithoutVersion, snapshot)
+			if err != nil {
+				return err
+			}
+			if revoked {
+				version = RevokedVersion(version, lrv.Version())
+				if err = snapshot.UpdateVersion(version); err != nil {
+					return err
+				}
+			}
+		}
+
+		lrv = &ExportData{
+			Topic:              configTopic,
+			DataWithoutVersion: snapshot,
+			version:            version,
+		}
+		return nil
+	})
+
+	return
+}
+
+func (vcm *VersionControlManager) exportConfig(ctx context.Context, generaotr ConfigGenerator) (*ExportData, error) {
+	lrv, err := generaotr(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = lrv.DataWithoutVersion.UpdateVersion(ZeroVersion); err != nil {
+		return nil, err
+	}
+
+	lrv.DataSignWithoutVersion, err = Sign(lrv.DataWithoutVersion)
+	if err != nil {
+		return nil, err
+	}
+	lrv.TriggeredBy = triggerFromContext(ctx)
+
+	rolled, err := vcm.exportRollback(ctx, lrv)
+	if err != nil || rolled != nil {
+		return rolled, err
+	}
+
+	var created bool
+	lrv.version, created, err = vcm.storager.UpsertConfigLastExportedVersion(ctx, lrv)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = lrv.DataWithoutVersion.UpdateVersion(lrv.version); err != nil {
+		return nil, err
+	}
+	if !created || !snapshotEnabled() {
+		return lrv, nil
+	}
+
+	data, err := encodeSnapshot(ctx, lrv.DataWithoutVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return lrv, vcm.storager.CreateConfigSnapshot(ctx, &ConfigSnapshot{
+		Topic:   lrv.Topic,
+		Version: lrv.version,
+		Data:    data,
+	})
+}
+
+// fetchConfigSnapshot returns error if snapshot not existed
+func (vcm *VersionControlManager) fetchConfigSnapshot(ctx context.Context, topic, version string) (*ConfigSnapshot, error) {
+	list, err := vcm.storager.FetchConfigSnapshots(ctx, &ConfigSnapshotFilter{
+		Topic:   &topic,
+		Version: &version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		if !snapshotEnabled() {
+			return nil, xerror.WrapParamErrorWithMsg("Snapshot Of Version %s Not Exist, Snapshots Are Not Stored Without Master Key", version)
+		}
+		return nil, xerror.WrapRecordNotExist("Snapshot")
+	}
+
+	return list[0], nil
+}
+
+// RekeySecrets seals snapshots with the current master key
+func (vcm *VersionControlManager) RekeySecrets(ctx context.Context) (count int, err error) {
+	err = vcm.txn.AtomExecute(ctx, func(ctx context.Context) error {
+		list, err := vcm.storager.FetchConfigSnapshots(ctx, nil)
 		if err != nil {
 			return err
 		}
 
-		return lrv.DataWithoutVersion.UpdateVersion(lrv.version)
+		for _, one := range list {
+			data, changed, err := isecret.Rekey(ctx, one.Data)
+			if err != nil {
+				return fmt.Errorf("rekey snapshot %s of %s fail: %v", one.Version, one.Topic, err)
+			}
+			if !changed {
+				continue
+			}
+
+			if err = vcm.storager.UpdateConfigSnapshotData(ctx, one, data); err != nil {
+				return err
+			}
+			count++
+		}
+
+		return nil
 	})
 
 	return
@@ -59,6 +59,16 @@ type Config struct {
 	Databases map[string]*DbConfig     `validate:"dive"`
 	Depends   DependsConfig
 	RunTime   RunTimeConfig
+	Secret    SecretConfig
+
+	Notification        NotificationConfig
+	ModelSync           ModelSyncConfig
+	PoolDrain           PoolDrainConfig
+	PoolDiscovery       PoolDiscoveryConfig
+	KubernetesDiscovery KubernetesDiscoveryConfig
+	TrafficPlan         TrafficPlanConfig
+	Canary              CanaryConfig
+	BFENode             BFENodeConfig
 
 	Vars      map[string]string
 	LogDir    string
@@ -82,6 +92,34 @@ func LoadConfig(file string) error {
 		RunTime: RunTimeConfig{
 			StaticFilePath: "./static",
 		},
+		Notification: NotificationConfig{
+			CheckIntervalInS: 600,
+			ExpireInDays:     []int{7},
+			QuotaPercents:    []int{80, 95},
+		},
+		ModelSync: ModelSyncConfig{
+			IntervalInS: 3600,
+		},
+		PoolDrain: PoolDrainConfig{
+			IntervalInS: 10,
+		},
+		PoolDiscovery: PoolDiscoveryConfig{
+			IntervalInS: 30,
+			TimeoutInS:  5,
+		},
+		KubernetesDiscovery: KubernetesDiscoveryConfig{
+			Resource:  "endpointslices",
+			ResyncInS: 300,
+		},
+		TrafficPlan: TrafficPlanConfig{
+			IntervalInS: 10,
+		},
+		Canary: CanaryConfig{
+			IntervalInS: 10,
+		},
+		BFENode: BFENodeConfig{
+			StaleInS: 120,
+		},
 		Vars: map[string]string{},
 		Databases: map[string]*DbConfig{
 			"bfe_db": {
@@ -111,8 +149,19 @@ func (config *Config) Init() error {
 
 	config.Depends.NavTreeFile = os.Expand(config.Depends.NavTreeFile, mapping)
 	config.Depends.I18nDir = os.Expand(config.Depends.I18nDir, mapping)
+	config.Secret.MasterKeyFile = os.Expand(config.Secret.MasterKeyFile, mapping)
+	config.Secret.OldMasterKeyFile = os.Expand(config.Secret.OldMasterKeyFile, mapping)
+	config.PoolDiscovery.FileDir = os.Expand(config.PoolDiscovery.FileDir, mapping)
+	config.KubernetesDiscovery.Kubeconfig = os.Expand(config.KubernetesDiscovery.Kubeconfig, mapping)
+	if config.Notification.File != nil {
+		config.Notification.File.Path = os.Expand(config.Notification.File.Path, mapping)
+	}
 
-	return nil
+	if err := config.Notification.Check(); err != nil {
+		return err
+	}
+
+	return config.Secret.Init()
 }
 
 var (
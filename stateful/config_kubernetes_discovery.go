@@ -0,0 +1,29 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package stateful
+
+// KubernetesDiscoveryConfig defines the watcher of Kubernetes, which resolves kubernetes discovery sources of pools
+type KubernetesDiscoveryConfig struct {
+	Enable     bool
+	Kubeconfig string // kubeconfig file, in-cluster config is used if empty
+	Namespace  string // only watch this namespace if set, all namespaces are watched if empty
+
+	// ProductNamespaces are namespaces which kubernetes sources of each product may select,
+	// product not listed can't use kubernetes source
+	ProductNamespaces map[string][]string
+
+	Resource  string `validate:"omitempty,oneof=endpointslices endpoints"` // watched resource
+	ResyncInS int    `validate:"min=1"`                                    // resync period of informer
+}
@@ -0,0 +1,26 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package stateful
+
+// ModelSyncConfig defines the background job which syncs models of llm clusters
+// from their model endpoint
+type ModelSyncConfig struct {
+	Enable      bool
+	IntervalInS int `validate:"min=60"` // interval between two syncs
+
+	// AutoApply writes the changed models into llm_config.models directly,
+	// otherwise the changes are staged and wait for approval
+	AutoApply bool
+}
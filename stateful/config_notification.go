@@ -0,0 +1,63 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package stateful
+
+import "errors"
+
+// NotificationConfig defines the background job which notify api key owners
+// before the key expires or the quota is exhausted
+type NotificationConfig struct {
+	Enable           bool
+	CheckIntervalInS int   `validate:"min=1"` // interval between two scans
+	ExpireInDays     []int // notify when key will expire in these days
+	QuotaPercents    []int // notify when used quota reaches these percents
+
+	Webhook *WebhookChannelConfig
+	SMTP    *SMTPChannelConfig
+	File    *FileChannelConfig
+}
+
+func (nc *NotificationConfig) Check() error {
+	if !nc.Enable {
+		return nil
+	}
+
+	if nc.Webhook == nil && nc.SMTP == nil && nc.File == nil {
+		return errors.New("Notification.Enable is true, but no channel be configured")
+	}
+
+	return nil
+}
+
+// WebhookChannelConfig post event as json to URL
+type WebhookChannelConfig struct {
+	URL         string `validate:"required,url"`
+	Headers     map[string]string
+	TimeoutInMs int
+}
+
+// SMTPChannelConfig send event as mail to product mail list and To
+type SMTPChannelConfig struct {
+	Addr     string `validate:"required"` // host:port
+	User     string
+	Password string
+	From     string   `validate:"required"`
+	To       []string // always receive mail, besides product mail list
+}
+
+// FileChannelConfig append event as json line to Path, used for test
+type FileChannelConfig struct {
+	Path string `validate:"required"`
+}
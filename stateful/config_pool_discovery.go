@@ -0,0 +1,29 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package stateful
+
+// PoolDiscoveryConfig defines the background job which syncs instances of pools from discovery sources
+type PoolDiscoveryConfig struct {
+	IntervalInS int `validate:"min=1"` // interval between two syncs
+	TimeoutInS  int `validate:"min=1"` // timeout of resolving all sources of one pool
+
+	// FileDir is the directory of file sources, path of file source is relative to
+	// the sub directory named by product. File source is not allowed if it is empty.
+	FileDir string
+
+	// DNSServers are the host:port of dns servers which dns sources may choose,
+	// dns sources can only use the system resolver if it is empty
+	DNSServers []string `validate:"dive,hostname_port"`
+}
@@ -0,0 +1,86 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package stateful
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SecretConfig defines where the master key used to encrypt secrets stored in database comes from
+type SecretConfig struct {
+	KMS string // key management backend, only "local" supported now, default "local"
+
+	MasterKeyFile string // file contains master key
+	MasterKeyEnv  string // env var contains master key, overwrite MasterKeyFile when both set
+
+	// previous master key, only used to decrypt secrets not yet re-keyed after master key rotation
+	OldMasterKeyFile string
+	OldMasterKeyEnv  string
+
+	masterKey    []byte
+	oldMasterKey []byte
+}
+
+func (sc *SecretConfig) Init() error {
+	if sc.KMS == "" {
+		sc.KMS = "local"
+	}
+
+	var err error
+	if sc.masterKey, err = loadKey(sc.MasterKeyFile, sc.MasterKeyEnv); err != nil {
+		return err
+	}
+	if sc.oldMasterKey, err = loadKey(sc.OldMasterKeyFile, sc.OldMasterKeyEnv); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// loadKey read key from file then env, env overwrite file when both set
+func loadKey(file, env string) ([]byte, error) {
+	var key []byte
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read master key file %s fail: %v", file, err)
+		}
+		key = []byte(strings.TrimSpace(string(data)))
+	}
+
+	if env != "" {
+		if v, ok := os.LookupEnv(env); ok {
+			// an empty one is a mistake of deployment, secrets would be stored in plain text silently
+			if v = strings.TrimSpace(v); v == "" {
+				return nil, fmt.Errorf("master key env %s is empty", env)
+			}
+			key = []byte(v)
+		}
+	}
+
+	return key, nil
+}
+
+// MasterKey return nil when master key not be configured
+func (sc *SecretConfig) MasterKey() []byte {
+	return sc.masterKey
+}
+
+// OldMasterKey return nil when old master key not be configured
+func (sc *SecretConfig) OldMasterKey() []byte {
+	return sc.oldMasterKey
+}
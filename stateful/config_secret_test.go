@@ -0,0 +1,68 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package stateful
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSecretConfigInit(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "master.key")
+	if err := os.WriteFile(file, []byte("key-from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		env     *string
+		file    string
+		key     string
+		wantErr bool
+	}{
+		{name: "not configured", key: ""},
+		{name: "file", file: file, key: "key-from-file"},
+		{name: "env overwrites file", env: strPtr("key-from-env"), file: file, key: "key-from-env"},
+		{name: "env not set", file: file, key: "key-from-file"},
+		{name: "env set but empty", env: strPtr(" "), file: file, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.env != nil {
+				t.Setenv("TEST_MASTER_KEY", *c.env)
+			} else {
+				os.Unsetenv("TEST_MASTER_KEY")
+			}
+
+			sc := &SecretConfig{
+				MasterKeyFile: c.file,
+				MasterKeyEnv:  "TEST_MASTER_KEY",
+			}
+			err := sc.Init()
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Init() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if !c.wantErr && string(sc.MasterKey()) != c.key {
+				t.Errorf("MasterKey() = %q, want %q", sc.MasterKey(), c.key)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
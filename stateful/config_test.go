@@ -0,0 +1,75 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package stateful
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestLoadConfigInterval(t *testing.T) {
+	sample, err := os.ReadFile("../conf/ai_gateway_api.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		section string
+		key     string
+		value   int
+		wantErr bool
+	}{
+		{section: "Notification", key: "CheckIntervalInS", value: 60},
+		{section: "Notification", key: "CheckIntervalInS", value: 0, wantErr: true},
+		{section: "Canary", key: "IntervalInS", value: 5},
+		{section: "Canary", key: "IntervalInS", value: 0, wantErr: true},
+		{section: "TrafficPlan", key: "IntervalInS", value: 5},
+		{section: "TrafficPlan", key: "IntervalInS", value: 0, wantErr: true},
+		{section: "PoolDrain", key: "IntervalInS", value: 5},
+		{section: "PoolDrain", key: "IntervalInS", value: 0, wantErr: true},
+		{section: "ModelSync", key: "IntervalInS", value: 60},
+		{section: "ModelSync", key: "IntervalInS", value: 0, wantErr: true},
+		{section: "PoolDiscovery", key: "IntervalInS", value: 5},
+		{section: "PoolDiscovery", key: "IntervalInS", value: 0, wantErr: true},
+		{section: "PoolDiscovery", key: "TimeoutInS", value: 0, wantErr: true},
+		{section: "KubernetesDiscovery", key: "ResyncInS", value: 60},
+		{section: "KubernetesDiscovery", key: "ResyncInS", value: 0, wantErr: true},
+		{section: "BFENode", key: "StaleInS", value: 60},
+		{section: "BFENode", key: "StaleInS", value: 0, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%s.%s=%d", c.section, c.key, c.value), func(t *testing.T) {
+			// replace key of section in the sample config
+			re := regexp.MustCompile(fmt.Sprintf(`(?s)(\[%s\][^\[]*?\n)%s = \d+`, c.section, c.key))
+			if !re.Match(sample) {
+				t.Fatalf("%s.%s not in sample config", c.section, c.key)
+			}
+			conf := re.ReplaceAll(sample, []byte(fmt.Sprintf("${1}%s = %d", c.key, c.value)))
+
+			file := filepath.Join(t.TempDir(), "ai_gateway_api.toml")
+			if err := os.WriteFile(file, conf, 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := LoadConfig(file); (err != nil) != c.wantErr {
+				t.Errorf("LoadConfig() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
@@ -43,9 +43,11 @@ import (
 var (
 	TxnStoragerSingleton            itxn.TxnStorager
 	VersionControlStoragerSingleton iversion_control.VersionControlStorager
+	ConfigTargetStorager            iversion_control.ConfigTargetStorager
 	RouteRuleStoragerSingleton      iroute_conf.RouteRuleStorager
 	ProductStoragerSingleton        ibasic.ProductStorager
 	BFEClusterStoragerSingleton     ibasic.BFEClusterStorager
+	BFENodeStorager                 ibasic.BFENodeStorager
 	DomainStoragerSingleton         iroute_conf.DomainStorager
 	ClusterStoragerSingleton        icluster_conf.ClusterStorager
 	APIKeyStorager                  icluster_conf.APIKeyStorager
@@ -56,10 +58,18 @@ var (
 	AuthorizeStoragerSingleton      iauth.AuthorizeStorager
 	ExtraFileStoragerSingleton      ibasic.ExtraFileStorager
 	AIRouteRuleStorager             iai_route.AIRouteRuleStorager
+	ModelSyncStorager               icluster_conf.ModelSyncStorager
+	ModelProviderStorager           icluster_conf.ModelProviderStorager
+	ClusterTemplateStorager         icluster_conf.ClusterTemplateStorager
+	ActiveHealthCheckStorager       icluster_conf.ActiveHealthCheckStorager
+	TrafficPlanStorager             icluster_conf.TrafficPlanStorager
+	CanaryStorager                  icluster_conf.CanaryStorager
+	PoolDiscoveryStorager           icluster_conf.PoolDiscoveryStorager
 	ExtraFileManager                *ibasic.ExtraFileManager
 	ProductManager                  *ibasic.ProductManager
 	DomainManager                   *iroute_conf.DomainManager
 	BFEClusterManager               *ibasic.BFEClusterManager
+	BFENodeManager                  *ibasic.BFENodeManager
 	VersionControlManager           *iversion_control.VersionControlManager
 	RouteRuleManager                *iroute_conf.RouteRuleManager
 	ClusterManager                  *icluster_conf.ClusterManager
@@ -71,4 +81,14 @@ var (
 	APIKeyRuleManager               *imods.APIKeyRuleManager
 	APIKeyManager                   *icluster_conf.APIKeyManager
 	AIRouteRuleManager              *iai_route.AIRouteRuleManager
+	APIKeyLifecycleWatcher          *icluster_conf.APIKeyLifecycleWatcher
+	ModelSyncManager                *icluster_conf.ModelSyncManager
+	ModelProviderManager            *icluster_conf.ModelProviderManager
+	ClusterTemplateManager          *icluster_conf.ClusterTemplateManager
+	ActiveHealthCheckManager        *icluster_conf.ActiveHealthCheckManager
+	PoolDrainRunner                 *icluster_conf.PoolDrainRunner
+	PoolDiscoveryManager            *icluster_conf.PoolDiscoveryManager
+	KubernetesDiscoverer            *icluster_conf.KubernetesDiscoverer
+	TrafficPlanManager              *icluster_conf.TrafficPlanManager
+	CanaryManager                   *icluster_conf.CanaryManager
 )
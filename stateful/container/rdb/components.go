@@ -36,6 +36,7 @@ import (
 	"github.com/yf-networks/ai-gateway-api/model/ibasic"
 	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
 	"github.com/yf-networks/ai-gateway-api/model/imods"
+	"github.com/yf-networks/ai-gateway-api/model/inotify"
 	"github.com/yf-networks/ai-gateway-api/model/iprotocol"
 	"github.com/yf-networks/ai-gateway-api/model/iroute_conf"
 	"github.com/yf-networks/ai-gateway-api/model/iversion_control"
@@ -54,12 +55,14 @@ import (
 func Init() {
 	container.TxnStoragerSingleton = txn.NewRDBTxnStorager(stateful.NewBFEDBContext)
 	container.VersionControlStoragerSingleton = version_control.NewVersionControllerStorage(stateful.NewBFEDBContext)
+	container.ConfigTargetStorager = version_control.NewConfigTargetStorager(stateful.NewBFEDBContext)
 	container.RouteRuleStoragerSingleton = route_conf.NewRouteRuleStorager(
 		stateful.NewBFEDBContext,
 		container.VersionControlStoragerSingleton)
 
 	container.ProductStoragerSingleton = basic.NewProductManager(stateful.NewBFEDBContext)
 	container.BFEClusterStoragerSingleton = basic.NewRDBBFEClusterStorager(stateful.NewBFEDBContext)
+	container.BFENodeStorager = basic.NewRDBBFENodeStorager(stateful.NewBFEDBContext)
 	container.PoolStoragerSingleton = cluster_conf.NewRDBPoolStorager(
 		stateful.NewBFEDBContext,
 		container.ProductStoragerSingleton)
@@ -75,6 +78,34 @@ func Init() {
 		stateful.NewBFEDBContext,
 	)
 
+	container.ModelSyncStorager = cluster_conf.NewModelSyncStorager(
+		stateful.NewBFEDBContext,
+	)
+
+	container.PoolDiscoveryStorager = cluster_conf.NewPoolDiscoveryStorager(
+		stateful.NewBFEDBContext,
+	)
+
+	container.ModelProviderStorager = cluster_conf.NewModelProviderStorager(
+		stateful.NewBFEDBContext,
+	)
+
+	container.ClusterTemplateStorager = cluster_conf.NewClusterTemplateStorager(
+		stateful.NewBFEDBContext,
+	)
+
+	container.ActiveHealthCheckStorager = cluster_conf.NewActiveHealthCheckStorager(
+		stateful.NewBFEDBContext,
+	)
+
+	container.TrafficPlanStorager = cluster_conf.NewTrafficPlanStorager(
+		stateful.NewBFEDBContext,
+	)
+
+	container.CanaryStorager = cluster_conf.NewCanaryStorager(
+		stateful.NewBFEDBContext,
+	)
+
 	container.AIRouteRuleStorager = ai_route.NewRDBAIRouteRuleStorager(
 		stateful.NewBFEDBContext,
 	)
@@ -91,23 +122,29 @@ func Init() {
 	container.ExtraFileManager = ibasic.NewExtraFileManager(container.ExtraFileStoragerSingleton)
 	container.VersionControlManager = iversion_control.NewVersionControllerManager(
 		container.TxnStoragerSingleton,
-		container.VersionControlStoragerSingleton)
-
-	container.BFEClusterManager = ibasic.NewBFEClusterManager(
-		container.TxnStoragerSingleton,
-		container.BFEClusterStoragerSingleton)
+		container.VersionControlStoragerSingleton,
+		container.ConfigTargetStorager,
+		// gslb is exported per bfe cluster already
+		[]*iversion_control.ConfigTargetGroup{
+			{
+				// route rules refer clusters of cluster table
+				Topics: []string{iroute_conf.ConfigTopicRouteRule, icluster_conf.ConfigTopicClusterTable},
+				Check:  iroute_conf.CheckClusterTableTarget,
+			},
+			{Topics: []string{iprotocol.ConfigTopicServerCert}},
+			{Topics: []string{icluster_conf.ConfigTopicActiveHealthCheck}},
+			{
+				// revoked, expired and deleted api keys are never staged
+				Topics: []string{imods.ConfigTopicProductAPIKeyRule},
+				Revoke: imods.RevokeAPIKeys,
+			},
+		})
 
 	container.APIKeyManager = icluster_conf.NewAPIKeyManager(
 		container.TxnStoragerSingleton,
 		container.APIKeyStorager,
 		container.ClusterStoragerSingleton,
 	)
-	container.CertificateManager = iprotocol.NewCertificateManager(
-		container.TxnStoragerSingleton,
-		container.CertificateStoragerSingleton,
-		container.VersionControlManager,
-		container.ExtraFileStoragerSingleton)
-
 	container.ProductManager = ibasic.NewProductManager(
 		container.TxnStoragerSingleton,
 		container.ProductStoragerSingleton)
@@ -125,13 +162,20 @@ func Init() {
 		container.VersionControlManager,
 		container.RouteRuleStoragerSingleton,
 	)
+	container.ModelProviderManager = icluster_conf.NewModelProviderManager(
+		container.TxnStoragerSingleton,
+		container.ModelProviderStorager,
+		container.ClusterStoragerSingleton)
+
 	container.RouteRuleManager = iroute_conf.NewRouteRuleManager(
 		container.TxnStoragerSingleton,
 		container.RouteRuleStoragerSingleton,
 		container.ClusterStoragerSingleton,
 		container.ProductStoragerSingleton,
 		container.VersionControlManager,
-		container.DomainStoragerSingleton)
+		container.DomainStoragerSingleton,
+		container.ModelProviderManager.ProviderProtocol,
+		container.CertificateStoragerSingleton.FetchCertificates)
 
 	container.ClusterManager = icluster_conf.NewClusterManager(
 		container.TxnStoragerSingleton,
@@ -141,6 +185,41 @@ func Init() {
 		container.VersionControlManager,
 		map[string]func(context.Context, *ibasic.Product, *icluster_conf.Cluster) error{
 			"rules": container.RouteRuleManager.ClusterDeleteChecker,
+		},
+		map[string]func(context.Context, *icluster_conf.Cluster) error{
+			"canary": icluster_conf.CanarySchedulerChecker(container.CanaryStorager),
+		})
+
+	container.BFEClusterManager = ibasic.NewBFEClusterManager(
+		container.TxnStoragerSingleton,
+		container.BFEClusterStoragerSingleton,
+		map[string]func(context.Context) error{
+			"auto_scheduler": container.ClusterManager.BFEClusterChangeHook,
+		})
+
+	container.BFENodeManager = ibasic.NewBFENodeManager(
+		container.TxnStoragerSingleton,
+		container.BFENodeStorager,
+		container.BFEClusterStoragerSingleton,
+		container.VersionControlStoragerSingleton,
+		container.ConfigTargetStorager,
+		map[string]bool{
+			iroute_conf.ConfigTopicRouteRule:           false,
+			icluster_conf.ConfigTopicGSLB:              true,
+			icluster_conf.ConfigTopicClusterTable:      false,
+			iprotocol.ConfigTopicServerCert:            false,
+			imods.ConfigTopicProductAPIKeyRule:         false,
+			icluster_conf.ConfigTopicActiveHealthCheck: false,
+		},
+		&stateful.DefaultConfig.BFENode)
+
+	container.CertificateManager = iprotocol.NewCertificateManager(
+		container.TxnStoragerSingleton,
+		container.CertificateStoragerSingleton,
+		container.VersionControlManager,
+		container.ExtraFileStoragerSingleton,
+		map[string]func(context.Context, *iprotocol.Certificate) error{
+			"clusters": container.ClusterManager.CertificateDeleteChecker,
 		})
 
 	container.SubClusterManager = icluster_conf.NewSubClusterManager(
@@ -148,7 +227,10 @@ func Init() {
 		container.SubClusterStoragerSingleton,
 		container.ProductStoragerSingleton,
 		container.PoolStoragerSingleton,
-		container.ClusterStoragerSingleton)
+		container.ClusterStoragerSingleton,
+		map[string]func(context.Context, *icluster_conf.SubCluster) error{
+			"auto_scheduler": container.ClusterManager.SubClusterChangeHook,
+		})
 
 	container.DomainManager = iroute_conf.NewDomainManager(
 		container.TxnStoragerSingleton,
@@ -169,4 +251,57 @@ func Init() {
 		container.PoolStoragerSingleton,
 		container.BFEClusterStoragerSingleton,
 		container.SubClusterStoragerSingleton)
+
+	container.APIKeyLifecycleWatcher = icluster_conf.NewAPIKeyLifecycleWatcher(
+		container.TxnStoragerSingleton,
+		container.APIKeyStorager,
+		container.ProductStoragerSingleton,
+		inotify.NewNotifierFromConfig(&stateful.DefaultConfig.Notification),
+		&stateful.DefaultConfig.Notification)
+
+	container.ModelSyncManager = icluster_conf.NewModelSyncManager(
+		container.TxnStoragerSingleton,
+		container.ModelSyncStorager,
+		container.ClusterStoragerSingleton,
+		container.APIKeyStorager,
+		container.ProductStoragerSingleton,
+		container.ModelProviderManager,
+		&stateful.DefaultConfig.ModelSync)
+
+	container.ClusterTemplateManager = icluster_conf.NewClusterTemplateManager(
+		container.TxnStoragerSingleton,
+		container.ClusterTemplateStorager)
+
+	container.ActiveHealthCheckManager = icluster_conf.NewActiveHealthCheckManager(
+		container.TxnStoragerSingleton,
+		container.ActiveHealthCheckStorager,
+		container.ClusterStoragerSingleton,
+		container.ModelProviderManager.ProviderProtocol,
+		container.VersionControlManager)
+
+	container.PoolDrainRunner = icluster_conf.NewPoolDrainRunner(
+		container.TxnStoragerSingleton,
+		container.PoolStoragerSingleton,
+		&stateful.DefaultConfig.PoolDrain)
+
+	container.PoolDiscoveryManager = icluster_conf.NewPoolDiscoveryManager(
+		container.TxnStoragerSingleton,
+		container.PoolDiscoveryStorager,
+		container.PoolManager,
+		container.KubernetesDiscoverer,
+		&stateful.DefaultConfig.PoolDiscovery)
+
+	container.TrafficPlanManager = icluster_conf.NewTrafficPlanManager(
+		container.TxnStoragerSingleton,
+		container.TrafficPlanStorager,
+		container.CanaryStorager,
+		container.ClusterManager,
+		&stateful.DefaultConfig.TrafficPlan)
+
+	container.CanaryManager = icluster_conf.NewCanaryManager(
+		container.TxnStoragerSingleton,
+		container.CanaryStorager,
+		container.TrafficPlanStorager,
+		container.ClusterManager,
+		&stateful.DefaultConfig.Canary)
 }
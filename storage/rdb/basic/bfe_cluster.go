@@ -92,6 +92,35 @@ func (ps *RDBBFEClusterStorager) DeleteBFECluster(ctx context.Context, pp *ibasi
 	_, err = dao.TBfeClusterDelete(dbCtx, &dao.TBfeClusterParam{
 		Name: &pp.Name,
 	})
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TBfeNodeDelete(dbCtx, &dao.TBfeNodeParam{
+		BfeCluster: &pp.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TBfeClusterConfigTargetDelete(dbCtx, &dao.TBfeClusterConfigTargetParam{
+		BfeCluster: &pp.Name,
+	})
+
+	return err
+}
+
+func (ps *RDBBFEClusterStorager) UpdateBFECluster(ctx context.Context, old *ibasic.BFECluster, pp *ibasic.BFEClusterParam) error {
+	dbCtx, err := ps.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TBfeClusterUpdate(dbCtx, &dao.TBfeClusterParam{
+		Capacity: pp.Capacity,
+	}, &dao.TBfeClusterParam{
+		Name: &old.Name,
+	})
 
 	return err
 }
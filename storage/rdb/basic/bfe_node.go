@@ -0,0 +1,129 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package basic
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/ibasic"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao"
+)
+
+type RDBBFENodeStorager struct {
+	dbCtxFactory lib.DBContextFactory
+}
+
+var _ ibasic.BFENodeStorager = &RDBBFENodeStorager{}
+
+func NewRDBBFENodeStorager(dbCtxFactory lib.DBContextFactory) *RDBBFENodeStorager {
+	return &RDBBFENodeStorager{
+		dbCtxFactory: dbCtxFactory,
+	}
+}
+
+func bfeNodeParami2d(param *ibasic.BFENodeParam) (*dao.TBfeNodeParam, error) {
+	rst := &dao.TBfeNodeParam{
+		NodeID:       param.NodeID,
+		BfeCluster:   param.BFECluster,
+		BuildVersion: param.BuildVersion,
+		Addr:         param.Addr,
+		HeartbeatAt:  param.HeartbeatAt,
+	}
+
+	if param.AppliedVersions != nil {
+		bs, err := json.Marshal(param.AppliedVersions)
+		if err != nil {
+			return nil, xerror.WrapParamErrorWithMsg("AppliedVersions Marshal, err: %s", err)
+		}
+		rst.AppliedVersions = lib.PString(string(bs))
+	}
+
+	return rst, nil
+}
+
+func (bs *RDBBFENodeStorager) FetchBFENodes(ctx context.Context, filter *ibasic.BFENodeFilter) ([]*ibasic.BFENode, error) {
+	dbCtx, err := bs.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var where *dao.TBfeNodeParam
+	if filter != nil {
+		where = &dao.TBfeNodeParam{
+			NodeID:     filter.NodeID,
+			BfeCluster: filter.BFECluster,
+		}
+	}
+
+	list, err := dao.TBfeNodeList(dbCtx, where)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := make([]*ibasic.BFENode, 0, len(list))
+	for _, one := range list {
+		node := &ibasic.BFENode{
+			NodeID:          one.NodeID,
+			BFECluster:      one.BfeCluster,
+			BuildVersion:    one.BuildVersion,
+			Addr:            one.Addr,
+			AppliedVersions: map[string]string{},
+			HeartbeatAt:     one.HeartbeatAt,
+			CreatedAt:       one.CreatedAt,
+		}
+		if one.AppliedVersions != "" {
+			if err := json.Unmarshal([]byte(one.AppliedVersions), &node.AppliedVersions); err != nil {
+				return nil, xerror.WrapDirtyDataErrorWithMsg("bfe node %s, raw: %s, err: %v", one.NodeID, one.AppliedVersions, err)
+			}
+		}
+
+		rst = append(rst, node)
+	}
+
+	return rst, nil
+}
+
+// UpsertBFENode creates node, or refreshes it if node_id existed, concurrent first heartbeats never conflict
+func (bs *RDBBFENodeStorager) UpsertBFENode(ctx context.Context, param *ibasic.BFENodeParam) error {
+	dbCtx, err := bs.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := bfeNodeParami2d(param)
+	if err != nil {
+		return err
+	}
+
+	val := *data
+	val.NodeID = nil
+	_, err = dao.TBfeNodeUpsert(dbCtx, data, &val)
+	return err
+}
+
+func (bs *RDBBFENodeStorager) DeleteBFENode(ctx context.Context, node *ibasic.BFENode) error {
+	dbCtx, err := bs.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TBfeNodeDelete(dbCtx, &dao.TBfeNodeParam{
+		NodeID: &node.NodeID,
+	})
+	return err
+}
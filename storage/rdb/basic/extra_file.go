@@ -109,3 +109,21 @@ func (ps *RDBExtraFileStorager) CreateExtraFile(ctx context.Context, product *ib
 
 	return err
 }
+
+func (ps *RDBExtraFileStorager) UpdateExtraFile(ctx context.Context, old *ibasic.ExtraFile, pp *ibasic.ExtraFileParam) error {
+	dbCtx, err := ps.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TExtraFileUpdate(dbCtx, &dao.TExtraFileParam{
+		Description: pp.Description,
+		Md5:         pp.Md5,
+		Content:     pp.Content,
+		UpdatedAt:   lib.PTimeNow(),
+	}, &dao.TExtraFileParam{
+		ID: &old.ID,
+	})
+
+	return err
+}
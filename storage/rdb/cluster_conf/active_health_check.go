@@ -0,0 +1,145 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cluster_conf
+
+import (
+	"context"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao"
+)
+
+type ActiveHealthCheckStorager struct {
+	dbCtxFactory lib.DBContextFactory
+}
+
+func NewActiveHealthCheckStorager(dbCtxFactory lib.DBContextFactory) *ActiveHealthCheckStorager {
+	return &ActiveHealthCheckStorager{
+		dbCtxFactory: dbCtxFactory,
+	}
+}
+
+var _ icluster_conf.ActiveHealthCheckStorager = &ActiveHealthCheckStorager{}
+
+func (s *ActiveHealthCheckStorager) FetchActiveHealthChecks(ctx context.Context,
+	filter *icluster_conf.ActiveHealthCheckFilter) ([]*icluster_conf.ActiveHealthCheck, error) {
+
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where := &dao.TClusterHealthCheckParam{}
+	if filter != nil {
+		where.ClusterID = filter.ClusterID
+		where.ProductID = filter.ProductID
+		where.ClusterIDs = filter.ClusterIDs
+	}
+	list, err := dao.TClusterHealthCheckList(dbCtx, where)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	var clusterIDs []int64
+	for _, one := range list {
+		clusterIDs = append(clusterIDs, one.ClusterID)
+	}
+	clusters, err := dao.TClusterList(dbCtx, &dao.TClusterParam{
+		IDs: clusterIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	clusterNames := map[int64]string{}
+	for _, one := range clusters {
+		clusterNames[one.ID] = one.Name
+	}
+
+	var rst []*icluster_conf.ActiveHealthCheck
+	for _, one := range list {
+		rst = append(rst, &icluster_conf.ActiveHealthCheck{
+			ClusterID:          one.ClusterID,
+			ProductID:          one.ProductID,
+			ClusterName:        clusterNames[one.ClusterID],
+			Enable:             one.Enable,
+			Probe:              one.Probe,
+			Model:              one.Model,
+			IntervalInS:        one.IntervalInS,
+			TimeoutInS:         one.TimeoutInS,
+			HealthyThreshold:   one.HealthyThreshold,
+			UnhealthyThreshold: one.UnhealthyThreshold,
+		})
+	}
+
+	return rst, nil
+}
+
+func newDaoClusterHealthCheckParam(data *icluster_conf.ActiveHealthCheck) *dao.TClusterHealthCheckParam {
+	return &dao.TClusterHealthCheckParam{
+		Enable:             &data.Enable,
+		Probe:              &data.Probe,
+		Model:              &data.Model,
+		IntervalInS:        &data.IntervalInS,
+		TimeoutInS:         &data.TimeoutInS,
+		HealthyThreshold:   &data.HealthyThreshold,
+		UnhealthyThreshold: &data.UnhealthyThreshold,
+		UpdatedAt:          lib.PTimeNow(),
+	}
+}
+
+func (s *ActiveHealthCheckStorager) CreateActiveHealthCheck(ctx context.Context, cluster *icluster_conf.Cluster,
+	data *icluster_conf.ActiveHealthCheck) error {
+
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	param := newDaoClusterHealthCheckParam(data)
+	param.ClusterID = &cluster.ID
+	param.ProductID = &cluster.ProductID
+	_, err = dao.TClusterHealthCheckCreate(dbCtx, param)
+
+	return err
+}
+
+func (s *ActiveHealthCheckStorager) UpdateActiveHealthCheck(ctx context.Context, cluster *icluster_conf.Cluster,
+	data *icluster_conf.ActiveHealthCheck) error {
+
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TClusterHealthCheckUpdate(dbCtx, newDaoClusterHealthCheckParam(data), &dao.TClusterHealthCheckParam{
+		ClusterID: &cluster.ID,
+	})
+
+	return err
+}
+
+func (s *ActiveHealthCheckStorager) DeleteActiveHealthCheck(ctx context.Context, cluster *icluster_conf.Cluster) error {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TClusterHealthCheckDelete(dbCtx, &dao.TClusterHealthCheckParam{
+		ClusterID: &cluster.ID,
+	})
+
+	return err
+}
@@ -60,19 +60,22 @@ func (rpps *APIKeyStorager) CreateAPIKey(ctx context.Context,
 	allowedSubnetsValue, _ := json.Marshal(allowedSubnets)
 	data.AllowedCIDR = lib.PString(string(allowedSubnetsValue))
 
+	data.Upstreams = lib.PString(marshalUpstreamCredentials(param.UpstreamCredentials))
+
 	return dao.TAPIKeyCreate(dbCtx, data)
 }
 
 func newAPIKeyDataToParam(param *icluster_conf.APIKeyParam) *dao.TAPIKeyParam {
 	data := &dao.TAPIKeyParam{
-		Name:        param.Name,
-		Enable:      param.Enable,
-		Key:         param.Key,
-		IsLimit:     param.IsLimit,
-		Limit:       param.Limit,
-		ExpiredTime: param.ExpiredTime,
-		ProductName: param.ProductName,
-		UpdatedAt:   lib.PTimeNow(),
+		Name:          param.Name,
+		Enable:        param.Enable,
+		Key:           param.Key,
+		IsLimit:       param.IsLimit,
+		Limit:         param.Limit,
+		ExpiredTime:   param.ExpiredTime,
+		EffectiveTime: param.EffectiveTime,
+		ProductName:   param.ProductName,
+		UpdatedAt:     lib.PTimeNow(),
 	}
 
 	return data
@@ -129,12 +132,69 @@ func apiKeyParamToData(one *dao.TAPIKey) *icluster_conf.APIKeyParam {
 		IsLimit:       &one.IsLimit,
 		Limit:         &one.Limit,
 		ExpiredTime:   &one.ExpiredTime,
+		EffectiveTime: &one.EffectiveTime,
 		AllowedModels: allowedModels,
 		AllowedCIDR:   allowedSubnets,
 		ProductName:   &one.ProductName,
 		KeyCreateAt:   &one.CreatedAt,
 		UpdatedTime:   lib.PString(one.CreatedAt.Format(lib.FormatTimeYYMMDD_HHMMSS)),
+
+		UpstreamCredentials: unmarshalUpstreamCredentials(one.Upstreams),
+	}
+}
+
+// upstreamCredential is the storage format of icluster_conf.UpstreamCredential, plain text key never be stored
+type upstreamCredential struct {
+	Cluster      string `json:"cluster,omitempty"`
+	ProviderType string `json:"provider_type,omitempty"`
+	SealedKey    string `json:"sealed_key"`
+	KeyHint      string `json:"key_hint"`
+}
+
+func marshalUpstreamCredentials(list []*icluster_conf.UpstreamCredential) string {
+	data := make([]*upstreamCredential, 0, len(list))
+	for _, one := range list {
+		item := &upstreamCredential{
+			SealedKey: one.SealedKey,
+			KeyHint:   one.KeyHint,
+		}
+		if one.Cluster != nil {
+			item.Cluster = *one.Cluster
+		}
+		if one.ProviderType != nil {
+			item.ProviderType = *one.ProviderType
+		}
+		data = append(data, item)
+	}
+
+	b, _ := json.Marshal(data)
+	return string(b)
+}
+
+func unmarshalUpstreamCredentials(s string) []*icluster_conf.UpstreamCredential {
+	if s == "" {
+		return nil
+	}
+
+	data := []*upstreamCredential{}
+	json.Unmarshal([]byte(s), &data)
+
+	var list []*icluster_conf.UpstreamCredential
+	for _, one := range data {
+		item := &icluster_conf.UpstreamCredential{
+			SealedKey: one.SealedKey,
+			KeyHint:   one.KeyHint,
+		}
+		if one.Cluster != "" {
+			item.Cluster = lib.PString(one.Cluster)
+		}
+		if one.ProviderType != "" {
+			item.ProviderType = lib.PString(one.ProviderType)
+		}
+		list = append(list, item)
 	}
+
+	return list
 }
 
 func (rpps *APIKeyStorager) DeleteAPIKey(ctx context.Context, filter *icluster_conf.APIKeyFilter) error {
@@ -143,6 +203,19 @@ func (rpps *APIKeyStorager) DeleteAPIKey(ctx context.Context, filter *icluster_c
 		return err
 	}
 
+	list, err := dao.TAPIKeyList(dbCtx, newAPIKeyFilterToParam(filter))
+	if err != nil {
+		return err
+	}
+	for _, one := range list {
+		id := one.ID
+		if _, err = dao.TAPIKeyNotificationDelete(dbCtx, &dao.TAPIKeyNotificationParam{
+			APIKeyID: &id,
+		}); err != nil {
+			return err
+		}
+	}
+
 	_, err = dao.TAPIKeyDelete(dbCtx, newAPIKeyFilterToParam(filter))
 
 	return err
@@ -170,6 +243,10 @@ func (rpps *APIKeyStorager) UpdateAPIKey(ctx context.Context, filter *icluster_c
 	allowedSubnetsValue, _ := json.Marshal(allowedSubnets)
 	data.AllowedCIDR = lib.PString(string(allowedSubnetsValue))
 
+	if param.UpstreamCredentials != nil {
+		data.Upstreams = lib.PString(marshalUpstreamCredentials(param.UpstreamCredentials))
+	}
+
 	return dao.TAPIKeyUpdate(dbCtx, data, newAPIKeyFilterToParam(filter))
 }
 
@@ -231,3 +308,47 @@ func newAPIKeyTokensDataToParam(list []*dao.TAPIKeyToken) []*icluster_conf.APIKe
 
 	return results
 }
+
+func (rpps *APIKeyStorager) FetchAPIKeyNotificationList(ctx context.Context,
+	filter *icluster_conf.APIKeyNotificationFilter) ([]*icluster_conf.APIKeyNotification, error) {
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := dao.TAPIKeyNotificationList(dbCtx, &dao.TAPIKeyNotificationParam{
+		APIKeyID: filter.APIKeyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []*icluster_conf.APIKeyNotification
+	for _, one := range list {
+		rst = append(rst, &icluster_conf.APIKeyNotification{
+			APIKeyID:  one.APIKeyID,
+			Kind:      one.Kind,
+			Threshold: one.Threshold,
+			Cycle:     one.Cycle,
+		})
+	}
+
+	return rst, nil
+}
+
+func (rpps *APIKeyStorager) CreateAPIKeyNotification(ctx context.Context,
+	param *icluster_conf.APIKeyNotification) error {
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TAPIKeyNotificationCreate(dbCtx, &dao.TAPIKeyNotificationParam{
+		APIKeyID:  &param.APIKeyID,
+		Kind:      &param.Kind,
+		Threshold: &param.Threshold,
+		Cycle:     &param.Cycle,
+		UpdatedAt: lib.PTimeNow(),
+	})
+	return err
+}
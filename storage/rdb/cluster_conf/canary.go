@@ -0,0 +1,280 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cluster_conf
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao"
+)
+
+type CanaryStorager struct {
+	dbCtxFactory lib.DBContextFactory
+}
+
+func NewCanaryStorager(dbCtxFactory lib.DBContextFactory) *CanaryStorager {
+	return &CanaryStorager{
+		dbCtxFactory: dbCtxFactory,
+	}
+}
+
+var _ icluster_conf.CanaryStorager = &CanaryStorager{}
+
+func (s *CanaryStorager) FetchCanaries(ctx context.Context, filter *icluster_conf.CanaryFilter) ([]*icluster_conf.Canary, error) {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where := &dao.TCanaryParam{
+		OrderBy: lib.PString("id"),
+	}
+	if filter != nil {
+		where.ID = filter.ID
+		where.ClusterID = filter.ClusterID
+		where.ClusterIDs = filter.ClusterIDs
+		where.Statuses = filter.Statuses
+		if filter.ForUpdate {
+			where.LockMode = &dao.ModeForUpdate
+		}
+	}
+	list, err := dao.TCanaryList(dbCtx, where)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	var clusterIDs []int64
+	for _, one := range list {
+		clusterIDs = append(clusterIDs, one.ClusterID)
+	}
+	clusters, err := dao.TClusterList(dbCtx, &dao.TClusterParam{
+		IDs: clusterIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	clusterNames := map[int64]string{}
+	for _, one := range clusters {
+		clusterNames[one.ID] = one.Name
+	}
+
+	var rst []*icluster_conf.Canary
+	for _, one := range list {
+		data := &icluster_conf.Canary{
+			ID:              one.ID,
+			ProductID:       one.ProductID,
+			ClusterID:       one.ClusterID,
+			ClusterName:     clusterNames[one.ClusterID],
+			SubCluster:      one.SubCluster,
+			Status:          one.Status,
+			StepIntervalInS: one.StepIntervalInS,
+			MaxErrorRate:    one.MaxErrorRate,
+			MaxLatencyMs:    one.MaxLatencyMs,
+			MinRequests:     one.MinRequests,
+			Step:            int(one.Step),
+			StepStartedAt:   one.StepStartedAt,
+			PausedAt:        one.PausedAt,
+			Reason:          one.Reason,
+			CreatedBy:       one.CreatedBy,
+			CreatedAt:       one.CreatedAt,
+			UpdatedAt:       one.UpdatedAt,
+		}
+		json.Unmarshal([]byte(one.Steps), &data.Steps)
+		json.Unmarshal([]byte(one.BaseLbMatrix), &data.BaseLbMatrix)
+		rst = append(rst, data)
+	}
+
+	return rst, nil
+}
+
+func (s *CanaryStorager) CreateCanary(ctx context.Context, cluster *icluster_conf.Cluster, data *icluster_conf.Canary) (int64, error) {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	steps, _ := json.Marshal(data.Steps)
+	baseLbMatrix, _ := json.Marshal(data.BaseLbMatrix)
+	return dao.TCanaryCreate(dbCtx, &dao.TCanaryParam{
+		ProductID:       &cluster.ProductID,
+		ClusterID:       &cluster.ID,
+		SubCluster:      &data.SubCluster,
+		Status:          &data.Status,
+		Steps:           lib.PString(string(steps)),
+		StepIntervalInS: &data.StepIntervalInS,
+		MaxErrorRate:    &data.MaxErrorRate,
+		MaxLatencyMs:    &data.MaxLatencyMs,
+		MinRequests:     &data.MinRequests,
+		BaseLbMatrix:    lib.PString(string(baseLbMatrix)),
+		Step:            lib.PInt32(int32(data.Step)),
+		StepStartedAt:   &data.StepStartedAt,
+		PausedAt:        &data.PausedAt,
+		Reason:          &data.Reason,
+		CreatedBy:       &data.CreatedBy,
+		CreatedAt:       &data.CreatedAt,
+		UpdatedAt:       &data.UpdatedAt,
+	})
+}
+
+func (s *CanaryStorager) UpdateCanary(ctx context.Context, data *icluster_conf.Canary) error {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TCanaryUpdate(dbCtx, &dao.TCanaryParam{
+		Status:        &data.Status,
+		Step:          lib.PInt32(int32(data.Step)),
+		StepStartedAt: &data.StepStartedAt,
+		PausedAt:      &data.PausedAt,
+		Reason:        &data.Reason,
+		UpdatedAt:     lib.PTimeNow(),
+	}, &dao.TCanaryParam{
+		ID: &data.ID,
+	})
+
+	return err
+}
+
+// deleteCanaries deletes canaries with their metrics and logs
+func deleteCanaries(dbCtx *lib.DBContext, canaryIDs []int64) error {
+	if len(canaryIDs) == 0 {
+		return nil
+	}
+
+	if _, err := dao.TCanaryMetricDelete(dbCtx, &dao.TCanaryMetricParam{
+		CanaryIDs: canaryIDs,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := dao.TCanaryLogDelete(dbCtx, &dao.TCanaryLogParam{
+		CanaryIDs: canaryIDs,
+	}); err != nil {
+		return err
+	}
+
+	_, err := dao.TCanaryDelete(dbCtx, &dao.TCanaryParam{
+		IDs: canaryIDs,
+	})
+
+	return err
+}
+
+func (s *CanaryStorager) DeleteCanary(ctx context.Context, data *icluster_conf.Canary) error {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	return deleteCanaries(dbCtx, []int64{data.ID})
+}
+
+func (s *CanaryStorager) CreateCanaryMetric(ctx context.Context, canary *icluster_conf.Canary,
+	metrics *icluster_conf.CanaryMetrics) error {
+
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TCanaryMetricCreate(dbCtx, &dao.TCanaryMetricParam{
+		CanaryID:     &canary.ID,
+		Step:         lib.PInt32(int32(canary.Step)),
+		Requests:     &metrics.Requests,
+		Errors:       &metrics.Errors,
+		LatencyMsSum: &metrics.LatencyMsSum,
+		UpdatedAt:    lib.PTimeNow(),
+	})
+
+	return err
+}
+
+func (s *CanaryStorager) FetchCanaryMetrics(ctx context.Context, canary *icluster_conf.Canary) (*icluster_conf.CanaryMetrics, error) {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := dao.TCanaryMetricList(dbCtx, &dao.TCanaryMetricParam{
+		CanaryID: &canary.ID,
+		Step:     lib.PInt32(int32(canary.Step)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rst := &icluster_conf.CanaryMetrics{}
+	for _, one := range list {
+		rst.Requests += one.Requests
+		rst.Errors += one.Errors
+		rst.LatencyMsSum += one.LatencyMsSum
+	}
+
+	return rst, nil
+}
+
+func (s *CanaryStorager) CreateCanaryLog(ctx context.Context, log *icluster_conf.CanaryLog) error {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TCanaryLogCreate(dbCtx, &dao.TCanaryLogParam{
+		CanaryID:  &log.CanaryID,
+		Step:      lib.PInt32(int32(log.Step)),
+		Percent:   lib.PInt32(int32(log.Percent)),
+		Action:    &log.Action,
+		Message:   &log.Message,
+		Operator:  &log.Operator,
+		UpdatedAt: lib.PTimeNow(),
+	})
+
+	return err
+}
+
+func (s *CanaryStorager) FetchCanaryLogs(ctx context.Context, canary *icluster_conf.Canary) ([]*icluster_conf.CanaryLog, error) {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := dao.TCanaryLogList(dbCtx, &dao.TCanaryLogParam{
+		CanaryID: &canary.ID,
+		OrderBy:  lib.PString("id"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []*icluster_conf.CanaryLog
+	for _, one := range list {
+		rst = append(rst, &icluster_conf.CanaryLog{
+			ID:        one.ID,
+			CanaryID:  one.CanaryID,
+			Step:      int(one.Step),
+			Percent:   int(one.Percent),
+			Action:    one.Action,
+			Message:   one.Message,
+			Operator:  one.Operator,
+			CreatedAt: one.CreatedAt,
+		})
+	}
+
+	return rst, nil
+}
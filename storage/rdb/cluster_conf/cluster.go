@@ -131,6 +131,55 @@ func (rm *RDBClusterStorager) ClusterDelete(ctx context.Context, product *ibasic
 		return err
 	}
 
+	if _, err = dao.TClusterModelSyncDelete(dbCtx, &dao.TClusterModelSyncParam{
+		ClusterID: &clusterID,
+	}); err != nil {
+		return err
+	}
+
+	if _, err = dao.TClusterHealthCheckDelete(dbCtx, &dao.TClusterHealthCheckParam{
+		ClusterID: &clusterID,
+	}); err != nil {
+		return err
+	}
+
+	plans, err := dao.TTrafficPlanList(dbCtx, &dao.TTrafficPlanParam{
+		ClusterID: &clusterID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(plans) > 0 {
+		var planIDs []int64
+		for _, one := range plans {
+			planIDs = append(planIDs, one.ID)
+		}
+		if _, err = dao.TTrafficPlanLogDelete(dbCtx, &dao.TTrafficPlanLogParam{
+			PlanIDs: planIDs,
+		}); err != nil {
+			return err
+		}
+		if _, err = dao.TTrafficPlanDelete(dbCtx, &dao.TTrafficPlanParam{
+			ClusterID: &clusterID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	canaries, err := dao.TCanaryList(dbCtx, &dao.TCanaryParam{
+		ClusterID: &clusterID,
+	})
+	if err != nil {
+		return err
+	}
+	var canaryIDs []int64
+	for _, one := range canaries {
+		canaryIDs = append(canaryIDs, one.ID)
+	}
+	if err = deleteCanaries(dbCtx, canaryIDs); err != nil {
+		return err
+	}
+
 	_, err = dao.TClusterDelete(dbCtx, &dao.TClusterParam{
 		ID: &clusterID,
 	})
@@ -264,6 +313,8 @@ func newCluster(dc *dao.TCluster, subClusters []*icluster_conf.SubCluster, capac
 				TimeoutWriteClient:     dc.TimeoutWriteClient,
 			},
 			Protocol: &dc.Protocol,
+
+			BalanceMode: dc.BalanceMode,
 		},
 
 		StickySessions: &icluster_conf.ClusterStickySessions{
@@ -285,6 +336,30 @@ func newCluster(dc *dao.TCluster, subClusters []*icluster_conf.SubCluster, capac
 		SubClusters: subClusters,
 	}
 
+	if dc.BalanceParams != "" {
+		params := &icluster_conf.ClusterBalanceParams{}
+		json.Unmarshal([]byte(dc.BalanceParams), params)
+		data.Basic.BalanceParams = params
+	}
+
+	if dc.UpstreamTLS != "" {
+		upstreamTLS := &icluster_conf.ClusterUpstreamTLS{}
+		json.Unmarshal([]byte(dc.UpstreamTLS), upstreamTLS)
+		data.UpstreamTLS = upstreamTLS
+	}
+
+	if dc.RetryPolicy != "" {
+		retryPolicy := &icluster_conf.ClusterRetryPolicy{}
+		json.Unmarshal([]byte(dc.RetryPolicy), retryPolicy)
+		data.RetryPolicy = retryPolicy
+	}
+
+	if dc.AutoScheduler != "" {
+		autoScheduler := &icluster_conf.ClusterAutoScheduler{}
+		json.Unmarshal([]byte(dc.AutoScheduler), autoScheduler)
+		data.AutoScheduler = autoScheduler
+	}
+
 	if dc.LLMConfig != "" {
 		llmConfig := &icluster_conf.LLMConfig{}
 		json.Unmarshal([]byte(dc.LLMConfig), llmConfig)
@@ -302,6 +377,10 @@ func clusterFilter2Param(filter *icluster_conf.ClusterFilter) *dao.TClusterParam
 	if filter.Product != nil {
 		productID = &filter.Product.ID
 	}
+	var lockMode *string
+	if filter.ForUpdate {
+		lockMode = &dao.ModeForUpdate
+	}
 	return &dao.TClusterParam{
 		ID:  filter.ID,
 		IDs: filter.IDs,
@@ -310,6 +389,8 @@ func clusterFilter2Param(filter *icluster_conf.ClusterFilter) *dao.TClusterParam
 		Names: filter.Names,
 
 		ProductID: productID,
+
+		LockMode: lockMode,
 	}
 }
 
@@ -333,6 +414,15 @@ func newDaoClusterParam(param *icluster_conf.ClusterParam) *dao.TClusterParam {
 
 		dc.Protocol = basic.Protocol
 
+		if basic.BalanceMode != nil {
+			dc.BalanceMode = basic.BalanceMode
+			dc.BalanceParams = lib.PString("")
+			if basic.BalanceParams != nil {
+				bs, _ := json.Marshal(basic.BalanceParams)
+				dc.BalanceParams = lib.PString(string(bs))
+			}
+		}
+
 		if retries := basic.Retries; retries != nil {
 			dc.MaxRetryInCluster = retries.MaxRetryInSubcluster
 			dc.MaxRetryCrossCluster = retries.MaxRetryCrossSubcluster
@@ -359,6 +449,25 @@ func newDaoClusterParam(param *icluster_conf.ClusterParam) *dao.TClusterParam {
 		dc.SessionSticky = stickySessions.SessionSticky
 	}
 
+	if param.UpstreamTLS != nil {
+		b, _ := json.Marshal(param.UpstreamTLS)
+		dc.UpstreamTLS = lib.PString(string(b))
+	} else if param.ClearUpstreamTLS {
+		dc.UpstreamTLS = lib.PString("")
+	}
+
+	if param.RetryPolicy != nil {
+		b, _ := json.Marshal(param.RetryPolicy)
+		dc.RetryPolicy = lib.PString(string(b))
+	} else if param.ClearRetryPolicy {
+		dc.RetryPolicy = lib.PString("")
+	}
+
+	if param.AutoScheduler != nil {
+		b, _ := json.Marshal(param.AutoScheduler)
+		dc.AutoScheduler = lib.PString(string(b))
+	}
+
 	if passive := param.PassiveHealthCheck; passive != nil {
 		dc.HealthcheckSchem = passive.Schema
 		dc.HealthcheckInterval = passive.Interval
@@ -0,0 +1,122 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cluster_conf
+
+import (
+	"context"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao"
+)
+
+type ClusterTemplateStorager struct {
+	dbCtxFactory lib.DBContextFactory
+}
+
+func NewClusterTemplateStorager(dbCtxFactory lib.DBContextFactory) *ClusterTemplateStorager {
+	return &ClusterTemplateStorager{
+		dbCtxFactory: dbCtxFactory,
+	}
+}
+
+var _ icluster_conf.ClusterTemplateStorager = &ClusterTemplateStorager{}
+
+func (s *ClusterTemplateStorager) FetchClusterTemplates(ctx context.Context,
+	filter *icluster_conf.ClusterTemplateFilter) ([]*icluster_conf.ClusterTemplate, error) {
+
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where := &dao.TClusterTemplateParam{
+		OrderBy: lib.PString("product_id,name"),
+	}
+	if filter != nil {
+		where.ID = filter.ID
+		where.Name = filter.Name
+		where.ProductID = filter.ProductID
+		where.ProductIDs = filter.ProductIDs
+	}
+	list, err := dao.TClusterTemplateList(dbCtx, where)
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []*icluster_conf.ClusterTemplate
+	for _, one := range list {
+		rst = append(rst, &icluster_conf.ClusterTemplate{
+			ID:          one.ID,
+			Name:        one.Name,
+			ProductID:   one.ProductID,
+			Description: one.Description,
+			Content:     one.Content,
+			CreatedAt:   one.CreatedAt,
+			UpdatedAt:   one.UpdatedAt,
+		})
+	}
+
+	return rst, nil
+}
+
+func (s *ClusterTemplateStorager) CreateClusterTemplate(ctx context.Context,
+	param *icluster_conf.ClusterTemplateParam) (int64, error) {
+
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return dao.TClusterTemplateCreate(dbCtx, &dao.TClusterTemplateParam{
+		Name:        param.Name,
+		ProductID:   param.ProductID,
+		Description: param.Description,
+		Content:     param.Content,
+		UpdatedAt:   lib.PTimeNow(),
+	})
+}
+
+func (s *ClusterTemplateStorager) UpdateClusterTemplate(ctx context.Context, old *icluster_conf.ClusterTemplate,
+	param *icluster_conf.ClusterTemplateParam) error {
+
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TClusterTemplateUpdate(dbCtx, &dao.TClusterTemplateParam{
+		Description: param.Description,
+		Content:     param.Content,
+		UpdatedAt:   lib.PTimeNow(),
+	}, &dao.TClusterTemplateParam{
+		ID: &old.ID,
+	})
+
+	return err
+}
+
+func (s *ClusterTemplateStorager) DeleteClusterTemplate(ctx context.Context, old *icluster_conf.ClusterTemplate) error {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TClusterTemplateDelete(dbCtx, &dao.TClusterTemplateParam{
+		ID: &old.ID,
+	})
+
+	return err
+}
@@ -0,0 +1,140 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cluster_conf
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao"
+)
+
+type ModelProviderStorager struct {
+	dbCtxFactory lib.DBContextFactory
+}
+
+func NewModelProviderStorager(dbCtxFactory lib.DBContextFactory) *ModelProviderStorager {
+	return &ModelProviderStorager{
+		dbCtxFactory: dbCtxFactory,
+	}
+}
+
+var _ icluster_conf.ModelProviderStorager = &ModelProviderStorager{}
+
+func (rpps *ModelProviderStorager) FetchModelProviders(ctx context.Context,
+	filter *icluster_conf.ModelProviderFilter) ([]*icluster_conf.ModelProvider, error) {
+
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where := &dao.TModelProviderParam{}
+	if filter != nil {
+		where.ProviderID = filter.ProviderID
+	}
+	list, err := dao.TModelProviderList(dbCtx, where)
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []*icluster_conf.ModelProvider
+	for _, one := range list {
+		item := &icluster_conf.ModelProvider{
+			ProviderID:  one.ProviderID,
+			Name:        one.Name,
+			Description: one.Description,
+			Protocol:    one.Protocol,
+			BaseURL:     one.BaseURL,
+			AuthHeader:  one.AuthHeader,
+			AuthScheme:  one.AuthScheme,
+		}
+		if one.FieldMapping != "" {
+			item.FieldMapping = &icluster_conf.FieldMapping{}
+			if err := json.Unmarshal([]byte(one.FieldMapping), item.FieldMapping); err != nil {
+				return nil, xerror.WrapDirtyDataErrorWithMsg("Model Provider %s FieldMapping Unmarshal fail, err: %v", one.ProviderID, err)
+			}
+		}
+
+		rst = append(rst, item)
+	}
+
+	return rst, nil
+}
+
+func newModelProviderParam(param *icluster_conf.ModelProviderParam) *dao.TModelProviderParam {
+	data := &dao.TModelProviderParam{
+		ProviderID:  param.ProviderID,
+		Name:        param.Name,
+		Description: param.Description,
+		Protocol:    param.Protocol,
+		BaseURL:     param.BaseURL,
+		AuthHeader:  param.AuthHeader,
+		AuthScheme:  param.AuthScheme,
+	}
+	if param.FieldMapping != nil {
+		bs, _ := json.Marshal(param.FieldMapping)
+		data.FieldMapping = lib.PString(string(bs))
+	}
+
+	return data
+}
+
+func (rpps *ModelProviderStorager) CreateModelProvider(ctx context.Context, param *icluster_conf.ModelProviderParam) error {
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := newModelProviderParam(param)
+	data.UpdatedAt = lib.PTimeNow()
+	_, err = dao.TModelProviderCreate(dbCtx, data)
+
+	return err
+}
+
+func (rpps *ModelProviderStorager) UpdateModelProvider(ctx context.Context, old *icluster_conf.ModelProvider,
+	param *icluster_conf.ModelProviderParam) error {
+
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := newModelProviderParam(param)
+	data.ProviderID = nil
+	data.UpdatedAt = lib.PTimeNow()
+	_, err = dao.TModelProviderUpdate(dbCtx, data, &dao.TModelProviderParam{
+		ProviderID: &old.ProviderID,
+	})
+
+	return err
+}
+
+func (rpps *ModelProviderStorager) DeleteModelProvider(ctx context.Context, old *icluster_conf.ModelProvider) error {
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TModelProviderDelete(dbCtx, &dao.TModelProviderParam{
+		ProviderID: &old.ProviderID,
+	})
+
+	return err
+}
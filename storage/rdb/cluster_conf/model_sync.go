@@ -0,0 +1,122 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cluster_conf
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao"
+)
+
+type ModelSyncStorager struct {
+	dbCtxFactory lib.DBContextFactory
+}
+
+func NewModelSyncStorager(dbCtxFactory lib.DBContextFactory) *ModelSyncStorager {
+	return &ModelSyncStorager{
+		dbCtxFactory: dbCtxFactory,
+	}
+}
+
+var _ icluster_conf.ModelSyncStorager = &ModelSyncStorager{}
+
+func (rpps *ModelSyncStorager) FetchModelSync(ctx context.Context, clusterID int64) (*icluster_conf.ModelSync, error) {
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	one, err := dao.TClusterModelSyncOne(dbCtx, &dao.TClusterModelSyncParam{
+		ClusterID: &clusterID,
+	})
+	if err != nil || one == nil {
+		return nil, err
+	}
+
+	rst := &icluster_conf.ModelSync{
+		ClusterID:  one.ClusterID,
+		ProductID:  one.ProductID,
+		Status:     one.Status,
+		ErrMsg:     one.ErrMsg,
+		ApprovedBy: one.ApprovedBy,
+		SyncedAt:   one.SyncedAt,
+	}
+
+	fields := []struct {
+		data string
+		v    interface{}
+	}{
+		{one.Discovered, &rst.Discovered},
+		{one.Added, &rst.Added},
+		{one.Removed, &rst.Removed},
+		{one.MissingRefs, &rst.MissingReferences},
+	}
+	for _, field := range fields {
+		if field.data == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(field.data), field.v); err != nil {
+			return nil, xerror.WrapDirtyDataErrorWithMsg("ModelSync of Cluster %d Unmarshal fail, err: %v", clusterID, err)
+		}
+	}
+
+	return rst, nil
+}
+
+func (rpps *ModelSyncStorager) UpsertModelSync(ctx context.Context, param *icluster_conf.ModelSync) error {
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	marshal := func(v interface{}) *string {
+		bs, _ := json.Marshal(v)
+		return lib.PString(string(bs))
+	}
+
+	data := &dao.TClusterModelSyncParam{
+		ClusterID:   &param.ClusterID,
+		ProductID:   &param.ProductID,
+		Status:      &param.Status,
+		Discovered:  marshal(param.Discovered),
+		Added:       marshal(param.Added),
+		Removed:     marshal(param.Removed),
+		MissingRefs: marshal(param.MissingReferences),
+		ErrMsg:      &param.ErrMsg,
+		ApprovedBy:  &param.ApprovedBy,
+		SyncedAt:    &param.SyncedAt,
+	}
+
+	old, err := dao.TClusterModelSyncOne(dbCtx, &dao.TClusterModelSyncParam{
+		ClusterID: &param.ClusterID,
+	})
+	if err != nil {
+		return err
+	}
+	if old == nil {
+		_, err = dao.TClusterModelSyncCreate(dbCtx, data)
+		return err
+	}
+
+	data.UpdatedAt = lib.PTimeNow()
+	_, err = dao.TClusterModelSyncUpdate(dbCtx, data, &dao.TClusterModelSyncParam{
+		ID: &old.ID,
+	})
+	return err
+}
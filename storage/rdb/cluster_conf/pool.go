@@ -47,11 +47,16 @@ func poolFilter2Param(filter *icluster_conf.PoolFilter) *dao.TPoolsParam {
 		return nil
 	}
 
+	var lockMode *string
+	if filter.ForUpdate {
+		lockMode = &dao.ModeForUpdate
+	}
 	return &dao.TPoolsParam{
 		Id:        filter.ID,
 		Ids:       filter.IDs,
 		Name:      filter.Name,
 		ProductID: filter.ProductID,
+		LockMode:  lockMode,
 	}
 }
 
@@ -210,6 +215,13 @@ func (rpps *RDBPoolStorager) DeletePool(ctx context.Context, pool *icluster_conf
 	_, err = dao.TPoolsDelete(dbCtx, &dao.TPoolsParam{
 		Name: &pool.Name,
 	})
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TPoolDiscoveryDelete(dbCtx, &dao.TPoolDiscoveryParam{
+		PoolID: &pool.ID,
+	})
 
 	return err
 }
@@ -0,0 +1,156 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cluster_conf
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao"
+)
+
+type PoolDiscoveryStorager struct {
+	dbCtxFactory lib.DBContextFactory
+}
+
+func NewPoolDiscoveryStorager(dbCtxFactory lib.DBContextFactory) *PoolDiscoveryStorager {
+	return &PoolDiscoveryStorager{
+		dbCtxFactory: dbCtxFactory,
+	}
+}
+
+var _ icluster_conf.PoolDiscoveryStorager = &PoolDiscoveryStorager{}
+
+func newPoolDiscovery(one *dao.TPoolDiscovery) (*icluster_conf.PoolDiscovery, error) {
+	rst := &icluster_conf.PoolDiscovery{
+		PoolID:     one.PoolID,
+		ProductID:  one.ProductID,
+		Status:     one.Status,
+		Discovered: []*icluster_conf.DiscoveredInstance{},
+		ErrMsg:     one.ErrMsg,
+		SyncedAt:   one.SyncedAt,
+	}
+
+	fields := []struct {
+		data string
+		v    interface{}
+	}{
+		{one.Sources, &rst.Sources},
+		{one.Discovered, &rst.Discovered},
+	}
+	for _, field := range fields {
+		if field.data == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(field.data), field.v); err != nil {
+			return nil, xerror.WrapDirtyDataErrorWithMsg("PoolDiscovery of Pool %d Unmarshal fail, err: %v", one.PoolID, err)
+		}
+	}
+
+	return rst, nil
+}
+
+func (rpps *PoolDiscoveryStorager) FetchPoolDiscovery(ctx context.Context, poolID int64) (*icluster_conf.PoolDiscovery, error) {
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	one, err := dao.TPoolDiscoveryOne(dbCtx, &dao.TPoolDiscoveryParam{
+		PoolID: &poolID,
+	})
+	if err != nil || one == nil {
+		return nil, err
+	}
+
+	return newPoolDiscovery(one)
+}
+
+func (rpps *PoolDiscoveryStorager) FetchPoolDiscoveries(ctx context.Context) ([]*icluster_conf.PoolDiscovery, error) {
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := dao.TPoolDiscoveryList(dbCtx, &dao.TPoolDiscoveryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []*icluster_conf.PoolDiscovery
+	for _, one := range list {
+		data, err := newPoolDiscovery(one)
+		if err != nil {
+			return nil, err
+		}
+		rst = append(rst, data)
+	}
+
+	return rst, nil
+}
+
+func (rpps *PoolDiscoveryStorager) UpsertPoolDiscovery(ctx context.Context, param *icluster_conf.PoolDiscovery) error {
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	marshal := func(v interface{}) *string {
+		bs, _ := json.Marshal(v)
+		return lib.PString(string(bs))
+	}
+
+	data := &dao.TPoolDiscoveryParam{
+		PoolID:     &param.PoolID,
+		ProductID:  &param.ProductID,
+		Sources:    marshal(param.Sources),
+		Status:     &param.Status,
+		Discovered: marshal(param.Discovered),
+		ErrMsg:     &param.ErrMsg,
+		SyncedAt:   &param.SyncedAt,
+	}
+
+	old, err := dao.TPoolDiscoveryOne(dbCtx, &dao.TPoolDiscoveryParam{
+		PoolID: &param.PoolID,
+	})
+	if err != nil {
+		return err
+	}
+	if old == nil {
+		_, err = dao.TPoolDiscoveryCreate(dbCtx, data)
+		return err
+	}
+
+	data.UpdatedAt = lib.PTimeNow()
+	_, err = dao.TPoolDiscoveryUpdate(dbCtx, data, &dao.TPoolDiscoveryParam{
+		ID: &old.ID,
+	})
+	return err
+}
+
+func (rpps *PoolDiscoveryStorager) DeletePoolDiscovery(ctx context.Context, poolID int64) error {
+	dbCtx, err := rpps.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TPoolDiscoveryDelete(dbCtx, &dao.TPoolDiscoveryParam{
+		PoolID: &poolID,
+	})
+	return err
+}
@@ -50,6 +50,7 @@ func subClusterFilter2Param(filter *icluster_conf.SubClusterFilter) *dao.TSubClu
 	tmp := &dao.TSubClusterParam{
 		PoolsIDs: filter.PoolIDs,
 
+		ID:    filter.ID,
 		Name:  filter.Name,
 		Names: filter.Names,
 
@@ -79,6 +80,7 @@ func subClusterParami2d(data *icluster_conf.SubClusterParam) *dao.TSubClusterPar
 
 		ClusterIDs:  data.ClusterIDs,
 		Description: data.Description,
+		Capacity:    data.Capacity,
 	}
 
 	if data.Product != nil {
@@ -101,6 +103,7 @@ func newSubCluster(pp *dao.TSubCluster, pool *icluster_conf.Pool, product *ibasi
 		ID:          pp.ID,
 		Name:        pp.Name,
 		Enabled:     pp.Enabled,
+		Capacity:    pp.Capacity,
 		Description: pp.Description,
 		ClusterID:   pp.ClusterID,
 
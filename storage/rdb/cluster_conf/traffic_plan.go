@@ -0,0 +1,217 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cluster_conf
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/icluster_conf"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao"
+)
+
+type TrafficPlanStorager struct {
+	dbCtxFactory lib.DBContextFactory
+}
+
+func NewTrafficPlanStorager(dbCtxFactory lib.DBContextFactory) *TrafficPlanStorager {
+	return &TrafficPlanStorager{
+		dbCtxFactory: dbCtxFactory,
+	}
+}
+
+var _ icluster_conf.TrafficPlanStorager = &TrafficPlanStorager{}
+
+func (s *TrafficPlanStorager) FetchTrafficPlans(ctx context.Context,
+	filter *icluster_conf.TrafficPlanFilter) ([]*icluster_conf.TrafficPlan, error) {
+
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where := &dao.TTrafficPlanParam{
+		OrderBy: lib.PString("id"),
+	}
+	if filter != nil {
+		where.ID = filter.ID
+		where.ProductID = filter.ProductID
+		where.ClusterID = filter.ClusterID
+		where.ClusterIDs = filter.ClusterIDs
+		where.Name = filter.Name
+		where.Statuses = filter.Statuses
+		if filter.ForUpdate {
+			where.LockMode = &dao.ModeForUpdate
+		}
+	}
+	list, err := dao.TTrafficPlanList(dbCtx, where)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	var clusterIDs []int64
+	for _, one := range list {
+		clusterIDs = append(clusterIDs, one.ClusterID)
+	}
+	clusters, err := dao.TClusterList(dbCtx, &dao.TClusterParam{
+		IDs: clusterIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	clusterNames := map[int64]string{}
+	for _, one := range clusters {
+		clusterNames[one.ID] = one.Name
+	}
+
+	var rst []*icluster_conf.TrafficPlan
+	for _, one := range list {
+		var steps []*icluster_conf.TrafficPlanStep
+		json.Unmarshal([]byte(one.Steps), &steps)
+
+		rst = append(rst, &icluster_conf.TrafficPlan{
+			ID:          one.ID,
+			ProductID:   one.ProductID,
+			ClusterID:   one.ClusterID,
+			ClusterName: clusterNames[one.ClusterID],
+			Name:        one.Name,
+			Description: one.Description,
+			Status:      one.Status,
+			Steps:       steps,
+			NextStep:    int(one.NextStep),
+			NextStepAt:  one.NextStepAt,
+			PausedAt:    one.PausedAt,
+			CreatedBy:   one.CreatedBy,
+			CreatedAt:   one.CreatedAt,
+			UpdatedAt:   one.UpdatedAt,
+		})
+	}
+
+	return rst, nil
+}
+
+func (s *TrafficPlanStorager) CreateTrafficPlan(ctx context.Context, cluster *icluster_conf.Cluster,
+	data *icluster_conf.TrafficPlan) (int64, error) {
+
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	steps, _ := json.Marshal(data.Steps)
+	return dao.TTrafficPlanCreate(dbCtx, &dao.TTrafficPlanParam{
+		ProductID:   &cluster.ProductID,
+		ClusterID:   &cluster.ID,
+		Name:        &data.Name,
+		Description: &data.Description,
+		Status:      &data.Status,
+		Steps:       lib.PString(string(steps)),
+		NextStep:    lib.PInt32(int32(data.NextStep)),
+		NextStepAt:  &data.NextStepAt,
+		PausedAt:    &data.PausedAt,
+		CreatedBy:   &data.CreatedBy,
+		CreatedAt:   &data.CreatedAt,
+		UpdatedAt:   &data.UpdatedAt,
+	})
+}
+
+func (s *TrafficPlanStorager) UpdateTrafficPlan(ctx context.Context, data *icluster_conf.TrafficPlan) error {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TTrafficPlanUpdate(dbCtx, &dao.TTrafficPlanParam{
+		Status:     &data.Status,
+		NextStep:   lib.PInt32(int32(data.NextStep)),
+		NextStepAt: &data.NextStepAt,
+		PausedAt:   &data.PausedAt,
+		UpdatedAt:  lib.PTimeNow(),
+	}, &dao.TTrafficPlanParam{
+		ID: &data.ID,
+	})
+
+	return err
+}
+
+func (s *TrafficPlanStorager) DeleteTrafficPlan(ctx context.Context, data *icluster_conf.TrafficPlan) error {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err = dao.TTrafficPlanLogDelete(dbCtx, &dao.TTrafficPlanLogParam{
+		PlanID: &data.ID,
+	}); err != nil {
+		return err
+	}
+
+	_, err = dao.TTrafficPlanDelete(dbCtx, &dao.TTrafficPlanParam{
+		ID: &data.ID,
+	})
+
+	return err
+}
+
+func (s *TrafficPlanStorager) CreateTrafficPlanLog(ctx context.Context, log *icluster_conf.TrafficPlanLog) error {
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TTrafficPlanLogCreate(dbCtx, &dao.TTrafficPlanLogParam{
+		PlanID:    &log.PlanID,
+		Step:      lib.PInt32(int32(log.Step)),
+		Action:    &log.Action,
+		Message:   &log.Message,
+		Operator:  &log.Operator,
+		UpdatedAt: lib.PTimeNow(),
+	})
+
+	return err
+}
+
+func (s *TrafficPlanStorager) FetchTrafficPlanLogs(ctx context.Context,
+	plan *icluster_conf.TrafficPlan) ([]*icluster_conf.TrafficPlanLog, error) {
+
+	dbCtx, err := s.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := dao.TTrafficPlanLogList(dbCtx, &dao.TTrafficPlanLogParam{
+		PlanID:  &plan.ID,
+		OrderBy: lib.PString("id"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []*icluster_conf.TrafficPlanLog
+	for _, one := range list {
+		rst = append(rst, &icluster_conf.TrafficPlanLog{
+			ID:        one.ID,
+			PlanID:    one.PlanID,
+			Step:      int(one.Step),
+			Action:    one.Action,
+			Message:   one.Message,
+			Operator:  one.Operator,
+			CreatedAt: one.CreatedAt,
+		})
+	}
+
+	return rst, nil
+}
@@ -232,9 +232,20 @@ func NewReplaceInsertBuilder(table string, assigns []map[string]interface{}) SQL
 	}
 }
 
+// NewInsertOnDuplicateBuilder inserts assigns, or updates existed row by update if unique key conflicts
+func NewInsertOnDuplicateBuilder(table string, assigns []map[string]interface{}, update map[string]interface{}) SQLBuilder {
+	return &InsertBuilder{
+		table:   table,
+		assigns: assigns,
+		update:  update,
+		typ:     insertOnDuplicate,
+	}
+}
+
 type InsertBuilder struct {
 	table   string
 	assigns []map[string]interface{}
+	update  map[string]interface{}
 	typ     int
 }
 
@@ -265,6 +276,7 @@ const (
 	insertCommon = iota
 	insertIgnore
 	insertReplace
+	insertOnDuplicate
 )
 
 func (i *InsertBuilder) Compile() (sql string, args []interface{}, err error) {
@@ -275,6 +287,8 @@ func (i *InsertBuilder) Compile() (sql string, args []interface{}, err error) {
 		return builder.BuildInsertIgnore(i.table, i.assigns)
 	case insertReplace:
 		return builder.BuildReplaceInsert(i.table, i.assigns)
+	case insertOnDuplicate:
+		return builder.BuildInsertOnDuplicate(i.table, i.assigns, i.update)
 	default:
 		return "", nil, fmt.Errorf("unknown type=%d", i.typ)
 	}
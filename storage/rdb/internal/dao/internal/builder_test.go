@@ -0,0 +1,100 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testParam struct {
+	ID   *int64  `db:"id"`
+	Name *string `db:"name"`
+	Addr *string `db:"addr"`
+}
+
+func TestInsertOnDuplicateBuilder(t *testing.T) {
+	name, addr := "n", "a"
+
+	cases := []struct {
+		name     string
+		data     *testParam
+		update   *testParam
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "nil fields not assigned",
+			data:     &testParam{Name: &name, Addr: &addr},
+			update:   &testParam{Addr: &addr},
+			wantSQL:  "INSERT INTO t (addr,name) VALUES (?,?) ON DUPLICATE KEY UPDATE addr=?",
+			wantArgs: []interface{}{"a", "n", "a"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sql, args, err := NewInsertOnDuplicateBuilder("t", Struct2AssignList(c.data), Struct2Assign(c.update)).Compile()
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			if sql != c.wantSQL {
+				t.Errorf("sql = %s, want %s", sql, c.wantSQL)
+			}
+			if !reflect.DeepEqual(args, c.wantArgs) {
+				t.Errorf("args = %v, want %v", args, c.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSelectBuilderFields(t *testing.T) {
+	type versionParam struct {
+		Names   []string `db:"name,in"`
+		GroupBy *string  `db:"_groupby"`
+	}
+	groupBy := "name"
+
+	cases := []struct {
+		name     string
+		where    *versionParam
+		fields   []string
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "last version of each name",
+			where:    &versionParam{Names: []string{"a", "b"}, GroupBy: &groupBy},
+			fields:   []string{"name", "MAX(version) AS version"},
+			wantSQL:  "SELECT name,MAX(version) AS version FROM t WHERE (name IN (?,?)) GROUP BY name",
+			wantArgs: []interface{}{"a", "b"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sql, args, err := NewSelectBuilder("t", Struct2Where(c.where), c.fields).Compile()
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			if sql != c.wantSQL {
+				t.Errorf("sql = %s, want %s", sql, c.wantSQL)
+			}
+			if !reflect.DeepEqual(args, c.wantArgs) {
+				t.Errorf("args = %v, want %v", args, c.wantArgs)
+			}
+		})
+	}
+}
@@ -29,20 +29,25 @@ func init() {
 }
 
 func QueryOne(dbCtx lib.DBContexter, table string, where interface{}, rst interface{}) error {
-	return queryList(dbCtx, table, where, rst, true)
+	return queryList(dbCtx, table, where, nil, rst, true)
 }
 
 func QueryList(dbCtx lib.DBContexter, table string, where interface{}, rst interface{}) error {
-	return queryList(dbCtx, table, where, rst, false)
+	return queryList(dbCtx, table, where, nil, rst, false)
 }
 
-func queryList(dbCtx lib.DBContexter, table string, where interface{}, rst interface{}, queryOne bool) error {
+// QueryListFields selects fields only, fields may be aggregations aliased as column, such as "MAX(version) AS version"
+func QueryListFields(dbCtx lib.DBContexter, table string, where interface{}, fields []string, rst interface{}) error {
+	return queryList(dbCtx, table, where, fields, rst, false)
+}
+
+func queryList(dbCtx lib.DBContexter, table string, where interface{}, fields []string, rst interface{}, queryOne bool) error {
 	tmp := Struct2Where(where)
 	if tmp != nil && queryOne {
 		tmp["_limit"] = []uint{0, 1}
 	}
 
-	build := NewSelectBuilder(table, tmp, nil)
+	build := NewSelectBuilder(table, tmp, fields)
 	sql, args, err := build.Compile()
 	if err != nil {
 		return xerror.WrapDaoError(err)
@@ -96,6 +101,33 @@ func Create(dbCtx lib.DBContexter, table string, data ...interface{}) (int64, er
 	return id, nil
 }
 
+// Upsert inserts data, or updates existed row by update if unique key conflicts
+func Upsert(dbCtx lib.DBContexter, table string, data interface{}, update interface{}) (int64, error) {
+	build := NewInsertOnDuplicateBuilder(table, Struct2AssignList(data), Struct2Assign(update))
+	sql, args, err := build.Compile()
+	if err != nil {
+		return 0, xerror.WrapDaoError(err)
+	}
+
+	now := time.Now()
+	rst, err := dbCtx.Conn().ExecContext(dbCtx, sql, args...)
+	sr := &stateful.SQLRecord{
+		SQL:  sql,
+		Args: args,
+		Err:  err,
+		Cost: time.Since(now),
+	}
+	sr.Print(dbCtx)
+	if err != nil {
+		return 0, xerror.WrapDaoError(err)
+	}
+	rows, err := rst.RowsAffected()
+	if err != nil {
+		return 0, xerror.WrapDaoError(err)
+	}
+	return rows, nil
+}
+
 func Update(dbCtx lib.DBContexter, table string, where interface{}, data interface{}) (int64, error) {
 	build := NewUpdateBuilder(table, Struct2Where(where), Struct2Assign(data))
 	sql, args, err := build.Compile()
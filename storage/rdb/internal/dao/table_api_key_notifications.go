@@ -0,0 +1,107 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tAPIKeyNotificationTableName = "api_key_notifications"
+
+// TAPIKeyNotification Query Result
+type TAPIKeyNotification struct {
+	ID        int64     `db:"id"`
+	APIKeyID  int64     `db:"api_key_id"`
+	Kind      string    `db:"kind"`
+	Threshold int64     `db:"threshold"`
+	Cycle     string    `db:"cycle"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// TAPIKeyNotificationOne Query One
+// return (nil, nil) if record not existed
+func TAPIKeyNotificationOne(dbCtx lib.DBContexter, where *TAPIKeyNotificationParam) (*TAPIKeyNotification, error) {
+	t := &TAPIKeyNotification{}
+	err := internal.QueryOne(dbCtx, tAPIKeyNotificationTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TAPIKeyNotificationList Query Multiple
+func TAPIKeyNotificationList(dbCtx lib.DBContexter, where *TAPIKeyNotificationParam) ([]*TAPIKeyNotification, error) {
+	t := []*TAPIKeyNotification{}
+	err := internal.QueryList(dbCtx, tAPIKeyNotificationTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TAPIKeyNotificationParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TAPIKeyNotificationParam struct {
+	ID        *int64     `db:"id"`
+	APIKeyID  *int64     `db:"api_key_id"`
+	Kind      *string    `db:"kind"`
+	Threshold *int64     `db:"threshold"`
+	Cycle     *string    `db:"cycle"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TAPIKeyNotificationCreate One/Multiple
+func TAPIKeyNotificationCreate(dbCtx lib.DBContexter, data ...*TAPIKeyNotificationParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tAPIKeyNotificationTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tAPIKeyNotificationTableName, list...)
+}
+
+// TAPIKeyNotificationUpdate Update One
+func TAPIKeyNotificationUpdate(dbCtx lib.DBContexter, val, where *TAPIKeyNotificationParam) (int64, error) {
+	return internal.Update(dbCtx, tAPIKeyNotificationTableName, where, val)
+}
+
+// TAPIKeyNotificationDelete Delete One/Multiple
+func TAPIKeyNotificationDelete(dbCtx lib.DBContexter, where *TAPIKeyNotificationParam) (int64, error) {
+	return internal.Delete(dbCtx, tAPIKeyNotificationTableName, where)
+}
@@ -33,8 +33,10 @@ type TAPIKey struct {
 	ProductName   string    `db:"product_name"`
 	Limit         int64     `db:"total_quota"`
 	ExpiredTime   string    `db:"expired_time"`
+	EffectiveTime string    `db:"effective_time"`
 	AllowedModels string    `db:"allowed_models"`
 	AllowedCIDR   string    `db:"allowed_cidr"`
+	Upstreams     string    `db:"upstream_credentials"`
 	CreatedAt     time.Time `db:"created_at"`
 	UpdatedAt     time.Time `db:"updated_at"`
 }
@@ -76,8 +78,10 @@ type TAPIKeyParam struct {
 	ProductName   *string    `db:"product_name"`
 	Limit         *int64     `db:"total_quota"`
 	ExpiredTime   *string    `db:"expired_time"`
+	EffectiveTime *string    `db:"effective_time"`
 	AllowedModels *string    `db:"allowed_models"`
 	AllowedCIDR   *string    `db:"allowed_cidr"`
+	Upstreams     *string    `db:"upstream_credentials"`
 	CreatedAt     *time.Time `db:"created_at"`
 	UpdatedAt     *time.Time `db:"updated_at"`
 
@@ -0,0 +1,107 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tBfeClusterConfigTargetTableName = "bfe_cluster_config_targets"
+
+// TBfeClusterConfigTarget Query Result
+type TBfeClusterConfigTarget struct {
+	ID         int64     `db:"id"`
+	BfeCluster string    `db:"bfe_cluster"`
+	Topic      string    `db:"topic"`
+	Channel    string    `db:"channel"`
+	Version    string    `db:"version"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// TBfeClusterConfigTargetOne Query One
+// return (nil, nil) if record not existed
+func TBfeClusterConfigTargetOne(dbCtx lib.DBContexter, where *TBfeClusterConfigTargetParam) (*TBfeClusterConfigTarget, error) {
+	t := &TBfeClusterConfigTarget{}
+	err := internal.QueryOne(dbCtx, tBfeClusterConfigTargetTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TBfeClusterConfigTargetList Query Multiple
+func TBfeClusterConfigTargetList(dbCtx lib.DBContexter, where *TBfeClusterConfigTargetParam) ([]*TBfeClusterConfigTarget, error) {
+	t := []*TBfeClusterConfigTarget{}
+	err := internal.QueryList(dbCtx, tBfeClusterConfigTargetTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TBfeClusterConfigTargetParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TBfeClusterConfigTargetParam struct {
+	ID         *int64     `db:"id"`
+	BfeCluster *string    `db:"bfe_cluster"`
+	Topic      *string    `db:"topic"`
+	Channel    *string    `db:"channel"`
+	Version    *string    `db:"version"`
+	CreatedAt  *time.Time `db:"created_at"`
+	UpdatedAt  *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TBfeClusterConfigTargetCreate One/Multiple
+func TBfeClusterConfigTargetCreate(dbCtx lib.DBContexter, data ...*TBfeClusterConfigTargetParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tBfeClusterConfigTargetTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tBfeClusterConfigTargetTableName, list...)
+}
+
+// TBfeClusterConfigTargetUpdate Update One
+func TBfeClusterConfigTargetUpdate(dbCtx lib.DBContexter, val, where *TBfeClusterConfigTargetParam) (int64, error) {
+	return internal.Update(dbCtx, tBfeClusterConfigTargetTableName, where, val)
+}
+
+// TBfeClusterConfigTargetDelete Delete One/Multiple
+func TBfeClusterConfigTargetDelete(dbCtx lib.DBContexter, where *TBfeClusterConfigTargetParam) (int64, error) {
+	return internal.Delete(dbCtx, tBfeClusterConfigTargetTableName, where)
+}
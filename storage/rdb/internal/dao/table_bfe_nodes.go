@@ -0,0 +1,119 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tBfeNodeTableName = "bfe_nodes"
+
+// TBfeNode Query Result
+type TBfeNode struct {
+	ID              int64     `db:"id"`
+	NodeID          string    `db:"node_id"`
+	BfeCluster      string    `db:"bfe_cluster"`
+	BuildVersion    string    `db:"build_version"`
+	AppliedVersions string    `db:"applied_versions"`
+	Addr            string    `db:"addr"`
+	HeartbeatAt     time.Time `db:"heartbeat_at"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// TBfeNodeOne Query One
+// return (nil, nil) if record not existed
+func TBfeNodeOne(dbCtx lib.DBContexter, where *TBfeNodeParam) (*TBfeNode, error) {
+	t := &TBfeNode{}
+	err := internal.QueryOne(dbCtx, tBfeNodeTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TBfeNodeList Query Multiple
+func TBfeNodeList(dbCtx lib.DBContexter, where *TBfeNodeParam) ([]*TBfeNode, error) {
+	t := []*TBfeNode{}
+	err := internal.QueryList(dbCtx, tBfeNodeTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TBfeNodeParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TBfeNodeParam struct {
+	ID              *int64     `db:"id"`
+	NodeID          *string    `db:"node_id"`
+	BfeCluster      *string    `db:"bfe_cluster"`
+	BuildVersion    *string    `db:"build_version"`
+	AppliedVersions *string    `db:"applied_versions"`
+	Addr            *string    `db:"addr"`
+	HeartbeatAt     *time.Time `db:"heartbeat_at"`
+	CreatedAt       *time.Time `db:"created_at"`
+	UpdatedAt       *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TBfeNodeCreate One/Multiple
+func TBfeNodeCreate(dbCtx lib.DBContexter, data ...*TBfeNodeParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tBfeNodeTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tBfeNodeTableName, list...)
+}
+
+// TBfeNodeUpsert Create One, or Update it by val if node_id existed
+func TBfeNodeUpsert(dbCtx lib.DBContexter, data, val *TBfeNodeParam) (int64, error) {
+	if data.CreatedAt == nil {
+		data.CreatedAt = internal.PTimeNow()
+	}
+	return internal.Upsert(dbCtx, tBfeNodeTableName, data, val)
+}
+
+// TBfeNodeUpdate Update One
+func TBfeNodeUpdate(dbCtx lib.DBContexter, val, where *TBfeNodeParam) (int64, error) {
+	return internal.Update(dbCtx, tBfeNodeTableName, where, val)
+}
+
+// TBfeNodeDelete Delete One/Multiple
+func TBfeNodeDelete(dbCtx lib.DBContexter, where *TBfeNodeParam) (int64, error) {
+	return internal.Delete(dbCtx, tBfeNodeTableName, where)
+}
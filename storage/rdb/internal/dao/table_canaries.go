@@ -0,0 +1,135 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tCanaryTableName = "canaries"
+
+// TCanary Query Result
+type TCanary struct {
+	ID              int64     `db:"id"`
+	ProductID       int64     `db:"product_id"`
+	ClusterID       int64     `db:"cluster_id"`
+	SubCluster      string    `db:"sub_cluster"`
+	Status          string    `db:"status"`
+	Steps           string    `db:"steps"`
+	StepIntervalInS int64     `db:"step_interval_in_s"`
+	MaxErrorRate    float64   `db:"max_error_rate"`
+	MaxLatencyMs    int64     `db:"max_latency_ms"`
+	MinRequests     int64     `db:"min_requests"`
+	BaseLbMatrix    string    `db:"base_lb_matrix"`
+	Step            int32     `db:"step"`
+	StepStartedAt   time.Time `db:"step_started_at"`
+	PausedAt        time.Time `db:"paused_at"`
+	Reason          string    `db:"reason"`
+	CreatedBy       string    `db:"created_by"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// TCanaryOne Query One
+// return (nil, nil) if record not existed
+func TCanaryOne(dbCtx lib.DBContexter, where *TCanaryParam) (*TCanary, error) {
+	t := &TCanary{}
+	err := internal.QueryOne(dbCtx, tCanaryTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TCanaryList Query Multiple
+func TCanaryList(dbCtx lib.DBContexter, where *TCanaryParam) ([]*TCanary, error) {
+	t := []*TCanary{}
+	err := internal.QueryList(dbCtx, tCanaryTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TCanaryParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TCanaryParam struct {
+	IDs        []int64  `db:"id,in"`
+	ClusterIDs []int64  `db:"cluster_id,in"`
+	Statuses   []string `db:"status,in"`
+
+	ID              *int64     `db:"id"`
+	ProductID       *int64     `db:"product_id"`
+	ClusterID       *int64     `db:"cluster_id"`
+	SubCluster      *string    `db:"sub_cluster"`
+	Status          *string    `db:"status"`
+	Steps           *string    `db:"steps"`
+	StepIntervalInS *int64     `db:"step_interval_in_s"`
+	MaxErrorRate    *float64   `db:"max_error_rate"`
+	MaxLatencyMs    *int64     `db:"max_latency_ms"`
+	MinRequests     *int64     `db:"min_requests"`
+	BaseLbMatrix    *string    `db:"base_lb_matrix"`
+	Step            *int32     `db:"step"`
+	StepStartedAt   *time.Time `db:"step_started_at"`
+	PausedAt        *time.Time `db:"paused_at"`
+	Reason          *string    `db:"reason"`
+	CreatedBy       *string    `db:"created_by"`
+	CreatedAt       *time.Time `db:"created_at"`
+	UpdatedAt       *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+
+	LockMode *string `db:"_lockMode"`
+}
+
+// TCanaryCreate One/Multiple
+func TCanaryCreate(dbCtx lib.DBContexter, data ...*TCanaryParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tCanaryTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tCanaryTableName, list...)
+}
+
+// TCanaryUpdate Update One
+func TCanaryUpdate(dbCtx lib.DBContexter, val, where *TCanaryParam) (int64, error) {
+	return internal.Update(dbCtx, tCanaryTableName, where, val)
+}
+
+// TCanaryDelete Delete One/Multiple
+func TCanaryDelete(dbCtx lib.DBContexter, where *TCanaryParam) (int64, error) {
+	return internal.Delete(dbCtx, tCanaryTableName, where)
+}
@@ -0,0 +1,113 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tCanaryLogTableName = "canary_logs"
+
+// TCanaryLog Query Result
+type TCanaryLog struct {
+	ID        int64     `db:"id"`
+	CanaryID  int64     `db:"canary_id"`
+	Step      int32     `db:"step"`
+	Percent   int32     `db:"percent"`
+	Action    string    `db:"action"`
+	Message   string    `db:"message"`
+	Operator  string    `db:"operator"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// TCanaryLogOne Query One
+// return (nil, nil) if record not existed
+func TCanaryLogOne(dbCtx lib.DBContexter, where *TCanaryLogParam) (*TCanaryLog, error) {
+	t := &TCanaryLog{}
+	err := internal.QueryOne(dbCtx, tCanaryLogTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TCanaryLogList Query Multiple
+func TCanaryLogList(dbCtx lib.DBContexter, where *TCanaryLogParam) ([]*TCanaryLog, error) {
+	t := []*TCanaryLog{}
+	err := internal.QueryList(dbCtx, tCanaryLogTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TCanaryLogParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TCanaryLogParam struct {
+	CanaryIDs []int64 `db:"canary_id,in"`
+
+	ID        *int64     `db:"id"`
+	CanaryID  *int64     `db:"canary_id"`
+	Step      *int32     `db:"step"`
+	Percent   *int32     `db:"percent"`
+	Action    *string    `db:"action"`
+	Message   *string    `db:"message"`
+	Operator  *string    `db:"operator"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TCanaryLogCreate One/Multiple
+func TCanaryLogCreate(dbCtx lib.DBContexter, data ...*TCanaryLogParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tCanaryLogTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tCanaryLogTableName, list...)
+}
+
+// TCanaryLogUpdate Update One
+func TCanaryLogUpdate(dbCtx lib.DBContexter, val, where *TCanaryLogParam) (int64, error) {
+	return internal.Update(dbCtx, tCanaryLogTableName, where, val)
+}
+
+// TCanaryLogDelete Delete One/Multiple
+func TCanaryLogDelete(dbCtx lib.DBContexter, where *TCanaryLogParam) (int64, error) {
+	return internal.Delete(dbCtx, tCanaryLogTableName, where)
+}
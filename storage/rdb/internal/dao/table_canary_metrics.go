@@ -0,0 +1,111 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tCanaryMetricTableName = "canary_metrics"
+
+// TCanaryMetric Query Result
+type TCanaryMetric struct {
+	ID           int64     `db:"id"`
+	CanaryID     int64     `db:"canary_id"`
+	Step         int32     `db:"step"`
+	Requests     int64     `db:"requests"`
+	Errors       int64     `db:"errors"`
+	LatencyMsSum int64     `db:"latency_ms_sum"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+// TCanaryMetricOne Query One
+// return (nil, nil) if record not existed
+func TCanaryMetricOne(dbCtx lib.DBContexter, where *TCanaryMetricParam) (*TCanaryMetric, error) {
+	t := &TCanaryMetric{}
+	err := internal.QueryOne(dbCtx, tCanaryMetricTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TCanaryMetricList Query Multiple
+func TCanaryMetricList(dbCtx lib.DBContexter, where *TCanaryMetricParam) ([]*TCanaryMetric, error) {
+	t := []*TCanaryMetric{}
+	err := internal.QueryList(dbCtx, tCanaryMetricTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TCanaryMetricParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TCanaryMetricParam struct {
+	CanaryIDs []int64 `db:"canary_id,in"`
+
+	ID           *int64     `db:"id"`
+	CanaryID     *int64     `db:"canary_id"`
+	Step         *int32     `db:"step"`
+	Requests     *int64     `db:"requests"`
+	Errors       *int64     `db:"errors"`
+	LatencyMsSum *int64     `db:"latency_ms_sum"`
+	CreatedAt    *time.Time `db:"created_at"`
+	UpdatedAt    *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TCanaryMetricCreate One/Multiple
+func TCanaryMetricCreate(dbCtx lib.DBContexter, data ...*TCanaryMetricParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tCanaryMetricTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tCanaryMetricTableName, list...)
+}
+
+// TCanaryMetricUpdate Update One
+func TCanaryMetricUpdate(dbCtx lib.DBContexter, val, where *TCanaryMetricParam) (int64, error) {
+	return internal.Update(dbCtx, tCanaryMetricTableName, where, val)
+}
+
+// TCanaryMetricDelete Delete One/Multiple
+func TCanaryMetricDelete(dbCtx lib.DBContexter, where *TCanaryMetricParam) (int64, error) {
+	return internal.Delete(dbCtx, tCanaryMetricTableName, where)
+}
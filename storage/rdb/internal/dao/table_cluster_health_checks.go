@@ -0,0 +1,119 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tClusterHealthCheckTableName = "cluster_health_checks"
+
+// TClusterHealthCheck Query Result
+type TClusterHealthCheck struct {
+	ID                 int64     `db:"id"`
+	ClusterID          int64     `db:"cluster_id"`
+	ProductID          int64     `db:"product_id"`
+	Enable             bool      `db:"enable"`
+	Probe              string    `db:"probe"`
+	Model              string    `db:"model"`
+	IntervalInS        int32     `db:"interval_in_s"`
+	TimeoutInS         int32     `db:"timeout_in_s"`
+	HealthyThreshold   int32     `db:"healthy_threshold"`
+	UnhealthyThreshold int32     `db:"unhealthy_threshold"`
+	CreatedAt          time.Time `db:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at"`
+}
+
+// TClusterHealthCheckOne Query One
+// return (nil, nil) if record not existed
+func TClusterHealthCheckOne(dbCtx lib.DBContexter, where *TClusterHealthCheckParam) (*TClusterHealthCheck, error) {
+	t := &TClusterHealthCheck{}
+	err := internal.QueryOne(dbCtx, tClusterHealthCheckTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TClusterHealthCheckList Query Multiple
+func TClusterHealthCheckList(dbCtx lib.DBContexter, where *TClusterHealthCheckParam) ([]*TClusterHealthCheck, error) {
+	t := []*TClusterHealthCheck{}
+	err := internal.QueryList(dbCtx, tClusterHealthCheckTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TClusterHealthCheckParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TClusterHealthCheckParam struct {
+	ClusterIDs []int64 `db:"cluster_id,in"`
+
+	ID                 *int64     `db:"id"`
+	ClusterID          *int64     `db:"cluster_id"`
+	ProductID          *int64     `db:"product_id"`
+	Enable             *bool      `db:"enable"`
+	Probe              *string    `db:"probe"`
+	Model              *string    `db:"model"`
+	IntervalInS        *int32     `db:"interval_in_s"`
+	TimeoutInS         *int32     `db:"timeout_in_s"`
+	HealthyThreshold   *int32     `db:"healthy_threshold"`
+	UnhealthyThreshold *int32     `db:"unhealthy_threshold"`
+	CreatedAt          *time.Time `db:"created_at"`
+	UpdatedAt          *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TClusterHealthCheckCreate One/Multiple
+func TClusterHealthCheckCreate(dbCtx lib.DBContexter, data ...*TClusterHealthCheckParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tClusterHealthCheckTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tClusterHealthCheckTableName, list...)
+}
+
+// TClusterHealthCheckUpdate Update One
+func TClusterHealthCheckUpdate(dbCtx lib.DBContexter, val, where *TClusterHealthCheckParam) (int64, error) {
+	return internal.Update(dbCtx, tClusterHealthCheckTableName, where, val)
+}
+
+// TClusterHealthCheckDelete Delete One/Multiple
+func TClusterHealthCheckDelete(dbCtx lib.DBContexter, where *TClusterHealthCheckParam) (int64, error) {
+	return internal.Delete(dbCtx, tClusterHealthCheckTableName, where)
+}
@@ -0,0 +1,119 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tClusterModelSyncTableName = "cluster_model_syncs"
+
+// TClusterModelSync Query Result
+type TClusterModelSync struct {
+	ID          int64     `db:"id"`
+	ClusterID   int64     `db:"cluster_id"`
+	ProductID   int64     `db:"product_id"`
+	Status      string    `db:"status"`
+	Discovered  string    `db:"discovered"`
+	Added       string    `db:"added"`
+	Removed     string    `db:"removed"`
+	MissingRefs string    `db:"missing_refs"`
+	ErrMsg      string    `db:"err_msg"`
+	ApprovedBy  string    `db:"approved_by"`
+	SyncedAt    time.Time `db:"synced_at"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// TClusterModelSyncOne Query One
+// return (nil, nil) if record not existed
+func TClusterModelSyncOne(dbCtx lib.DBContexter, where *TClusterModelSyncParam) (*TClusterModelSync, error) {
+	t := &TClusterModelSync{}
+	err := internal.QueryOne(dbCtx, tClusterModelSyncTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TClusterModelSyncList Query Multiple
+func TClusterModelSyncList(dbCtx lib.DBContexter, where *TClusterModelSyncParam) ([]*TClusterModelSync, error) {
+	t := []*TClusterModelSync{}
+	err := internal.QueryList(dbCtx, tClusterModelSyncTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TClusterModelSyncParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TClusterModelSyncParam struct {
+	ID          *int64     `db:"id"`
+	ClusterID   *int64     `db:"cluster_id"`
+	ProductID   *int64     `db:"product_id"`
+	Status      *string    `db:"status"`
+	Discovered  *string    `db:"discovered"`
+	Added       *string    `db:"added"`
+	Removed     *string    `db:"removed"`
+	MissingRefs *string    `db:"missing_refs"`
+	ErrMsg      *string    `db:"err_msg"`
+	ApprovedBy  *string    `db:"approved_by"`
+	SyncedAt    *time.Time `db:"synced_at"`
+	CreatedAt   *time.Time `db:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TClusterModelSyncCreate One/Multiple
+func TClusterModelSyncCreate(dbCtx lib.DBContexter, data ...*TClusterModelSyncParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tClusterModelSyncTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tClusterModelSyncTableName, list...)
+}
+
+// TClusterModelSyncUpdate Update One
+func TClusterModelSyncUpdate(dbCtx lib.DBContexter, val, where *TClusterModelSyncParam) (int64, error) {
+	return internal.Update(dbCtx, tClusterModelSyncTableName, where, val)
+}
+
+// TClusterModelSyncDelete Delete One/Multiple
+func TClusterModelSyncDelete(dbCtx lib.DBContexter, where *TClusterModelSyncParam) (int64, error) {
+	return internal.Delete(dbCtx, tClusterModelSyncTableName, where)
+}
@@ -0,0 +1,109 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tClusterTemplateTableName = "cluster_templates"
+
+// TClusterTemplate Query Result
+type TClusterTemplate struct {
+	ID          int64     `db:"id"`
+	Name        string    `db:"name"`
+	ProductID   int64     `db:"product_id"`
+	Description string    `db:"description"`
+	Content     string    `db:"content"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// TClusterTemplateOne Query One
+// return (nil, nil) if record not existed
+func TClusterTemplateOne(dbCtx lib.DBContexter, where *TClusterTemplateParam) (*TClusterTemplate, error) {
+	t := &TClusterTemplate{}
+	err := internal.QueryOne(dbCtx, tClusterTemplateTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TClusterTemplateList Query Multiple
+func TClusterTemplateList(dbCtx lib.DBContexter, where *TClusterTemplateParam) ([]*TClusterTemplate, error) {
+	t := []*TClusterTemplate{}
+	err := internal.QueryList(dbCtx, tClusterTemplateTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TClusterTemplateParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TClusterTemplateParam struct {
+	ProductIDs []int64 `db:"product_id,in"`
+
+	ID          *int64     `db:"id"`
+	Name        *string    `db:"name"`
+	ProductID   *int64     `db:"product_id"`
+	Description *string    `db:"description"`
+	Content     *string    `db:"content"`
+	CreatedAt   *time.Time `db:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TClusterTemplateCreate One/Multiple
+func TClusterTemplateCreate(dbCtx lib.DBContexter, data ...*TClusterTemplateParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tClusterTemplateTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tClusterTemplateTableName, list...)
+}
+
+// TClusterTemplateUpdate Update One
+func TClusterTemplateUpdate(dbCtx lib.DBContexter, val, where *TClusterTemplateParam) (int64, error) {
+	return internal.Update(dbCtx, tClusterTemplateTableName, where, val)
+}
+
+// TClusterTemplateDelete Delete One/Multiple
+func TClusterTemplateDelete(dbCtx lib.DBContexter, where *TClusterTemplateParam) (int64, error) {
+	return internal.Delete(dbCtx, tClusterTemplateTableName, where)
+}
@@ -58,6 +58,11 @@ type TCluster struct {
 	CancelOnClientClose    bool      `db:"cancel_on_client_close"`
 	FailureStatus          bool      `db:"failure_status"`
 	LLMConfig              string    `db:"llm_config"`
+	BalanceMode            string    `db:"balance_mode"`
+	BalanceParams          string    `db:"balance_params"`
+	UpstreamTLS            string    `db:"upstream_tls"`
+	RetryPolicy            string    `db:"retry_policy"`
+	AutoScheduler          string    `db:"auto_scheduler"`
 	CreatedAt              time.Time `db:"created_at"`
 	UpdatedAt              time.Time `db:"updated_at"`
 }
@@ -127,10 +132,17 @@ type TClusterParam struct {
 	CancelOnClientClose    *bool      `db:"cancel_on_client_close"`
 	FailureStatus          *bool      `db:"failure_status"`
 	LLMConfig              *string    `db:"llm_config"`
+	BalanceMode            *string    `db:"balance_mode"`
+	BalanceParams          *string    `db:"balance_params"`
+	UpstreamTLS            *string    `db:"upstream_tls"`
+	RetryPolicy            *string    `db:"retry_policy"`
+	AutoScheduler          *string    `db:"auto_scheduler"`
 	CreatedAt              *time.Time `db:"created_at"`
 	UpdatedAt              *time.Time `db:"updated_at"`
 
 	OrderBy *string `db:"_orderby"`
+
+	LockMode *string `db:"_lockMode"`
 }
 
 // TClusterCreate One/Multiple
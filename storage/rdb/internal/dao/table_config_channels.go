@@ -0,0 +1,105 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tConfigChannelTableName = "config_channels"
+
+// TConfigChannel Query Result
+type TConfigChannel struct {
+	ID        int64     `db:"id"`
+	Topic     string    `db:"topic"`
+	Channel   string    `db:"channel"`
+	Version   string    `db:"version"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// TConfigChannelOne Query One
+// return (nil, nil) if record not existed
+func TConfigChannelOne(dbCtx lib.DBContexter, where *TConfigChannelParam) (*TConfigChannel, error) {
+	t := &TConfigChannel{}
+	err := internal.QueryOne(dbCtx, tConfigChannelTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TConfigChannelList Query Multiple
+func TConfigChannelList(dbCtx lib.DBContexter, where *TConfigChannelParam) ([]*TConfigChannel, error) {
+	t := []*TConfigChannel{}
+	err := internal.QueryList(dbCtx, tConfigChannelTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TConfigChannelParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TConfigChannelParam struct {
+	ID        *int64     `db:"id"`
+	Topic     *string    `db:"topic"`
+	Channel   *string    `db:"channel"`
+	Version   *string    `db:"version"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TConfigChannelCreate One/Multiple
+func TConfigChannelCreate(dbCtx lib.DBContexter, data ...*TConfigChannelParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tConfigChannelTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tConfigChannelTableName, list...)
+}
+
+// TConfigChannelUpdate Update One
+func TConfigChannelUpdate(dbCtx lib.DBContexter, val, where *TConfigChannelParam) (int64, error) {
+	return internal.Update(dbCtx, tConfigChannelTableName, where, val)
+}
+
+// TConfigChannelDelete Delete One/Multiple
+func TConfigChannelDelete(dbCtx lib.DBContexter, where *TConfigChannelParam) (int64, error) {
+	return internal.Delete(dbCtx, tConfigChannelTableName, where)
+}
@@ -0,0 +1,109 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tConfigRollbackTableName = "config_rollbacks"
+
+// TConfigRollback Query Result
+type TConfigRollback struct {
+	ID          int64     `db:"id"`
+	Topic       string    `db:"topic"`
+	Version     string    `db:"version"`
+	FromVersion string    `db:"from_version"`
+	SourceSign  string    `db:"source_sign"`
+	CreatedBy   string    `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// TConfigRollbackOne Query One
+// return (nil, nil) if record not existed
+func TConfigRollbackOne(dbCtx lib.DBContexter, where *TConfigRollbackParam) (*TConfigRollback, error) {
+	t := &TConfigRollback{}
+	err := internal.QueryOne(dbCtx, tConfigRollbackTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TConfigRollbackList Query Multiple
+func TConfigRollbackList(dbCtx lib.DBContexter, where *TConfigRollbackParam) ([]*TConfigRollback, error) {
+	t := []*TConfigRollback{}
+	err := internal.QueryList(dbCtx, tConfigRollbackTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TConfigRollbackParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TConfigRollbackParam struct {
+	ID          *int64     `db:"id"`
+	Topic       *string    `db:"topic"`
+	Version     *string    `db:"version"`
+	FromVersion *string    `db:"from_version"`
+	SourceSign  *string    `db:"source_sign"`
+	CreatedBy   *string    `db:"created_by"`
+	CreatedAt   *time.Time `db:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TConfigRollbackCreate One/Multiple
+func TConfigRollbackCreate(dbCtx lib.DBContexter, data ...*TConfigRollbackParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tConfigRollbackTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tConfigRollbackTableName, list...)
+}
+
+// TConfigRollbackUpdate Update One
+func TConfigRollbackUpdate(dbCtx lib.DBContexter, val, where *TConfigRollbackParam) (int64, error) {
+	return internal.Update(dbCtx, tConfigRollbackTableName, where, val)
+}
+
+// TConfigRollbackDelete Delete One/Multiple
+func TConfigRollbackDelete(dbCtx lib.DBContexter, where *TConfigRollbackParam) (int64, error) {
+	return internal.Delete(dbCtx, tConfigRollbackTableName, where)
+}
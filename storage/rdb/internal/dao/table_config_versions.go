@@ -26,12 +26,13 @@ const tConfigVersionTableName = "config_versions"
 
 // TConfigVersion Query Result
 type TConfigVersion struct {
-	ID        int64     `db:"id"`
-	Name      string    `db:"name"`
-	DataSign  string    `db:"data_sign"`
-	Version   string    `db:"version"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID          int64     `db:"id"`
+	Name        string    `db:"name"`
+	DataSign    string    `db:"data_sign"`
+	Version     string    `db:"version"`
+	TriggeredBy string    `db:"triggered_by"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
 }
 
 // TConfigVersionOne Query One
@@ -66,14 +67,32 @@ func TConfigVersionList(dbCtx lib.DBContexter, where *TConfigVersionParam) ([]*T
 type TConfigVersionParam struct {
 	// IDs              []int64     `db:"id,in"`
 
-	ID        *int64     `db:"id"`
-	Name      *string    `db:"name"`
-	DataSign  *string    `db:"data_sign"`
-	Version   *string    `db:"version"`
-	CreatedAt *time.Time `db:"created_at"`
-	UpdatedAt *time.Time `db:"updated_at"`
+	ID          *int64     `db:"id"`
+	Name        *string    `db:"name"`
+	Names       []string   `db:"name,in"`
+	DataSign    *string    `db:"data_sign"`
+	Version     *string    `db:"version"`
+	TriggeredBy *string    `db:"triggered_by"`
+	CreatedAt   *time.Time `db:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at"`
 
 	OrderBy *string `db:"_orderby"`
+	GroupBy *string `db:"_groupby"`
+}
+
+// TConfigVersionLastList Query the last version of each name in where, only Name and Version are set
+func TConfigVersionLastList(dbCtx lib.DBContexter, where *TConfigVersionParam) ([]*TConfigVersion, error) {
+	where.GroupBy = lib.PString("name")
+
+	t := []*TConfigVersion{}
+	err := internal.QueryListFields(dbCtx, tConfigVersionTableName, where, []string{"name", "MAX(version) AS version"}, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
 }
 
 // TConfigVersionCreate One/Multiple
@@ -0,0 +1,115 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tModelProviderTableName = "model_providers"
+
+// TModelProvider Query Result
+type TModelProvider struct {
+	ID           int64     `db:"id"`
+	ProviderID   string    `db:"provider_id"`
+	Name         string    `db:"name"`
+	Description  string    `db:"description"`
+	Protocol     string    `db:"protocol"`
+	BaseURL      string    `db:"base_url"`
+	AuthHeader   string    `db:"auth_header"`
+	AuthScheme   string    `db:"auth_scheme"`
+	FieldMapping string    `db:"field_mapping"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+// TModelProviderOne Query One
+// return (nil, nil) if record not existed
+func TModelProviderOne(dbCtx lib.DBContexter, where *TModelProviderParam) (*TModelProvider, error) {
+	t := &TModelProvider{}
+	err := internal.QueryOne(dbCtx, tModelProviderTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TModelProviderList Query Multiple
+func TModelProviderList(dbCtx lib.DBContexter, where *TModelProviderParam) ([]*TModelProvider, error) {
+	t := []*TModelProvider{}
+	err := internal.QueryList(dbCtx, tModelProviderTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TModelProviderParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TModelProviderParam struct {
+	ID           *int64     `db:"id"`
+	ProviderID   *string    `db:"provider_id"`
+	Name         *string    `db:"name"`
+	Description  *string    `db:"description"`
+	Protocol     *string    `db:"protocol"`
+	BaseURL      *string    `db:"base_url"`
+	AuthHeader   *string    `db:"auth_header"`
+	AuthScheme   *string    `db:"auth_scheme"`
+	FieldMapping *string    `db:"field_mapping"`
+	CreatedAt    *time.Time `db:"created_at"`
+	UpdatedAt    *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TModelProviderCreate One/Multiple
+func TModelProviderCreate(dbCtx lib.DBContexter, data ...*TModelProviderParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tModelProviderTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tModelProviderTableName, list...)
+}
+
+// TModelProviderUpdate Update One
+func TModelProviderUpdate(dbCtx lib.DBContexter, val, where *TModelProviderParam) (int64, error) {
+	return internal.Update(dbCtx, tModelProviderTableName, where, val)
+}
+
+// TModelProviderDelete Delete One/Multiple
+func TModelProviderDelete(dbCtx lib.DBContexter, where *TModelProviderParam) (int64, error) {
+	return internal.Delete(dbCtx, tModelProviderTableName, where)
+}
@@ -0,0 +1,113 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tPoolDiscoveryTableName = "pool_discoveries"
+
+// TPoolDiscovery Query Result
+type TPoolDiscovery struct {
+	ID         int64     `db:"id"`
+	PoolID     int64     `db:"pool_id"`
+	ProductID  int64     `db:"product_id"`
+	Sources    string    `db:"sources"`
+	Status     string    `db:"status"`
+	Discovered string    `db:"discovered"`
+	ErrMsg     string    `db:"err_msg"`
+	SyncedAt   time.Time `db:"synced_at"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// TPoolDiscoveryOne Query One
+// return (nil, nil) if record not existed
+func TPoolDiscoveryOne(dbCtx lib.DBContexter, where *TPoolDiscoveryParam) (*TPoolDiscovery, error) {
+	t := &TPoolDiscovery{}
+	err := internal.QueryOne(dbCtx, tPoolDiscoveryTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TPoolDiscoveryList Query Multiple
+func TPoolDiscoveryList(dbCtx lib.DBContexter, where *TPoolDiscoveryParam) ([]*TPoolDiscovery, error) {
+	t := []*TPoolDiscovery{}
+	err := internal.QueryList(dbCtx, tPoolDiscoveryTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TPoolDiscoveryParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TPoolDiscoveryParam struct {
+	ID         *int64     `db:"id"`
+	PoolID     *int64     `db:"pool_id"`
+	ProductID  *int64     `db:"product_id"`
+	Sources    *string    `db:"sources"`
+	Status     *string    `db:"status"`
+	Discovered *string    `db:"discovered"`
+	ErrMsg     *string    `db:"err_msg"`
+	SyncedAt   *time.Time `db:"synced_at"`
+	CreatedAt  *time.Time `db:"created_at"`
+	UpdatedAt  *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TPoolDiscoveryCreate One/Multiple
+func TPoolDiscoveryCreate(dbCtx lib.DBContexter, data ...*TPoolDiscoveryParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tPoolDiscoveryTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tPoolDiscoveryTableName, list...)
+}
+
+// TPoolDiscoveryUpdate Update One
+func TPoolDiscoveryUpdate(dbCtx lib.DBContexter, val, where *TPoolDiscoveryParam) (int64, error) {
+	return internal.Update(dbCtx, tPoolDiscoveryTableName, where, val)
+}
+
+// TPoolDiscoveryDelete Delete One/Multiple
+func TPoolDiscoveryDelete(dbCtx lib.DBContexter, where *TPoolDiscoveryParam) (int64, error) {
+	return internal.Delete(dbCtx, tPoolDiscoveryTableName, where)
+}
@@ -79,7 +79,8 @@ type TPoolsParam struct {
 	CreatedAt      *time.Time `db:"created_at"`
 	UpdatedAt      *time.Time `db:"updated_at"`
 
-	OrderBy *string `db:"_orderby"`
+	OrderBy  *string `db:"_orderby"`
+	LockMode *string `db:"_lockMode"`
 }
 
 // TPoolsCreate One/Multiple
@@ -0,0 +1,111 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tTrafficPlanLogTableName = "traffic_plan_logs"
+
+// TTrafficPlanLog Query Result
+type TTrafficPlanLog struct {
+	ID        int64     `db:"id"`
+	PlanID    int64     `db:"plan_id"`
+	Step      int32     `db:"step"`
+	Action    string    `db:"action"`
+	Message   string    `db:"message"`
+	Operator  string    `db:"operator"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// TTrafficPlanLogOne Query One
+// return (nil, nil) if record not existed
+func TTrafficPlanLogOne(dbCtx lib.DBContexter, where *TTrafficPlanLogParam) (*TTrafficPlanLog, error) {
+	t := &TTrafficPlanLog{}
+	err := internal.QueryOne(dbCtx, tTrafficPlanLogTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TTrafficPlanLogList Query Multiple
+func TTrafficPlanLogList(dbCtx lib.DBContexter, where *TTrafficPlanLogParam) ([]*TTrafficPlanLog, error) {
+	t := []*TTrafficPlanLog{}
+	err := internal.QueryList(dbCtx, tTrafficPlanLogTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TTrafficPlanLogParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TTrafficPlanLogParam struct {
+	PlanIDs []int64 `db:"plan_id,in"`
+
+	ID        *int64     `db:"id"`
+	PlanID    *int64     `db:"plan_id"`
+	Step      *int32     `db:"step"`
+	Action    *string    `db:"action"`
+	Message   *string    `db:"message"`
+	Operator  *string    `db:"operator"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+}
+
+// TTrafficPlanLogCreate One/Multiple
+func TTrafficPlanLogCreate(dbCtx lib.DBContexter, data ...*TTrafficPlanLogParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tTrafficPlanLogTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tTrafficPlanLogTableName, list...)
+}
+
+// TTrafficPlanLogUpdate Update One
+func TTrafficPlanLogUpdate(dbCtx lib.DBContexter, val, where *TTrafficPlanLogParam) (int64, error) {
+	return internal.Update(dbCtx, tTrafficPlanLogTableName, where, val)
+}
+
+// TTrafficPlanLogDelete Delete One/Multiple
+func TTrafficPlanLogDelete(dbCtx lib.DBContexter, where *TTrafficPlanLogParam) (int64, error) {
+	return internal.Delete(dbCtx, tTrafficPlanLogTableName, where)
+}
@@ -0,0 +1,124 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/lib/xerror"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao/internal"
+)
+
+const tTrafficPlanTableName = "traffic_plans"
+
+// TTrafficPlan Query Result
+type TTrafficPlan struct {
+	ID          int64     `db:"id"`
+	ProductID   int64     `db:"product_id"`
+	ClusterID   int64     `db:"cluster_id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Status      string    `db:"status"`
+	Steps       string    `db:"steps"`
+	NextStep    int32     `db:"next_step"`
+	NextStepAt  time.Time `db:"next_step_at"`
+	PausedAt    time.Time `db:"paused_at"`
+	CreatedBy   string    `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// TTrafficPlanOne Query One
+// return (nil, nil) if record not existed
+func TTrafficPlanOne(dbCtx lib.DBContexter, where *TTrafficPlanParam) (*TTrafficPlan, error) {
+	t := &TTrafficPlan{}
+	err := internal.QueryOne(dbCtx, tTrafficPlanTableName, where, t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TTrafficPlanList Query Multiple
+func TTrafficPlanList(dbCtx lib.DBContexter, where *TTrafficPlanParam) ([]*TTrafficPlan, error) {
+	t := []*TTrafficPlan{}
+	err := internal.QueryList(dbCtx, tTrafficPlanTableName, where, &t)
+	if err == nil {
+		return t, nil
+	}
+	if xerror.Cause(err) == internal.ErrRecordNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// TTrafficPlanParam Create/Update/Where Data Carrier
+// See: https://github.com/didi/gendry/blob/master/builder/README.md
+type TTrafficPlanParam struct {
+	ClusterIDs []int64  `db:"cluster_id,in"`
+	Statuses   []string `db:"status,in"`
+
+	ID          *int64     `db:"id"`
+	ProductID   *int64     `db:"product_id"`
+	ClusterID   *int64     `db:"cluster_id"`
+	Name        *string    `db:"name"`
+	Description *string    `db:"description"`
+	Status      *string    `db:"status"`
+	Steps       *string    `db:"steps"`
+	NextStep    *int32     `db:"next_step"`
+	NextStepAt  *time.Time `db:"next_step_at"`
+	PausedAt    *time.Time `db:"paused_at"`
+	CreatedBy   *string    `db:"created_by"`
+	CreatedAt   *time.Time `db:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+
+	OrderBy *string `db:"_orderby"`
+
+	LockMode *string `db:"_lockMode"`
+}
+
+// TTrafficPlanCreate One/Multiple
+func TTrafficPlanCreate(dbCtx lib.DBContexter, data ...*TTrafficPlanParam) (int64, error) {
+	if len(data) == 1 {
+		if data[0].CreatedAt == nil {
+			data[0].CreatedAt = internal.PTimeNow()
+		}
+		return internal.Create(dbCtx, tTrafficPlanTableName, data[0])
+	}
+
+	list := make([]interface{}, len(data))
+	for i, one := range data {
+		if one.CreatedAt == nil {
+			one.CreatedAt = internal.PTimeNow()
+		}
+		list[i] = one
+	}
+
+	return internal.Create(dbCtx, tTrafficPlanTableName, list...)
+}
+
+// TTrafficPlanUpdate Update One
+func TTrafficPlanUpdate(dbCtx lib.DBContexter, val, where *TTrafficPlanParam) (int64, error) {
+	return internal.Update(dbCtx, tTrafficPlanTableName, where, val)
+}
+
+// TTrafficPlanDelete Delete One/Multiple
+func TTrafficPlanDelete(dbCtx lib.DBContexter, where *TTrafficPlanParam) (int64, error) {
+	return internal.Delete(dbCtx, tTrafficPlanTableName, where)
+}
@@ -0,0 +1,179 @@
+// Copyright(c) 2026 Beijing Yingfei Networks Technology Co.Ltd.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http: //www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package version_control
+
+import (
+	"context"
+
+	"github.com/yf-networks/ai-gateway-api/lib"
+	"github.com/yf-networks/ai-gateway-api/model/iversion_control"
+	"github.com/yf-networks/ai-gateway-api/storage/rdb/internal/dao"
+)
+
+var _ iversion_control.ConfigTargetStorager = &ConfigTargetStorager{}
+
+type ConfigTargetStorager struct {
+	dbCtxFactory lib.DBContextFactory
+}
+
+func NewConfigTargetStorager(dbCtxFactory lib.DBContextFactory) *ConfigTargetStorager {
+	return &ConfigTargetStorager{
+		dbCtxFactory: dbCtxFactory,
+	}
+}
+
+func (cts *ConfigTargetStorager) FetchConfigChannels(ctx context.Context,
+	filter *iversion_control.ConfigChannelFilter) ([]*iversion_control.ConfigChannel, error) {
+
+	dbCtx, err := cts.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var where *dao.TConfigChannelParam
+	if filter != nil {
+		where = &dao.TConfigChannelParam{
+			Topic:   filter.Topic,
+			Channel: filter.Channel,
+		}
+	}
+
+	list, err := dao.TConfigChannelList(dbCtx, where)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := make([]*iversion_control.ConfigChannel, 0, len(list))
+	for _, one := range list {
+		rst = append(rst, &iversion_control.ConfigChannel{
+			Topic:     one.Topic,
+			Channel:   one.Channel,
+			Version:   one.Version,
+			UpdatedAt: one.UpdatedAt,
+		})
+	}
+
+	return rst, nil
+}
+
+func (cts *ConfigTargetStorager) UpsertConfigChannel(ctx context.Context, channel *iversion_control.ConfigChannel) error {
+	dbCtx, err := cts.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	where := &dao.TConfigChannelParam{
+		Topic:   &channel.Topic,
+		Channel: &channel.Channel,
+	}
+	old, err := dao.TConfigChannelOne(dbCtx, where)
+	if err != nil {
+		return err
+	}
+
+	if old == nil {
+		_, err = dao.TConfigChannelCreate(dbCtx, &dao.TConfigChannelParam{
+			Topic:   &channel.Topic,
+			Channel: &channel.Channel,
+			Version: &channel.Version,
+		})
+		return err
+	}
+
+	_, err = dao.TConfigChannelUpdate(dbCtx, &dao.TConfigChannelParam{
+		Version: &channel.Version,
+	}, where)
+	return err
+}
+
+func (cts *ConfigTargetStorager) FetchConfigTargets(ctx context.Context,
+	filter *iversion_control.ConfigTargetFilter) ([]*iversion_control.ConfigTarget, error) {
+
+	dbCtx, err := cts.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var where *dao.TBfeClusterConfigTargetParam
+	if filter != nil {
+		where = &dao.TBfeClusterConfigTargetParam{
+			BfeCluster: filter.BFECluster,
+			Topic:      filter.Topic,
+		}
+	}
+
+	list, err := dao.TBfeClusterConfigTargetList(dbCtx, where)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := make([]*iversion_control.ConfigTarget, 0, len(list))
+	for _, one := range list {
+		rst = append(rst, &iversion_control.ConfigTarget{
+			BFECluster: one.BfeCluster,
+			Topic:      one.Topic,
+			Channel:    one.Channel,
+			Version:    one.Version,
+			UpdatedAt:  one.UpdatedAt,
+		})
+	}
+
+	return rst, nil
+}
+
+func (cts *ConfigTargetStorager) UpsertConfigTarget(ctx context.Context, target *iversion_control.ConfigTarget) error {
+	dbCtx, err := cts.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	where := &dao.TBfeClusterConfigTargetParam{
+		BfeCluster: &target.BFECluster,
+		Topic:      &target.Topic,
+	}
+	old, err := dao.TBfeClusterConfigTargetOne(dbCtx, where)
+	if err != nil {
+		return err
+	}
+
+	if old == nil {
+		_, err = dao.TBfeClusterConfigTargetCreate(dbCtx, &dao.TBfeClusterConfigTargetParam{
+			BfeCluster: &target.BFECluster,
+			Topic:      &target.Topic,
+			Channel:    &target.Channel,
+			Version:    &target.Version,
+		})
+		return err
+	}
+
+	_, err = dao.TBfeClusterConfigTargetUpdate(dbCtx, &dao.TBfeClusterConfigTargetParam{
+		Channel: &target.Channel,
+		Version: &target.Version,
+	}, where)
+	return err
+}
+
+func (cts *ConfigTargetStorager) DeleteConfigTarget(ctx context.Context, target *iversion_control.ConfigTarget) error {
+	dbCtx, err := cts.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TBfeClusterConfigTargetDelete(dbCtx, &dao.TBfeClusterConfigTargetParam{
+		BfeCluster: &target.BFECluster,
+		Topic:      &target.Topic,
+	})
+	return err
+}
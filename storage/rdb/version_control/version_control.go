@@ -34,10 +34,10 @@ func NewVersionControllerStorage(dbCtxFactory lib.DBContextFactory) *VersionCont
 	}
 }
 
-func (vcs *VersionControlStorager) UpsertConfigLastExportedVersion(ctx context.Context, css *iversion_control.ExportData) (string, error) {
+func (vcs *VersionControlStorager) UpsertConfigLastExportedVersion(ctx context.Context, css *iversion_control.ExportData) (string, bool, error) {
 	dbCtx, err := vcs.dbCtxFactory(ctx)
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 
 	lastestConfigVersion, err := dao.TConfigVersionOne(dbCtx, &dao.TConfigVersionParam{
@@ -45,22 +45,214 @@ func (vcs *VersionControlStorager) UpsertConfigLastExportedVersion(ctx context.C
 		OrderBy: lib.PString("version DESC"),
 	})
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 
 	if lastestConfigVersion != nil && lastestConfigVersion.DataSign == css.DataSignWithoutVersion {
-		return lastestConfigVersion.Version, nil
+		return lastestConfigVersion.Version, false, nil
 	}
 
 	version, err := css.CalculateVersion()
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 
 	_, err = dao.TConfigVersionCreate(dbCtx, &dao.TConfigVersionParam{
-		Name:     &css.Topic,
-		DataSign: &css.DataSignWithoutVersion,
-		Version:  &version,
+		Name:        &css.Topic,
+		DataSign:    &css.DataSignWithoutVersion,
+		Version:     &version,
+		TriggeredBy: &css.TriggeredBy,
 	})
-	return version, err
+	return version, true, err
+}
+
+func (vcs *VersionControlStorager) FetchLastExportedVersions(ctx context.Context, topics []string) (map[string]string, error) {
+	dbCtx, err := vcs.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := map[string]string{}
+	if len(topics) == 0 {
+		return rst, nil
+	}
+
+	// versions are time based strings of same length, max is the last one
+	list, err := dao.TConfigVersionLastList(dbCtx, &dao.TConfigVersionParam{
+		Names: topics,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, one := range list {
+		rst[one.Name] = one.Version
+	}
+
+	return rst, nil
+}
+
+func (vcs *VersionControlStorager) CreateConfigSnapshot(ctx context.Context, snapshot *iversion_control.ConfigSnapshot) error {
+	dbCtx, err := vcs.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TConfigSnapshotCreate(dbCtx, &dao.TConfigSnapshotParam{
+		Topic:   &snapshot.Topic,
+		Version: &snapshot.Version,
+		Data:    &snapshot.Data,
+	})
+	return err
+}
+
+func (vcs *VersionControlStorager) FetchConfigSnapshots(ctx context.Context,
+	filter *iversion_control.ConfigSnapshotFilter) ([]*iversion_control.ConfigSnapshot, error) {
+
+	dbCtx, err := vcs.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var where *dao.TConfigSnapshotParam
+	if filter != nil {
+		where = &dao.TConfigSnapshotParam{
+			Topic:   filter.Topic,
+			Version: filter.Version,
+		}
+	}
+
+	list, err := dao.TConfigSnapshotList(dbCtx, where)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := make([]*iversion_control.ConfigSnapshot, 0, len(list))
+	for _, one := range list {
+		rst = append(rst, &iversion_control.ConfigSnapshot{
+			Topic:     one.Topic,
+			Version:   one.Version,
+			Data:      one.Data,
+			CreatedAt: one.CreatedAt,
+		})
+	}
+
+	return rst, nil
+}
+
+func (vcs *VersionControlStorager) UpdateConfigSnapshotData(ctx context.Context,
+	snapshot *iversion_control.ConfigSnapshot, data string) error {
+
+	dbCtx, err := vcs.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TConfigSnapshotUpdate(dbCtx, &dao.TConfigSnapshotParam{
+		Data: &data,
+	}, &dao.TConfigSnapshotParam{
+		Topic:   &snapshot.Topic,
+		Version: &snapshot.Version,
+	})
+	return err
+}
+
+func (vcs *VersionControlStorager) FetchConfigVersions(ctx context.Context,
+	filter *iversion_control.ConfigVersionFilter) ([]*iversion_control.ConfigVersion, error) {
+
+	dbCtx, err := vcs.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where := &dao.TConfigVersionParam{
+		OrderBy: lib.PString("version DESC"),
+	}
+	if filter != nil {
+		where.Name = filter.Topic
+		where.Version = filter.Version
+	}
+
+	list, err := dao.TConfigVersionList(dbCtx, where)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := make([]*iversion_control.ConfigVersion, 0, len(list))
+	for _, one := range list {
+		rst = append(rst, &iversion_control.ConfigVersion{
+			Topic:       one.Name,
+			Version:     one.Version,
+			DataSign:    one.DataSign,
+			TriggeredBy: one.TriggeredBy,
+			CreatedAt:   one.CreatedAt,
+		})
+	}
+
+	return rst, nil
+}
+
+func (vcs *VersionControlStorager) CreateConfigVersion(ctx context.Context, version *iversion_control.ConfigVersion) error {
+	dbCtx, err := vcs.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TConfigVersionCreate(dbCtx, &dao.TConfigVersionParam{
+		Name:        &version.Topic,
+		DataSign:    &version.DataSign,
+		Version:     &version.Version,
+		TriggeredBy: &version.TriggeredBy,
+	})
+	return err
+}
+
+func (vcs *VersionControlStorager) FetchConfigRollback(ctx context.Context, topic string) (*iversion_control.ConfigRollback, error) {
+	dbCtx, err := vcs.dbCtxFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	one, err := dao.TConfigRollbackOne(dbCtx, &dao.TConfigRollbackParam{
+		Topic: &topic,
+	})
+	if err != nil || one == nil {
+		return nil, err
+	}
+
+	return &iversion_control.ConfigRollback{
+		Topic:       one.Topic,
+		Version:     one.Version,
+		FromVersion: one.FromVersion,
+		SourceSign:  one.SourceSign,
+		CreatedBy:   one.CreatedBy,
+		CreatedAt:   one.CreatedAt,
+	}, nil
+}
+
+func (vcs *VersionControlStorager) CreateConfigRollback(ctx context.Context, rollback *iversion_control.ConfigRollback) error {
+	dbCtx, err := vcs.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TConfigRollbackCreate(dbCtx, &dao.TConfigRollbackParam{
+		Topic:       &rollback.Topic,
+		Version:     &rollback.Version,
+		FromVersion: &rollback.FromVersion,
+		SourceSign:  &rollback.SourceSign,
+		CreatedBy:   &rollback.CreatedBy,
+	})
+	return err
+}
+
+func (vcs *VersionControlStorager) DeleteConfigRollback(ctx context.Context, topic string) error {
+	dbCtx, err := vcs.dbCtxFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.TConfigRollbackDelete(dbCtx, &dao.TConfigRollbackParam{
+		Topic: &topic,
+	})
+	return err
 }